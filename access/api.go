@@ -6,6 +6,7 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 
+	"github.com/onflow/flow-go/access/lightclient"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -47,6 +48,27 @@ type API interface {
 
 	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
 	GetExecutionResultByID(ctx context.Context, id flow.Identifier) (*flow.ExecutionResult, error)
+
+	// GetAccountTransactions returns a page of the transactions signed by the given account as
+	// authorizer, payer or proposer within the height range [startHeight, endHeight]. A start height
+	// of 0 starts at the first indexed height, and an end height of 0 ends at the latest indexed height.
+	// If cursor is not nil, the page starts at the cursor returned with the previous page.
+	GetAccountTransactions(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, cursor *flow.AccountIndexCursor, limit uint) (*AccountTransactionsPage, error)
+
+	// GetAccountEvents returns a page of the events emitted by contracts deployed to the given account
+	// within the height range [startHeight, endHeight], optionally filtered by contract name. Heights
+	// and pagination are handled like in GetAccountTransactions.
+	GetAccountEvents(ctx context.Context, address flow.Address, contract string, startHeight uint64, endHeight uint64, cursor *flow.AccountIndexCursor, limit uint) (*AccountEventsPage, error)
+
+	// GetAccountRegisterProof returns the values of the given registers of the account after executing the
+	// sealed block at the given height, together with the proof linking the values to the finalized block
+	// including the seal of the block. If no keys are given, the registers of the account status are proven.
+	GetAccountRegisterProof(ctx context.Context, address flow.Address, keys []string, height uint64) (*lightclient.RegisterProof, error)
+
+	// GetEpochTransitionProof returns the EpochSetup and EpochCommit service events of the epoch with the given
+	// counter, together with the proofs that they were sealed in finalized blocks certified by the consensus
+	// committee of the previous epoch.
+	GetEpochTransitionProof(ctx context.Context, counter uint64) (*lightclient.EpochTransitionProof, error)
 }
 
 // TODO: Combine this with flow.TransactionResult?
@@ -99,6 +121,20 @@ func MessageToTransactionResult(message *access.TransactionResultResponse) *Tran
 	}
 }
 
+// AccountTransactionsPage is a page of the transactions signed by an account. NextCursor is nil if
+// there are no more results within the requested height range.
+type AccountTransactionsPage struct {
+	Transactions []flow.AccountTransaction
+	NextCursor   *flow.AccountIndexCursor
+}
+
+// AccountEventsPage is a page of the events emitted by the contracts of an account. NextCursor is
+// nil if there are no more results within the requested height range.
+type AccountEventsPage struct {
+	Events     []flow.AccountEvent
+	NextCursor *flow.AccountIndexCursor
+}
+
 // NetworkParameters contains the network-wide parameters for the Flow blockchain.
 type NetworkParameters struct {
 	ChainID flow.ChainID
@@ -90,3 +90,40 @@ type InvalidTxByteSizeError struct {
 func (e InvalidTxByteSizeError) Error() string {
 	return fmt.Sprintf("transaction byte size (%d) exceeds the maximum byte size allowed for a transaction (%d)", e.Actual, e.Maximum)
 }
+
+// InvalidProposalKeyError indicates that the proposal key of a transaction does not exist or has been
+// revoked on the proposer account.
+type InvalidProposalKeyError struct {
+	Address  flow.Address
+	KeyIndex uint64
+}
+
+func (e InvalidProposalKeyError) Error() string {
+	return fmt.Sprintf("invalid proposal key (address: %s, index: %d)", e.Address, e.KeyIndex)
+}
+
+// InvalidProposalSeqNumberError indicates that the proposal key sequence number of a transaction has
+// already been used by the proposer account.
+type InvalidProposalSeqNumberError struct {
+	Address  flow.Address
+	KeyIndex uint64
+	Actual   uint64
+	Minimum  uint64
+}
+
+func (e InvalidProposalSeqNumberError) Error() string {
+	return fmt.Sprintf("proposal key sequence number (%d) is lower than the current sequence number (%d) of key (address: %s, index: %d)",
+		e.Actual, e.Minimum, e.Address, e.KeyIndex)
+}
+
+// InsufficientBalanceError indicates that the payer of a transaction does not have enough balance to
+// pay for the transaction.
+type InsufficientBalanceError struct {
+	Payer   flow.Address
+	Actual  uint64
+	Minimum uint64
+}
+
+func (e InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("payer (%s) balance (%d) is lower than the minimum balance (%d)", e.Payer, e.Actual, e.Minimum)
+}
@@ -0,0 +1,154 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ServiceEventProof proves that a service event was emitted when executing a block. It contains the chain of
+// entities linking the event to a certified block header:
+//
+//	event --(execution result)--> seal --(payload)--> sealing header --(QC)--> consensus committee
+//
+// The protocol state applies the service event once the sealing block is certified, i.e. in the child of the
+// sealing block, which contains the QC.
+type ServiceEventProof struct {
+	// Result is the sealed execution result which contains the service event.
+	Result *flow.ExecutionResult
+
+	// Seal is the seal of the execution result.
+	Seal *flow.Seal
+
+	// SealingHeader is the header of the block which includes the seal.
+	SealingHeader *flow.Header
+
+	// SealingPayload is the index of the payload of the block which includes the seal.
+	SealingPayload *flow.Index
+
+	// QC certifies the sealing block. It is signed by the consensus committee of the epoch of the sealing block.
+	QC *flow.QuorumCertificate
+}
+
+// Verify verifies that the given service event was emitted in the execution result sealed by the sealing block,
+// and that the QC references the sealing block. The signatures of the QC are not verified, as this requires the
+// consensus committee of the epoch of the sealing block.
+// Expected errors during normal operations:
+//   - InvalidProofError if any link of the proof is invalid
+func (p *ServiceEventProof) Verify(event flow.ServiceEvent) error {
+	if p.Result == nil || p.Seal == nil || p.SealingHeader == nil || p.SealingPayload == nil || p.QC == nil {
+		return NewInvalidProofErrorf("incomplete service event proof")
+	}
+
+	sealingBlockID := p.SealingHeader.ID()
+	if p.QC.BlockID != sealingBlockID || p.QC.View != p.SealingHeader.View {
+		return NewInvalidProofErrorf("qc for block %x at view %d does not certify sealing block %x at view %d",
+			p.QC.BlockID, p.QC.View, sealingBlockID, p.SealingHeader.View)
+	}
+
+	err := verifySealInclusion(p.Seal, p.SealingHeader, p.SealingPayload)
+	if err != nil {
+		return err
+	}
+	if p.Seal.BlockID != p.Result.BlockID {
+		return NewInvalidProofErrorf("seal is for block %x, not block %x of the execution result", p.Seal.BlockID, p.Result.BlockID)
+	}
+	resultID := p.Result.ID()
+	if p.Seal.ResultID != resultID {
+		return NewInvalidProofErrorf("seal is for execution result %x, not result %x", p.Seal.ResultID, resultID)
+	}
+
+	for _, emitted := range p.Result.ServiceEvents {
+		equal, err := emitted.EqualTo(&event)
+		if err != nil {
+			return NewInvalidProofErrorf("could not compare service events: %w", err)
+		}
+		if equal {
+			return nil
+		}
+	}
+	return NewInvalidProofErrorf("%s service event is not emitted in execution result %x", event.Type, resultID)
+}
+
+// EpochTransitionProof proves the configuration of an epoch to a light client which knows the configuration of
+// the previous epoch. It contains the EpochSetup and EpochCommit service events of the epoch, each together with
+// the proof that it was emitted and sealed in a block certified by the consensus committee of the previous epoch.
+// With the committee of the new epoch, the light client can then verify the QCs of all headers in the new epoch.
+type EpochTransitionProof struct {
+	// Setup is the EpochSetup service event of the epoch.
+	Setup *flow.EpochSetup
+
+	// Commit is the EpochCommit service event of the epoch.
+	Commit *flow.EpochCommit
+
+	// SetupProof proves that the EpochSetup event was sealed during the previous epoch.
+	SetupProof *ServiceEventProof
+
+	// CommitProof proves that the EpochCommit event was sealed during the previous epoch.
+	CommitProof *ServiceEventProof
+}
+
+// Encode encodes the proof, so that it can be transferred to light clients, which decode it with
+// DecodeEpochTransitionProof.
+func (p *EpochTransitionProof) Encode() ([]byte, error) {
+	return cbor.EncMode.Marshal(p)
+}
+
+// DecodeEpochTransitionProof decodes a proof encoded with EpochTransitionProof.Encode. The decoded proof is not
+// verified.
+func DecodeEpochTransitionProof(data []byte) (*EpochTransitionProof, error) {
+	var p EpochTransitionProof
+	err := cbor.DecMode.Unmarshal(data, &p)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode epoch transition proof: %w", err)
+	}
+	return &p, nil
+}
+
+// Verify verifies that the service events of the proof configure the epoch following the given epoch, and that
+// they were emitted in sealed execution results. The QCs of the sealing blocks are not verified, as this requires
+// the consensus committee of the previous epoch: they must be verified by the caller, e.g. with an
+// epochs.HeaderVerifier.
+// Expected errors during normal operations:
+//   - InvalidProofError if any link of the proof is invalid
+func (p *EpochTransitionProof) Verify(previousCounter uint64, previousFinalView uint64) error {
+	if p.Setup == nil || p.Commit == nil || p.SetupProof == nil || p.CommitProof == nil {
+		return NewInvalidProofErrorf("incomplete epoch transition proof")
+	}
+
+	// the service events must configure the epoch directly following the previous epoch
+	if p.Setup.Counter != previousCounter+1 {
+		return NewInvalidProofErrorf("epoch setup counter %d does not follow epoch %d", p.Setup.Counter, previousCounter)
+	}
+	if p.Commit.Counter != p.Setup.Counter {
+		return NewInvalidProofErrorf("epoch commit counter %d does not match epoch setup counter %d", p.Commit.Counter, p.Setup.Counter)
+	}
+	if p.Setup.FirstView != previousFinalView+1 {
+		return NewInvalidProofErrorf("epoch first view %d does not follow final view %d of epoch %d", p.Setup.FirstView, previousFinalView, previousCounter)
+	}
+	if p.Setup.FinalView < p.Setup.FirstView {
+		return NewInvalidProofErrorf("epoch final view %d is below first view %d", p.Setup.FinalView, p.Setup.FirstView)
+	}
+
+	// both service events must be sealed during the previous epoch, the setup event first
+	for _, proof := range []*ServiceEventProof{p.SetupProof, p.CommitProof} {
+		if proof.SealingHeader != nil && proof.SealingHeader.View > previousFinalView {
+			return NewInvalidProofErrorf("sealing block at view %d is not within epoch %d", proof.SealingHeader.View, previousCounter)
+		}
+	}
+	err := p.SetupProof.Verify(p.Setup.ServiceEvent())
+	if err != nil {
+		return fmt.Errorf("invalid epoch setup proof: %w", err)
+	}
+	err = p.CommitProof.Verify(p.Commit.ServiceEvent())
+	if err != nil {
+		return fmt.Errorf("invalid epoch commit proof: %w", err)
+	}
+	if p.CommitProof.SealingHeader.Height < p.SetupProof.SealingHeader.Height {
+		return NewInvalidProofErrorf("epoch commit is sealed at height %d, before epoch setup at height %d",
+			p.CommitProof.SealingHeader.Height, p.SetupProof.SealingHeader.Height)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package lightclient_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEpochTransitionProof_Verify tests that an epoch transition proof is valid if it configures the next epoch
+// with service events sealed in the previous epoch, and invalid if any link of the proof is broken.
+func TestEpochTransitionProof_Verify(t *testing.T) {
+	const previousCounter, previousFinalView = 1, 999
+
+	t.Run("valid proof", func(t *testing.T) {
+		p := epochTransitionProofFixture(previousCounter+1, previousFinalView+1)
+		require.NoError(t, p.Verify(previousCounter, previousFinalView))
+	})
+
+	invalidate := map[string]func(p *lightclient.EpochTransitionProof){
+		"incomplete proof": func(p *lightclient.EpochTransitionProof) {
+			p.CommitProof = nil
+		},
+		"incomplete service event proof": func(p *lightclient.EpochTransitionProof) {
+			p.SetupProof.QC = nil
+		},
+		"skipped epoch": func(p *lightclient.EpochTransitionProof) {
+			p.Setup.Counter++
+			p.Commit.Counter++
+		},
+		"commit for other epoch": func(p *lightclient.EpochTransitionProof) {
+			p.Commit.Counter++
+		},
+		"gap between epochs": func(p *lightclient.EpochTransitionProof) {
+			p.Setup.FirstView++
+		},
+		"setup not emitted": func(p *lightclient.EpochTransitionProof) {
+			p.SetupProof.Result.ServiceEvents = nil
+		},
+		"commit not emitted": func(p *lightclient.EpochTransitionProof) {
+			p.CommitProof = p.SetupProof
+		},
+		"seal not in sealing payload": func(p *lightclient.EpochTransitionProof) {
+			p.SetupProof.SealingPayload.SealIDs = nil
+		},
+		"seal for other result": func(p *lightclient.EpochTransitionProof) {
+			p.CommitProof.Seal = p.SetupProof.Seal
+		},
+		"qc for other block": func(p *lightclient.EpochTransitionProof) {
+			p.SetupProof.QC.BlockID = unittest.IdentifierFixture()
+		},
+		"sealed after previous epoch": func(p *lightclient.EpochTransitionProof) {
+			p.CommitProof.SealingHeader.View = previousFinalView + 1
+			p.CommitProof.QC.View = previousFinalView + 1
+			p.CommitProof.QC.BlockID = p.CommitProof.SealingHeader.ID()
+		},
+		"commit sealed before setup": func(p *lightclient.EpochTransitionProof) {
+			p.CommitProof.SealingHeader.Height = p.SetupProof.SealingHeader.Height - 1
+			p.CommitProof.QC.BlockID = p.CommitProof.SealingHeader.ID()
+		},
+	}
+	for name, apply := range invalidate {
+		t.Run(name, func(t *testing.T) {
+			p := epochTransitionProofFixture(previousCounter+1, previousFinalView+1)
+			apply(p)
+
+			err := p.Verify(previousCounter, previousFinalView)
+			require.Error(t, err)
+			assert.True(t, lightclient.IsInvalidProofError(err), err)
+		})
+	}
+}
+
+// TestEpochTransitionProof_Encoding tests that proofs are still valid after encoding and decoding them.
+func TestEpochTransitionProof_Encoding(t *testing.T) {
+	p := epochTransitionProofFixture(2, 1000)
+
+	encoded, err := p.Encode()
+	require.NoError(t, err)
+	decoded, err := lightclient.DecodeEpochTransitionProof(encoded)
+	require.NoError(t, err)
+
+	assert.True(t, p.Setup.EqualTo(decoded.Setup))
+	assert.True(t, p.Commit.EqualTo(decoded.Commit))
+	require.NoError(t, decoded.Verify(1, 999))
+}
+
+// epochTransitionProofFixture returns a proof of the transition into the epoch with the given counter and first
+// view. The QCs of the sealing blocks are not valid.
+func epochTransitionProofFixture(counter uint64, firstView uint64) *lightclient.EpochTransitionProof {
+	setup := unittest.EpochSetupFixture(
+		unittest.SetupWithCounter(counter),
+		unittest.WithFirstView(firstView),
+		unittest.WithFinalView(firstView+1000),
+	)
+	commit := unittest.EpochCommitFixture(unittest.CommitWithCounter(counter))
+
+	return &lightclient.EpochTransitionProof{
+		Setup:       setup,
+		Commit:      commit,
+		SetupProof:  serviceEventProofFixture(firstView-200, setup.ServiceEvent()),
+		CommitProof: serviceEventProofFixture(firstView-100, commit.ServiceEvent()),
+	}
+}
+
+// serviceEventProofFixture returns a proof of the service event, sealed in a block at the given view.
+func serviceEventProofFixture(view uint64, event flow.ServiceEvent) *lightclient.ServiceEventProof {
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	result.ServiceEvents = []flow.ServiceEvent{event}
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+
+	sealingBlock := unittest.BlockWithParentFixture(block.Header)
+	sealingBlock.Header.Height = view
+	sealingBlock.Header.View = view
+	sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+	qc := unittest.QuorumCertificateFixture(unittest.QCWithBlockID(sealingBlock.ID()))
+	qc.View = view
+
+	return &lightclient.ServiceEventProof{
+		Result:         result,
+		Seal:           seal,
+		SealingHeader:  sealingBlock.Header,
+		SealingPayload: sealingBlock.Payload.Index(),
+		QC:             qc,
+	}
+}
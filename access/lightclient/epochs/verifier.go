@@ -0,0 +1,157 @@
+// Package epochs verifies block headers for light clients across epochs. Starting from the epoch of a trusted root
+// snapshot, a HeaderVerifier follows epoch transition proofs served by Access nodes, so that it can verify the QC of
+// any later header with the consensus committee of its epoch, without downloading block payloads.
+package epochs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/signature"
+	"github.com/onflow/flow-go/consensus/hotstuff/validator"
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+)
+
+// ErrViewOutsideEpoch is returned when a header is verified whose view is not within the current epoch of the
+// verifier. Headers of later epochs can be verified after applying the epoch transitions up to their epoch.
+var ErrViewOutsideEpoch = errors.New("view outside of current epoch")
+
+// HeaderVerifier verifies the QCs of block headers with the consensus committee of its current epoch. It is not
+// concurrency safe.
+//
+// CAUTION: a valid QC proves that a block was certified, not that it was finalized. Moreover, the verifier does
+// not support epoch emergency fallback, where an epoch is extended beyond its final view.
+type HeaderVerifier struct {
+	counter   uint64
+	firstView uint64
+	finalView uint64
+	validator hotstuff.Validator
+}
+
+// NewHeaderVerifier returns a verifier for headers of the current epoch of the given trusted snapshot, e.g. the
+// root snapshot a node bootstraps from.
+func NewHeaderVerifier(snapshot protocol.Snapshot) (*HeaderVerifier, error) {
+	verifier, err := newHeaderVerifier(snapshot.Epochs().Current())
+	if err != nil {
+		return nil, fmt.Errorf("could not create header verifier for current epoch of snapshot: %w", err)
+	}
+	return verifier, nil
+}
+
+func newHeaderVerifier(epoch protocol.Epoch) (*HeaderVerifier, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch counter: %w", err)
+	}
+	firstView, err := epoch.FirstView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch first view: %w", err)
+	}
+	finalView, err := epoch.FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch final view: %w", err)
+	}
+	identities, err := epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch initial identities: %w", err)
+	}
+	dkg, err := epoch.DKG()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch dkg: %w", err)
+	}
+
+	committee, err := committees.NewStaticCommitteeWithDKG(identities.Filter(filter.IsVotingConsensusCommitteeMember), flow.Identifier{}, dkg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create static committee: %w", err)
+	}
+	verifier := verification.NewCombinedVerifier(committee, signature.NewConsensusSigDataPacker(committee))
+	// QCs are validated against the committee only, hence forks are never queried
+	forks := &mocks.ForksReader{}
+
+	return &HeaderVerifier{
+		counter:   counter,
+		firstView: firstView,
+		finalView: finalView,
+		validator: validator.New(committee, forks, verifier),
+	}, nil
+}
+
+// Counter returns the counter of the current epoch of the verifier.
+func (v *HeaderVerifier) Counter() uint64 {
+	return v.counter
+}
+
+// VerifyHeader verifies that the given QC certifies the header, and is signed by the consensus committee of the
+// current epoch of the verifier.
+// Expected errors during normal operations:
+//   - ErrViewOutsideEpoch if the header is not within the current epoch
+//   - lightclient.InvalidProofError if the QC is invalid
+func (v *HeaderVerifier) VerifyHeader(header *flow.Header, qc *flow.QuorumCertificate) error {
+	if header.View < v.firstView || header.View > v.finalView {
+		return fmt.Errorf("header view %d is not within views [%d, %d] of epoch %d: %w", header.View, v.firstView, v.finalView, v.counter, ErrViewOutsideEpoch)
+	}
+
+	blockID := header.ID()
+	if qc.BlockID != blockID {
+		return lightclient.NewInvalidProofErrorf("qc for block %x does not certify block %x", qc.BlockID, blockID)
+	}
+
+	block := &model.Block{
+		View:        header.View,
+		BlockID:     blockID,
+		ProposerID:  header.ProposerID,
+		PayloadHash: header.PayloadHash,
+		Timestamp:   header.Timestamp,
+	}
+	err := v.validator.ValidateQC(qc, block)
+	if model.IsInvalidBlockError(err) {
+		return lightclient.NewInvalidProofErrorf("invalid qc for block %x: %w", blockID, err)
+	}
+	if err != nil {
+		return fmt.Errorf("could not validate qc for block %x: %w", blockID, err)
+	}
+	return nil
+}
+
+// ApplyEpochTransition verifies the proof of the transition to the epoch following the current epoch, and makes
+// the new epoch the current epoch of the verifier. Afterwards, only headers of the new epoch can be verified.
+// Expected errors during normal operations:
+//   - lightclient.InvalidProofError if the proof is invalid
+func (v *HeaderVerifier) ApplyEpochTransition(proof *lightclient.EpochTransitionProof) error {
+	err := proof.Verify(v.counter, v.finalView)
+	if err != nil {
+		return err
+	}
+
+	// the service events were sealed in blocks of the current epoch, which the current committee certified
+	for _, eventProof := range []*lightclient.ServiceEventProof{proof.SetupProof, proof.CommitProof} {
+		err = v.VerifyHeader(eventProof.SealingHeader, eventProof.QC)
+		if errors.Is(err, ErrViewOutsideEpoch) {
+			return lightclient.NewInvalidProofErrorf("sealing block is not within epoch %d: %w", v.counter, err)
+		}
+		if err != nil {
+			return fmt.Errorf("could not verify sealing block: %w", err)
+		}
+	}
+
+	epoch, err := inmem.NewCommittedEpoch(proof.Setup, proof.Commit)
+	if err != nil {
+		return lightclient.NewInvalidProofErrorf("invalid epoch configuration: %w", err)
+	}
+	next, err := newHeaderVerifier(epoch)
+	if err != nil {
+		return lightclient.NewInvalidProofErrorf("invalid epoch configuration: %w", err)
+	}
+
+	*v = *next
+	return nil
+}
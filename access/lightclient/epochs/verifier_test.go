@@ -0,0 +1,195 @@
+package epochs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/access/lightclient/epochs"
+	"github.com/onflow/flow-go/cmd/bootstrap/run"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestHeaderVerifier tests verifying headers of the epoch of the root snapshot, and of later epochs after applying
+// the epoch transitions.
+func TestHeaderVerifier(t *testing.T) {
+	first := newEpochFixture(t, 1, 0, 999)
+	second := newEpochFixture(t, 2, 1000, 1999)
+
+	newVerifier := func(t *testing.T) *epochs.HeaderVerifier {
+		verifier, err := epochs.NewHeaderVerifier(first.snapshot(t))
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), verifier.Counter())
+		return verifier
+	}
+
+	t.Run("header of root epoch", func(t *testing.T) {
+		verifier := newVerifier(t)
+		header := headerFixture(500)
+		require.NoError(t, verifier.VerifyHeader(header, first.certify(t, header)))
+	})
+
+	t.Run("qc of other committee", func(t *testing.T) {
+		verifier := newVerifier(t)
+		header := headerFixture(500)
+		err := verifier.VerifyHeader(header, second.certify(t, header))
+		assert.True(t, lightclient.IsInvalidProofError(err), err)
+	})
+
+	t.Run("qc of other block", func(t *testing.T) {
+		verifier := newVerifier(t)
+		header := headerFixture(500)
+		err := verifier.VerifyHeader(header, first.certify(t, headerFixture(500)))
+		assert.True(t, lightclient.IsInvalidProofError(err), err)
+	})
+
+	t.Run("header of next epoch", func(t *testing.T) {
+		verifier := newVerifier(t)
+		header := headerFixture(1500)
+		err := verifier.VerifyHeader(header, second.certify(t, header))
+		assert.ErrorIs(t, err, epochs.ErrViewOutsideEpoch)
+	})
+
+	t.Run("epoch transition", func(t *testing.T) {
+		verifier := newVerifier(t)
+		require.NoError(t, verifier.ApplyEpochTransition(transitionProof(t, first, second)))
+		assert.Equal(t, uint64(2), verifier.Counter())
+
+		header := headerFixture(1500)
+		require.NoError(t, verifier.VerifyHeader(header, second.certify(t, header)))
+
+		// headers of the previous epoch are no longer verified
+		header = headerFixture(500)
+		err := verifier.VerifyHeader(header, first.certify(t, header))
+		assert.ErrorIs(t, err, epochs.ErrViewOutsideEpoch)
+	})
+
+	t.Run("epoch transition sealed in uncertified block", func(t *testing.T) {
+		verifier := newVerifier(t)
+		proof := transitionProof(t, first, second)
+		// the new committee may not certify its own configuration
+		proof.CommitProof.QC = second.certify(t, proof.CommitProof.SealingHeader)
+
+		err := verifier.ApplyEpochTransition(proof)
+		assert.True(t, lightclient.IsInvalidProofError(err), err)
+		assert.Equal(t, uint64(1), verifier.Counter())
+	})
+
+	t.Run("skipped epoch transition", func(t *testing.T) {
+		verifier := newVerifier(t)
+		third := newEpochFixture(t, 3, 2000, 2999)
+
+		err := verifier.ApplyEpochTransition(transitionProof(t, second, third))
+		assert.True(t, lightclient.IsInvalidProofError(err), err)
+		assert.Equal(t, uint64(1), verifier.Counter())
+	})
+}
+
+// epochFixture is the configuration of an epoch, together with the private keys of its consensus committee.
+type epochFixture struct {
+	setup   *flow.EpochSetup
+	commit  *flow.EpochCommit
+	signers *run.ParticipantData
+}
+
+func newEpochFixture(t *testing.T, counter uint64, firstView uint64, finalView uint64) *epochFixture {
+	nodes := bootstrap.Sort(unittest.PrivateNodeInfosFixture(4, unittest.WithRole(flow.RoleConsensus)), order.Canonical)
+
+	seed := unittest.SeedFixture(crypto.SeedMinLenDKG)
+	privKeyShares, pubKeyShares, groupKey, err := crypto.BLSThresholdKeyGen(len(nodes), signature.RandomBeaconThreshold(len(nodes)), seed)
+	require.NoError(t, err)
+	signers, err := run.GenerateQCParticipantData(nodes, nodes, dkg.DKGData{
+		PrivKeyShares: privKeyShares,
+		PubGroupKey:   groupKey,
+		PubKeyShares:  pubKeyShares,
+	})
+	require.NoError(t, err)
+
+	setup := unittest.EpochSetupFixture(
+		unittest.WithParticipants(bootstrap.ToIdentityList(nodes)),
+		unittest.SetupWithCounter(counter),
+		unittest.WithFirstView(firstView),
+		unittest.WithFinalView(finalView),
+	)
+	commit := unittest.EpochCommitFixture(unittest.CommitWithCounter(counter), func(commit *flow.EpochCommit) {
+		commit.DKGGroupKey = groupKey
+		commit.DKGParticipantKeys = pubKeyShares
+	})
+
+	return &epochFixture{
+		setup:   setup,
+		commit:  commit,
+		signers: signers,
+	}
+}
+
+// snapshot returns a snapshot whose current epoch is the epoch.
+func (e *epochFixture) snapshot(t *testing.T) *mockprotocol.Snapshot {
+	epoch, err := inmem.NewCommittedEpoch(e.setup, e.commit)
+	require.NoError(t, err)
+	query := new(mockprotocol.EpochQuery)
+	query.On("Current").Return(epoch)
+	snapshot := new(mockprotocol.Snapshot)
+	snapshot.On("Epochs").Return(query)
+	return snapshot
+}
+
+// certify returns a QC for the header, signed by the consensus committee of the epoch.
+func (e *epochFixture) certify(t *testing.T, header *flow.Header) *flow.QuorumCertificate {
+	block := &flow.Block{Header: header}
+	votes, err := run.GenerateRootBlockVotes(block, e.signers)
+	require.NoError(t, err)
+	qc, err := run.GenerateRootQC(block, votes, e.signers, e.signers.Identities())
+	require.NoError(t, err)
+	return qc
+}
+
+// transitionProof returns the proof of the transition from the current into the next epoch, with the service
+// events sealed in blocks certified by the committee of the current epoch.
+func transitionProof(t *testing.T, current *epochFixture, next *epochFixture) *lightclient.EpochTransitionProof {
+	return &lightclient.EpochTransitionProof{
+		Setup:       next.setup,
+		Commit:      next.commit,
+		SetupProof:  serviceEventProof(t, current, current.setup.FirstView+100, next.setup.ServiceEvent()),
+		CommitProof: serviceEventProof(t, current, current.setup.FirstView+200, next.commit.ServiceEvent()),
+	}
+}
+
+// serviceEventProof returns the proof of the service event, sealed in a block at the given view which is
+// certified by the committee of the given epoch.
+func serviceEventProof(t *testing.T, signers *epochFixture, view uint64, event flow.ServiceEvent) *lightclient.ServiceEventProof {
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	result.ServiceEvents = []flow.ServiceEvent{event}
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+
+	sealingBlock := unittest.BlockWithParentFixture(block.Header)
+	sealingBlock.Header.Height = view
+	sealingBlock.Header.View = view
+	sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+
+	return &lightclient.ServiceEventProof{
+		Result:         result,
+		Seal:           seal,
+		SealingHeader:  sealingBlock.Header,
+		SealingPayload: sealingBlock.Payload.Index(),
+		QC:             signers.certify(t, sealingBlock.Header),
+	}
+}
+
+// headerFixture returns a header at the given view.
+func headerFixture(view uint64) *flow.Header {
+	header := unittest.BlockHeaderFixture()
+	header.View = view
+	return header
+}
@@ -0,0 +1,247 @@
+// Package lightclient verifies register values and epoch transitions served by Access nodes offline, against
+// block headers trusted by the light client.
+package lightclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/common/proof"
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// pathFinderVersion is the version used by the execution state to derive the trie paths of registers.
+	// It must match complete.DefaultPathFinderVersion.
+	pathFinderVersion = 1
+
+	// keyPartOwner and keyPartKey are the types of the ledger key parts of registers.
+	// They must match state.KeyPartOwner and state.KeyPartKey.
+	keyPartOwner = uint16(0)
+	keyPartKey   = uint16(2)
+)
+
+// Register is a register together with its value in the execution state.
+type Register struct {
+	ID    flow.RegisterID
+	Value flow.RegisterValue
+}
+
+// encodableRegister is the encoding of a register. The owner and key of registers are arbitrary bytes, hence
+// they are encoded like the value, rather than as (UTF-8) strings.
+type encodableRegister struct {
+	Owner []byte
+	Key   []byte
+	Value []byte
+}
+
+func (r Register) MarshalCBOR() ([]byte, error) {
+	return cbor.EncMode.Marshal(encodableRegister{
+		Owner: []byte(r.ID.Owner),
+		Key:   []byte(r.ID.Key),
+		Value: r.Value,
+	})
+}
+
+func (r *Register) UnmarshalCBOR(data []byte) error {
+	var register encodableRegister
+	err := cbor.DecMode.Unmarshal(data, &register)
+	if err != nil {
+		return err
+	}
+	r.ID = flow.NewRegisterID(string(register.Owner), string(register.Key))
+	r.Value = register.Value
+	return nil
+}
+
+// RegisterProof proves the values of registers after the execution of a sealed block. It contains the chain of
+// entities linking the register values to the header of a finalized block:
+//
+//	registers --(trie proof)--> state commitment --(execution result)--> seal --(payload)--> sealing header
+//
+// The sealing header is the header of the finalized block, which includes the seal of the execution result.
+type RegisterProof struct {
+	// Registers are the proven registers, and their values.
+	Registers []Register
+
+	// Proof is the encoded trie batch proof of the register values against the final state commitment of
+	// the execution result.
+	Proof flow.StorageProof
+
+	// Header is the header of the block at which the registers are read.
+	Header *flow.Header
+
+	// Result is the sealed execution result of the block.
+	Result *flow.ExecutionResult
+
+	// Seal is the seal of the execution result.
+	Seal *flow.Seal
+
+	// SealingHeader is the header of the finalized block which includes the seal.
+	SealingHeader *flow.Header
+
+	// SealingPayload is the index of the payload of the block which includes the seal.
+	SealingPayload *flow.Index
+}
+
+// Encode encodes the proof, so that it can be transferred to light clients, which decode it with DecodeRegisterProof.
+func (p *RegisterProof) Encode() ([]byte, error) {
+	return cbor.EncMode.Marshal(p)
+}
+
+// DecodeRegisterProof decodes a proof encoded with RegisterProof.Encode. The decoded proof is not verified.
+func DecodeRegisterProof(data []byte) (*RegisterProof, error) {
+	var p RegisterProof
+	err := cbor.DecMode.Unmarshal(data, &p)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode register proof: %w", err)
+	}
+	return &p, nil
+}
+
+// InvalidProofError is returned when a proof does not prove its claims, e.g. the values of its registers.
+type InvalidProofError struct {
+	err error
+}
+
+func NewInvalidProofErrorf(msg string, args ...interface{}) error {
+	return InvalidProofError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e InvalidProofError) Unwrap() error {
+	return e.err
+}
+
+func (e InvalidProofError) Error() string {
+	return fmt.Sprintf("invalid proof: %v", e.err)
+}
+
+// IsInvalidProofError returns whether the given error is an InvalidProofError error
+func IsInvalidProofError(err error) bool {
+	var errInvalidProof InvalidProofError
+	return errors.As(err, &errInvalidProof)
+}
+
+// Verify verifies that the registers of the proof have the given values after the execution of the block,
+// as sealed in the block with the given ID. The light client must trust the sealing block to be finalized,
+// e.g. because it was obtained by following the finalized chain independently of the Access node serving
+// the proof.
+// Expected errors during normal operations:
+//   - InvalidProofError if any link of the proof is invalid
+func (p *RegisterProof) Verify(sealingBlockID flow.Identifier) error {
+	if p.Header == nil || p.Result == nil || p.Seal == nil || p.SealingHeader == nil || p.SealingPayload == nil {
+		return NewInvalidProofErrorf("incomplete proof")
+	}
+
+	// the sealing header must be the trusted finalized header
+	if p.SealingHeader.ID() != sealingBlockID {
+		return NewInvalidProofErrorf("sealing header %x does not match trusted block %x", p.SealingHeader.ID(), sealingBlockID)
+	}
+
+	// the seal must be included in the payload of the sealing block
+	err := verifySealInclusion(p.Seal, p.SealingHeader, p.SealingPayload)
+	if err != nil {
+		return err
+	}
+
+	// the seal must seal the execution result of the block, which is an ancestor of the sealing block
+	blockID := p.Header.ID()
+	if p.Header.Height >= p.SealingHeader.Height {
+		return NewInvalidProofErrorf("block height %d is not below sealing block height %d", p.Header.Height, p.SealingHeader.Height)
+	}
+	if p.Seal.BlockID != blockID {
+		return NewInvalidProofErrorf("seal is for block %x, not block %x", p.Seal.BlockID, blockID)
+	}
+	if p.Result.BlockID != blockID {
+		return NewInvalidProofErrorf("execution result is for block %x, not block %x", p.Result.BlockID, blockID)
+	}
+	resultID := p.Result.ID()
+	if p.Seal.ResultID != resultID {
+		return NewInvalidProofErrorf("seal is for execution result %x, not result %x", p.Seal.ResultID, resultID)
+	}
+	commit, err := p.Result.FinalStateCommitment()
+	if err != nil {
+		return NewInvalidProofErrorf("could not get final state commitment of execution result: %w", err)
+	}
+	if p.Seal.FinalState != commit {
+		return NewInvalidProofErrorf("seal final state %x does not match execution result final state %x", p.Seal.FinalState, commit)
+	}
+
+	return verifyRegisters(p.Registers, p.Proof, commit)
+}
+
+// verifySealInclusion verifies that the seal is included in the given payload of the sealing block.
+func verifySealInclusion(seal *flow.Seal, sealingHeader *flow.Header, sealingPayload *flow.Index) error {
+	payloadHash := flow.ConcatSum(
+		flow.MerkleRoot(sealingPayload.CollectionIDs...),
+		flow.MerkleRoot(sealingPayload.SealIDs...),
+		flow.MerkleRoot(sealingPayload.ReceiptIDs...),
+		flow.MerkleRoot(sealingPayload.ResultIDs...),
+	)
+	if payloadHash != sealingHeader.PayloadHash {
+		return NewInvalidProofErrorf("sealing payload hash %x does not match sealing header payload hash %x", payloadHash, sealingHeader.PayloadHash)
+	}
+	sealID := seal.ID()
+	if !flow.IdentifierList(sealingPayload.SealIDs).Contains(sealID) {
+		return NewInvalidProofErrorf("seal %x is not included in the sealing payload", sealID)
+	}
+	return nil
+}
+
+// verifyRegisters verifies the values of the registers against the state commitment.
+func verifyRegisters(registers []Register, encodedProof flow.StorageProof, commit flow.StateCommitment) error {
+	if len(registers) == 0 {
+		return NewInvalidProofErrorf("no registers")
+	}
+
+	batchProof, err := encoding.DecodeTrieBatchProof(encodedProof)
+	if err != nil {
+		return NewInvalidProofErrorf("could not decode trie batch proof: %w", err)
+	}
+	if !proof.VerifyTrieBatchProof(batchProof, ledger.State(commit)) {
+		return NewInvalidProofErrorf("trie batch proof does not match state commitment %x", commit)
+	}
+
+	// the proofs are not ordered by register, hence we index them by path. The execution state proves absent
+	// registers with inclusion proofs of empty values. Non-inclusion proofs carry neither the path nor the
+	// sibling leaf, so they are not verifiable and never accepted.
+	proofs := make(map[ledger.Path]*ledger.TrieProof, len(batchProof.Proofs))
+	for _, p := range batchProof.Proofs {
+		if p.Inclusion {
+			proofs[p.Path] = p
+		}
+	}
+
+	for _, register := range registers {
+		path, err := pathfinder.KeyToPath(RegisterIDToKey(register.ID), pathFinderVersion)
+		if err != nil {
+			return NewInvalidProofErrorf("could not derive path of register %s: %w", register.ID, err)
+		}
+
+		p, ok := proofs[path]
+		if !ok {
+			return NewInvalidProofErrorf("no inclusion proof for register %s", register.ID)
+		}
+		// the leaf hash commits to the path and value, but not to the key of the payload
+		if !bytes.Equal(p.Payload.Value, register.Value) {
+			return NewInvalidProofErrorf("value of register %s does not match proven value", register.ID)
+		}
+	}
+
+	return nil
+}
+
+// RegisterIDToKey converts a register ID into the ledger key of the register in the execution state.
+func RegisterIDToKey(reg flow.RegisterID) ledger.Key {
+	return ledger.NewKey([]ledger.KeyPart{
+		ledger.NewKeyPart(keyPartOwner, []byte(reg.Owner)),
+		ledger.NewKeyPart(keyPartKey, []byte(reg.Key)),
+	})
+}
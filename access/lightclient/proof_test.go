@@ -0,0 +1,164 @@
+package lightclient_test
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRegisterProof_Verify tests that a register proof is valid if all links of the chain from the registers to
+// the trusted sealing block are valid, and invalid if any link is broken.
+func TestRegisterProof_Verify(t *testing.T) {
+	owner := string(unittest.AddressFixture().Bytes())
+	registers := []lightclient.Register{
+		{ID: flow.NewRegisterID(owner, "storage_used"), Value: []byte{0, 0, 0, 1}},
+		{ID: flow.NewRegisterID(owner, "public_key_count"), Value: []byte{1}},
+	}
+	missing := flow.NewRegisterID(owner, "storage_index")
+
+	valid := func() (*lightclient.RegisterProof, flow.Identifier) {
+		return registerProofFixture(t, registers, missing)
+	}
+
+	t.Run("valid proof", func(t *testing.T) {
+		p, sealingBlockID := valid()
+		require.NoError(t, p.Verify(sealingBlockID))
+	})
+
+	t.Run("absent register", func(t *testing.T) {
+		p, sealingBlockID := valid()
+		p.Registers = append(p.Registers, lightclient.Register{ID: missing})
+		require.NoError(t, p.Verify(sealingBlockID))
+	})
+
+	t.Run("untrusted sealing block", func(t *testing.T) {
+		p, _ := valid()
+		err := p.Verify(unittest.IdentifierFixture())
+		require.True(t, lightclient.IsInvalidProofError(err), err)
+	})
+
+	invalidate := map[string]func(p *lightclient.RegisterProof){
+		"incomplete proof": func(p *lightclient.RegisterProof) {
+			p.Seal = nil
+		},
+		"seal not in sealing payload": func(p *lightclient.RegisterProof) {
+			p.SealingPayload.SealIDs = nil
+		},
+		"seal for other block": func(p *lightclient.RegisterProof) {
+			p.Seal.BlockID = unittest.IdentifierFixture()
+		},
+		"seal for other result": func(p *lightclient.RegisterProof) {
+			p.Result.ExecutionDataID = unittest.IdentifierFixture()
+		},
+		"result for other block": func(p *lightclient.RegisterProof) {
+			p.Header.Height++
+		},
+		"tampered value": func(p *lightclient.RegisterProof) {
+			p.Registers[0].Value = []byte{0, 0, 0, 2}
+		},
+		"tampered register": func(p *lightclient.RegisterProof) {
+			p.Registers[1].ID = missing
+		},
+		"value of absent register": func(p *lightclient.RegisterProof) {
+			p.Registers = append(p.Registers, lightclient.Register{ID: missing, Value: []byte{1}})
+		},
+		"register without proof": func(p *lightclient.RegisterProof) {
+			p.Registers = append(p.Registers, lightclient.Register{ID: flow.NewRegisterID(owner, "contract_names")})
+		},
+		"no registers": func(p *lightclient.RegisterProof) {
+			p.Registers = nil
+		},
+		"corrupted proof": func(p *lightclient.RegisterProof) {
+			p.Proof = p.Proof[:len(p.Proof)/2]
+		},
+	}
+	for name, apply := range invalidate {
+		t.Run(name, func(t *testing.T) {
+			p, sealingBlockID := valid()
+			// the sealing header is the trust anchor, hence it is only modified through its payload
+			apply(p)
+
+			err := p.Verify(sealingBlockID)
+			require.Error(t, err)
+			assert.True(t, lightclient.IsInvalidProofError(err), err)
+		})
+	}
+}
+
+// TestRegisterProof_Encoding tests that proofs are still valid after encoding and decoding them.
+func TestRegisterProof_Encoding(t *testing.T) {
+	// the owner and key of registers are not necessarily valid UTF-8 strings
+	owner := string(unittest.AddressFixture().Bytes())
+	registers := []lightclient.Register{
+		{ID: flow.NewRegisterID(owner, "storage_used"), Value: []byte{0, 0, 0, 1}},
+		{ID: flow.NewRegisterID(owner, string([]byte{'$', 0xff, 0, 0, 0, 0, 0, 0, 1})), Value: []byte{0xfe, 2}},
+	}
+	p, sealingBlockID := registerProofFixture(t, registers, flow.NewRegisterID(owner, "storage_index"))
+
+	encoded, err := p.Encode()
+	require.NoError(t, err)
+	decoded, err := lightclient.DecodeRegisterProof(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, p.Registers, decoded.Registers)
+	require.NoError(t, decoded.Verify(sealingBlockID))
+}
+
+// TestRegisterIDToKey tests that registers are converted into the same ledger keys as in the execution state.
+func TestRegisterIDToKey(t *testing.T) {
+	id := flow.NewRegisterID(string(unittest.AddressFixture().Bytes()), "storage_used")
+	assert.Equal(t, state.RegisterIDToKey(id), lightclient.RegisterIDToKey(id))
+}
+
+// registerProofFixture returns a proof of the given registers, and of the given missing register, together with
+// the ID of the block sealing the execution result.
+func registerProofFixture(t *testing.T, registers []lightclient.Register, missing flow.RegisterID) (*lightclient.RegisterProof, flow.Identifier) {
+	led, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	keys := make([]ledger.Key, 0, len(registers))
+	values := make([]ledger.Value, 0, len(registers))
+	for _, register := range registers {
+		keys = append(keys, state.RegisterIDToKey(register.ID))
+		values = append(values, register.Value)
+	}
+	update, err := ledger.NewUpdate(led.InitialState(), keys, values)
+	require.NoError(t, err)
+	commit, _, err := led.Set(update)
+	require.NoError(t, err)
+
+	query, err := ledger.NewQuery(commit, append(keys, state.RegisterIDToKey(missing)))
+	require.NoError(t, err)
+	proof, err := led.Prove(query)
+	require.NoError(t, err)
+
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	result.Chunks[len(result.Chunks)-1].EndState = flow.StateCommitment(commit)
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+
+	sealingBlock := unittest.BlockWithParentFixture(block.Header)
+	sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+
+	p := &lightclient.RegisterProof{
+		Registers:      append([]lightclient.Register(nil), registers...),
+		Proof:          proof,
+		Header:         block.Header,
+		Result:         result,
+		Seal:           seal,
+		SealingHeader:  sealingBlock.Header,
+		SealingPayload: sealingBlock.Payload.Index(),
+	}
+	return p, sealingBlock.ID()
+}
@@ -9,6 +9,8 @@ import (
 
 	flow "github.com/onflow/flow-go/model/flow"
 
+	lightclient "github.com/onflow/flow-go/access/lightclient"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -155,6 +157,75 @@ func (_m *API) GetAccountAtLatestBlock(ctx context.Context, address flow.Address
 	return r0, r1
 }
 
+// GetAccountEvents provides a mock function with given fields: ctx, address, contract, startHeight, endHeight, cursor, limit
+func (_m *API) GetAccountEvents(ctx context.Context, address flow.Address, contract string, startHeight uint64, endHeight uint64, cursor *flow.AccountIndexCursor, limit uint) (*access.AccountEventsPage, error) {
+	ret := _m.Called(ctx, address, contract, startHeight, endHeight, cursor, limit)
+
+	var r0 *access.AccountEventsPage
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, string, uint64, uint64, *flow.AccountIndexCursor, uint) *access.AccountEventsPage); ok {
+		r0 = rf(ctx, address, contract, startHeight, endHeight, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.AccountEventsPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, string, uint64, uint64, *flow.AccountIndexCursor, uint) error); ok {
+		r1 = rf(ctx, address, contract, startHeight, endHeight, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountRegisterProof provides a mock function with given fields: ctx, address, keys, height
+func (_m *API) GetAccountRegisterProof(ctx context.Context, address flow.Address, keys []string, height uint64) (*lightclient.RegisterProof, error) {
+	ret := _m.Called(ctx, address, keys, height)
+
+	var r0 *lightclient.RegisterProof
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []string, uint64) *lightclient.RegisterProof); ok {
+		r0 = rf(ctx, address, keys, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lightclient.RegisterProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, []string, uint64) error); ok {
+		r1 = rf(ctx, address, keys, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountTransactions provides a mock function with given fields: ctx, address, startHeight, endHeight, cursor, limit
+func (_m *API) GetAccountTransactions(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, cursor *flow.AccountIndexCursor, limit uint) (*access.AccountTransactionsPage, error) {
+	ret := _m.Called(ctx, address, startHeight, endHeight, cursor, limit)
+
+	var r0 *access.AccountTransactionsPage
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, *flow.AccountIndexCursor, uint) *access.AccountTransactionsPage); ok {
+		r0 = rf(ctx, address, startHeight, endHeight, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.AccountTransactionsPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64, *flow.AccountIndexCursor, uint) error); ok {
+		r1 = rf(ctx, address, startHeight, endHeight, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: ctx, height
 func (_m *API) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
 	ret := _m.Called(ctx, height)
@@ -270,6 +341,29 @@ func (_m *API) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow
 	return r0, r1
 }
 
+// GetEpochTransitionProof provides a mock function with given fields: ctx, counter
+func (_m *API) GetEpochTransitionProof(ctx context.Context, counter uint64) (*lightclient.EpochTransitionProof, error) {
+	ret := _m.Called(ctx, counter)
+
+	var r0 *lightclient.EpochTransitionProof
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *lightclient.EpochTransitionProof); ok {
+		r0 = rf(ctx, counter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*lightclient.EpochTransitionProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, counter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsForBlockIDs provides a mock function with given fields: ctx, eventType, blockIDs
 func (_m *API) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error) {
 	ret := _m.Called(ctx, eventType, blockIDs)
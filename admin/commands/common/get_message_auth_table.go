@@ -0,0 +1,41 @@
+package common
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/network"
+)
+
+var _ commands.AdminCommand = (*GetMessageAuthTableCommand)(nil)
+
+// GetMessageAuthTableCommand returns the active message authorization table, which lists the roles
+// authorized to send each message type on each channel.
+type GetMessageAuthTableCommand struct{}
+
+func (g *GetMessageAuthTableCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	table := network.ActiveMessageAuthTable()
+
+	messages := make(map[string]interface{}, len(table.Messages))
+	for name, config := range table.Messages {
+		channels := make(map[string]interface{}, len(config))
+		for channel, roles := range config {
+			roleNames := make([]interface{}, 0, len(roles))
+			for _, role := range roles {
+				roleNames = append(roleNames, role.String())
+			}
+			channels[channel.String()] = roleNames
+		}
+		messages[name] = channels
+	}
+
+	return map[string]interface{}{
+		"version":  table.Version,
+		"messages": messages,
+	}, nil
+}
+
+func (g *GetMessageAuthTableCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
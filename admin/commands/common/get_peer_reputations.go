@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/network/p2p"
+)
+
+var _ commands.AdminCommand = (*GetPeerReputationsCommand)(nil)
+
+// GetPeerReputationsCommand returns the current penalty scores, responses and reported offenses
+// of all remote peers which are tracked by the reputation manager.
+type GetPeerReputationsCommand struct {
+	reputation *p2p.ReputationManager
+}
+
+func NewGetPeerReputationsCommand(reputation *p2p.ReputationManager) *GetPeerReputationsCommand {
+	return &GetPeerReputationsCommand{
+		reputation: reputation,
+	}
+}
+
+func (g *GetPeerReputationsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if g.reputation == nil {
+		return nil, errors.New("peer reputation is not tracked by this node")
+	}
+
+	reputations := g.reputation.Reputations()
+
+	result := make([]interface{}, 0, len(reputations))
+	for _, reputation := range reputations {
+		offenses := make(map[string]interface{}, len(reputation.Offenses))
+		for offense, count := range reputation.Offenses {
+			offenses[offense.String()] = count
+		}
+
+		peer := map[string]interface{}{
+			"peer_id":  reputation.PeerID.String(),
+			"node_id":  reputation.NodeID.String(),
+			"score":    reputation.Score,
+			"response": reputation.Response.String(),
+			"offenses": offenses,
+		}
+		if !reputation.DisconnectedUntil.IsZero() {
+			peer["disconnected_until"] = reputation.DisconnectedUntil.UTC().Format(time.RFC3339)
+		}
+
+		result = append(result, peer)
+	}
+
+	return result, nil
+}
+
+func (g *GetPeerReputationsCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/pruner"
+)
+
+var _ commands.AdminCommand = (*GetPrunerStatusCommand)(nil)
+
+// GetPrunerStatusCommand returns the configuration and progress of the execution data pruner.
+type GetPrunerStatusCommand struct {
+	pruner *pruner.Pruner
+}
+
+func (g *GetPrunerStatusCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	prunableHeight, err := g.pruner.PrunableHeight()
+	if err != nil {
+		return nil, fmt.Errorf("could not get prunable height: %w", err)
+	}
+
+	return map[string]interface{}{
+		"enabled":            g.pruner.Enabled(),
+		"retention_heights":  g.pruner.RetentionHeights(),
+		"last_pruned_height": g.pruner.LastPrunedHeight(),
+		"prunable_height":    prunableHeight,
+	}, nil
+}
+
+func (g *GetPrunerStatusCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+func NewGetPrunerStatusCommand(pruner *pruner.Pruner) commands.AdminCommand {
+	return &GetPrunerStatusCommand{
+		pruner: pruner,
+	}
+}
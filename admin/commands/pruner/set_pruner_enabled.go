@@ -0,0 +1,39 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/pruner"
+)
+
+var _ commands.AdminCommand = (*SetPrunerEnabledCommand)(nil)
+
+// SetPrunerEnabledCommand enables or disables pruning of execution data.
+type SetPrunerEnabledCommand struct {
+	pruner *pruner.Pruner
+}
+
+func (s *SetPrunerEnabledCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	enabled := req.ValidatorData.(bool)
+	s.pruner.SetEnabled(enabled)
+	return "ok", nil
+}
+
+func (s *SetPrunerEnabledCommand) Validator(req *admin.CommandRequest) error {
+	enabled, ok := req.Data.(bool)
+	if !ok {
+		return errors.New("the input must be a boolean")
+	}
+
+	req.ValidatorData = enabled
+	return nil
+}
+
+func NewSetPrunerEnabledCommand(pruner *pruner.Pruner) commands.AdminCommand {
+	return &SetPrunerEnabledCommand{
+		pruner: pruner,
+	}
+}
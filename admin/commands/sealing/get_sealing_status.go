@@ -0,0 +1,129 @@
+package sealing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+var _ commands.AdminCommand = (*GetSealingStatusCommand)(nil)
+
+// DefaultSealingStatusHeights is the number of heights returned if no "to_height" is given.
+const DefaultSealingStatusHeights = 100
+
+// MaxSealingStatusHeights is the maximum number of heights which can be requested at once.
+const MaxSealingStatusHeights = 1000
+
+// SealingStatusProvider provides the approval progress of the execution results tracked by the
+// sealing engine.
+type SealingStatusProvider interface {
+	AssignmentCollectors(fromHeight, toHeight uint64) (*approvals.AssignmentCollectorTreeSnapshot, error)
+}
+
+type getSealingStatusRequest struct {
+	fromHeight *uint64
+	toHeight   *uint64
+}
+
+// GetSealingStatusCommand returns the approval progress of all execution results for the blocks
+// within ["from_height", "to_height"], including results of orphaned forks. By default, the
+// heights start at the first unsealed height. For each result, it reports the approvals collected
+// per chunk for every incorporating block, and the assigned verifiers that have not responded.
+type GetSealingStatusCommand struct {
+	state    protocol.State
+	provider func() SealingStatusProvider
+}
+
+func (g *GetSealingStatusCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*getSealingStatusRequest)
+
+	provider := g.provider()
+	if provider == nil {
+		return nil, errors.New("sealing engine is not started yet")
+	}
+
+	var fromHeight uint64
+	if data.fromHeight != nil {
+		fromHeight = *data.fromHeight
+	} else {
+		sealed, err := g.state.Sealed().Head()
+		if err != nil {
+			return nil, fmt.Errorf("could not get latest sealed block: %w", err)
+		}
+		fromHeight = sealed.Height + 1
+	}
+
+	toHeight := fromHeight + DefaultSealingStatusHeights - 1
+	if data.toHeight != nil {
+		toHeight = *data.toHeight
+	}
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("\"from_height\" %d must not be greater than \"to_height\" %d", fromHeight, toHeight)
+	}
+	if toHeight-fromHeight >= MaxSealingStatusHeights {
+		return nil, fmt.Errorf("too many heights requested: at most %d heights can be requested at once", MaxSealingStatusHeights)
+	}
+
+	snapshot, err := provider.AssignmentCollectors(fromHeight, toHeight)
+	if err != nil {
+		return nil, fmt.Errorf("could not get assignment collectors: %w", err)
+	}
+
+	return commands.ConvertToMap(snapshot)
+}
+
+func (g *GetSealingStatusCommand) Validator(req *admin.CommandRequest) error {
+	data := &getSealingStatusRequest{}
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return errors.New("wrong input format")
+		}
+
+		if fromHeight, ok := input["from_height"]; ok {
+			height, err := parseHeight("from_height", fromHeight)
+			if err != nil {
+				return err
+			}
+			data.fromHeight = &height
+		}
+		if toHeight, ok := input["to_height"]; ok {
+			height, err := parseHeight("to_height", toHeight)
+			if err != nil {
+				return err
+			}
+			data.toHeight = &height
+		}
+		if data.fromHeight != nil && data.toHeight != nil && *data.fromHeight > *data.toHeight {
+			return fmt.Errorf("\"from_height\" must not be greater than \"to_height\"")
+		}
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+func parseHeight(field string, height interface{}) (uint64, error) {
+	h, ok := height.(float64)
+	if !ok || h < 0 || math.Trunc(h) != h {
+		return 0, fmt.Errorf("invalid value for %q: expected a height, but got: %v", field, height)
+	}
+	return uint64(h), nil
+}
+
+// NewGetSealingStatusCommand creates the command. The provider is resolved whenever the command is run,
+// and returns nil if the sealing engine is not available, in which case the command fails.
+func NewGetSealingStatusCommand(state protocol.State, provider func() SealingStatusProvider) commands.AdminCommand {
+	return &GetSealingStatusCommand{
+		state:    state,
+		provider: provider,
+	}
+}
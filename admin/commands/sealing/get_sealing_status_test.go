@@ -0,0 +1,111 @@
+package sealing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// sealingStatusProvider records the requested heights and returns a snapshot with one collector per height.
+type sealingStatusProvider struct {
+	fromHeight uint64
+	toHeight   uint64
+}
+
+func (p *sealingStatusProvider) AssignmentCollectors(fromHeight, toHeight uint64) (*approvals.AssignmentCollectorTreeSnapshot, error) {
+	p.fromHeight, p.toHeight = fromHeight, toHeight
+	snapshot := &approvals.AssignmentCollectorTreeSnapshot{LastSealedHeight: fromHeight - 1}
+	for height := fromHeight; height <= toHeight; height++ {
+		snapshot.Heights = append(snapshot.Heights, approvals.HeightSnapshot{
+			Height: height,
+			Collectors: []approvals.AssignmentCollectorSnapshot{{
+				ResultID: unittest.IdentifierFixture(),
+				Status:   approvals.VerifyingApprovals.String(),
+				IncorporatedResults: []approvals.IncorporatedResultSnapshot{{
+					IncorporatedBlockID: unittest.IdentifierFixture(),
+					Chunks: []approvals.ChunkApprovalsSnapshot{{
+						Approvals:         1,
+						RequiredApprovals: 2,
+						MissingVerifiers:  unittest.IdentifierListFixture(1),
+					}},
+				}},
+			}},
+		})
+	}
+	return snapshot, nil
+}
+
+func TestGetSealingStatus(t *testing.T) {
+	sealed := unittest.BlockHeaderFixture()
+	snapshot := new(protocolmock.Snapshot)
+	snapshot.On("Head").Return(sealed, nil)
+	state := new(protocolmock.State)
+	state.On("Sealed").Return(snapshot)
+
+	run := func(t *testing.T, provider SealingStatusProvider, data interface{}) (map[string]interface{}, error) {
+		command := NewGetSealingStatusCommand(state, func() SealingStatusProvider { return provider })
+		req := &admin.CommandRequest{Data: data}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		if err != nil {
+			return nil, err
+		}
+		return result.(map[string]interface{}), nil
+	}
+
+	t.Run("defaults to unsealed heights", func(t *testing.T) {
+		provider := &sealingStatusProvider{}
+		result, err := run(t, provider, nil)
+		require.NoError(t, err)
+		assert.Equal(t, sealed.Height+1, provider.fromHeight)
+		assert.Equal(t, sealed.Height+DefaultSealingStatusHeights, provider.toHeight)
+		assert.Len(t, result["heights"], DefaultSealingStatusHeights)
+	})
+
+	t.Run("height range", func(t *testing.T) {
+		provider := &sealingStatusProvider{}
+		result, err := run(t, provider, map[string]interface{}{"from_height": float64(10), "to_height": float64(12)})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), provider.fromHeight)
+		assert.Equal(t, uint64(12), provider.toHeight)
+
+		heights := result["heights"].([]interface{})
+		require.Len(t, heights, 3)
+		collector := heights[0].(map[string]interface{})["collectors"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "VerifyingApprovals", collector["status"])
+		chunk := collector["incorporated_results"].([]interface{})[0].(map[string]interface{})["chunks"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, float64(1), chunk["approvals"])
+		assert.Equal(t, float64(2), chunk["required_approvals"])
+		assert.Len(t, chunk["missing_verifiers"], 1)
+	})
+
+	t.Run("too many heights", func(t *testing.T) {
+		_, err := run(t, &sealingStatusProvider{}, map[string]interface{}{"from_height": float64(10), "to_height": float64(10 + MaxSealingStatusHeights)})
+		assert.Error(t, err)
+	})
+
+	t.Run("sealing engine not started", func(t *testing.T) {
+		_, err := run(t, nil, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid heights", func(t *testing.T) {
+		command := NewGetSealingStatusCommand(state, func() SealingStatusProvider { return nil })
+		for _, data := range []interface{}{
+			"heights",
+			map[string]interface{}{"from_height": "10"},
+			map[string]interface{}{"from_height": float64(-1)},
+			map[string]interface{}{"to_height": 1.5},
+			map[string]interface{}{"from_height": float64(20), "to_height": float64(10)},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package sealing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var _ commands.AdminCommand = (*GetVerifierStatsCommand)(nil)
+
+// VerifierStatsProvider provides the responsiveness of verifiers to the approval requests sent by
+// the sealing engine.
+type VerifierStatsProvider interface {
+	VerifierStats() []approvals.VerifierStatus
+}
+
+// verifierStats is the output format of a single verifier. Times are omitted if they are not set.
+type verifierStats struct {
+	VerifierID          flow.Identifier `json:"verifier_id"`
+	Requests            uint64          `json:"requests"`
+	Responses           uint64          `json:"responses"`
+	Misses              uint64          `json:"misses"`
+	ConsecutiveMisses   uint64          `json:"consecutive_misses"`
+	AverageResponseTime string          `json:"average_response_time"`
+	LastRequest         *time.Time      `json:"last_request,omitempty"`
+	LastResponse        *time.Time      `json:"last_response,omitempty"`
+	LastMiss            *time.Time      `json:"last_miss,omitempty"`
+	BackoffUntil        *time.Time      `json:"backoff_until,omitempty"`
+}
+
+// GetVerifierStatsCommand returns, for every verifier which approvals were requested from, the
+// number of requests it responded to or missed, its average response time, and until when no
+// approvals are requested from it because of missed requests.
+type GetVerifierStatsCommand struct {
+	provider func() VerifierStatsProvider
+}
+
+func (g *GetVerifierStatsCommand) Handler(_ context.Context, _ *admin.CommandRequest) (interface{}, error) {
+	provider := g.provider()
+	if provider == nil {
+		return nil, errors.New("sealing engine is not started yet")
+	}
+
+	statuses := provider.VerifierStats()
+	stats := make([]verifierStats, 0, len(statuses))
+	for _, status := range statuses {
+		stats = append(stats, verifierStats{
+			VerifierID:          status.VerifierID,
+			Requests:            status.Requests,
+			Responses:           status.Responses,
+			Misses:              status.Misses,
+			ConsecutiveMisses:   status.ConsecutiveMisses,
+			AverageResponseTime: status.AverageResponseTime().String(),
+			LastRequest:         optionalTime(status.LastRequest),
+			LastResponse:        optionalTime(status.LastResponse),
+			LastMiss:            optionalTime(status.LastMiss),
+			BackoffUntil:        optionalTime(status.BackoffUntil),
+		})
+	}
+
+	return commands.ConvertToInterfaceList(stats)
+}
+
+func (g *GetVerifierStatsCommand) Validator(_ *admin.CommandRequest) error {
+	return nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// NewGetVerifierStatsCommand creates the command. The provider is resolved whenever the command is run,
+// and returns nil if the sealing engine is not available, in which case the command fails.
+func NewGetVerifierStatsCommand(provider func() VerifierStatsProvider) commands.AdminCommand {
+	return &GetVerifierStatsCommand{
+		provider: provider,
+	}
+}
@@ -0,0 +1,94 @@
+package sealing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type verifierStatsProvider []approvals.VerifierStatus
+
+func (p verifierStatsProvider) VerifierStats() []approvals.VerifierStatus {
+	return p
+}
+
+func TestGetVerifierStats(t *testing.T) {
+	run := func(t *testing.T, provider VerifierStatsProvider) ([]interface{}, error) {
+		command := NewGetVerifierStatsCommand(func() VerifierStatsProvider { return provider })
+		req := &admin.CommandRequest{}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		if err != nil {
+			return nil, err
+		}
+		return result.([]interface{}), nil
+	}
+
+	t.Run("verifier stats", func(t *testing.T) {
+		now := time.Now().UTC()
+		responsive := approvals.VerifierStatus{
+			VerifierStats: flow.VerifierStats{
+				VerifierID:        unittest.IdentifierFixture(),
+				Requests:          3,
+				Responses:         2,
+				Misses:            1,
+				TotalResponseTime: 4 * time.Second,
+				LastRequest:       now,
+				LastResponse:      now,
+			},
+		}
+		backedOff := approvals.VerifierStatus{
+			VerifierStats: flow.VerifierStats{
+				VerifierID:        unittest.IdentifierFixture(),
+				Requests:          2,
+				Misses:            2,
+				ConsecutiveMisses: 2,
+				LastRequest:       now,
+				LastMiss:          now,
+			},
+			BackoffUntil: now.Add(time.Minute),
+		}
+
+		result, err := run(t, verifierStatsProvider{responsive, backedOff})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+
+		stats := result[0].(map[string]interface{})
+		assert.Equal(t, responsive.VerifierID.String(), stats["verifier_id"])
+		assert.Equal(t, float64(3), stats["requests"])
+		assert.Equal(t, float64(2), stats["responses"])
+		assert.Equal(t, float64(1), stats["misses"])
+		assert.Equal(t, float64(0), stats["consecutive_misses"])
+		assert.Equal(t, "2s", stats["average_response_time"])
+		assert.Equal(t, now.Format(time.RFC3339Nano), stats["last_response"])
+		assert.NotContains(t, stats, "last_miss")
+		assert.NotContains(t, stats, "backoff_until")
+
+		stats = result[1].(map[string]interface{})
+		assert.Equal(t, backedOff.VerifierID.String(), stats["verifier_id"])
+		assert.Equal(t, float64(2), stats["consecutive_misses"])
+		assert.Equal(t, "0s", stats["average_response_time"])
+		assert.NotContains(t, stats, "last_response")
+		assert.Equal(t, now.Format(time.RFC3339Nano), stats["last_miss"])
+		assert.Equal(t, backedOff.BackoffUntil.Format(time.RFC3339Nano), stats["backoff_until"])
+	})
+
+	t.Run("no verifiers", func(t *testing.T) {
+		result, err := run(t, verifierStatsProvider{})
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("sealing engine not started", func(t *testing.T) {
+		_, err := run(t, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadSlashingEvidenceCommand)(nil)
+
+type readSlashingEvidenceRequest struct {
+	fromView uint64
+	toView   uint64
+}
+
+// ReadSlashingEvidenceCommand returns the slashing evidence recorded by the node, optionally
+// restricted to the views within ["from_view", "to_view"].
+type ReadSlashingEvidenceCommand struct {
+	evidence storage.SlashingEvidence
+}
+
+func (r *ReadSlashingEvidenceCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readSlashingEvidenceRequest)
+
+	evidence, err := r.evidence.ByView(data.fromView, data.toView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slashing evidence: %w", err)
+	}
+
+	return commands.ConvertToInterfaceList(evidence)
+}
+
+func (r *ReadSlashingEvidenceCommand) Validator(req *admin.CommandRequest) error {
+	data := &readSlashingEvidenceRequest{
+		fromView: 0,
+		toView:   math.MaxUint64,
+	}
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return ErrValidatorReqDataFormat
+		}
+
+		var err error
+		if fromView, ok := input["from_view"]; ok {
+			data.fromView, err = parseView("from_view", fromView)
+			if err != nil {
+				return err
+			}
+		}
+		if toView, ok := input["to_view"]; ok {
+			data.toView, err = parseView("to_view", toView)
+			if err != nil {
+				return err
+			}
+		}
+		if data.fromView > data.toView {
+			return fmt.Errorf("\"from_view\" must not be greater than \"to_view\"")
+		}
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+func parseView(field string, view interface{}) (uint64, error) {
+	v, ok := view.(float64)
+	if !ok || v < 0 || math.Trunc(v) != v {
+		return 0, fmt.Errorf("invalid value for %q: expected a view, but got: %v", field, view)
+	}
+	return uint64(v), nil
+}
+
+func NewReadSlashingEvidenceCommand(evidence storage.SlashingEvidence) commands.AdminCommand {
+	return &ReadSlashingEvidenceCommand{
+		evidence,
+	}
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadSlashingEvidence(t *testing.T) {
+	evidence := []*flow.SlashingEvidence{unittest.SlashingEvidenceFixture(), unittest.SlashingEvidenceFixture()}
+
+	read := func(t *testing.T, reqData map[string]interface{}, fromView uint64, toView uint64) []interface{} {
+		store := storagemock.NewSlashingEvidence(t)
+		store.On("ByView", fromView, toView).Return(evidence, nil).Once()
+		command := NewReadSlashingEvidenceCommand(store)
+
+		req := &admin.CommandRequest{Data: reqData}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+		return result.([]interface{})
+	}
+
+	t.Run("all views", func(t *testing.T) {
+		result := read(t, nil, 0, math.MaxUint64)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("view range", func(t *testing.T) {
+		result := read(t, map[string]interface{}{"from_view": float64(10), "to_view": float64(20)}, 10, 20)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("invalid views", func(t *testing.T) {
+		command := NewReadSlashingEvidenceCommand(storagemock.NewSlashingEvidence(t))
+		for _, data := range []interface{}{
+			"views",
+			map[string]interface{}{"from_view": "10"},
+			map[string]interface{}{"from_view": float64(-1)},
+			map[string]interface{}{"to_view": 1.5},
+			map[string]interface{}{"from_view": float64(20), "to_view": float64(10)},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+		}
+	})
+}
@@ -63,7 +63,8 @@ func (ret *FlowAccessAPIForwarder) setFlowAccessAPI(accessNodeAddressAndPort flo
 // It splits requests between a local and a remote API service.
 type FlowAccessAPIRouter struct {
 	access.AccessAPIServer
-	upstream FlowAccessAPIForwarder
+	upstream       FlowAccessAPIForwarder
+	scriptExecMode backend.ScriptExecutionMode
 }
 
 // SetLocalAPI sets the local backend that responds to block related calls
@@ -72,6 +73,28 @@ func (h *FlowAccessAPIRouter) SetLocalAPI(local access.AccessAPIServer) {
 	h.AccessAPIServer = local
 }
 
+// SetScriptExecutionMode sets where scripts and account queries are executed. In local mode they are
+// executed by the local backend, in failover mode they are forwarded to an upstream node if the local
+// execution fails for a reason other than an error of the script. Comparing results is not supported.
+func (h *FlowAccessAPIRouter) SetScriptExecutionMode(mode backend.ScriptExecutionMode) {
+	h.scriptExecMode = mode
+}
+
+// executeLocally returns true if scripts and account queries are executed by the local backend.
+func (h *FlowAccessAPIRouter) executeLocally() bool {
+	return h.scriptExecMode == backend.ScriptExecutionModeLocal || h.scriptExecMode == backend.ScriptExecutionModeFailover
+}
+
+// shouldFailover returns true if a request that failed locally with the given error should be
+// forwarded to an upstream node. Errors caused by the request itself would also occur upstream.
+func (h *FlowAccessAPIRouter) shouldFailover(err error) bool {
+	if err == nil || h.scriptExecMode != backend.ScriptExecutionModeFailover {
+		return false
+	}
+	code := status.Code(err)
+	return code != codes.InvalidArgument && code != codes.NotFound
+}
+
 // reconnectingClient returns an active client, or
 // creates one, if the last one is not ready anymore.
 func (h *FlowAccessAPIForwarder) reconnectingClient(i int) error {
@@ -205,26 +228,62 @@ func (h *FlowAccessAPIRouter) GetTransactionResultByIndex(context context.Contex
 }
 
 func (h *FlowAccessAPIRouter) GetAccount(context context.Context, req *access.GetAccountRequest) (*access.GetAccountResponse, error) {
+	if h.executeLocally() {
+		res, err := h.AccessAPIServer.GetAccount(context, req)
+		if !h.shouldFailover(err) {
+			return res, err
+		}
+	}
 	return h.upstream.GetAccount(context, req)
 }
 
 func (h *FlowAccessAPIRouter) GetAccountAtLatestBlock(context context.Context, req *access.GetAccountAtLatestBlockRequest) (*access.AccountResponse, error) {
+	if h.executeLocally() {
+		res, err := h.AccessAPIServer.GetAccountAtLatestBlock(context, req)
+		if !h.shouldFailover(err) {
+			return res, err
+		}
+	}
 	return h.upstream.GetAccountAtLatestBlock(context, req)
 }
 
 func (h *FlowAccessAPIRouter) GetAccountAtBlockHeight(context context.Context, req *access.GetAccountAtBlockHeightRequest) (*access.AccountResponse, error) {
+	if h.executeLocally() {
+		res, err := h.AccessAPIServer.GetAccountAtBlockHeight(context, req)
+		if !h.shouldFailover(err) {
+			return res, err
+		}
+	}
 	return h.upstream.GetAccountAtBlockHeight(context, req)
 }
 
 func (h *FlowAccessAPIRouter) ExecuteScriptAtLatestBlock(context context.Context, req *access.ExecuteScriptAtLatestBlockRequest) (*access.ExecuteScriptResponse, error) {
+	if h.executeLocally() {
+		res, err := h.AccessAPIServer.ExecuteScriptAtLatestBlock(context, req)
+		if !h.shouldFailover(err) {
+			return res, err
+		}
+	}
 	return h.upstream.ExecuteScriptAtLatestBlock(context, req)
 }
 
 func (h *FlowAccessAPIRouter) ExecuteScriptAtBlockID(context context.Context, req *access.ExecuteScriptAtBlockIDRequest) (*access.ExecuteScriptResponse, error) {
+	if h.executeLocally() {
+		res, err := h.AccessAPIServer.ExecuteScriptAtBlockID(context, req)
+		if !h.shouldFailover(err) {
+			return res, err
+		}
+	}
 	return h.upstream.ExecuteScriptAtBlockID(context, req)
 }
 
 func (h *FlowAccessAPIRouter) ExecuteScriptAtBlockHeight(context context.Context, req *access.ExecuteScriptAtBlockHeightRequest) (*access.ExecuteScriptResponse, error) {
+	if h.executeLocally() {
+		res, err := h.AccessAPIServer.ExecuteScriptAtBlockHeight(context, req)
+		if !h.shouldFailover(err) {
+			return res, err
+		}
+	}
 	return h.upstream.ExecuteScriptAtBlockHeight(context, req)
 }
 
@@ -36,22 +36,28 @@ import (
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/common/follower"
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encoding/cbor"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/compliance"
+	"github.com/onflow/flow-go/module/execution"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/metrics/unstaked"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	edrequester "github.com/onflow/flow-go/module/state_synchronization/requester"
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/network"
@@ -109,6 +115,13 @@ type AccessNodeConfig struct {
 	executionDataDir             string
 	executionDataStartHeight     uint64
 	executionDataConfig          edrequester.ExecutionDataConfig
+	stateStreamEnabled           bool
+	stateStreamConf              state_stream.Config
+	accountIndexEnabled          bool
+	scriptExecutionMode          string
+	registerIndexCheckpoint      string
+	protocolPruningEnabled       bool
+	protocolPrunerConfig         pruner.Config
 	baseOptions                  []cmd.Option
 
 	PublicNetworkConfig PublicNetworkConfig
@@ -166,6 +179,13 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			RetryDelay:         edrequester.DefaultRetryDelay,
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
+		stateStreamEnabled:      false,
+		stateStreamConf:         state_stream.DefaultConfig(),
+		accountIndexEnabled:     false,
+		scriptExecutionMode:     backend.ScriptExecutionModeRemote.String(),
+		registerIndexCheckpoint: "",
+		protocolPruningEnabled:  false,
+		protocolPrunerConfig:    pruner.DefaultConfig(),
 	}
 }
 
@@ -197,6 +217,12 @@ type FlowAccessNodeBuilder struct {
 	FollowerCore               module.HotStuffFollower
 	ExecutionDataService       state_synchronization.ExecutionDataService
 	ExecutionDataRequester     state_synchronization.ExecutionDataRequester
+	StateStreamBackend         *state_stream.Backend
+	AccountIndex               storage.AccountIndex
+	AccountIndexer             *indexer.Indexer
+	RegisterIndex              storage.RegisterIndex
+	RegisterIndexer            *indexer.Indexer
+	ScriptExecutor             execution.ScriptExecutor
 
 	// The sync engine participants provider is the libp2p peer store for the access node
 	// which is not available until after the network has started.
@@ -503,9 +529,168 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionDataRequester() *FlowAccessN
 			return builder.ExecutionDataRequester, nil
 		})
 
+	if builder.stateStreamEnabled {
+		builder.Component("state stream backend", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			// the execution data for all heights up to the last notified height was already
+			// downloaded, and can be streamed immediately
+			highestExecDataHeight, err := processedNotifications.ProcessedIndex()
+			if err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return nil, fmt.Errorf("could not get highest notified execution data height: %w", err)
+				}
+				highestExecDataHeight = builder.executionDataConfig.InitialBlockHeight
+			}
+
+			builder.StateStreamBackend, err = state_stream.New(
+				node.Logger,
+				builder.stateStreamConf,
+				node.State,
+				node.Storage.Headers,
+				node.Storage.Seals,
+				node.Storage.Results,
+				builder.ExecutionDataService,
+				builder.RootBlock.Header.Height,
+				builder.executionDataConfig.InitialBlockHeight+1,
+				highestExecDataHeight,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create state stream backend: %w", err)
+			}
+
+			builder.FinalizationDistributor.AddOnBlockFinalizedConsumer(builder.StateStreamBackend.OnFinalizedBlock)
+			builder.ExecutionDataRequester.AddOnExecutionDataFetchedConsumer(builder.StateStreamBackend.OnExecutionData)
+
+			return &module.NoopReadyDoneAware{}, nil
+		})
+	}
+
+	if builder.accountIndexEnabled {
+		builder.Module("account index storage", func(node *cmd.NodeConfig) error {
+			// the index is created as a module, since the RPC engine may be built before the indexer
+			builder.AccountIndex = bstorage.NewAccountIndex(node.DB)
+			return nil
+		})
+
+		builder.Component("account indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			// the execution data for all heights up to the last notified height was already
+			// downloaded, and can be indexed immediately
+			highestExecDataHeight, err := processedNotifications.ProcessedIndex()
+			if err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return nil, fmt.Errorf("could not get highest notified execution data height: %w", err)
+				}
+				highestExecDataHeight = builder.executionDataConfig.InitialBlockHeight
+			}
+
+			builder.AccountIndexer = indexer.NewIndexer(
+				node.Logger.With().Str("component", "account_indexer").Logger(),
+				indexer.NewAccountIndexer(node.Logger, builder.AccountIndex),
+				node.Storage.Headers,
+				node.Storage.Seals,
+				node.Storage.Results,
+				builder.ExecutionDataService,
+				builder.executionDataConfig.InitialBlockHeight+1,
+				highestExecDataHeight,
+			)
+
+			builder.ExecutionDataRequester.AddOnExecutionDataFetchedConsumer(builder.AccountIndexer.OnExecutionData)
+
+			return builder.AccountIndexer, nil
+		})
+	}
+
+	if builder.rpcConf.ScriptExecutionMode != backend.ScriptExecutionModeRemote {
+		builder.Module("register index storage", func(node *cmd.NodeConfig) error {
+			// the index and script executor are created as a module, since the RPC engine may be
+			// built before the indexer
+			builder.RegisterIndex = bstorage.NewRegisterIndex(node.DB)
+			builder.ScriptExecutor = execution.NewScripts(
+				node.Logger,
+				fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()),
+				fvm.NewContext(node.Logger, node.FvmOptions...),
+				node.Storage.Headers,
+				builder.RegisterIndex,
+				execution.DefaultScriptExecutionTimeLimit,
+			)
+			return nil
+		})
+
+		builder.Component("register indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			registerIndexer := indexer.NewRegisterIndexer(node.Logger, builder.RegisterIndex)
+
+			_, err := builder.RegisterIndex.FirstHeight()
+			if errors.Is(err, storage.ErrNotFound) {
+				// the index is bootstrapped with the execution state at the height the execution
+				// data sync starts from, which must be contained in the checkpoint
+				commit, err := builder.sealedStateCommitment(node, builder.executionDataConfig.InitialBlockHeight)
+				if err != nil {
+					return nil, err
+				}
+
+				checkpoint := builder.registerIndexCheckpoint
+				if checkpoint == "" {
+					checkpoint = filepath.Join(node.BootstrapDir, bootstrap.PathRootCheckpoint)
+				}
+
+				err = registerIndexer.Bootstrap(checkpoint, builder.executionDataConfig.InitialBlockHeight, commit)
+				if err != nil {
+					return nil, fmt.Errorf("could not bootstrap register index: %w", err)
+				}
+			} else if err != nil {
+				return nil, fmt.Errorf("could not get first indexed register height: %w", err)
+			}
+
+			// the execution data for all heights up to the last notified height was already
+			// downloaded, and can be indexed immediately
+			highestExecDataHeight, err := processedNotifications.ProcessedIndex()
+			if err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return nil, fmt.Errorf("could not get highest notified execution data height: %w", err)
+				}
+				highestExecDataHeight = builder.executionDataConfig.InitialBlockHeight
+			}
+
+			builder.RegisterIndexer = indexer.NewIndexer(
+				node.Logger.With().Str("component", "register_indexer").Logger(),
+				registerIndexer,
+				node.Storage.Headers,
+				node.Storage.Seals,
+				node.Storage.Results,
+				builder.ExecutionDataService,
+				builder.executionDataConfig.InitialBlockHeight+1,
+				highestExecDataHeight,
+			)
+
+			builder.ExecutionDataRequester.AddOnExecutionDataFetchedConsumer(builder.RegisterIndexer.OnExecutionData)
+
+			return builder.RegisterIndexer, nil
+		})
+	}
+
 	return builder
 }
 
+// sealedStateCommitment returns the state commitment of the execution result sealed for the block at
+// the given height.
+func (builder *FlowAccessNodeBuilder) sealedStateCommitment(node *cmd.NodeConfig, height uint64) (flow.StateCommitment, error) {
+	if height == builder.RootBlock.Header.Height {
+		return builder.RootSeal.FinalState, nil
+	}
+
+	header, err := node.Storage.Headers.ByHeight(height)
+	if err != nil {
+		return flow.DummyStateCommitment, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+	blockID := header.ID()
+
+	seal, err := node.Storage.Seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return flow.DummyStateCommitment, fmt.Errorf("could not get seal for block %v: %w", blockID, err)
+	}
+
+	return seal.FinalState, nil
+}
+
 type Option func(*AccessNodeConfig)
 
 func FlowAccessNode(opts ...Option) *FlowAccessNodeBuilder {
@@ -568,6 +753,26 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.DurationVar(&builder.executionDataConfig.MaxFetchTimeout, "execution-data-max-fetch-timeout", defaultConfig.executionDataConfig.MaxFetchTimeout, "maximum timeout to use when fetching execution data from the network e.g. 300s")
 		flags.DurationVar(&builder.executionDataConfig.RetryDelay, "execution-data-retry-delay", defaultConfig.executionDataConfig.RetryDelay, "initial delay for exponential backoff when fetching execution data fails e.g. 10s")
 		flags.DurationVar(&builder.executionDataConfig.MaxRetryDelay, "execution-data-max-retry-delay", defaultConfig.executionDataConfig.MaxRetryDelay, "maximum delay for exponential backoff when fetching execution data fails e.g. 5m")
+
+		// Execution State Streaming API
+		flags.BoolVar(&builder.stateStreamEnabled, "state-stream-enabled", defaultConfig.stateStreamEnabled, "whether to enable the streaming API for block headers, events, execution data and transaction statuses. requires execution-data-sync-enabled")
+		flags.DurationVar(&builder.stateStreamConf.ClientSendTimeout, "state-stream-send-timeout", defaultConfig.stateStreamConf.ClientSendTimeout, "maximum wait before timing out while sending a response to a streaming client e.g. 30s")
+		flags.UintVar(&builder.stateStreamConf.ClientSendBufferSize, "state-stream-send-buffer-size", defaultConfig.stateStreamConf.ClientSendBufferSize, "maximum number of responses to buffer within a stream")
+		flags.Uint32Var(&builder.stateStreamConf.MaxGlobalStreams, "state-stream-global-max-streams", defaultConfig.stateStreamConf.MaxGlobalStreams, "global maximum number of concurrent streams")
+		flags.Uint32Var(&builder.stateStreamConf.ExecutionDataCacheSize, "state-stream-execution-data-cache-size", defaultConfig.stateStreamConf.ExecutionDataCacheSize, "max number of execution data entries to cache for streaming")
+		flags.Float64Var(&builder.stateStreamConf.ResponseLimit, "state-stream-response-limit", defaultConfig.stateStreamConf.ResponseLimit, "max number of responses per second to send over a stream. 0 means no limit")
+
+		// Account index
+		flags.BoolVar(&builder.accountIndexEnabled, "account-index-enabled", defaultConfig.accountIndexEnabled, "whether to index transactions and events by account address, and serve the account history API. requires execution-data-sync-enabled")
+
+		// Local script execution
+		flags.StringVar(&builder.scriptExecutionMode, "script-execution-mode", defaultConfig.scriptExecutionMode, "where scripts and account queries are executed, one of remote, local, failover or compare. all modes other than remote require execution-data-sync-enabled")
+		flags.StringVar(&builder.registerIndexCheckpoint, "register-index-checkpoint", defaultConfig.registerIndexCheckpoint, "checkpoint file used to bootstrap the register index, defaults to the root checkpoint in the bootstrap directory")
+
+		// Protocol state pruning
+		flags.BoolVar(&builder.protocolPruningEnabled, "protocol-pruning-enabled", defaultConfig.protocolPruningEnabled, "whether to prune block payloads, collections and transactions of blocks older than protocol-pruning-retention-heights")
+		flags.Uint64Var(&builder.protocolPrunerConfig.RetentionHeights, "protocol-pruning-retention-heights", defaultConfig.protocolPrunerConfig.RetentionHeights, "number of heights below the latest sealed height for which block payloads, collections and transactions are kept")
+		flags.Float64Var(&builder.protocolPrunerConfig.MaxHeightsPerSecond, "protocol-pruning-max-heights-per-second", defaultConfig.protocolPrunerConfig.MaxHeightsPerSecond, "max number of heights pruned per second. 0 means no limit")
 	}).ValidateFlags(func() error {
 		if builder.supportsObserver && (builder.PublicNetworkConfig.BindAddress == cmd.NotSet || builder.PublicNetworkConfig.BindAddress == "") {
 			return errors.New("public-network-address must be set if supports-observer is true")
@@ -589,6 +794,39 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 				return errors.New("execution-data-max-search-ahead must be greater than 0")
 			}
 		}
+		if builder.stateStreamEnabled {
+			if !builder.executionDataSyncEnabled {
+				return errors.New("execution-data-sync-enabled must be set if state-stream-enabled is true")
+			}
+			if builder.stateStreamConf.ClientSendTimeout <= 0 {
+				return errors.New("state-stream-send-timeout must be greater than 0")
+			}
+			if builder.stateStreamConf.ClientSendBufferSize == 0 {
+				return errors.New("state-stream-send-buffer-size must be greater than 0")
+			}
+			if builder.stateStreamConf.MaxGlobalStreams == 0 {
+				return errors.New("state-stream-global-max-streams must be greater than 0")
+			}
+			if builder.stateStreamConf.ResponseLimit < 0 {
+				return errors.New("state-stream-response-limit must be greater than or equal to 0")
+			}
+		}
+		if builder.accountIndexEnabled && !builder.executionDataSyncEnabled {
+			return errors.New("execution-data-sync-enabled must be set if account-index-enabled is true")
+		}
+
+		scriptExecutionMode, err := backend.ParseScriptExecutionMode(builder.scriptExecutionMode)
+		if err != nil {
+			return fmt.Errorf("invalid script-execution-mode: %w", err)
+		}
+		if scriptExecutionMode != backend.ScriptExecutionModeRemote && !builder.executionDataSyncEnabled {
+			return errors.New("execution-data-sync-enabled must be set if script-execution-mode is not remote")
+		}
+		builder.rpcConf.ScriptExecutionMode = scriptExecutionMode
+
+		if builder.protocolPruningEnabled && builder.protocolPrunerConfig.RetentionHeights < pruner.MinRetentionHeights {
+			return fmt.Errorf("protocol-pruning-retention-heights must be at least %d", pruner.MinRetentionHeights)
+		}
 
 		return nil
 	})
@@ -713,8 +951,14 @@ func (builder *FlowAccessNodeBuilder) enqueueRelayNetwork() {
 }
 
 func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
+	builder.BuildConsensusFollower()
+
+	// the execution data requester is built before the RPC engine, which serves the streaming API
+	if builder.executionDataSyncEnabled {
+		builder.BuildExecutionDataRequester()
+	}
+
 	builder.
-		BuildConsensusFollower().
 		Module("collection node client", func(node *cmd.NodeConfig) error {
 			// collection node address is optional (if not specified, collection nodes will be chosen at random)
 			if strings.TrimSpace(builder.rpcConf.CollectionAddr) == "" {
@@ -812,6 +1056,7 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				node.Storage.Transactions,
 				node.Storage.Receipts,
 				node.Storage.Results,
+				node.Storage.Seals,
 				node.RootChainID,
 				builder.TransactionMetrics,
 				builder.AccessMetrics,
@@ -821,15 +1066,24 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.rpcMetricsEnabled,
 				builder.apiRatelimits,
 				builder.apiBurstlimits,
+				builder.AccountIndex,
+				builder.ScriptExecutor,
 			)
 			if err != nil {
 				return nil, err
 			}
 
-			builder.RpcEng = engineBuilder.
+			engineBuilder.
 				WithLegacy().
-				WithBlockSignerDecoder(signature.NewBlockSignerDecoder(builder.Committee)).
-				Build()
+				WithBlockSignerDecoder(signature.NewBlockSignerDecoder(builder.Committee))
+
+			if builder.stateStreamEnabled {
+				// transaction statuses are looked up using the access API served by this engine
+				builder.StateStreamBackend.SetTransactionsAPI(engineBuilder.Backend())
+				engineBuilder.WithStateStream(builder.StateStreamBackend, builder.stateStreamConf)
+			}
+
+			builder.RpcEng = engineBuilder.Build()
 			return builder.RpcEng, nil
 		}).
 		Component("ingestion engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
@@ -882,6 +1136,30 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			return builder.RequestEng, nil
 		})
 
+	if builder.protocolPruningEnabled {
+		builder.Component("protocol state pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			protocolPruner := pruner.New(
+				node.Logger,
+				builder.protocolPrunerConfig,
+				node.State,
+				node.Storage.PayloadPruner,
+				node.RootBlock.Header.Height,
+				// collections of blocks above the last full block height are still requested by the
+				// ingestion engine, which reads the block payloads to do so
+				pruner.WithHeightLimit(func() (uint64, error) {
+					height, err := node.Storage.Blocks.GetLastFullBlockHeight()
+					if errors.Is(err, storage.ErrNotFound) {
+						return node.RootBlock.Header.Height, nil
+					}
+					return height, err
+				}),
+			)
+			builder.FinalizationDistributor.AddOnBlockFinalizedConsumer(protocolPruner.OnBlockFinalized)
+
+			return protocolPruner, nil
+		})
+	}
+
 	if builder.supportsObserver {
 		builder.Component("public sync request handler", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			syncRequestHandler, err := synceng.NewRequestHandlerEngine(
@@ -902,10 +1180,6 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 		})
 	}
 
-	if builder.executionDataSyncEnabled {
-		builder.BuildExecutionDataRequester()
-	}
-
 	builder.Component("ping engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 		ping, err := pingeng.New(
 			node.Logger,
@@ -937,7 +1211,13 @@ func (builder *FlowAccessNodeBuilder) enqueuePublicNetworkInit() {
 
 		msgValidators := publicNetworkMsgValidators(node.Logger.With().Bool("public", true).Logger(), node.IdentityProvider, builder.NodeID)
 
-		middleware := builder.initMiddleware(builder.NodeID, builder.PublicNetworkConfig.Metrics, libP2PFactory, msgValidators...)
+		// unstaked peers are rate limited more strictly than staked peers
+		rateLimiter, err := p2p.NewRateLimiter(node.Logger.With().Bool("public", true).Logger(), p2p.DefaultPublicRateLimiterConfig(), builder.PublicNetworkConfig.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("could not create public network rate limiter: %w", err)
+		}
+
+		middleware := builder.initMiddleware(builder.NodeID, builder.PublicNetworkConfig.Metrics, libP2PFactory, rateLimiter, msgValidators...)
 
 		// topology returns empty list since peers are not known upfront
 		top := topology.EmptyListTopology{}
@@ -950,7 +1230,7 @@ func (builder *FlowAccessNodeBuilder) enqueuePublicNetworkInit() {
 			builder.Logger,
 			heroCacheCollector)
 
-		err := node.Metrics.Mempool.Register(metrics.ResourcePublicNetworkingReceiveCache, receiveCache.Size)
+		err = node.Metrics.Mempool.Register(metrics.ResourcePublicNetworkingReceiveCache, receiveCache.Size)
 		if err != nil {
 			return nil, fmt.Errorf("could not register networking receive cache metric: %w", err)
 		}
@@ -1011,10 +1291,11 @@ func (builder *FlowAccessNodeBuilder) initLibP2PFactory(networkKey crypto.Privat
 }
 
 // initMiddleware creates the network.Middleware implementation with the libp2p factory function, metrics, peer update
-// interval, and validators. The network.Middleware is then passed into the initNetwork function.
+// interval, inbound rate limiter and validators. The network.Middleware is then passed into the initNetwork function.
 func (builder *FlowAccessNodeBuilder) initMiddleware(nodeID flow.Identifier,
 	networkMetrics module.NetworkMetrics,
 	factoryFunc p2p.LibP2PFactoryFunc,
+	rateLimiter *p2p.RateLimiter,
 	validators ...network.MessageValidator) network.Middleware {
 
 	// disable connection pruning for the access node which supports the observer
@@ -1031,6 +1312,7 @@ func (builder *FlowAccessNodeBuilder) initMiddleware(nodeID flow.Identifier,
 		builder.CodecFactory(),
 		p2p.WithMessageValidators(validators...),
 		p2p.WithPeerManager(peerManagerFactory),
+		p2p.WithRateLimiter(rateLimiter),
 		// use default identifier provider
 	)
 
@@ -53,6 +53,7 @@ func main() {
 
 	var (
 		txLimit                                uint
+		txPriority                             string
 		maxCollectionSize                      uint
 		maxCollectionByteSize                  uint64
 		maxCollectionTotalGas                  uint64
@@ -98,6 +99,9 @@ func main() {
 	nodeBuilder.ExtraFlags(func(flags *pflag.FlagSet) {
 		flags.UintVar(&txLimit, "tx-limit", 50_000,
 			"maximum number of transactions in the memory pool")
+		flags.StringVar(&txPriority, "tx-priority", "",
+			"priority of transactions in the memory pool and proposed collections (empty for arrival order, or gas-limit). "+
+				"The gas limit is chosen freely by the sender and is not charged for, so gas-limit priority can be claimed by anyone at no cost")
 		flags.StringVarP(&rpcConf.ListenAddr, "ingress-addr", "i", "localhost:9000",
 			"the address the ingress server listens on")
 		flags.BoolVar(&rpcConf.RpcMetricsEnabled, "rpc-metrics-enabled", false,
@@ -114,6 +118,18 @@ func main() {
 			"expiry buffer for inbound transactions")
 		flags.UintVar(&ingestConf.PropagationRedundancy, "ingest-tx-propagation-redundancy", 10,
 			"how many additional cluster members we propagate transactions to")
+		flags.BoolVar(&ingestConf.CheckAccounts, "ingest-check-accounts", false,
+			"whether we check inbound transactions against the state of their proposer and payer accounts, retrieved from the access nodes")
+		flags.UintVar(&ingestConf.AccountCacheSize, "ingest-account-cache-size", ingestConf.AccountCacheSize,
+			"maximum number of accounts cached for account checks of inbound transactions")
+		flags.DurationVar(&ingestConf.AccountCacheTTL, "ingest-account-cache-ttl", ingestConf.AccountCacheTTL,
+			"how long accounts are cached for account checks of inbound transactions")
+		flags.DurationVar(&ingestConf.AccountRequestTimeout, "ingest-account-request-timeout", ingestConf.AccountRequestTimeout,
+			"timeout for retrieving the accounts of an inbound transaction, after which it is accepted without account checks")
+		flags.UintVar(&ingestConf.MaxAccountRequests, "ingest-max-account-requests", ingestConf.MaxAccountRequests,
+			"maximum number of concurrent account requests for account checks, beyond which inbound transactions are accepted without account checks")
+		flags.Uint64Var(&ingestConf.MinPayerBalance, "ingest-min-payer-balance", ingestConf.MinPayerBalance,
+			"minimum balance of the payer of inbound transactions, if account checks are enabled")
 		flags.UintVar(&builderExpiryBuffer, "builder-expiry-buffer", builder.DefaultExpiryBuffer,
 			"expiry buffer for transactions in proposed collections")
 		flags.Float64Var(&builderPayerRateLimit, "builder-rate-limit", builder.DefaultMaxPayerTransactionRate, // no rate limiting
@@ -186,13 +202,24 @@ func main() {
 			return err
 		}).
 		Module("transactions mempool", func(node *cmd.NodeConfig) error {
+			var priority mempool.TransactionPriorityFunc
+			switch txPriority {
+			case "":
+				// transactions are ordered by arrival
+			case "gas-limit":
+				priority = mempool.TransactionPriorityGasLimit
+			default:
+				return fmt.Errorf("invalid transaction priority: %s", txPriority)
+			}
+
 			create := func(epoch uint64) mempool.Transactions {
 				var heroCacheMetricsCollector module.HeroCacheMetrics = metrics.NewNoopCollector()
 				if node.BaseConfig.HeroCacheMetricsEnable {
 					heroCacheMetricsCollector = metrics.CollectionNodeTransactionsCacheMetrics(node.MetricsRegisterer, epoch)
 				}
-				return herocache.NewTransactions(
+				return herocache.NewPriorityTransactions(
 					uint32(txLimit),
+					priority,
 					node.Logger,
 					heroCacheMetricsCollector)
 			}
@@ -348,6 +375,16 @@ func main() {
 			return sync, nil
 		}).
 		Component("ingestion engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var accounts ingest.AccountProvider
+			if ingestConf.CheckAccounts {
+				//@TODO use fallback logic for flowClient similar to DKG/QC contract clients
+				flowClient, err := common.FlowClient(flowClientConfigs[0])
+				if err != nil {
+					return nil, fmt.Errorf("failed to get flow client connection option for access node (0): %s %w", flowClientConfigs[0].AccessAddress, err)
+				}
+				accounts = ingest.NewAccessAccountProvider(flowClient)
+			}
+
 			ing, err = ingest.New(
 				node.Logger,
 				node.Network,
@@ -358,6 +395,7 @@ func main() {
 				node.Me,
 				node.RootChainID.Chain(),
 				pools,
+				accounts,
 				ingestConf,
 			)
 			return ing, err
@@ -10,18 +10,22 @@ import (
 	"path/filepath"
 	"time"
 
+	badgerDB "github.com/dgraph-io/badger/v2"
 	"github.com/spf13/pflag"
 
 	client "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go/admin/commands"
 	admincommon "github.com/onflow/flow-go/admin/commands/common"
+	sealingCommands "github.com/onflow/flow-go/admin/commands/sealing"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/blockproducer"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
@@ -65,6 +69,7 @@ import (
 	"github.com/onflow/flow-go/state/protocol/events/gadgets"
 	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
+	sutil "github.com/onflow/flow-go/storage/util"
 	"github.com/onflow/flow-go/utils/io"
 )
 
@@ -93,6 +98,7 @@ func main() {
 		dkgControllerConfig                    dkgmodule.ControllerConfig
 		startupTimeString                      string
 		startupTime                            time.Time
+		slashingEvidenceDir                    string
 
 		// DKG contract client
 		machineAccountInfo *bootstrap.NodeMachineAccountInfo
@@ -124,6 +130,9 @@ func main() {
 		safeBeaconKeys               *bstorage.SafeBeaconPrivateKeys
 		adminCmdSetRequiredApprovals commands.AdminCommand
 		getSealingConfigs            module.SealingConfigsGetter
+		slashingEvidence             *bstorage.SlashingEvidence
+		slashingEvidenceConsumer     *notifications.SlashingEvidenceConsumer
+		sealingEngine                *sealing.Engine
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleConsensus.String())
@@ -154,6 +163,7 @@ func main() {
 		flags.DurationVar(&dkgControllerConfig.BaseStartDelay, "dkg-controller-base-start-delay", dkgmodule.DefaultBaseStartDelay, "used to define the range for jitter prior to DKG start (eg. 500µs) - the base value is scaled quadratically with the # of DKG participants")
 		flags.DurationVar(&dkgControllerConfig.BaseHandleFirstBroadcastDelay, "dkg-controller-base-handle-first-broadcast-delay", dkgmodule.DefaultBaseHandleFirstBroadcastDelay, "used to define the range for jitter prior to DKG handling the first broadcast messages (eg. 50ms) - the base value is scaled quadratically with the # of DKG participants")
 		flags.DurationVar(&dkgControllerConfig.HandleSubsequentBroadcastDelay, "dkg-controller-handle-subsequent-broadcast-delay", dkgmodule.DefaultHandleSubsequentBroadcastDelay, "used to define the constant delay introduced prior to DKG handling subsequent broadcast messages (eg. 2s)")
+		flags.StringVar(&slashingEvidenceDir, "slashing-evidence-dir", "/data/slashing-evidence", "directory to store the evidence of slashable violations detected by hotstuff")
 		flags.StringVar(&startupTimeString, "hotstuff-startup-time", cmd.NotSet, "specifies date and time (in ISO 8601 format) after which the consensus participant may enter the first view (e.g 1996-04-24T15:04:05-07:00)")
 	}).ValidateFlags(func() error {
 		nodeBuilder.Logger.Info().Str("startup_time_str", startupTimeString).Msg("got startup_time_str")
@@ -186,6 +196,23 @@ func main() {
 			safeBeaconKeys = bstorage.NewSafeBeaconPrivateKeys(dkgState)
 			return nil
 		}).
+		Module("slashing evidence storage", func(node *cmd.NodeConfig) error {
+			err := os.MkdirAll(slashingEvidenceDir, 0700)
+			if err != nil {
+				return fmt.Errorf("could not create slashing evidence dir: %w", err)
+			}
+			db, err := bstorage.InitSlashingEvidence(badgerDB.DefaultOptions(slashingEvidenceDir).WithLogger(sutil.NewLogger(node.Logger)))
+			if err != nil {
+				return fmt.Errorf("could not open slashing evidence db: %w", err)
+			}
+			nodeBuilder.ShutdownFunc(db.Close)
+
+			slashingEvidence, err = bstorage.NewSlashingEvidence(db)
+			return err
+		}).
+		AdminCommand("read-slashing-evidence", func(node *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadSlashingEvidenceCommand(slashingEvidence)
+		}).
 		Module("requiredApprovalsForSealConstruction setter", func(node *cmd.NodeConfig) error {
 			setter, err := updatable_configs.NewSealingConfigs(
 				requiredApprovalsForSealConstruction,
@@ -213,6 +240,26 @@ func main() {
 		AdminCommand("get-required-approvals-for-sealing", func(node *cmd.NodeConfig) commands.AdminCommand {
 			return admincommon.NewGetRequiredApprovalsForSealingCommand(getSealingConfigs)
 		}).
+		AdminCommand("get-sealing-status", func(node *cmd.NodeConfig) commands.AdminCommand {
+			return sealingCommands.NewGetSealingStatusCommand(node.State, func() sealingCommands.SealingStatusProvider {
+				// the admin commands are created by the admin server, which is the last component
+				// to start, after the sealing engine component has been created and is ready
+				if sealingEngine == nil {
+					return nil
+				}
+				return sealingEngine
+			})
+		}).
+		AdminCommand("get-verifier-stats", func(node *cmd.NodeConfig) commands.AdminCommand {
+			return sealingCommands.NewGetVerifierStatsCommand(func() sealingCommands.VerifierStatsProvider {
+				// the admin commands are created by the admin server, which is the last component
+				// to start, after the sealing engine component has been created and is ready
+				if sealingEngine == nil {
+					return nil
+				}
+				return sealingEngine
+			})
+		}).
 		Module("mutable follower state", func(node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -425,7 +472,9 @@ func main() {
 				chunkAssigner,
 				seals,
 				getSealingConfigs,
+				bstorage.NewVerifierStats(node.DB),
 			)
+			sealingEngine = e
 
 			// subscribe for finalization events from hotstuff
 			finalizationDistributor.AddOnBlockFinalizedConsumer(e.OnFinalizedBlock)
@@ -519,6 +568,20 @@ func main() {
 
 			return ing, err
 		}).
+		Component("slashing evidence consumer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var err error
+			slashingEvidenceConsumer, err = notifications.NewSlashingEvidenceConsumer(
+				node.Logger,
+				node.Me,
+				node.State,
+				node.Storage.Headers,
+				slashingEvidence,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create slashing evidence consumer: %w", err)
+			}
+			return slashingEvidenceConsumer, nil
+		}).
 		Component("hotstuff modules", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			// initialize the block finalizer
 			finalize := finalizer.NewFinalizer(
@@ -563,6 +626,7 @@ func main() {
 			)
 
 			notifier.AddConsumer(finalizationDistributor)
+			notifier.AddConsumer(slashingEvidenceConsumer)
 
 			// initialize the persister
 			persist := persister.New(node.DB, node.RootChainID)
@@ -12,6 +12,7 @@ import (
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	badgerDB "github.com/dgraph-io/badger/v2"
 	"github.com/ipfs/go-bitswap"
 	badger "github.com/ipfs/go-ds-badger2"
 	"github.com/onflow/cadence/runtime"
@@ -23,6 +24,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-go/admin/commands"
+	prunerCommands "github.com/onflow/flow-go/admin/commands/pruner"
 	stateSyncCommands "github.com/onflow/flow-go/admin/commands/state_synchronization"
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	uploaderCommands "github.com/onflow/flow-go/admin/commands/uploader"
@@ -43,6 +45,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
+	"github.com/onflow/flow-go/engine/execution/pruner"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
@@ -53,6 +56,7 @@ import (
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/history"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encoding/cbor"
@@ -72,6 +76,7 @@ import (
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
 	storage "github.com/onflow/flow-go/storage/badger"
+	sutil "github.com/onflow/flow-go/storage/util"
 )
 
 type ExecutionConfig struct {
@@ -82,6 +87,12 @@ type ExecutionConfig struct {
 	transactionResultsCacheSize uint
 	checkpointDistance          uint
 	checkpointsToKeep           uint
+	deltaCheckpointsEnabled     bool
+	maxDeltaCheckpointsSize     uint64
+	fullCheckpointInterval      time.Duration
+	ledgerHistoryEnabled        bool
+	ledgerHistoryDir            string
+	ledgerHistoryConfig         history.Config
 	stateDeltasLimit            uint
 	cadenceExecutionCache       uint
 	cadenceTracing              bool
@@ -104,6 +115,7 @@ type ExecutionConfig struct {
 	edsDatastoreTTL             time.Duration
 	apiRatelimits               map[string]int
 	apiBurstlimits              map[string]int
+	prunerConfig                pruner.Config
 }
 
 type ExecutionNodeBuilder struct {
@@ -132,6 +144,20 @@ func (e *ExecutionNodeBuilder) LoadFlags() {
 			flags.Uint32Var(&e.exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
 			flags.UintVar(&e.exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 			flags.UintVar(&e.exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+			flags.BoolVar(&e.exeConf.deltaCheckpointsEnabled, "delta-checkpoints-enabled", false,
+				"create delta checkpoints which only contain the trie nodes created since the previous checkpoint")
+			flags.Uint64Var(&e.exeConf.maxDeltaCheckpointsSize, "max-delta-checkpoints-size", wal.DefaultMaxDeltaCheckpointsSize,
+				"total size in bytes of delta checkpoints on top of a full checkpoint, after which a full checkpoint is created (0 for no limit)")
+			flags.DurationVar(&e.exeConf.fullCheckpointInterval, "full-checkpoint-interval", wal.DefaultFullCheckpointInterval,
+				"maximum age of the latest full checkpoint, after which a full checkpoint is created instead of a delta checkpoint (0 for no limit)")
+			flags.BoolVar(&e.exeConf.ledgerHistoryEnabled, "ledger-history-enabled", false,
+				"store all tries of the execution state on disk, so that registers and proofs can be read for states evicted from memory")
+			flags.StringVar(&e.exeConf.ledgerHistoryDir, "ledger-history-dir", filepath.Join(homedir, ".flow", "execution_history"),
+				"directory of the database which stores the tries of the execution state in historical mode")
+			flags.IntVar(&e.exeConf.ledgerHistoryConfig.NodeCacheSize, "ledger-history-node-cache-size", history.DefaultNodeCacheSize,
+				"number of trie nodes of the historical store cached in memory")
+			flags.IntVar(&e.exeConf.ledgerHistoryConfig.TrieCacheSize, "ledger-history-trie-cache-size", history.DefaultTrieCacheSize,
+				"number of tries of the historical store cached in memory")
 			flags.UintVar(&e.exeConf.stateDeltasLimit, "state-deltas-limit", 100, "maximum number of state deltas in the memory pool")
 			flags.UintVar(&e.exeConf.cadenceExecutionCache, "cadence-execution-cache", computation.DefaultProgramsCacheSize,
 				"cache size for Cadence execution")
@@ -164,6 +190,12 @@ func (e *ExecutionNodeBuilder) LoadFlags() {
 				"TTL for new blobs added to the execution data service blobstore")
 			flags.StringToIntVar(&e.exeConf.apiRatelimits, "api-rate-limits", map[string]int{}, "per second rate limits for GRPC API methods e.g. Ping=300,ExecuteScriptAtBlockID=500 etc. note limits apply globally to all clients.")
 			flags.StringToIntVar(&e.exeConf.apiBurstlimits, "api-burst-limits", map[string]int{}, "burst limits for gRPC API methods e.g. Ping=100,ExecuteScriptAtBlockID=100 etc. note limits apply globally to all clients.")
+			flags.BoolVar(&e.exeConf.prunerConfig.Enabled, "pruning-enabled", false,
+				"enable pruning of chunk data packs, events, transaction results and receipts of old sealed blocks")
+			flags.Uint64Var(&e.exeConf.prunerConfig.RetentionHeights, "pruning-retention-heights", pruner.DefaultRetentionHeights,
+				"number of sealed heights for which chunk data packs, events, transaction results and receipts are kept")
+			flags.Float64Var(&e.exeConf.prunerConfig.MaxHeightsPerSecond, "pruning-max-heights-per-second", pruner.DefaultMaxHeightsPerSecond,
+				"maximum number of heights pruned per second (0 for no limit)")
 		}).
 		ValidateFlags(func() error {
 			if e.exeConf.enableBlockDataUpload {
@@ -188,6 +220,7 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		txResults                     *storage.TransactionResults
 		results                       *storage.ExecutionResults
 		myReceipts                    *storage.MyExecutionReceipts
+		chunkDataPacks                *storage.ChunkDataPacks
 		providerEngine                *exeprovider.Engine
 		checkerEng                    *checker.Engine
 		syncCore                      *chainsync.Core
@@ -208,6 +241,7 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		executionDataService          state_synchronization.ExecutionDataService
 		executionDataCIDCache         state_synchronization.ExecutionDataCIDCache
 		executionDataCIDCacheSize     uint = 100
+		executionPruner               *pruner.Pruner
 	)
 
 	e.FlowNodeBuilder.
@@ -217,6 +251,12 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		AdminCommand("set-uploader-enabled", func(config *NodeConfig) commands.AdminCommand {
 			return uploaderCommands.NewToggleUploaderCommand()
 		}).
+		AdminCommand("set-pruner-enabled", func(config *NodeConfig) commands.AdminCommand {
+			return prunerCommands.NewSetPrunerEnabledCommand(executionPruner)
+		}).
+		AdminCommand("get-pruner-status", func(config *NodeConfig) commands.AdminCommand {
+			return prunerCommands.NewGetPrunerStatusCommand(executionPruner)
+		}).
 		AdminCommand("get-transactions", func(conf *NodeConfig) commands.AdminCommand {
 			return storageCommands.NewGetTransactionsCommand(conf.State, conf.Storage.Payloads, conf.Storage.Collections)
 		}).
@@ -384,8 +424,28 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 				}
 			}
 
+			var ledgerOpts []ledger.Option
+			if e.exeConf.ledgerHistoryEnabled {
+				err := os.MkdirAll(e.exeConf.ledgerHistoryDir, 0700)
+				if err != nil {
+					return nil, fmt.Errorf("could not create ledger history dir: %w", err)
+				}
+
+				historyDB, err := badgerDB.Open(badgerDB.DefaultOptions(e.exeConf.ledgerHistoryDir).WithLogger(sutil.NewLogger(node.Logger)))
+				if err != nil {
+					return nil, fmt.Errorf("could not open ledger history db: %w", err)
+				}
+				e.FlowNodeBuilder.ShutdownFunc(historyDB.Close)
+
+				historyStore, err := history.NewStore(historyDB, e.exeConf.ledgerHistoryConfig)
+				if err != nil {
+					return nil, fmt.Errorf("could not create ledger history store: %w", err)
+				}
+				ledgerOpts = append(ledgerOpts, ledger.WithHistory(historyStore))
+			}
+
 			ledgerStorage, err = ledger.NewLedger(diskWAL, int(e.exeConf.mTrieCacheSize), collector, node.Logger.With().Str("subcomponent",
-				"ledger").Logger(), ledger.DefaultPathFinderVersion)
+				"ledger").Logger(), ledger.DefaultPathFinderVersion, ledgerOpts...)
 			return ledgerStorage, err
 		}).
 		Component("execution state ledger WAL compactor", func(node *NodeConfig) (module.ReadyDoneAware, error) {
@@ -394,11 +454,17 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 			if err != nil {
 				return nil, fmt.Errorf("cannot create checkpointer: %w", err)
 			}
+			var compactorOpts []wal.CompactorOption
+			if e.exeConf.deltaCheckpointsEnabled {
+				compactorOpts = append(compactorOpts, wal.WithDeltaCheckpoints(e.exeConf.maxDeltaCheckpointsSize, e.exeConf.fullCheckpointInterval))
+			}
+
 			compactor := wal.NewCompactor(checkpointer,
 				10*time.Second,
 				e.exeConf.checkpointDistance,
 				e.exeConf.checkpointsToKeep,
-				node.Logger.With().Str("subcomponent", "checkpointer").Logger())
+				node.Logger.With().Str("subcomponent", "checkpointer").Logger(),
+				compactorOpts...)
 
 			return compactor, nil
 		}).
@@ -460,6 +526,8 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 				return nil, err
 			}
 
+			// the compressor determines the blob CIDs, and hence the execution data ID included in
+			// execution results, so it must be the same on all nodes and is not configurable
 			eds := state_synchronization.NewExecutionDataService(
 				cbor.NewCodec(),
 				compressor.NewLz4Compressor(),
@@ -514,7 +582,7 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 			}
 			computationManager = manager
 
-			chunkDataPacks := storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, e.exeConf.chdpCacheSize)
+			chunkDataPacks = storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, e.exeConf.chdpCacheSize)
 			stateCommitments := storage.NewCommits(node.Metrics.Cache, node.DB)
 
 			// Needed for gRPC server, make sure to assign to main scoped vars
@@ -730,6 +798,27 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 
 			return followerEng, nil
 		}).
+		Component("execution data pruner", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			executionPruner = pruner.New(
+				node.Logger,
+				e.exeConf.prunerConfig,
+				storage.NewConsumerProgress(node.DB, module.ConsumeProgressExecutionPruner),
+				node.State,
+				node.Storage.Headers,
+				executionState,
+				chunkDataPacks,
+				events,
+				serviceEvents,
+				txResults,
+				node.Storage.Receipts,
+				myReceipts,
+				node.RootBlock.Header.Height,
+			)
+
+			finalizationDistributor.AddOnBlockFinalizedConsumer(executionPruner.OnBlockFinalized)
+
+			return executionPruner, nil
+		}).
 		Component("collection requester engine", func(node *NodeConfig) (module.ReadyDoneAware, error) {
 			// We initialize the requester engine inside the ingestion engine due to the mutual dependency. However, in
 			// order for it to properly start and shut down, we should still return it as its own engine here, so it can
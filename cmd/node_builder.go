@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/codec/cbor"
 	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/queue"
 	"github.com/onflow/flow-go/network/topology"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/events"
@@ -163,6 +164,17 @@ type BaseConfig struct {
 	TopologyEdgeProbability         float64
 	HeroCacheMetricsEnable          bool
 	SyncCoreConfig                  synchronization.Config
+	ReputationConfig                p2p.ReputationConfig
+	RateLimiterConfig               p2p.RateLimiterConfig
+	RateLimitOverrides              []string
+	OutboundQueueConfig             queue.OutboundQueueConfig
+	OutboundQueueClasses            []string
+	MessageAuthTableFile            string
+	TransportConfig                 p2p.TransportConfig
+	DialPreference                  string
+	TrafficRecordingDir             string
+	TrafficRecordingMaxFileSize     int64
+	TrafficRecordingMaxFiles        int
 	CodecFactory                    func() network.Codec
 	// ComplianceConfig configures either the compliance engine (consensus nodes)
 	// or the follower engine (all other node roles)
@@ -190,6 +202,8 @@ type NodeConfig struct {
 	Middleware        network.Middleware
 	Network           network.Network
 	ConduitFactory    network.ConduitFactory
+	ReputationManager *p2p.ReputationManager
+	LatencyTracker    *topology.LatencyTracker
 	PingService       network.PingService
 	MsgValidators     []network.MessageValidator
 	FvmOptions        []fvm.Option
@@ -253,6 +267,13 @@ func DefaultBaseConfig() *BaseConfig {
 		TopologyEdgeProbability:         topology.MaximumEdgeProbability,
 		HeroCacheMetricsEnable:          false,
 		SyncCoreConfig:                  synchronization.DefaultConfig(),
+		ReputationConfig:                p2p.DefaultReputationConfig(),
+		RateLimiterConfig:               p2p.DefaultRateLimiterConfig(),
+		OutboundQueueConfig:             queue.DefaultOutboundQueueConfig(),
+		TransportConfig:                 p2p.DefaultTransportConfig(),
+		DialPreference:                  string(p2p.DefaultTransportConfig().DialPreference),
+		TrafficRecordingMaxFileSize:     64 * 1024 * 1024, // 64 MB
+		TrafficRecordingMaxFiles:        10,
 		CodecFactory:                    codecFactory,
 		ComplianceConfig:                compliance.DefaultConfig(),
 	}
@@ -34,6 +34,8 @@ import (
 	"github.com/onflow/flow-go/engine/common/follower"
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/encoding/cbor"
 	"github.com/onflow/flow-go/model/flow"
@@ -41,11 +43,14 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/compliance"
+	"github.com/onflow/flow-go/module/execution"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	edrequester "github.com/onflow/flow-go/module/state_synchronization/requester"
 	"github.com/onflow/flow-go/module/synchronization"
 	consensus_follower "github.com/onflow/flow-go/module/upstream"
@@ -102,6 +107,11 @@ type ObserverServiceConfig struct {
 	executionDataDir          string
 	executionDataStartHeight  uint64
 	executionDataConfig       edrequester.ExecutionDataConfig
+	accountIndexEnabled       bool
+	scriptExecutionMode       string
+	registerIndexCheckpoint   string
+	protocolPruningEnabled    bool
+	protocolPrunerConfig      pruner.Config
 	apiTimeout                time.Duration
 	upstreamNodeAddresses     []string
 	upstreamNodePublicKeys    []string
@@ -141,9 +151,14 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 			RetryDelay:         edrequester.DefaultRetryDelay,
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
-		apiTimeout:             3 * time.Second,
-		upstreamNodeAddresses:  []string{},
-		upstreamNodePublicKeys: []string{},
+		accountIndexEnabled:     false,
+		scriptExecutionMode:     backend.ScriptExecutionModeRemote.String(),
+		registerIndexCheckpoint: "",
+		protocolPruningEnabled:  false,
+		protocolPrunerConfig:    pruner.DefaultConfig(),
+		apiTimeout:              3 * time.Second,
+		upstreamNodeAddresses:   []string{},
+		upstreamNodePublicKeys:  []string{},
 	}
 }
 
@@ -165,7 +180,14 @@ type ObserverServiceBuilder struct {
 	Pending                 []*flow.Header
 	FollowerCore            module.HotStuffFollower
 	ExecutionDataService    state_synchronization.ExecutionDataService
-	ExecutionDataRequester  state_synchronization.ExecutionDataRequester // for the observer, the sync engine participants provider is the libp2p peer store which is not
+	ExecutionDataRequester  state_synchronization.ExecutionDataRequester
+	AccountIndex            storage.AccountIndex
+	AccountIndexer          *indexer.Indexer
+	RegisterIndex           storage.RegisterIndex
+	RegisterIndexer         *indexer.Indexer
+	ScriptExecutor          execution.ScriptExecutor
+
+	// for the observer, the sync engine participants provider is the libp2p peer store which is not
 	// available until after the network has started. Hence, a factory function that needs to be called just before
 	// creating the sync engine
 	SyncEngineParticipantsProviderFactory func() id.IdentifierProvider
@@ -426,6 +448,24 @@ func (builder *ObserverServiceBuilder) BuildConsensusFollower() cmd.NodeBuilder
 	return builder
 }
 
+// BuildProtocolStatePruner enqueues the pruner which removes the payloads of old blocks.
+func (builder *ObserverServiceBuilder) BuildProtocolStatePruner() *ObserverServiceBuilder {
+	builder.Component("protocol state pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+		protocolPruner := pruner.New(
+			node.Logger,
+			builder.protocolPrunerConfig,
+			node.State,
+			node.Storage.PayloadPruner,
+			node.RootBlock.Header.Height,
+		)
+		builder.FinalizationDistributor.AddOnBlockFinalizedConsumer(protocolPruner.OnBlockFinalized)
+
+		return protocolPruner, nil
+	})
+
+	return builder
+}
+
 func (builder *ObserverServiceBuilder) BuildExecutionDataRequester() *ObserverServiceBuilder {
 	var ds *badger.Datastore
 	var bs network.BlobService
@@ -529,9 +569,133 @@ func (builder *ObserverServiceBuilder) BuildExecutionDataRequester() *ObserverSe
 			return builder.ExecutionDataRequester, nil
 		})
 
+	if builder.accountIndexEnabled {
+		builder.Module("account index storage", func(node *cmd.NodeConfig) error {
+			// the index is created as a module, since the RPC engine may be built before the indexer
+			builder.AccountIndex = bstorage.NewAccountIndex(node.DB)
+			return nil
+		})
+
+		builder.Component("account indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			// the execution data for all heights up to the last notified height was already
+			// downloaded, and can be indexed immediately
+			highestExecDataHeight, err := processedNotifications.ProcessedIndex()
+			if err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return nil, fmt.Errorf("could not get highest notified execution data height: %w", err)
+				}
+				highestExecDataHeight = builder.executionDataConfig.InitialBlockHeight
+			}
+
+			builder.AccountIndexer = indexer.NewIndexer(
+				node.Logger.With().Str("component", "account_indexer").Logger(),
+				indexer.NewAccountIndexer(node.Logger, builder.AccountIndex),
+				node.Storage.Headers,
+				node.Storage.Seals,
+				node.Storage.Results,
+				builder.ExecutionDataService,
+				builder.executionDataConfig.InitialBlockHeight+1,
+				highestExecDataHeight,
+			)
+
+			builder.ExecutionDataRequester.AddOnExecutionDataFetchedConsumer(builder.AccountIndexer.OnExecutionData)
+
+			return builder.AccountIndexer, nil
+		})
+	}
+
+	if builder.rpcConf.ScriptExecutionMode != backend.ScriptExecutionModeRemote {
+		builder.Module("register index storage", func(node *cmd.NodeConfig) error {
+			// the index and script executor are created as a module, since the RPC engine may be
+			// built before the indexer
+			builder.RegisterIndex = bstorage.NewRegisterIndex(node.DB)
+			builder.ScriptExecutor = execution.NewScripts(
+				node.Logger,
+				fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()),
+				fvm.NewContext(node.Logger, node.FvmOptions...),
+				node.Storage.Headers,
+				builder.RegisterIndex,
+				execution.DefaultScriptExecutionTimeLimit,
+			)
+			return nil
+		})
+
+		builder.Component("register indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			registerIndexer := indexer.NewRegisterIndexer(node.Logger, builder.RegisterIndex)
+
+			_, err := builder.RegisterIndex.FirstHeight()
+			if errors.Is(err, storage.ErrNotFound) {
+				// the index is bootstrapped with the execution state at the height the execution
+				// data sync starts from, which must be contained in the checkpoint
+				commit, err := builder.sealedStateCommitment(node, builder.executionDataConfig.InitialBlockHeight)
+				if err != nil {
+					return nil, err
+				}
+
+				checkpoint := builder.registerIndexCheckpoint
+				if checkpoint == "" {
+					checkpoint = filepath.Join(node.BootstrapDir, bootstrap.PathRootCheckpoint)
+				}
+
+				err = registerIndexer.Bootstrap(checkpoint, builder.executionDataConfig.InitialBlockHeight, commit)
+				if err != nil {
+					return nil, fmt.Errorf("could not bootstrap register index: %w", err)
+				}
+			} else if err != nil {
+				return nil, fmt.Errorf("could not get first indexed register height: %w", err)
+			}
+
+			// the execution data for all heights up to the last notified height was already
+			// downloaded, and can be indexed immediately
+			highestExecDataHeight, err := processedNotifications.ProcessedIndex()
+			if err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return nil, fmt.Errorf("could not get highest notified execution data height: %w", err)
+				}
+				highestExecDataHeight = builder.executionDataConfig.InitialBlockHeight
+			}
+
+			builder.RegisterIndexer = indexer.NewIndexer(
+				node.Logger.With().Str("component", "register_indexer").Logger(),
+				registerIndexer,
+				node.Storage.Headers,
+				node.Storage.Seals,
+				node.Storage.Results,
+				builder.ExecutionDataService,
+				builder.executionDataConfig.InitialBlockHeight+1,
+				highestExecDataHeight,
+			)
+
+			builder.ExecutionDataRequester.AddOnExecutionDataFetchedConsumer(builder.RegisterIndexer.OnExecutionData)
+
+			return builder.RegisterIndexer, nil
+		})
+	}
+
 	return builder
 }
 
+// sealedStateCommitment returns the state commitment of the execution result sealed for the block at
+// the given height.
+func (builder *ObserverServiceBuilder) sealedStateCommitment(node *cmd.NodeConfig, height uint64) (flow.StateCommitment, error) {
+	if height == builder.RootBlock.Header.Height {
+		return builder.RootSeal.FinalState, nil
+	}
+
+	header, err := node.Storage.Headers.ByHeight(height)
+	if err != nil {
+		return flow.DummyStateCommitment, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+	blockID := header.ID()
+
+	seal, err := node.Storage.Seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return flow.DummyStateCommitment, fmt.Errorf("could not get seal for block %v: %w", blockID, err)
+	}
+
+	return seal.FinalState, nil
+}
+
 type Option func(*ObserverServiceConfig)
 
 func NewFlowObserverServiceBuilder(opts ...Option) *ObserverServiceBuilder {
@@ -584,6 +748,18 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 		flags.DurationVar(&builder.executionDataConfig.FetchTimeout, "execution-data-fetch-timeout", defaultConfig.executionDataConfig.FetchTimeout, "timeout to use when fetching execution data from the network e.g. 300s")
 		flags.DurationVar(&builder.executionDataConfig.RetryDelay, "execution-data-retry-delay", defaultConfig.executionDataConfig.RetryDelay, "initial delay for exponential backoff when fetching execution data fails e.g. 10s")
 		flags.DurationVar(&builder.executionDataConfig.MaxRetryDelay, "execution-data-max-retry-delay", defaultConfig.executionDataConfig.MaxRetryDelay, "maximum delay for exponential backoff when fetching execution data fails e.g. 5m")
+
+		// Account index
+		flags.BoolVar(&builder.accountIndexEnabled, "account-index-enabled", defaultConfig.accountIndexEnabled, "whether to index transactions and events by account address, and serve the account history API. requires execution-data-sync-enabled")
+
+		// Local script execution
+		flags.StringVar(&builder.scriptExecutionMode, "script-execution-mode", defaultConfig.scriptExecutionMode, "where scripts and account queries are executed, one of remote, local or failover. remote forwards them to the upstream access nodes. all modes other than remote require execution-data-sync-enabled")
+		flags.StringVar(&builder.registerIndexCheckpoint, "register-index-checkpoint", defaultConfig.registerIndexCheckpoint, "checkpoint file used to bootstrap the register index, defaults to the root checkpoint in the bootstrap directory")
+
+		// Protocol state pruning
+		flags.BoolVar(&builder.protocolPruningEnabled, "protocol-pruning-enabled", defaultConfig.protocolPruningEnabled, "whether to prune block payloads of blocks older than protocol-pruning-retention-heights")
+		flags.Uint64Var(&builder.protocolPrunerConfig.RetentionHeights, "protocol-pruning-retention-heights", defaultConfig.protocolPrunerConfig.RetentionHeights, "number of heights below the latest sealed height for which block payloads are kept")
+		flags.Float64Var(&builder.protocolPrunerConfig.MaxHeightsPerSecond, "protocol-pruning-max-heights-per-second", defaultConfig.protocolPrunerConfig.MaxHeightsPerSecond, "max number of heights pruned per second. 0 means no limit")
 	}).ValidateFlags(func() error {
 		if builder.executionDataSyncEnabled {
 			if builder.executionDataConfig.FetchTimeout <= 0 {
@@ -599,6 +775,25 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 				return errors.New("execution-data-max-search-ahead must be greater than 0")
 			}
 		}
+		if builder.accountIndexEnabled && !builder.executionDataSyncEnabled {
+			return errors.New("execution-data-sync-enabled must be set if account-index-enabled is true")
+		}
+
+		scriptExecutionMode, err := backend.ParseScriptExecutionMode(builder.scriptExecutionMode)
+		if err != nil {
+			return fmt.Errorf("invalid script-execution-mode: %w", err)
+		}
+		if scriptExecutionMode == backend.ScriptExecutionModeCompare {
+			return errors.New("script-execution-mode compare is not supported by observers")
+		}
+		if scriptExecutionMode != backend.ScriptExecutionModeRemote && !builder.executionDataSyncEnabled {
+			return errors.New("execution-data-sync-enabled must be set if script-execution-mode is not remote")
+		}
+		builder.rpcConf.ScriptExecutionMode = scriptExecutionMode
+
+		if builder.protocolPruningEnabled && builder.protocolPrunerConfig.RetentionHeights < pruner.MinRetentionHeights {
+			return fmt.Errorf("protocol-pruning-retention-heights must be at least %d", pruner.MinRetentionHeights)
+		}
 		return nil
 	})
 }
@@ -911,6 +1106,9 @@ func (builder *ObserverServiceBuilder) Build() (cmd.Node, error) {
 	if builder.executionDataSyncEnabled {
 		builder.BuildExecutionDataRequester()
 	}
+	if builder.protocolPruningEnabled {
+		builder.BuildProtocolStatePruner()
+	}
 	return builder.FlowNodeBuilder.Build()
 }
 
@@ -967,10 +1165,17 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 		if err != nil {
 			return nil, err
 		}
+		// the router falls back to the upstream access nodes, so the local backend only executes
+		// scripts locally
+		proxy.SetScriptExecutionMode(builder.rpcConf.ScriptExecutionMode)
+		rpcConf := builder.rpcConf
+		if rpcConf.ScriptExecutionMode != backend.ScriptExecutionModeRemote {
+			rpcConf.ScriptExecutionMode = backend.ScriptExecutionModeLocal
+		}
 		engineBuilder, err := rpc.NewBuilder(
 			node.Logger,
 			node.State,
-			builder.rpcConf,
+			rpcConf,
 			nil,
 			nil,
 			node.Storage.Blocks,
@@ -979,6 +1184,7 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			node.Storage.Transactions,
 			node.Storage.Receipts,
 			node.Storage.Results,
+			node.Storage.Seals,
 			node.RootChainID,
 			nil,
 			nil,
@@ -988,6 +1194,8 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			builder.rpcMetricsEnabled,
 			builder.apiRatelimits,
 			builder.apiBurstlimits,
+			builder.AccountIndex,
+			builder.ScriptExecutor,
 		)
 		if err != nil {
 			return nil, err
@@ -47,6 +47,8 @@ import (
 	"github.com/onflow/flow-go/network/p2p/conduit"
 	"github.com/onflow/flow-go/network/p2p/dns"
 	"github.com/onflow/flow-go/network/p2p/unicast"
+	"github.com/onflow/flow-go/network/queue"
+	"github.com/onflow/flow-go/network/recorder"
 	"github.com/onflow/flow-go/network/topology"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
@@ -63,8 +65,9 @@ import (
 )
 
 const (
-	NetworkComponent        = "network"
-	ConduitFactoryComponent = "conduit-factory"
+	NetworkComponent           = "network"
+	ConduitFactoryComponent    = "conduit-factory"
+	ReputationManagerComponent = "reputation-manager"
 )
 
 type Metrics struct {
@@ -145,12 +148,12 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminClientCAs, "admin-client-certs", defaultConfig.AdminClientCAs, "admin client certs (for mutual TLS)")
 
 	fnb.flags.DurationVar(&fnb.BaseConfig.DNSCacheTTL, "dns-cache-ttl", defaultConfig.DNSCacheTTL, "time-to-live for dns cache")
-	fnb.flags.StringSliceVar(&fnb.BaseConfig.PreferredUnicastProtocols, "preferred-unicast-protocols", nil, "preferred unicast protocols in ascending order of preference")
+	fnb.flags.StringSliceVar(&fnb.BaseConfig.PreferredUnicastProtocols, "preferred-unicast-protocols", nil, "preferred unicast protocols in ascending order of preference, the most preferred protocol supported by both nodes is used for each stream (gzip-compression, zstd-compression)")
 	fnb.flags.Uint32Var(&fnb.BaseConfig.NetworkReceivedMessageCacheSize, "networking-receive-cache-size", p2p.DefaultReceiveCacheSize,
 		"incoming message cache size at networking layer")
 	fnb.flags.UintVar(&fnb.BaseConfig.guaranteesCacheSize, "guarantees-cache-size", bstorage.DefaultCacheSize, "collection guarantees cache size")
 	fnb.flags.UintVar(&fnb.BaseConfig.receiptsCacheSize, "receipts-cache-size", bstorage.DefaultCacheSize, "receipts cache size")
-	fnb.flags.StringVar(&fnb.BaseConfig.TopologyProtocolName, "topology", defaultConfig.TopologyProtocolName, "networking overlay topology")
+	fnb.flags.StringVar(&fnb.BaseConfig.TopologyProtocolName, "topology", defaultConfig.TopologyProtocolName, "networking overlay topology (fixed-list, fully-connected, randomized, topic-based or latency-aware)")
 	fnb.flags.Float64Var(&fnb.BaseConfig.TopologyEdgeProbability, "topology-edge-probability", defaultConfig.TopologyEdgeProbability,
 		"pairwise edge probability between nodes in topology")
 
@@ -172,6 +175,40 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.UintVar(&fnb.BaseConfig.SyncCoreConfig.MaxRequests, "sync-max-requests", defaultConfig.SyncCoreConfig.MaxRequests, "the maximum number of requests we send during each scanning period")
 
 	fnb.flags.Uint64Var(&fnb.BaseConfig.ComplianceConfig.SkipNewProposalsThreshold, "compliance-skip-proposals-threshold", defaultConfig.ComplianceConfig.SkipNewProposalsThreshold, "threshold at which new proposals are discarded rather than cached, if their height is this much above local finalized height")
+
+	// peer reputation flags
+	fnb.flags.DurationVar(&fnb.BaseConfig.ReputationConfig.HalfLife, "reputation-half-life", defaultConfig.ReputationConfig.HalfLife, "duration after which the penalty score of a misbehaving peer is halved")
+	fnb.flags.Float64Var(&fnb.BaseConfig.ReputationConfig.ThrottleThreshold, "reputation-throttle-threshold", defaultConfig.ReputationConfig.ThrottleThreshold, "penalty score above which the inbound messages of a peer are throttled")
+	fnb.flags.Float64Var(&fnb.BaseConfig.ReputationConfig.DisconnectThreshold, "reputation-disconnect-threshold", defaultConfig.ReputationConfig.DisconnectThreshold, "penalty score at which a peer is temporarily disconnected")
+	fnb.flags.Float64Var(&fnb.BaseConfig.ReputationConfig.BlockThreshold, "reputation-block-threshold", defaultConfig.ReputationConfig.BlockThreshold, "penalty score at which a peer is blocked until the next epoch")
+	fnb.flags.DurationVar(&fnb.BaseConfig.ReputationConfig.DisconnectDuration, "reputation-disconnect-duration", defaultConfig.ReputationConfig.DisconnectDuration, "duration for which a disconnected peer is not allowed to reconnect")
+	fnb.flags.Float64Var(&fnb.BaseConfig.ReputationConfig.ThrottleRate, "reputation-throttle-rate", defaultConfig.ReputationConfig.ThrottleRate, "number of inbound messages per second accepted from a throttled peer")
+
+	// inbound rate limit flags
+	fnb.flags.Float64Var(&fnb.BaseConfig.RateLimiterConfig.Default.MessagesPerSecond, "inbound-message-rate-limit", defaultConfig.RateLimiterConfig.Default.MessagesPerSecond, "number of inbound messages per second accepted from a peer on a channel (0 to not limit messages)")
+	fnb.flags.IntVar(&fnb.BaseConfig.RateLimiterConfig.Default.MessageBurst, "inbound-message-burst", defaultConfig.RateLimiterConfig.Default.MessageBurst, "number of inbound messages accepted at once from a peer on a channel (0 for one second of messages at the configured rate)")
+	fnb.flags.Float64Var(&fnb.BaseConfig.RateLimiterConfig.Default.BytesPerSecond, "inbound-byte-rate-limit", defaultConfig.RateLimiterConfig.Default.BytesPerSecond, "number of inbound bytes per second accepted from a peer on a channel (0 to not limit bytes)")
+	fnb.flags.IntVar(&fnb.BaseConfig.RateLimiterConfig.Default.ByteBurst, "inbound-byte-burst", defaultConfig.RateLimiterConfig.Default.ByteBurst, "number of inbound bytes accepted at once from a peer on a channel (0 for one second of bytes at the configured rate)")
+	fnb.flags.StringSliceVar(&fnb.BaseConfig.RateLimitOverrides, "inbound-rate-limits", defaultConfig.RateLimitOverrides, "inbound rate limits overriding the defaults for roles and channels, in the form <role|*>:<channel|*>=<messages per second>:<bytes per second>")
+	fnb.flags.IntVar(&fnb.BaseConfig.RateLimiterConfig.MaxViolations, "inbound-rate-limit-max-violations", defaultConfig.RateLimiterConfig.MaxViolations, "number of rate limited messages within the violation window after which a peer is disconnected (0 to never disconnect)")
+	fnb.flags.DurationVar(&fnb.BaseConfig.RateLimiterConfig.ViolationWindow, "inbound-rate-limit-violation-window", defaultConfig.RateLimiterConfig.ViolationWindow, "window in which the rate limit violations of a peer are counted")
+
+	// outbound queue flags
+	fnb.flags.BoolVar(&fnb.BaseConfig.OutboundQueueConfig.Enabled, "outbound-queues-enabled", defaultConfig.OutboundQueueConfig.Enabled, "whether to queue outbound messages by the class of their channel (consensus > sync > data), instead of sending them in call order")
+	fnb.flags.IntVar(&fnb.BaseConfig.OutboundQueueConfig.Workers, "outbound-queue-workers", defaultConfig.OutboundQueueConfig.Workers, "number of workers sending queued outbound messages")
+	fnb.flags.StringSliceVar(&fnb.BaseConfig.OutboundQueueClasses, "outbound-queue-classes", defaultConfig.OutboundQueueClasses, "outbound queue configs overriding the defaults for channel classes, in the form <consensus|sync|data>=<capacity>:<drop-newest|drop-oldest>")
+
+	// transport flags
+	fnb.flags.BoolVar(&fnb.BaseConfig.TransportConfig.QUICEnabled, "quic", defaultConfig.TransportConfig.QUICEnabled, "whether to listen on QUIC in addition to TCP, the QUIC address is advertised to peers through libp2p identify")
+	fnb.flags.StringVar(&fnb.BaseConfig.TransportConfig.QUICAddress, "quic-bind", defaultConfig.TransportConfig.QUICAddress, "address to bind on for QUIC, defaults to the TCP bind address")
+	fnb.flags.StringVar(&fnb.BaseConfig.DialPreference, "dial-preference", defaultConfig.DialPreference, "transport used to dial peers which support both TCP and QUIC: tcp or quic")
+
+	fnb.flags.StringVar(&fnb.BaseConfig.MessageAuthTableFile, "message-auth-table", defaultConfig.MessageAuthTableFile, "path to a JSON file with the table of roles authorized to send each message type on each channel, replacing the built-in table")
+
+	// traffic recording flags
+	fnb.flags.StringVar(&fnb.BaseConfig.TrafficRecordingDir, "traffic-recording-dir", defaultConfig.TrafficRecordingDir, "directory to record all messages sent and received by the engines to, for debugging (empty to disable recording)")
+	fnb.flags.Int64Var(&fnb.BaseConfig.TrafficRecordingMaxFileSize, "traffic-recording-max-file-size", defaultConfig.TrafficRecordingMaxFileSize, "maximum size in bytes of a traffic recording file before a new file is started")
+	fnb.flags.IntVar(&fnb.BaseConfig.TrafficRecordingMaxFiles, "traffic-recording-max-files", defaultConfig.TrafficRecordingMaxFiles, "maximum number of traffic recording files to keep, the oldest files are removed (0 to keep all files)")
 }
 
 func (fnb *FlowNodeBuilder) EnqueuePingService() {
@@ -211,10 +248,26 @@ func (fnb *FlowNodeBuilder) EnqueuePingService() {
 		}
 
 		pingService, err := node.Network.RegisterPingService(pingLibP2PProtocolID, pingInfoProvider)
+		if err != nil {
+			return nil, err
+		}
 
 		node.PingService = pingService
 
-		return &module.NoopReadyDoneAware{}, err
+		if node.LatencyTracker != nil {
+			// measures the round-trip times to all other nodes for the latency-aware topology
+			return topology.NewLatencyProber(
+				node.Logger,
+				node.NodeID,
+				node.LatencyTracker,
+				node.IdentityProvider,
+				node.IDTranslator,
+				pingService,
+				topology.DefaultLatencyProbeInterval,
+			), nil
+		}
+
+		return &module.NoopReadyDoneAware{}, nil
 	})
 }
 
@@ -246,10 +299,39 @@ func (fnb *FlowNodeBuilder) EnqueueResolver() {
 }
 
 func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
+	fnb.Component(ReputationManagerComponent, func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		rm := p2p.NewReputationManager(node.Logger, node.ReputationConfig, node.IDTranslator, node.Metrics.Network)
+		fnb.ReputationManager = rm
+
+		// blocked peers are unblocked at the next epoch transition
+		node.ProtocolEvents.AddConsumer(rm)
+
+		return rm, nil
+	})
 	fnb.Component(ConduitFactoryComponent, func(node *NodeConfig) (module.ReadyDoneAware, error) {
-		cf := conduit.NewDefaultConduitFactory()
+		var cfOpts []conduit.ConduitFactoryOption
+		if node.OutboundQueueConfig.Enabled {
+			classes, err := queue.ParseClassConfigs(node.OutboundQueueClasses)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse outbound queue configs: %w", err)
+			}
+			for class, classConfig := range classes {
+				node.OutboundQueueConfig.Classes[class] = classConfig
+			}
+
+			outboundQueue, err := queue.NewOutboundQueue(node.OutboundQueueConfig, node.Metrics.Network)
+			if err != nil {
+				return nil, fmt.Errorf("could not create outbound queue: %w", err)
+			}
+			cfOpts = append(cfOpts, conduit.WithOutboundQueue(node.Logger, outboundQueue, node.OutboundQueueConfig.Workers))
+		}
+
+		cf := conduit.NewDefaultConduitFactory(cfOpts...)
 		fnb.ConduitFactory = cf
-		node.Logger.Info().Hex("node_id", logging.ID(node.NodeID)).Msg("default conduit factory initiated")
+		node.Logger.Info().
+			Hex("node_id", logging.ID(node.NodeID)).
+			Bool("outbound_queues_enabled", node.OutboundQueueConfig.Enabled).
+			Msg("default conduit factory initiated")
 
 		return cf, nil
 	})
@@ -259,11 +341,36 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 }
 
 func (fnb *FlowNodeBuilder) InitFlowNetworkWithConduitFactory(node *NodeConfig, cf network.ConduitFactory) (network.Network, error) {
+	if fnb.MessageAuthTableFile != "" {
+		table, err := network.LoadMessageAuthTable(fnb.MessageAuthTableFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load message authorization table: %w", err)
+		}
+		err = network.SetMessageAuthTable(table)
+		if err != nil {
+			return nil, fmt.Errorf("could not set message authorization table: %w", err)
+		}
+	}
+	fnb.Logger.Info().
+		Uint64("version", network.ActiveMessageAuthTable().Version).
+		Msg("message authorization table loaded")
+
 	myAddr := fnb.NodeConfig.Me.Address()
 	if fnb.BaseConfig.BindAddr != NotSet {
 		myAddr = fnb.BaseConfig.BindAddr
 	}
 
+	dialPreference, err := p2p.ParseDialPreference(fnb.DialPreference)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse dial preference: %w", err)
+	}
+	fnb.TransportConfig.DialPreference = dialPreference
+
+	// by default, QUIC listens on the UDP port with the same number as the TCP port
+	if fnb.TransportConfig.QUICEnabled && fnb.TransportConfig.QUICAddress == "" {
+		fnb.TransportConfig.QUICAddress = myAddr
+	}
+
 	libP2PNodeFactory := p2p.DefaultLibP2PNodeFactory(
 		fnb.Logger,
 		myAddr,
@@ -273,6 +380,8 @@ func (fnb *FlowNodeBuilder) InitFlowNetworkWithConduitFactory(node *NodeConfig,
 		fnb.Metrics.Network,
 		fnb.Resolver,
 		fnb.BaseConfig.NodeRole,
+		fnb.ReputationManager,
+		fnb.TransportConfig,
 	)
 
 	var mwOpts []p2p.MiddlewareOption
@@ -280,11 +389,27 @@ func (fnb *FlowNodeBuilder) InitFlowNetworkWithConduitFactory(node *NodeConfig,
 		mwOpts = append(mwOpts, p2p.WithMessageValidators(fnb.MsgValidators...))
 	}
 
+	rateLimits, err := p2p.ParseRateLimits(fnb.RateLimitOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse inbound rate limits: %w", err)
+	}
+	fnb.RateLimiterConfig.Limits = rateLimits
+
+	// inbound messages are only rate limited if any limit is configured
+	if fnb.RateLimiterConfig.Enabled() {
+		rateLimiter, err := p2p.NewRateLimiter(fnb.Logger, fnb.RateLimiterConfig, fnb.Metrics.Network)
+		if err != nil {
+			return nil, fmt.Errorf("could not create inbound rate limiter: %w", err)
+		}
+		mwOpts = append(mwOpts, p2p.WithRateLimiter(rateLimiter))
+	}
+
 	// run peer manager with the specified interval and let it also prune connections
 	peerManagerFactory := p2p.PeerManagerFactory([]p2p.Option{p2p.WithInterval(fnb.PeerUpdateInterval)})
 	mwOpts = append(mwOpts,
 		p2p.WithPeerManager(peerManagerFactory),
 		p2p.WithPreferredUnicastProtocols(unicast.ToProtocolNames(fnb.PreferredUnicastProtocols)),
+		p2p.WithReputationManager(fnb.ReputationManager),
 	)
 
 	fnb.Middleware = p2p.NewMiddleware(
@@ -301,9 +426,16 @@ func (fnb *FlowNodeBuilder) InitFlowNetworkWithConduitFactory(node *NodeConfig,
 
 	subscriptionManager := p2p.NewChannelSubscriptionManager(fnb.Middleware)
 
-	topologyFactory, err := topology.Factory(topology.Name(fnb.TopologyProtocolName))
-	if err != nil {
-		return nil, fmt.Errorf("could not retrieve topology factory for %s: %w", fnb.TopologyProtocolName, err)
+	var topologyFactory topology.FactoryFunction
+	if topology.Name(fnb.TopologyProtocolName) == topology.LatencyAware {
+		// the latency-aware topology uses the round-trip times measured by the latency prober
+		fnb.LatencyTracker = topology.NewLatencyTracker()
+		topologyFactory = topology.LatencyAwareTopologyFactory(fnb.LatencyTracker)
+	} else {
+		topologyFactory, err = topology.Factory(topology.Name(fnb.TopologyProtocolName))
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve topology factory for %s: %w", fnb.TopologyProtocolName, err)
+		}
 	}
 	top, err := topologyFactory(fnb.NodeID, fnb.Logger, fnb.State, fnb.TopologyEdgeProbability)
 	if err != nil {
@@ -336,17 +468,30 @@ func (fnb *FlowNodeBuilder) InitFlowNetworkWithConduitFactory(node *NodeConfig,
 		fnb.IdentityProvider,
 		receiveCache,
 		p2p.WithConduitFactory(cf),
+		p2p.WithMisbehaviorReporter(fnb.ReputationManager),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize network: %w", err)
 	}
 
-	fnb.Network = net
+	if fnb.TrafficRecordingDir != "" {
+		writer, err := recorder.NewRotatingFileWriter(fnb.TrafficRecordingDir, fnb.TrafficRecordingMaxFileSize, fnb.TrafficRecordingMaxFiles)
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize traffic recording: %w", err)
+		}
+		rec := recorder.NewRecorder(fnb.Logger, fnb.CodecFactory(), fnb.Me.NodeID(), writer)
+		fnb.ShutdownFunc(rec.Close)
+
+		fnb.Logger.Warn().Str("dir", fnb.TrafficRecordingDir).Msg("recording all network traffic of the engines")
+		fnb.Network = recorder.NewNetwork(net, rec)
+	} else {
+		fnb.Network = net
+	}
 
 	idEvents := gadgets.NewIdentityDeltas(fnb.Middleware.UpdateNodeAddresses)
 	fnb.ProtocolEvents.AddConsumer(idEvents)
 
-	return net, nil
+	return fnb.Network, nil
 }
 
 func (fnb *FlowNodeBuilder) EnqueueMetricsServerInit() {
@@ -665,21 +810,23 @@ func (fnb *FlowNodeBuilder) initStorage() {
 	setups := bstorage.NewEpochSetups(fnb.Metrics.Cache, fnb.DB)
 	commits := bstorage.NewEpochCommits(fnb.Metrics.Cache, fnb.DB)
 	statuses := bstorage.NewEpochStatuses(fnb.Metrics.Cache, fnb.DB)
+	payloadPruner := bstorage.NewPayloadPruner(fnb.DB, index, guarantees, transactions)
 
 	fnb.Storage = Storage{
-		Headers:      headers,
-		Guarantees:   guarantees,
-		Receipts:     receipts,
-		Results:      results,
-		Seals:        seals,
-		Index:        index,
-		Payloads:     payloads,
-		Blocks:       blocks,
-		Transactions: transactions,
-		Collections:  collections,
-		Setups:       setups,
-		EpochCommits: commits,
-		Statuses:     statuses,
+		Headers:       headers,
+		Guarantees:    guarantees,
+		Receipts:      receipts,
+		Results:       results,
+		Seals:         seals,
+		Index:         index,
+		Payloads:      payloads,
+		Blocks:        blocks,
+		Transactions:  transactions,
+		Collections:   collections,
+		Setups:        setups,
+		EpochCommits:  commits,
+		Statuses:      statuses,
+		PayloadPruner: payloadPruner,
 	}
 }
 
@@ -1268,6 +1415,10 @@ func (fnb *FlowNodeBuilder) RegisterDefaultAdminCommands() {
 		return storageCommands.NewReadSealsCommand(config.State, config.Storage.Seals, config.Storage.Index)
 	}).AdminCommand("get-latest-identity", func(config *NodeConfig) commands.AdminCommand {
 		return common.NewGetIdentityCommand(config.IdentityProvider)
+	}).AdminCommand("get-peer-reputations", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewGetPeerReputationsCommand(config.ReputationManager)
+	}).AdminCommand("get-message-auth-table", func(config *NodeConfig) commands.AdminCommand {
+		return &common.GetMessageAuthTableCommand{}
 	})
 }
 
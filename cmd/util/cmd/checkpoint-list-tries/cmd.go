@@ -28,6 +28,24 @@ func init() {
 
 func run(*cobra.Command, []string) {
 
+	info, err := wal.ReadCheckpointInfo(flagCheckpoint)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while reading checkpoint info")
+	}
+	log.Info().
+		Uint16("version", info.Version).
+		Int("base_checkpoint", info.BaseCheckpoint).
+		Msgf("checkpoint info of %v", flagCheckpoint)
+
+	// version 7 checkpoints are split into sub-trie parts followed by a top part
+	for i, count := range info.PartNodeCounts {
+		if i == len(info.PartNodeCounts)-1 {
+			fmt.Printf("top part: %d nodes\n", count)
+			continue
+		}
+		fmt.Printf("sub-trie part %d: %d nodes\n", i, count)
+	}
+
 	log.Info().Msgf("loading checkpoint %v", flagCheckpoint)
 	tries, err := wal.LoadCheckpoint(flagCheckpoint, &log.Logger)
 	if err != nil {
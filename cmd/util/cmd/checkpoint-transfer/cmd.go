@@ -0,0 +1,127 @@
+package checkpoint_transfer
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/ledger/complete/wal/objectstore"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+var (
+	flagExecutionStateDir string
+	flagGCSBucket         string
+	flagS3Bucket          string
+	flagLocalDir          string
+	flagPrefix            string
+	flagCheckpoint        int
+	flagRoot              bool
+	flagPartSize          int64
+	flagConcurrency       int
+)
+
+var Cmd = &cobra.Command{
+	Use:   "checkpoint-transfer",
+	Short: "Uploads checkpoints to, and downloads checkpoints from object storage",
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs and checkpoints are written)")
+	_ = Cmd.MarkPersistentFlagRequired("execution-state-dir")
+
+	Cmd.PersistentFlags().StringVar(&flagGCSBucket, "gcs-bucket", "",
+		"GCS bucket to transfer checkpoints to or from")
+	Cmd.PersistentFlags().StringVar(&flagS3Bucket, "s3-bucket", "",
+		"S3 bucket to transfer checkpoints to or from")
+	Cmd.PersistentFlags().StringVar(&flagLocalDir, "local-dir", "",
+		"local directory to transfer checkpoints to or from")
+	Cmd.PersistentFlags().StringVar(&flagPrefix, "prefix", "",
+		"prefix of the object names in the GCS or S3 bucket")
+
+	Cmd.PersistentFlags().IntVar(&flagCheckpoint, "checkpoint", -1,
+		"number of the checkpoint to transfer, the latest checkpoint by default")
+	Cmd.PersistentFlags().BoolVar(&flagRoot, "root", false,
+		"transfer the root checkpoint instead of a numbered checkpoint")
+
+	Cmd.PersistentFlags().Int64Var(&flagPartSize, "part-size", wal.DefaultCheckpointPartSize,
+		"size of the parts in which checkpoints are uploaded")
+	Cmd.PersistentFlags().IntVar(&flagConcurrency, "concurrency", wal.DefaultCheckpointTransferConcurrency,
+		"number of parts which are transferred concurrently")
+
+	Cmd.AddCommand(uploadCmd)
+	Cmd.AddCommand(downloadCmd)
+}
+
+// newObjectStore returns the object store configured by the flags. Exactly one of the GCS bucket,
+// the S3 bucket and the local directory must be set.
+func newObjectStore(ctx context.Context) (wal.ObjectStore, error) {
+	configured := 0
+	for _, flag := range []string{flagGCSBucket, flagS3Bucket, flagLocalDir} {
+		if flag != "" {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return nil, fmt.Errorf("exactly one of --gcs-bucket, --s3-bucket and --local-dir is required")
+	}
+
+	switch {
+	case flagGCSBucket != "":
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create GCS client: %w", err)
+		}
+		return objectstore.NewGCSStore(client, flagGCSBucket, flagPrefix), nil
+
+	case flagS3Bucket != "":
+		config, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+		return objectstore.NewS3Store(s3.NewFromConfig(config), flagS3Bucket, flagPrefix), nil
+
+	default:
+		return wal.NewDirectoryStore(flagLocalDir), nil
+	}
+}
+
+// newCheckpointer returns a checkpointer for the execution state dir. The caller must wait
+// for the returned WAL to be done.
+func newCheckpointer() (*wal.DiskWAL, *wal.Checkpointer) {
+	w, err := wal.NewDiskWAL(
+		log.Logger,
+		nil,
+		metrics.NewNoopCollector(),
+		flagExecutionStateDir,
+		complete.DefaultCacheSize,
+		pathfinder.PathByteSize,
+		wal.SegmentSize,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating WAL")
+	}
+
+	checkpointer, err := w.NewCheckpointer()
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating checkpointer")
+	}
+
+	return w, checkpointer
+}
+
+func transferOptions() []wal.TransferOption {
+	return []wal.TransferOption{
+		wal.WithPartSize(flagPartSize),
+		wal.WithTransferConcurrency(flagConcurrency),
+	}
+}
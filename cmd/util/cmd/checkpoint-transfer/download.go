@@ -0,0 +1,59 @@
+package checkpoint_transfer
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var downloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Downloads a checkpoint, together with its base checkpoints, from object storage",
+	Run:   runDownload,
+}
+
+func runDownload(*cobra.Command, []string) {
+	ctx := context.Background()
+
+	store, err := newObjectStore(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating object store")
+	}
+
+	w, checkpointer := newCheckpointer()
+	defer func() {
+		<-w.Done()
+	}()
+
+	if flagRoot {
+		log.Info().Msg("downloading root checkpoint")
+		err = checkpointer.DownloadRootCheckpoint(ctx, store, transferOptions()...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while downloading root checkpoint")
+		}
+		log.Info().Msg("root checkpoint downloaded")
+		return
+	}
+
+	checkpoint := flagCheckpoint
+	if checkpoint < 0 {
+		uploaded, err := wal.UploadedCheckpoints(ctx, store)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while listing uploaded checkpoints")
+		}
+		if len(uploaded) == 0 {
+			log.Fatal().Msg("no checkpoint to download")
+		}
+		checkpoint = uploaded[len(uploaded)-1]
+	}
+
+	log.Info().Msgf("downloading checkpoint %d", checkpoint)
+	err = checkpointer.DownloadCheckpoint(ctx, store, checkpoint, transferOptions()...)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("error while downloading checkpoint %d", checkpoint)
+	}
+	log.Info().Msgf("checkpoint %d downloaded", checkpoint)
+}
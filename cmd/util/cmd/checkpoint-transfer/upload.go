@@ -0,0 +1,56 @@
+package checkpoint_transfer
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var uploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Uploads a checkpoint, together with its base checkpoints, to object storage",
+	Run:   runUpload,
+}
+
+func runUpload(*cobra.Command, []string) {
+	ctx := context.Background()
+
+	store, err := newObjectStore(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating object store")
+	}
+
+	w, checkpointer := newCheckpointer()
+	defer func() {
+		<-w.Done()
+	}()
+
+	if flagRoot {
+		log.Info().Msg("uploading root checkpoint")
+		err = checkpointer.UploadRootCheckpoint(ctx, store, transferOptions()...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while uploading root checkpoint")
+		}
+		log.Info().Msg("root checkpoint uploaded")
+		return
+	}
+
+	checkpoint := flagCheckpoint
+	if checkpoint < 0 {
+		checkpoint, err = checkpointer.LatestCheckpoint()
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while getting latest checkpoint")
+		}
+		if checkpoint < 0 {
+			log.Fatal().Msg("no checkpoint to upload")
+		}
+	}
+
+	log.Info().Msgf("uploading checkpoint %d", checkpoint)
+	err = checkpointer.UploadCheckpoint(ctx, store, checkpoint, transferOptions()...)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("error while uploading checkpoint %d", checkpoint)
+	}
+	log.Info().Msgf("checkpoint %d uploaded", checkpoint)
+}
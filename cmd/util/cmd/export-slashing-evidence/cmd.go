@@ -0,0 +1,96 @@
+package export_slashing_evidence
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var flagSlashingEvidenceDir string
+var flagOutputFile string
+var flagFromView uint64
+var flagToView uint64
+
+// example:
+// ./util export-slashing-evidence --slashing-evidence-dir /var/flow/data/slashing-evidence --output-file ./evidence.json --from-view 1000
+var Cmd = &cobra.Command{
+	Use:   "export-slashing-evidence",
+	Short: "exports the slashing evidence recorded by a consensus node into a json file",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagSlashingEvidenceDir, "slashing-evidence-dir", "/var/flow/data/slashing-evidence",
+		"the slashing evidence database of the consensus node")
+
+	Cmd.Flags().StringVar(&flagOutputFile, "output-file", "",
+		"File to write the slashing evidence JSON to")
+	_ = Cmd.MarkFlagRequired("output-file")
+
+	Cmd.Flags().Uint64Var(&flagFromView, "from-view", 0,
+		"First view of the exported violations")
+
+	Cmd.Flags().Uint64Var(&flagToView, "to-view", math.MaxUint64,
+		"Last view of the exported violations")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().Msg("start exporting slashing evidence")
+	count, err := ExportSlashingEvidence(flagSlashingEvidenceDir, flagOutputFile, flagFromView, flagToView)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot export slashing evidence")
+	}
+	log.Info().Msgf("exported %d pieces of slashing evidence to %v", count, flagOutputFile)
+}
+
+// ExportSlashingEvidence exports the evidence of violations committed in views within [fromView, toView]
+// from the slashing evidence database in dir to the output file, and returns the number of exported records.
+func ExportSlashingEvidence(dir string, outputFile string, fromView uint64, toView uint64) (int, error) {
+	if fromView > toView {
+		return 0, fmt.Errorf("from view %d is greater than to view %d", fromView, toView)
+	}
+
+	// the node creates the database, hence we do not create it if it does not exist
+	if _, err := os.Stat(dir); err != nil {
+		return 0, fmt.Errorf("could not access slashing evidence database: %w", err)
+	}
+
+	db, err := badger.Open(badger.DefaultOptions(dir).WithLogger(nil))
+	if err != nil {
+		return 0, fmt.Errorf("could not open slashing evidence database: %w", err)
+	}
+	defer db.Close()
+
+	err = db.View(operation.InitMax)
+	if err != nil {
+		return 0, fmt.Errorf("could not initialize max tracker: %w", err)
+	}
+
+	store, err := bstorage.NewSlashingEvidence(db)
+	if err != nil {
+		return 0, err
+	}
+	evidence, err := store.ByView(fromView, toView)
+	if err != nil {
+		return 0, err
+	}
+
+	jsonData, err := json.MarshalIndent(evidence, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("could not marshal JSON: %w", err)
+	}
+	err = os.WriteFile(outputFile, jsonData, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("could not write json to %v: %w", outputFile, err)
+	}
+
+	return len(evidence), nil
+}
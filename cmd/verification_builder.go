@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -30,10 +31,12 @@ import (
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger"
 )
 
@@ -49,6 +52,9 @@ type VerificationConfig struct {
 
 	blockWorkers uint64 // number of blocks processed in parallel.
 	chunkWorkers uint64 // number of chunks processed in parallel.
+
+	protocolPruningEnabled bool          // whether block payloads of old blocks are pruned.
+	protocolPrunerConfig   pruner.Config // configuration of the pruner of block payloads.
 }
 
 type VerificationNodeBuilder struct {
@@ -75,6 +81,15 @@ func (v *VerificationNodeBuilder) LoadFlags() {
 			flags.Uint64Var(&v.verConf.requestTargets, "request-targets", requester.DefaultRequestTargets, "maximum number of execution nodes a chunk data pack request is dispatched to")
 			flags.Uint64Var(&v.verConf.blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 			flags.Uint64Var(&v.verConf.chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
+			flags.BoolVar(&v.verConf.protocolPruningEnabled, "protocol-pruning-enabled", false, "whether to prune block payloads of blocks older than protocol-pruning-retention-heights")
+			flags.Uint64Var(&v.verConf.protocolPrunerConfig.RetentionHeights, "protocol-pruning-retention-heights", pruner.DefaultRetentionHeights, "number of heights below the latest sealed height for which block payloads are kept")
+			flags.Float64Var(&v.verConf.protocolPrunerConfig.MaxHeightsPerSecond, "protocol-pruning-max-heights-per-second", pruner.DefaultMaxHeightsPerSecond, "maximum number of heights pruned per second (0 for no limit)")
+		}).
+		ValidateFlags(func() error {
+			if v.verConf.protocolPruningEnabled && v.verConf.protocolPrunerConfig.RetentionHeights < pruner.MinRetentionHeights {
+				return fmt.Errorf("protocol-pruning-retention-heights must be at least %d", pruner.MinRetentionHeights)
+			}
+			return nil
 		})
 }
 
@@ -393,4 +408,28 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			}
 			return sync, nil
 		})
+
+	if v.verConf.protocolPruningEnabled {
+		v.FlowNodeBuilder.
+			Component("protocol state pruner", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+				protocolPruner := pruner.New(
+					node.Logger,
+					v.verConf.protocolPrunerConfig,
+					node.State,
+					node.Storage.PayloadPruner,
+					node.RootBlock.Header.Height,
+					// the block consumer reads the payloads of the blocks it did not process yet
+					pruner.WithHeightLimit(func() (uint64, error) {
+						height, err := processedBlockHeight.ProcessedIndex()
+						if errors.Is(err, storage.ErrNotFound) {
+							return node.RootBlock.Header.Height, nil
+						}
+						return height, err
+					}),
+				)
+				finalizationDistributor.AddOnBlockFinalizedConsumer(protocolPruner.OnBlockFinalized)
+
+				return protocolPruner, nil
+			})
+	}
 }
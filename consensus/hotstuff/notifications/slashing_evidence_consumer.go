@@ -0,0 +1,316 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/common/fifoqueue"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// slashingEvidenceQueueCapacity is the maximum number of violations waiting to be recorded.
+	// Violations detected while the queue is full are dropped.
+	slashingEvidenceQueueCapacity = 1000
+
+	// slashingEvidenceDedupCacheSize is the number of recently reported violations, identified by
+	// offender, view and type of violation, for which no further evidence is recorded.
+	slashingEvidenceDedupCacheSize = 10_000
+)
+
+// SlashingEvidenceConsumer is an implementation of the notifications consumer that persists
+// evidence for any slashable offences. Each piece of evidence contains the votes or proposals
+// of the offender together with the consensus committee of the epoch of the offence, and is
+// signed by this node, so that it can be verified by third parties.
+//
+// The notifications only queue the detected violations, which are recorded by a single worker,
+// so that looking up the committee, signing and storing the evidence does not block HotStuff.
+// Only the first violation of each type by an offender within a view is recorded.
+//
+// Failures to record evidence are logged, but do not affect the consensus participant.
+type SlashingEvidenceConsumer struct {
+	NoopConsumer
+	*component.ComponentManager
+	log      zerolog.Logger
+	me       module.Local
+	state    protocol.State
+	headers  storage.Headers
+	evidence storage.SlashingEvidence
+	hasher   hash.Hasher
+	pending  *fifoqueue.FifoQueue // queue of *pendingEvidence
+	notifier engine.Notifier
+	reported *lru.Cache // violationKey -> struct{}
+}
+
+var _ component.Component = (*SlashingEvidenceConsumer)(nil)
+
+// pendingEvidence is a detected violation waiting to be recorded.
+type pendingEvidence struct {
+	evidence *flow.SlashingEvidence
+	// proposalIDs are the IDs of the stored proposals which are added to the evidence
+	proposalIDs []flow.Identifier
+}
+
+// violationKey identifies a violation for deduplication.
+type violationKey struct {
+	offenderID flow.Identifier
+	view       uint64
+	violation  flow.SlashingViolation
+}
+
+func NewSlashingEvidenceConsumer(
+	log zerolog.Logger,
+	me module.Local,
+	state protocol.State,
+	headers storage.Headers,
+	evidence storage.SlashingEvidence,
+) (*SlashingEvidenceConsumer, error) {
+	pending, err := fifoqueue.NewFifoQueue(fifoqueue.WithCapacity(slashingEvidenceQueueCapacity))
+	if err != nil {
+		return nil, fmt.Errorf("could not create slashing evidence queue: %w", err)
+	}
+	reported, err := lru.New(slashingEvidenceDedupCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create reported violations cache: %w", err)
+	}
+
+	c := &SlashingEvidenceConsumer{
+		log:      log.With().Str("component", "slashing_evidence").Logger(),
+		me:       me,
+		state:    state,
+		headers:  headers,
+		evidence: evidence,
+		hasher:   signature.NewBLSHasher(signature.SlashingEvidenceTag),
+		pending:  pending,
+		notifier: engine.NewNotifier(),
+		reported: reported,
+	}
+
+	c.ComponentManager = component.NewComponentManagerBuilder().
+		AddWorker(c.processingLoop).
+		Build()
+
+	return c, nil
+}
+
+func (c *SlashingEvidenceConsumer) OnDoubleVotingDetected(vote1 *model.Vote, vote2 *model.Vote) {
+	c.enqueue(&pendingEvidence{evidence: &flow.SlashingEvidence{
+		Violation:  flow.SlashingViolationDoubleVote,
+		View:       vote1.View,
+		OffenderID: vote1.SignerID,
+		Votes:      []flow.SlashingVote{slashingVote(vote1), slashingVote(vote2)},
+	}})
+}
+
+func (c *SlashingEvidenceConsumer) OnInvalidVoteDetected(vote *model.Vote) {
+	c.enqueue(&pendingEvidence{evidence: &flow.SlashingEvidence{
+		Violation:  flow.SlashingViolationInvalidVote,
+		View:       vote.View,
+		OffenderID: vote.SignerID,
+		Votes:      []flow.SlashingVote{slashingVote(vote)},
+	}})
+}
+
+func (c *SlashingEvidenceConsumer) OnVoteForInvalidBlockDetected(vote *model.Vote, proposal *model.Proposal) {
+	// invalid proposals are not stored, hence we reconstruct the header from the proposal
+	c.enqueue(&pendingEvidence{evidence: &flow.SlashingEvidence{
+		Violation:  flow.SlashingViolationVoteForInvalidBlock,
+		View:       vote.View,
+		OffenderID: vote.SignerID,
+		Votes:      []flow.SlashingVote{slashingVote(vote)},
+		Proposals:  []*flow.Header{model.ProposalToFlow(proposal)},
+	}})
+}
+
+func (c *SlashingEvidenceConsumer) OnDoubleProposeDetected(block1 *model.Block, block2 *model.Block) {
+	// both proposals have been stored before they were added to forks, hence the worker can
+	// include the full headers with the signatures of the proposer
+	c.enqueue(&pendingEvidence{
+		evidence: &flow.SlashingEvidence{
+			Violation:  flow.SlashingViolationDoubleProposal,
+			View:       block1.View,
+			OffenderID: block1.ProposerID,
+		},
+		proposalIDs: []flow.Identifier{block1.BlockID, block2.BlockID},
+	})
+}
+
+// enqueue queues the violation to be recorded, unless the same violation of the offender in
+// the same view was queued before, or the queue is full.
+func (c *SlashingEvidenceConsumer) enqueue(pending *pendingEvidence) {
+	evidence := pending.evidence
+	key := violationKey{
+		offenderID: evidence.OffenderID,
+		view:       evidence.View,
+		violation:  evidence.Violation,
+	}
+	if found, _ := c.reported.ContainsOrAdd(key, struct{}{}); found {
+		return
+	}
+
+	if !c.pending.Push(pending) {
+		// forget the violation, so that it is recorded if it is detected again once the queue has room
+		c.reported.Remove(key)
+		c.log.Error().
+			Str("violation", string(evidence.Violation)).
+			Uint64("view", evidence.View).
+			Hex("offender_id", evidence.OffenderID[:]).
+			Msg("slashing evidence queue is full, dropping evidence")
+		return
+	}
+	c.notifier.Notify()
+}
+
+// processingLoop records the queued violations until the component is stopped.
+func (c *SlashingEvidenceConsumer) processingLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	notifier := c.notifier.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notifier:
+		}
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			item, ok := c.pending.Pop()
+			if !ok {
+				break
+			}
+			c.report(item.(*pendingEvidence))
+		}
+	}
+}
+
+// report completes the evidence with the stored proposals and the committee of the epoch of
+// the violation, signs it, and stores it.
+func (c *SlashingEvidenceConsumer) report(pending *pendingEvidence) {
+	evidence := pending.evidence
+	log := c.log.With().
+		Str("violation", string(evidence.Violation)).
+		Uint64("view", evidence.View).
+		Hex("offender_id", evidence.OffenderID[:]).
+		Logger()
+
+	for _, blockID := range pending.proposalIDs {
+		header, err := c.headers.ByBlockID(blockID)
+		if err != nil {
+			log.Error().Err(err).
+				Hex("block_id", blockID[:]).
+				Msg("could not retrieve proposal for slashing evidence")
+			return
+		}
+		evidence.Proposals = append(evidence.Proposals, header)
+	}
+
+	committee, err := c.committee(evidence.View)
+	if err != nil {
+		log.Error().Err(err).Msg("could not get committee for slashing evidence")
+		return
+	}
+	evidence.Committee = *committee
+	evidence.ReporterID = c.me.NodeID()
+	evidence.DetectedAt = time.Now().UTC()
+
+	evidenceID := evidence.ID()
+	evidence.ReporterSig, err = c.me.Sign(evidenceID[:], c.hasher)
+	if err != nil {
+		log.Error().Err(err).Msg("could not sign slashing evidence")
+		return
+	}
+
+	err = c.evidence.Store(evidence)
+	if err != nil {
+		log.Error().Err(err).Msg("could not store slashing evidence")
+		return
+	}
+
+	log.Warn().Hex("evidence_id", evidenceID[:]).Msg("slashing violation detected, evidence stored")
+}
+
+// committee returns the consensus committee of the epoch containing the given view, which
+// must be the previous, current or next epoch as of the latest finalized block.
+func (c *SlashingEvidenceConsumer) committee(view uint64) (*flow.SlashingCommittee, error) {
+	epochs := c.state.Final().Epochs()
+	for _, epoch := range []protocol.Epoch{epochs.Previous(), epochs.Current(), epochs.Next()} {
+		firstView, err := epoch.FirstView()
+		if errors.Is(err, protocol.ErrNoPreviousEpoch) || errors.Is(err, protocol.ErrNextEpochNotSetup) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get epoch first view: %w", err)
+		}
+		finalView, err := epoch.FinalView()
+		if err != nil {
+			return nil, fmt.Errorf("could not get epoch final view: %w", err)
+		}
+		if view < firstView || view > finalView {
+			continue
+		}
+		return slashingCommittee(epoch)
+	}
+
+	return nil, fmt.Errorf("no known epoch contains view %d", view)
+}
+
+// slashingCommittee returns the consensus participants of the epoch with their staking keys and
+// random beacon key shares.
+func slashingCommittee(epoch protocol.Epoch) (*flow.SlashingCommittee, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch counter: %w", err)
+	}
+	identities, err := epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch initial identities: %w", err)
+	}
+	dkg, err := epoch.DKG()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch dkg: %w", err)
+	}
+
+	participants := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	keys := make([]encodable.RandomBeaconPubKey, 0, len(participants))
+	for _, participant := range participants {
+		key, err := dkg.KeyShare(participant.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get random beacon key share of participant %x: %w", participant.NodeID, err)
+		}
+		keys = append(keys, encodable.RandomBeaconPubKey{PublicKey: key})
+	}
+
+	return &flow.SlashingCommittee{
+		EpochCounter:       counter,
+		Participants:       participants,
+		DKGGroupKey:        encodable.RandomBeaconPubKey{PublicKey: dkg.GroupKey()},
+		DKGParticipantKeys: keys,
+	}, nil
+}
+
+func slashingVote(vote *model.Vote) flow.SlashingVote {
+	return flow.SlashingVote{
+		View:     vote.View,
+		BlockID:  vote.BlockID,
+		SignerID: vote.SignerID,
+		SigData:  vote.SigData,
+	}
+}
@@ -0,0 +1,205 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/state/protocol"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSlashingEvidenceConsumer tests that the consumer stores evidence which includes the committee of the
+// epoch of the violation and is signed by the node.
+func TestSlashingEvidenceConsumer(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	offender := participants[0]
+	stakingKey := unittest.StakingPrivKeyFixture()
+	reporter := unittest.IdentityFixture(unittest.WithRole(flow.RoleConsensus), unittest.WithStakingPubKey(stakingKey.PublicKey()))
+	me, err := local.New(reporter, stakingKey)
+	require.NoError(t, err)
+
+	// the current epoch spans views [1000, 1999] and there is no previous or next epoch
+	dkg := new(mockprotocol.DKG)
+	dkg.On("GroupKey").Return(unittest.KeyFixture(crypto.BLSBLS12381).PublicKey())
+	dkg.On("KeyShare", mock.Anything).Return(unittest.KeyFixture(crypto.BLSBLS12381).PublicKey(), nil)
+	current := new(mockprotocol.Epoch)
+	current.On("Counter").Return(uint64(1), nil)
+	current.On("FirstView").Return(uint64(1000), nil)
+	current.On("FinalView").Return(uint64(1999), nil)
+	current.On("InitialIdentities").Return(append(participants.Copy(), unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))), nil)
+	current.On("DKG").Return(dkg, nil)
+	previous := new(mockprotocol.Epoch)
+	previous.On("FirstView").Return(uint64(0), protocol.ErrNoPreviousEpoch)
+	next := new(mockprotocol.Epoch)
+	next.On("FirstView").Return(uint64(0), protocol.ErrNextEpochNotSetup)
+	query := new(mockprotocol.EpochQuery)
+	query.On("Previous").Return(previous)
+	query.On("Current").Return(current)
+	query.On("Next").Return(next)
+	snapshot := new(mockprotocol.Snapshot)
+	snapshot.On("Epochs").Return(query)
+	state := new(mockprotocol.State)
+	state.On("Final").Return(snapshot)
+
+	headers := mockstorage.NewHeaders(t)
+
+	// startConsumer starts a consumer storing evidence in the given store, which is stopped when the test ends
+	startConsumer := func(t *testing.T, store *mockstorage.SlashingEvidence) *SlashingEvidenceConsumer {
+		consumer, err := NewSlashingEvidenceConsumer(zerolog.Nop(), me, state, headers, store)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx, _ := irrecoverable.WithSignaler(ctx)
+		consumer.Start(signalerCtx)
+		unittest.RequireComponentsReadyBefore(t, time.Second, consumer)
+		t.Cleanup(func() {
+			cancel()
+			unittest.RequireComponentsDoneBefore(t, time.Second, consumer)
+		})
+
+		return consumer
+	}
+
+	// expectStored returns a channel which receives the evidence stored in the given store
+	expectStored := func(store *mockstorage.SlashingEvidence, times int) <-chan *flow.SlashingEvidence {
+		stored := make(chan *flow.SlashingEvidence, times)
+		store.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+			stored <- args.Get(0).(*flow.SlashingEvidence)
+		}).Return(nil).Times(times)
+		return stored
+	}
+
+	// received waits for the next stored evidence
+	received := func(t *testing.T, stored <-chan *flow.SlashingEvidence) *flow.SlashingEvidence {
+		select {
+		case evidence := <-stored:
+			return evidence
+		case <-time.After(time.Second):
+			t.Fatal("no evidence stored")
+			return nil
+		}
+	}
+
+	// verifyEvidence verifies the committee and the signature of the reporter of the stored evidence
+	verifyEvidence := func(t *testing.T, evidence *flow.SlashingEvidence) {
+		assert.Equal(t, uint64(1), evidence.Committee.EpochCounter)
+		assert.ElementsMatch(t, participants, evidence.Committee.Participants)
+		assert.Len(t, evidence.Committee.DKGParticipantKeys, len(participants))
+		assert.Equal(t, reporter.NodeID, evidence.ReporterID)
+
+		evidenceID := evidence.ID()
+		valid, err := reporter.StakingPubKey.Verify(evidence.ReporterSig, evidenceID[:], signature.NewBLSHasher(signature.SlashingEvidenceTag))
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	t.Run("double vote", func(t *testing.T) {
+		store := mockstorage.NewSlashingEvidence(t)
+		stored := expectStored(store, 1)
+		consumer := startConsumer(t, store)
+		vote1 := unittest.VoteFixture(unittest.WithVoteView(1500), unittest.WithVoteSignerID(offender.NodeID))
+		vote2 := unittest.VoteFixture(unittest.WithVoteView(1500), unittest.WithVoteSignerID(offender.NodeID))
+
+		consumer.OnDoubleVotingDetected(vote1, vote2)
+
+		evidence := received(t, stored)
+		assert.Equal(t, flow.SlashingViolationDoubleVote, evidence.Violation)
+		assert.Equal(t, uint64(1500), evidence.View)
+		assert.Equal(t, offender.NodeID, evidence.OffenderID)
+		require.Len(t, evidence.Votes, 2)
+		assert.Equal(t, vote1.BlockID, evidence.Votes[0].BlockID)
+		assert.Equal(t, vote2.SigData, evidence.Votes[1].SigData)
+		verifyEvidence(t, evidence)
+	})
+
+	t.Run("double proposal", func(t *testing.T) {
+		store := mockstorage.NewSlashingEvidence(t)
+		stored := expectStored(store, 1)
+		consumer := startConsumer(t, store)
+		header1 := unittest.BlockHeaderFixture(unittest.HeaderWithView(1500))
+		header1.ProposerID = offender.NodeID
+		header2 := unittest.BlockHeaderFixture(unittest.HeaderWithView(1500))
+		header2.ProposerID = offender.NodeID
+		headers.On("ByBlockID", header1.ID()).Return(header1, nil).Once()
+		headers.On("ByBlockID", header2.ID()).Return(header2, nil).Once()
+
+		consumer.OnDoubleProposeDetected(model.BlockFromFlow(header1, 1499), model.BlockFromFlow(header2, 1499))
+
+		evidence := received(t, stored)
+		assert.Equal(t, flow.SlashingViolationDoubleProposal, evidence.Violation)
+		assert.Equal(t, offender.NodeID, evidence.OffenderID)
+		assert.Equal(t, []*flow.Header{header1, header2}, evidence.Proposals)
+		verifyEvidence(t, evidence)
+	})
+
+	t.Run("duplicate violations", func(t *testing.T) {
+		// only the first invalid vote of the offender in a view is recorded
+		store := mockstorage.NewSlashingEvidence(t)
+		stored := expectStored(store, 2)
+		consumer := startConsumer(t, store)
+
+		for i := 0; i < 10; i++ {
+			consumer.OnInvalidVoteDetected(unittest.VoteFixture(unittest.WithVoteView(1500), unittest.WithVoteSignerID(offender.NodeID)))
+		}
+		consumer.OnInvalidVoteDetected(unittest.VoteFixture(unittest.WithVoteView(1501), unittest.WithVoteSignerID(offender.NodeID)))
+
+		assert.Equal(t, uint64(1500), received(t, stored).View)
+		assert.Equal(t, uint64(1501), received(t, stored).View)
+	})
+
+	t.Run("violation dropped by full queue", func(t *testing.T) {
+		// the consumer is not started, so that the queue is not drained
+		consumer, err := NewSlashingEvidenceConsumer(zerolog.Nop(), me, state, headers, mockstorage.NewSlashingEvidence(t))
+		require.NoError(t, err)
+		for view := uint64(1000); view < 1000+slashingEvidenceQueueCapacity; view++ {
+			consumer.OnInvalidVoteDetected(unittest.VoteFixture(unittest.WithVoteView(view), unittest.WithVoteSignerID(offender.NodeID)))
+		}
+		require.Equal(t, slashingEvidenceQueueCapacity, consumer.pending.Len())
+
+		dropped := unittest.VoteFixture(unittest.WithVoteView(1000+slashingEvidenceQueueCapacity), unittest.WithVoteSignerID(offender.NodeID))
+		consumer.OnInvalidVoteDetected(dropped)
+		require.Equal(t, slashingEvidenceQueueCapacity, consumer.pending.Len())
+
+		// the violation is queued once it is detected again after the queue has room
+		_, ok := consumer.pending.Pop()
+		require.True(t, ok)
+		consumer.OnInvalidVoteDetected(dropped)
+		require.Equal(t, slashingEvidenceQueueCapacity, consumer.pending.Len())
+
+		var last *pendingEvidence
+		for {
+			item, ok := consumer.pending.Pop()
+			if !ok {
+				break
+			}
+			last = item.(*pendingEvidence)
+		}
+		assert.Equal(t, uint64(1000+slashingEvidenceQueueCapacity), last.evidence.View)
+	})
+
+	t.Run("view outside known epochs", func(t *testing.T) {
+		// the evidence is dropped, as its committee is unknown
+		store := mockstorage.NewSlashingEvidence(t)
+		consumer := startConsumer(t, store)
+
+		consumer.OnInvalidVoteDetected(unittest.VoteFixture(unittest.WithVoteView(2500)))
+		consumer.OnInvalidVoteDetected(unittest.VoteFixture(unittest.WithVoteView(2501)))
+		require.Eventually(t, func() bool {
+			return consumer.pending.Len() == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -190,6 +190,11 @@ func (c *Conduit) Unicast(event interface{}, targetID flow.Identifier) error {
 	return c.net.unicast(event, c.channel, targetID)
 }
 
+// UnicastLatencyCritical sends the event like Unicast, as the conduit does not queue outbound messages.
+func (c *Conduit) UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error {
+	return c.Unicast(event, targetID)
+}
+
 func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Identifier) error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit closed")
@@ -197,6 +202,9 @@ func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Ident
 	return c.net.multicast(event, c.channel, num, targetIDs...)
 }
 
+// ReportMisbehavior is a no-op, as the nodes of the integration tests do not track peer reputation.
+func (c *Conduit) ReportMisbehavior(_ flow.Identifier, _ network.Offense) {}
+
 func (c *Conduit) Close() error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit closed")
@@ -108,7 +108,7 @@ func (suite *Suite) RunTest(
 	f func(handler *access.Handler, db *badger.DB, blocks *storage.Blocks, headers *storage.Headers, results *storage.ExecutionResults),
 ) {
 	unittest.RunWithBadgerDB(suite.T(), func(db *badger.DB) {
-		headers, _, _, _, _, blocks, _, _, _, results := util.StorageLayer(suite.T(), db)
+		headers, _, seals, _, _, blocks, _, _, _, results := util.StorageLayer(suite.T(), db)
 		transactions := storage.NewTransactions(suite.metrics, db)
 		collections := storage.NewCollections(db, transactions)
 		receipts := storage.NewExecutionReceipts(suite.metrics, db, results, storage.DefaultCacheSize)
@@ -122,6 +122,7 @@ func (suite *Suite) RunTest(
 			transactions,
 			receipts,
 			results,
+			seals,
 			suite.chainID,
 			suite.metrics,
 			nil,
@@ -131,6 +132,9 @@ func (suite *Suite) RunTest(
 			nil,
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			backend.ScriptExecutionModeRemote,
 		)
 
 		handler := access.NewHandler(suite.backend, suite.chainID.Chain(), access.WithBlockSignerDecoder(suite.signerIndicesDecoder))
@@ -297,6 +301,7 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			transactions,
 			nil,
 			nil,
+			nil,
 			suite.chainID,
 			metrics,
 			connFactory,
@@ -306,6 +311,9 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			nil,
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			backend.ScriptExecutionModeRemote,
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
@@ -550,7 +558,7 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 // is reported as sealed
 func (suite *Suite) TestGetSealedTransaction() {
 	unittest.RunWithBadgerDB(suite.T(), func(db *badger.DB) {
-		headers, _, _, _, _, blocks, _, _, _, _ := util.StorageLayer(suite.T(), db)
+		headers, _, seals, _, _, blocks, _, _, _, _ := util.StorageLayer(suite.T(), db)
 		results := storage.NewExecutionResults(suite.metrics, db)
 		receipts := storage.NewExecutionReceipts(suite.metrics, db, results, storage.DefaultCacheSize)
 		enIdentities := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
@@ -607,6 +615,7 @@ func (suite *Suite) TestGetSealedTransaction() {
 			transactions,
 			receipts,
 			results,
+			seals,
 			suite.chainID,
 			suite.metrics,
 			connFactory,
@@ -616,12 +625,15 @@ func (suite *Suite) TestGetSealedTransaction() {
 			enNodeIDs.Strings(),
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			backend.ScriptExecutionModeRemote,
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
 
 		rpcEngBuilder, err := rpc.NewBuilder(suite.log, suite.state, rpc.Config{}, nil, nil, blocks, headers, collections, transactions,
-			receipts, results, suite.chainID, metrics, metrics, 0, 0, false, false, nil, nil)
+			receipts, results, seals, suite.chainID, metrics, metrics, 0, 0, false, false, nil, nil, nil, nil)
 		rpcEng := rpcEngBuilder.WithLegacy().Build()
 		require.NoError(suite.T(), err)
 
@@ -677,7 +689,7 @@ func (suite *Suite) TestGetSealedTransaction() {
 // the correct block id
 func (suite *Suite) TestExecuteScript() {
 	unittest.RunWithBadgerDB(suite.T(), func(db *badger.DB) {
-		headers, _, _, _, _, blocks, _, _, _, _ := util.StorageLayer(suite.T(), db)
+		headers, _, seals, _, _, blocks, _, _, _, _ := util.StorageLayer(suite.T(), db)
 		transactions := storage.NewTransactions(suite.metrics, db)
 		collections := storage.NewCollections(db, transactions)
 		results := storage.NewExecutionResults(suite.metrics, db)
@@ -699,6 +711,7 @@ func (suite *Suite) TestExecuteScript() {
 			transactions,
 			receipts,
 			results,
+			seals,
 			suite.chainID,
 			suite.metrics,
 			connFactory,
@@ -708,6 +721,9 @@ func (suite *Suite) TestExecuteScript() {
 			flow.IdentifierList(identities.NodeIDs()).Strings(),
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			backend.ScriptExecutionModeRemote,
 		)
 
 		handler := access.NewHandler(suite.backend, suite.chainID.Chain())
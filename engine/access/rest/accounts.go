@@ -31,3 +31,64 @@ func GetAccount(r *request.Request, backend access.API, link models.LinkGenerato
 	err = response.Build(account, link, r.ExpandFields)
 	return response, err
 }
+
+// GetAccountProof handler retrieves the values of registers of an account, with a proof of the values against
+// the finalized block sealing the requested block
+func GetAccountProof(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountProofRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	// in case we receive special height values 'final' and 'sealed', fetch that height and overwrite request with it
+	if req.Height == request.FinalHeight || req.Height == request.SealedHeight {
+		header, err := backend.GetLatestBlockHeader(r.Context(), req.Height == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.Height = header.Height
+	}
+
+	proof, err := backend.GetAccountRegisterProof(r.Context(), req.Address, req.Keys, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountProof
+	err = response.Build(req.Address, proof)
+	return response, err
+}
+
+// GetAccountTransactions handler retrieves a page of the transactions signed by an account
+func GetAccountTransactions(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountTransactionsRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	page, err := backend.GetAccountTransactions(r.Context(), req.Address, req.StartHeight, req.EndHeight, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountTransactionsPage
+	response.Build(page)
+	return response, nil
+}
+
+// GetAccountEvents handler retrieves a page of the events emitted by the contracts of an account
+func GetAccountEvents(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountEventsRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	page, err := backend.GetAccountEvents(r.Context(), req.Address, req.Contract, req.StartHeight, req.EndHeight, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountEventsPage
+	response.Build(page)
+	return response, nil
+}
@@ -10,9 +10,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	mocktestify "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/lightclient"
 	"github.com/onflow/flow-go/access/mock"
 	"github.com/onflow/flow-go/engine/access/rest/middleware"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/rest/util"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -127,6 +133,229 @@ func TestGetAccount(t *testing.T) {
 	})
 }
 
+func TestGetAccountProof(t *testing.T) {
+	backend := &mock.API{}
+	address := unittest.AddressFixture()
+
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+	sealingBlock := unittest.BlockWithParentFixture(block.Header)
+	sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+
+	proof := &lightclient.RegisterProof{
+		Registers: []lightclient.Register{
+			{ID: flow.NewRegisterID(string(address.Bytes()), "storage_used"), Value: []byte{0, 0, 0, 1}},
+		},
+		Proof:          unittest.RandomBytes(32),
+		Header:         block.Header,
+		Result:         result,
+		Seal:           seal,
+		SealingHeader:  sealingBlock.Header,
+		SealingPayload: sealingBlock.Payload.Index(),
+	}
+	encoded, err := proof.Encode()
+	require.NoError(t, err)
+
+	t.Run("get by address at latest sealed block", func(t *testing.T) {
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block.Header, nil).
+			Once()
+		backend.Mock.
+			On("GetAccountRegisterProof", mocktestify.Anything, address, []string{"storage_used"}, block.Header.Height).
+			Return(proof, nil).
+			Once()
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("/v1/accounts/%s/proof?keys=storage_used", address), nil)
+		require.NoError(t, err)
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"block_id": "%s",
+			"block_height": "%d",
+			"sealing_block_id": "%s",
+			"sealing_block_height": "%d",
+			"state_commitment": "%s",
+			"registers": [{"key": "storage_used", "value": "AAAAAQ=="}],
+			"proof": "%s"
+		}`,
+			address,
+			block.ID(),
+			block.Header.Height,
+			sealingBlock.ID(),
+			sealingBlock.Header.Height,
+			util.ToBase64(seal.FinalState[:]),
+			util.ToBase64(encoded),
+		)
+
+		assertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("block not sealed", func(t *testing.T) {
+		backend.Mock.
+			On("GetAccountRegisterProof", mocktestify.Anything, address, []string(nil), uint64(1000)).
+			Return(nil, status.Error(codes.OutOfRange, "block at height 1000 is not sealed yet")).
+			Once()
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("/v1/accounts/%s/proof?block_height=1000", address), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"Invalid Flow argument: block at height 1000 is not sealed yet"}`, backend)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		req, err := http.NewRequest("GET", fmt.Sprintf("/v1/accounts/%s/proof?keys=storage_used,", address), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid key: must not be empty"}`, backend)
+	})
+}
+
+func TestGetAccountTransactions(t *testing.T) {
+	backend := &mock.API{}
+	address := unittest.AddressFixture()
+
+	t.Run("first page", func(t *testing.T) {
+		entry := flow.AccountTransaction{
+			Address:          address,
+			BlockID:          unittest.IdentifierFixture(),
+			BlockHeight:      12,
+			TransactionID:    unittest.IdentifierFixture(),
+			TransactionIndex: 3,
+			Roles:            flow.TransactionRoleAuthorizer | flow.TransactionRolePayer,
+		}
+		next := &flow.AccountIndexCursor{BlockHeight: 13, TransactionIndex: 1}
+
+		backend.Mock.
+			On("GetAccountTransactions", mocktestify.Anything, address, uint64(10), uint64(0), (*flow.AccountIndexCursor)(nil), uint(1)).
+			Return(&access.AccountTransactionsPage{
+				Transactions: []flow.AccountTransaction{entry},
+				NextCursor:   next,
+			}, nil).
+			Once()
+
+		url := fmt.Sprintf("/v1/accounts/%s/transactions?start_height=10&limit=1", address)
+		req, err := http.NewRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		expected := fmt.Sprintf(`{
+			"transactions": [{
+				"transaction_id": "%s",
+				"transaction_index": "3",
+				"block_id": "%s",
+				"block_height": "12",
+				"roles": ["authorizer", "payer"]
+			}],
+			"next_cursor": "%s"
+		}`, entry.TransactionID, entry.BlockID, util.EncodeCursor(next))
+
+		assertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		cursor := &flow.AccountIndexCursor{BlockHeight: 13, TransactionIndex: 1}
+
+		backend.Mock.
+			On("GetAccountTransactions", mocktestify.Anything, address, uint64(10), uint64(20), cursor, uint(request.DefaultAccountHistoryLimit)).
+			Return(&access.AccountTransactionsPage{}, nil).
+			Once()
+
+		url := fmt.Sprintf("/v1/accounts/%s/transactions?start_height=10&end_height=20&cursor=%s", address, util.EncodeCursor(cursor))
+		req, err := http.NewRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		assertOKResponse(t, req, `{"transactions": []}`, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		tests := []struct {
+			url string
+			out string
+		}{
+			{"/v1/accounts/123/transactions", `{"code":400, "message":"invalid address"}`},
+			{fmt.Sprintf("/v1/accounts/%s/transactions?start_height=20&end_height=10", address), `{"code":400, "message":"start height must be less than or equal to end height"}`},
+			{fmt.Sprintf("/v1/accounts/%s/transactions?end_height=final", address), `{"code":400, "message":"invalid end height: must be an explicit height or sealed"}`},
+			{fmt.Sprintf("/v1/accounts/%s/transactions?cursor=foo", address), `{"code":400, "message":"invalid cursor"}`},
+			{fmt.Sprintf("/v1/accounts/%s/transactions?limit=0", address), `{"code":400, "message":"invalid limit: must be a positive integer"}`},
+		}
+
+		for i, test := range tests {
+			req, _ := http.NewRequest("GET", test.url, nil)
+			rr, err := executeRequest(req, backend)
+			assert.NoError(t, err)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, test.out, rr.Body.String(), fmt.Sprintf("test #%d failed: %v", i, test))
+		}
+	})
+}
+
+func TestGetAccountEvents(t *testing.T) {
+	backend := &mock.API{}
+	address := unittest.AddressFixture()
+
+	t.Run("filtered by contract", func(t *testing.T) {
+		event := unittest.EventFixture(flow.EventType(fmt.Sprintf("A.%s.Foo.Bar", address.Hex())), 0, 1, unittest.IdentifierFixture(), 0)
+		entry := flow.AccountEvent{
+			Address:     address,
+			BlockID:     unittest.IdentifierFixture(),
+			BlockHeight: 12,
+			Event:       event,
+		}
+
+		backend.Mock.
+			On("GetAccountEvents", mocktestify.Anything, address, "Foo", uint64(0), uint64(0), (*flow.AccountIndexCursor)(nil), uint(request.DefaultAccountHistoryLimit)).
+			Return(&access.AccountEventsPage{
+				Events: []flow.AccountEvent{entry},
+			}, nil).
+			Once()
+
+		url := fmt.Sprintf("/v1/accounts/%s/events?contract=Foo&end_height=sealed", address)
+		req, err := http.NewRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		expected := fmt.Sprintf(`{
+			"events": [{
+				"block_id": "%s",
+				"block_height": "12",
+				"event": {
+					"type": "%s",
+					"transaction_id": "%s",
+					"transaction_index": "0",
+					"event_index": "1",
+					"payload": ""
+				}
+			}]
+		}`, entry.BlockID, event.Type, event.TransactionID)
+
+		assertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("account indexing disabled", func(t *testing.T) {
+		backend.Mock.
+			On("GetAccountEvents", mocktestify.Anything, address, "", uint64(0), uint64(0), (*flow.AccountIndexCursor)(nil), uint(request.DefaultAccountHistoryLimit)).
+			Return(nil, status.Error(codes.Unavailable, "account indexing is disabled")).
+			Once()
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("/v1/accounts/%s/events", address), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusServiceUnavailable, `{"code":503, "message":"Flow resource unavailable: account indexing is disabled"}`, backend)
+	})
+
+	t.Run("invalid contract", func(t *testing.T) {
+		req, err := http.NewRequest("GET", fmt.Sprintf("/v1/accounts/%s/events?contract=A.Foo", address), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid contract name"}`, backend)
+	})
+}
+
 func expectedExpandedResponse(account *flow.Account) string {
 	return fmt.Sprintf(`{
 			  "address":"%s",
@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+// GetEpochTransitionProof handler retrieves the proof of the transition into an epoch, which light clients use to
+// verify the headers of the epoch
+func GetEpochTransitionProof(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetEpochTransitionProofRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	proof, err := backend.GetEpochTransitionProof(r.Context(), req.Counter)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.EpochTransitionProof
+	err = response.Build(proof)
+	return response, err
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetEpochTransitionProof(t *testing.T) {
+	backend := &mock.API{}
+
+	setup := &flow.EpochSetup{Counter: 2, FirstView: 1001, FinalView: 2000}
+	commit := &flow.EpochCommit{Counter: 2}
+	setupProof := serviceEventProofFixture(setup.ServiceEvent())
+	// the handler does not verify the proof, hence the commit event is not included in the execution result
+	commitProof := serviceEventProofFixture()
+	proof := &lightclient.EpochTransitionProof{
+		Setup:       setup,
+		Commit:      commit,
+		SetupProof:  setupProof,
+		CommitProof: commitProof,
+	}
+	encoded, err := proof.Encode()
+	require.NoError(t, err)
+
+	t.Run("get by counter", func(t *testing.T) {
+		backend.Mock.
+			On("GetEpochTransitionProof", mocktestify.Anything, uint64(2)).
+			Return(proof, nil).
+			Once()
+
+		req, err := http.NewRequest("GET", "/v1/epochs/2/transition_proof", nil)
+		require.NoError(t, err)
+
+		expected := fmt.Sprintf(`{
+			"epoch_counter": "2",
+			"first_view": "1001",
+			"final_view": "2000",
+			"setup_sealing_block_id": "%s",
+			"setup_sealing_block_height": "%d",
+			"commit_sealing_block_id": "%s",
+			"commit_sealing_block_height": "%d",
+			"proof": "%s"
+		}`,
+			setupProof.SealingHeader.ID(),
+			setupProof.SealingHeader.Height,
+			commitProof.SealingHeader.ID(),
+			commitProof.SealingHeader.Height,
+			util.ToBase64(encoded),
+		)
+
+		assertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("epoch not committed", func(t *testing.T) {
+		backend.Mock.
+			On("GetEpochTransitionProof", mocktestify.Anything, uint64(3)).
+			Return(nil, status.Error(codes.NotFound, "epoch 2 has not reached EpochCommitted phase yet")).
+			Once()
+
+		req, err := http.NewRequest("GET", "/v1/epochs/3/transition_proof", nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusNotFound, `{"code":404, "message":"Flow resource not found: epoch 2 has not reached EpochCommitted phase yet"}`, backend)
+	})
+
+	t.Run("invalid counter", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/v1/epochs/0/transition_proof", nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid epoch counter: the first epoch has no transition"}`, backend)
+	})
+}
+
+// serviceEventProofFixture returns a proof of the given service events, sealed in a block with a random QC.
+func serviceEventProofFixture(events ...flow.ServiceEvent) *lightclient.ServiceEventProof {
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	result.ServiceEvents = events
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+	sealingBlock := unittest.BlockWithParentFixture(block.Header)
+	sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+
+	return &lightclient.ServiceEventProof{
+		Result:         result,
+		Seal:           seal,
+		SealingHeader:  sealingBlock.Header,
+		SealingPayload: sealingBlock.Payload.Index(),
+		QC:             unittest.QuorumCertificateFixture(unittest.QCWithBlockID(sealingBlock.ID())),
+	}
+}
@@ -114,11 +114,21 @@ func (h *Handler) errorHandler(w http.ResponseWriter, err error, errorLogger zer
 			h.errorResponse(w, http.StatusNotFound, msg, errorLogger)
 			return
 		}
-		if se.Code() == codes.InvalidArgument {
+		if se.Code() == codes.InvalidArgument || se.Code() == codes.OutOfRange {
 			msg := fmt.Sprintf("Invalid Flow argument: %s", se.Message())
 			h.errorResponse(w, http.StatusBadRequest, msg, errorLogger)
 			return
 		}
+		if se.Code() == codes.FailedPrecondition {
+			msg := fmt.Sprintf("Flow resource pruned: %s", se.Message())
+			h.errorResponse(w, http.StatusGone, msg, errorLogger)
+			return
+		}
+		if se.Code() == codes.Unavailable {
+			msg := fmt.Sprintf("Flow resource unavailable: %s", se.Message())
+			h.errorResponse(w, http.StatusServiceUnavailable, msg, errorLogger)
+			return
+		}
 		if se.Code() == codes.Internal {
 			msg := fmt.Sprintf("Invalid Flow request: %s", se.Message())
 			h.errorResponse(w, http.StatusBadRequest, msg, errorLogger)
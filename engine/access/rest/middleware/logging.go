@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -20,7 +23,7 @@ func LoggingMiddleware(logger zerolog.Logger) mux.MiddlewareFunc {
 			// continue to the next handler
 			inner.ServeHTTP(respWriter, req)
 			log := logger.Info()
-			if respWriter.statusCode != http.StatusOK {
+			if respWriter.statusCode != http.StatusOK && respWriter.statusCode != http.StatusSwitchingProtocols {
 				log = logger.Error()
 			}
 			log.Str("method", req.Method).
@@ -48,3 +51,15 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Hijack lets the connection be taken over by the handler, which is required to upgrade the
+// connection for streaming endpoints.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	// the connection is upgraded, which is reported as a switching protocols response
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
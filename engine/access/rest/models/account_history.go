@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountTransaction references a transaction signed by an account, with the roles the account
+// signed it in.
+type AccountTransaction struct {
+	TransactionId    string   `json:"transaction_id"`
+	TransactionIndex string   `json:"transaction_index"`
+	BlockId          string   `json:"block_id"`
+	BlockHeight      string   `json:"block_height"`
+	Roles            []string `json:"roles"`
+}
+
+func (a *AccountTransaction) Build(entry flow.AccountTransaction) {
+	a.TransactionId = entry.TransactionID.String()
+	a.TransactionIndex = util.FromUint64(uint64(entry.TransactionIndex))
+	a.BlockId = entry.BlockID.String()
+	a.BlockHeight = util.FromUint64(entry.BlockHeight)
+	a.Roles = strings.Split(entry.Roles.String(), ",")
+}
+
+// AccountTransactionsPage is a page of the transactions signed by an account. The next page is
+// requested with the cursor, which is omitted on the last page.
+type AccountTransactionsPage struct {
+	Transactions []AccountTransaction `json:"transactions"`
+	NextCursor   string               `json:"next_cursor,omitempty"`
+}
+
+func (a *AccountTransactionsPage) Build(page *access.AccountTransactionsPage) {
+	a.Transactions = make([]AccountTransaction, len(page.Transactions))
+	for i, entry := range page.Transactions {
+		a.Transactions[i].Build(entry)
+	}
+
+	if page.NextCursor != nil {
+		a.NextCursor = util.EncodeCursor(page.NextCursor)
+	}
+}
+
+// AccountEvent is an event emitted by a contract of an account, with the block it was emitted in.
+type AccountEvent struct {
+	BlockId     string `json:"block_id"`
+	BlockHeight string `json:"block_height"`
+	Event       Event  `json:"event"`
+}
+
+func (a *AccountEvent) Build(entry flow.AccountEvent) {
+	a.BlockId = entry.BlockID.String()
+	a.BlockHeight = util.FromUint64(entry.BlockHeight)
+	a.Event.Build(entry.Event)
+}
+
+// AccountEventsPage is a page of the events emitted by the contracts of an account. The next page
+// is requested with the cursor, which is omitted on the last page.
+type AccountEventsPage struct {
+	Events     []AccountEvent `json:"events"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func (a *AccountEventsPage) Build(page *access.AccountEventsPage) {
+	a.Events = make([]AccountEvent, len(page.Events))
+	for i, entry := range page.Events {
+		a.Events[i].Build(entry)
+	}
+
+	if page.NextCursor != nil {
+		a.NextCursor = util.EncodeCursor(page.NextCursor)
+	}
+}
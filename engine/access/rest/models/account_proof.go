@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountRegister is a register of an account, with its value at the proven block.
+type AccountRegister struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// AccountProof is the proof of register values of an account at a sealed block. The encoded proof
+// contains the register values, the trie proof, and the execution result and seal linking the state
+// commitment to the sealing block. Light clients verify it offline with the lightclient package,
+// against their own view of the sealing block.
+type AccountProof struct {
+	Address            string            `json:"address"`
+	BlockId            string            `json:"block_id"`
+	BlockHeight        string            `json:"block_height"`
+	SealingBlockId     string            `json:"sealing_block_id"`
+	SealingBlockHeight string            `json:"sealing_block_height"`
+	StateCommitment    string            `json:"state_commitment"`
+	Registers          []AccountRegister `json:"registers"`
+	Proof              string            `json:"proof"`
+}
+
+func (a *AccountProof) Build(address flow.Address, proof *lightclient.RegisterProof) error {
+	encoded, err := proof.Encode()
+	if err != nil {
+		return err
+	}
+
+	a.Address = address.String()
+	a.BlockId = proof.Header.ID().String()
+	a.BlockHeight = util.FromUint64(proof.Header.Height)
+	a.SealingBlockId = proof.SealingHeader.ID().String()
+	a.SealingBlockHeight = util.FromUint64(proof.SealingHeader.Height)
+	a.StateCommitment = util.ToBase64(proof.Seal.FinalState[:])
+	a.Registers = make([]AccountRegister, len(proof.Registers))
+	for i, register := range proof.Registers {
+		a.Registers[i] = AccountRegister{
+			Key:   register.ID.Key,
+			Value: util.ToBase64(register.Value),
+		}
+	}
+	a.Proof = util.ToBase64(encoded)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+// EpochTransitionProof is the proof of the configuration of an epoch. The encoded proof contains the EpochSetup
+// and EpochCommit service events of the epoch, and for each event the execution result, seal and QC-certified
+// header of the block sealing it. Light clients verify it offline with the lightclient/epochs package, starting
+// from the epoch of a trusted root snapshot.
+type EpochTransitionProof struct {
+	EpochCounter         string `json:"epoch_counter"`
+	FirstView            string `json:"first_view"`
+	FinalView            string `json:"final_view"`
+	SetupSealingBlockId  string `json:"setup_sealing_block_id"`
+	SetupSealingHeight   string `json:"setup_sealing_block_height"`
+	CommitSealingBlockId string `json:"commit_sealing_block_id"`
+	CommitSealingHeight  string `json:"commit_sealing_block_height"`
+	Proof                string `json:"proof"`
+}
+
+func (e *EpochTransitionProof) Build(proof *lightclient.EpochTransitionProof) error {
+	encoded, err := proof.Encode()
+	if err != nil {
+		return err
+	}
+
+	e.EpochCounter = util.FromUint64(proof.Setup.Counter)
+	e.FirstView = util.FromUint64(proof.Setup.FirstView)
+	e.FinalView = util.FromUint64(proof.Setup.FinalView)
+	e.SetupSealingBlockId = proof.SetupProof.SealingHeader.ID().String()
+	e.SetupSealingHeight = util.FromUint64(proof.SetupProof.SealingHeader.Height)
+	e.CommitSealingBlockId = proof.CommitProof.SealingHeader.ID().String()
+	e.CommitSealingHeight = util.FromUint64(proof.CommitProof.SealingHeader.Height)
+	e.Proof = util.ToBase64(encoded)
+
+	return nil
+}
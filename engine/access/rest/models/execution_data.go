@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/module/state_synchronization"
+)
+
+// ExecutionData is the response model for the execution data of a block. Collections are
+// represented by the IDs of their transactions, and events are grouped by chunk.
+type ExecutionData struct {
+	BlockId     string                    `json:"block_id"`
+	BlockHeight string                    `json:"block_height"`
+	Collections []ExecutionDataCollection `json:"collections"`
+	Events      [][]Event                 `json:"events"`
+	TrieUpdates []TrieUpdate              `json:"trie_updates"`
+}
+
+type ExecutionDataCollection struct {
+	TransactionIds []string `json:"transaction_ids"`
+}
+
+type TrieUpdate struct {
+	RootHash string    `json:"root_hash"`
+	Paths    []string  `json:"paths"`
+	Payloads []Payload `json:"payloads"`
+}
+
+type Payload struct {
+	KeyParts []KeyPart `json:"key_parts"`
+	Value    string    `json:"value"`
+}
+
+type KeyPart struct {
+	Type_ string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (e *ExecutionData) Build(height uint64, data *state_synchronization.ExecutionData) {
+	e.BlockId = data.BlockID.String()
+	e.BlockHeight = util.FromUint64(height)
+
+	e.Collections = make([]ExecutionDataCollection, len(data.Collections))
+	for i, collection := range data.Collections {
+		txIDs := make([]string, len(collection.Transactions))
+		for j, tx := range collection.Transactions {
+			txIDs[j] = tx.ID().String()
+		}
+		e.Collections[i] = ExecutionDataCollection{TransactionIds: txIDs}
+	}
+
+	e.Events = make([][]Event, len(data.Events))
+	for i, chunkEvents := range data.Events {
+		var events Events
+		events.Build(chunkEvents)
+		e.Events[i] = events
+	}
+
+	e.TrieUpdates = make([]TrieUpdate, len(data.TrieUpdates))
+	for i, update := range data.TrieUpdates {
+		var trieUpdate TrieUpdate
+		trieUpdate.Build(update)
+		e.TrieUpdates[i] = trieUpdate
+	}
+}
+
+func (t *TrieUpdate) Build(update *ledger.TrieUpdate) {
+	t.RootHash = hex.EncodeToString(update.RootHash[:])
+
+	t.Paths = make([]string, len(update.Paths))
+	for i, path := range update.Paths {
+		t.Paths[i] = hex.EncodeToString(path[:])
+	}
+
+	t.Payloads = make([]Payload, len(update.Payloads))
+	for i, payload := range update.Payloads {
+		keyParts := make([]KeyPart, len(payload.Key.KeyParts))
+		for j, keyPart := range payload.Key.KeyParts {
+			keyParts[j] = KeyPart{
+				Type_: util.FromUint64(uint64(keyPart.Type)),
+				Value: util.ToBase64(keyPart.Value),
+			}
+		}
+		t.Payloads[i] = Payload{
+			KeyParts: keyParts,
+			Value:    util.ToBase64(payload.Value),
+		}
+	}
+}
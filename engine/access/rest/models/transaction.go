@@ -145,3 +145,33 @@ func (p *ProposalKey) Build(key flow.ProposalKey) {
 	p.KeyIndex = util.FromUint64(key.KeyIndex)
 	p.SequenceNumber = util.FromUint64(key.SequenceNumber)
 }
+
+// TransactionStatusUpdate is the response model of the transaction status stream. The block is
+// set once the transaction is finalized, and the execution outcome once it is executed.
+type TransactionStatusUpdate struct {
+	TransactionId string             `json:"transaction_id"`
+	Status        *TransactionStatus `json:"status"`
+	BlockId       string             `json:"block_id"`
+	BlockHeight   string             `json:"block_height"`
+	StatusCode    int32              `json:"status_code"`
+	ErrorMessage  string             `json:"error_message"`
+	Events        []Event            `json:"events"`
+}
+
+func (t *TransactionStatusUpdate) Build(txr *access.TransactionResult) {
+	var status TransactionStatus
+	status.Build(txr.Status)
+
+	var events Events
+	events.Build(txr.Events)
+
+	t.TransactionId = txr.TransactionID.String()
+	t.Status = &status
+	if txr.BlockID != flow.ZeroID { // don't send back 0 ID
+		t.BlockId = txr.BlockID.String()
+		t.BlockHeight = util.FromUint64(txr.BlockHeight)
+	}
+	t.StatusCode = int32(txr.StatusCode)
+	t.ErrorMessage = txr.ErrorMessage
+	t.Events = events
+}
@@ -0,0 +1,127 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const cursorQuery = "cursor"
+const limitQuery = "limit"
+const contractQuery = "contract"
+
+// DefaultAccountHistoryLimit is the number of results per page if no limit is provided.
+const DefaultAccountHistoryLimit = 50
+
+// GetAccountTransactions is the request for the transactions signed by an account. A start height
+// of 0 starts at the first indexed height, and an end height of 0 ends at the latest indexed height.
+type GetAccountTransactions struct {
+	Address     flow.Address
+	StartHeight uint64
+	EndHeight   uint64
+	Cursor      *flow.AccountIndexCursor
+	Limit       uint
+}
+
+func (g *GetAccountTransactions) Build(r *Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+		r.GetQueryParam(cursorQuery),
+		r.GetQueryParam(limitQuery),
+	)
+}
+
+func (g *GetAccountTransactions) Parse(rawAddress string, rawStart string, rawEnd string, rawCursor string, rawLimit string) error {
+	var address Address
+	err := address.Parse(rawAddress)
+	if err != nil {
+		return err
+	}
+	g.Address = address.Flow()
+
+	g.StartHeight, err = parseAccountHistoryHeight(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+
+	g.EndHeight, err = parseAccountHistoryHeight(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+
+	if g.StartHeight != 0 && g.EndHeight != 0 && g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	g.Cursor = nil
+	if rawCursor != "" {
+		g.Cursor, err = util.DecodeCursor(rawCursor)
+		if err != nil {
+			return err
+		}
+	}
+
+	g.Limit = DefaultAccountHistoryLimit
+	if rawLimit != "" {
+		limit, err := strconv.ParseUint(rawLimit, 10, 32)
+		if err != nil || limit == 0 {
+			return fmt.Errorf("invalid limit: must be a positive integer")
+		}
+		g.Limit = uint(limit)
+	}
+
+	return nil
+}
+
+// parseAccountHistoryHeight parses a height of the account history range. The latest indexed
+// height is requested with the value "sealed", or by omitting the height.
+func parseAccountHistoryHeight(raw string) (uint64, error) {
+	var height Height
+	err := height.Parse(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	switch height.Flow() {
+	case EmptyHeight, SealedHeight:
+		return 0, nil
+	case FinalHeight:
+		// only sealed blocks are indexed
+		return 0, fmt.Errorf("must be an explicit height or %s", sealed)
+	default:
+		return height.Flow(), nil
+	}
+}
+
+// GetAccountEvents is the request for the events emitted by the contracts of an account, optionally
+// filtered by contract name.
+type GetAccountEvents struct {
+	GetAccountTransactions
+	Contract string
+}
+
+func (g *GetAccountEvents) Build(r *Request) error {
+	err := g.GetAccountTransactions.Build(r)
+	if err != nil {
+		return err
+	}
+
+	return g.Parse(r.GetQueryParam(contractQuery))
+}
+
+func (g *GetAccountEvents) Parse(rawContract string) error {
+	if rawContract != "" {
+		valid, _ := regexp.MatchString(`^\w+$`, rawContract)
+		if !valid {
+			return fmt.Errorf("invalid contract name")
+		}
+	}
+	g.Contract = rawContract
+
+	return nil
+}
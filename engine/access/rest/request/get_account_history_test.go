@@ -0,0 +1,69 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func Test_GetAccountTransactions_InvalidParse(t *testing.T) {
+	var getAccountTransactions GetAccountTransactions
+
+	tests := []struct {
+		address string
+		start   string
+		end     string
+		cursor  string
+		limit   string
+		err     string
+	}{
+		{"", "", "", "", "", "invalid address"},
+		{"f8d6e0586b0a20c7", "foo", "", "", "", "invalid start height: invalid height format"},
+		{"f8d6e0586b0a20c7", "", "final", "", "", "invalid end height: must be an explicit height or sealed"},
+		{"f8d6e0586b0a20c7", "20", "10", "", "", "start height must be less than or equal to end height"},
+		{"f8d6e0586b0a20c7", "", "", "AAAA", "", "invalid cursor"},
+		{"f8d6e0586b0a20c7", "", "", "", "-1", "invalid limit: must be a positive integer"},
+		{"f8d6e0586b0a20c7", "", "", "", "0", "invalid limit: must be a positive integer"},
+	}
+
+	for i, test := range tests {
+		err := getAccountTransactions.Parse(test.address, test.start, test.end, test.cursor, test.limit)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func Test_GetAccountTransactions_ValidParse(t *testing.T) {
+	var getAccountTransactions GetAccountTransactions
+
+	addr := "f8d6e0586b0a20c7"
+	err := getAccountTransactions.Parse(addr, "", "sealed", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, addr, getAccountTransactions.Address.String())
+	assert.Equal(t, uint64(0), getAccountTransactions.StartHeight)
+	assert.Equal(t, uint64(0), getAccountTransactions.EndHeight)
+	assert.Nil(t, getAccountTransactions.Cursor)
+	assert.Equal(t, uint(DefaultAccountHistoryLimit), getAccountTransactions.Limit)
+
+	cursor := &flow.AccountIndexCursor{BlockHeight: 15, TransactionIndex: 2, EventIndex: 7}
+	err = getAccountTransactions.Parse(addr, "10", "20", util.EncodeCursor(cursor), "5")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), getAccountTransactions.StartHeight)
+	assert.Equal(t, uint64(20), getAccountTransactions.EndHeight)
+	assert.Equal(t, cursor, getAccountTransactions.Cursor)
+	assert.Equal(t, uint(5), getAccountTransactions.Limit)
+}
+
+func Test_GetAccountEvents_Parse(t *testing.T) {
+	var getAccountEvents GetAccountEvents
+
+	err := getAccountEvents.Parse("FungibleToken")
+	assert.NoError(t, err)
+	assert.Equal(t, "FungibleToken", getAccountEvents.Contract)
+
+	err = getAccountEvents.Parse("A.f8d6e0586b0a20c7.FungibleToken")
+	assert.EqualError(t, err, "invalid contract name")
+}
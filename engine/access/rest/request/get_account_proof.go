@@ -0,0 +1,58 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const keysQuery = "keys"
+
+// MaxRegisterKeysLength is the maximum number of register keys which can be proven at a time.
+const MaxRegisterKeysLength = 100
+
+// GetAccountProof is the request for the proof of register values of an account. If no keys are
+// provided, the registers of the account status are proven.
+type GetAccountProof struct {
+	Address flow.Address
+	Height  uint64
+	Keys    []string
+}
+
+func (g *GetAccountProof) Build(r *Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(blockHeightQuery),
+		r.GetQueryParams(keysQuery),
+	)
+}
+
+func (g *GetAccountProof) Parse(rawAddress string, rawHeight string, rawKeys []string) error {
+	var account GetAccount
+	err := account.Parse(rawAddress, rawHeight)
+	if err != nil {
+		return err
+	}
+	g.Address = account.Address
+	g.Height = account.Height
+
+	if len(rawKeys) > MaxRegisterKeysLength {
+		return fmt.Errorf("at most %d keys can be requested at a time", MaxRegisterKeysLength)
+	}
+
+	g.Keys = nil
+	seen := make(map[string]bool, len(rawKeys))
+	for _, key := range rawKeys {
+		if key == "" {
+			return fmt.Errorf("invalid key: must not be empty")
+		}
+		// deduplicate keys, as every register is only proven once
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		g.Keys = append(g.Keys, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetAccountProof_InvalidParse(t *testing.T) {
+	var getAccountProof GetAccountProof
+
+	tooManyKeys := strings.Split(strings.Repeat("key,", MaxRegisterKeysLength+1), ",")[:MaxRegisterKeysLength+1]
+
+	tests := []struct {
+		address string
+		height  string
+		keys    []string
+		err     string
+	}{
+		{"", "", nil, "invalid address"},
+		{"f8d6e0586b0a20c7", "-1", nil, "invalid height format"},
+		{"f8d6e0586b0a20c7", "", []string{"storage_used", ""}, "invalid key: must not be empty"},
+		{"f8d6e0586b0a20c7", "", tooManyKeys, fmt.Sprintf("at most %d keys can be requested at a time", MaxRegisterKeysLength)},
+	}
+
+	for i, test := range tests {
+		err := getAccountProof.Parse(test.address, test.height, test.keys)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func Test_GetAccountProof_ValidParse(t *testing.T) {
+	var getAccountProof GetAccountProof
+
+	addr := "f8d6e0586b0a20c7"
+	err := getAccountProof.Parse(addr, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, getAccountProof.Address.String(), addr)
+	assert.Equal(t, getAccountProof.Height, SealedHeight)
+	assert.Empty(t, getAccountProof.Keys)
+
+	err = getAccountProof.Parse(addr, "100", []string{"storage_used", "public_key_count", "storage_used"})
+	assert.NoError(t, err)
+	assert.Equal(t, getAccountProof.Height, uint64(100))
+	assert.Equal(t, []string{"storage_used", "public_key_count"}, getAccountProof.Keys)
+}
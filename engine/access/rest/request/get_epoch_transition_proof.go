@@ -0,0 +1,31 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+const counterVar = "counter"
+
+// GetEpochTransitionProof is the request for the proof of the transition into the epoch with the given counter.
+type GetEpochTransitionProof struct {
+	Counter uint64
+}
+
+func (g *GetEpochTransitionProof) Build(r *Request) error {
+	return g.Parse(r.GetVar(counterVar))
+}
+
+func (g *GetEpochTransitionProof) Parse(rawCounter string) error {
+	counter, err := util.ToUint64(rawCounter)
+	if err != nil {
+		return fmt.Errorf("invalid epoch counter: %w", err)
+	}
+	if counter == 0 {
+		return fmt.Errorf("invalid epoch counter: the first epoch has no transition")
+	}
+	g.Counter = counter
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetEpochTransitionProof_InvalidParse(t *testing.T) {
+	var getProof GetEpochTransitionProof
+
+	tests := []struct {
+		counter string
+		err     string
+	}{
+		{"", "invalid epoch counter: value must be an unsigned 64 bit integer"},
+		{"-1", "invalid epoch counter: value must be an unsigned 64 bit integer"},
+		{"current", "invalid epoch counter: value must be an unsigned 64 bit integer"},
+		{"0", "invalid epoch counter: the first epoch has no transition"},
+	}
+
+	for i, test := range tests {
+		err := getProof.Parse(test.counter)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func Test_GetEpochTransitionProof_ValidParse(t *testing.T) {
+	var getProof GetEpochTransitionProof
+
+	err := getProof.Parse("42")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), getProof.Counter)
+}
@@ -54,6 +54,30 @@ func (rd *Request) GetAccountRequest() (GetAccount, error) {
 	return req, err
 }
 
+func (rd *Request) GetAccountTransactionsRequest() (GetAccountTransactions, error) {
+	var req GetAccountTransactions
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetAccountEventsRequest() (GetAccountEvents, error) {
+	var req GetAccountEvents
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetAccountProofRequest() (GetAccountProof, error) {
+	var req GetAccountProof
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetEpochTransitionProofRequest() (GetEpochTransitionProof, error) {
+	var req GetEpochTransitionProof
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) GetExecutionResultByBlockIDsRequest() (GetExecutionResultByBlockIDs, error) {
 	var req GetExecutionResultByBlockIDs
 	err := req.Build(rd)
@@ -84,6 +108,30 @@ func (rd *Request) GetEventsRequest() (GetEvents, error) {
 	return req, err
 }
 
+func (rd *Request) SubscribeRequest() (Subscribe, error) {
+	var req Subscribe
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) SubscribeBlockHeadersRequest() (SubscribeBlockHeaders, error) {
+	var req SubscribeBlockHeaders
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) SubscribeEventsRequest() (SubscribeEvents, error) {
+	var req SubscribeEvents
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) SubscribeTransactionStatusesRequest() (SubscribeTransactionStatuses, error) {
+	var req SubscribeTransactionStatuses
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) CreateTransactionRequest() (CreateTransaction, error) {
 	var req CreateTransaction
 	err := req.Build(rd)
@@ -0,0 +1,128 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const startBlockIDQuery = "start_block_id"
+const blockStatusQuery = "block_status"
+const eventTypesQuery = "event_types"
+const addressesQuery = "addresses"
+const contractsQuery = "contracts"
+const transactionIDQuery = "transaction_id"
+
+// Subscribe contains the common parameters of all streaming requests. At most one of
+// StartBlockID and StartHeight is set. If neither is set, the stream starts at the latest block.
+type Subscribe struct {
+	StartBlockID flow.Identifier
+	StartHeight  uint64
+}
+
+func (s *Subscribe) Build(r *Request) error {
+	return s.Parse(
+		r.GetQueryParam(startBlockIDQuery),
+		r.GetQueryParam(startHeightQuery),
+	)
+}
+
+func (s *Subscribe) Parse(rawStartBlockID string, rawStartHeight string) error {
+	var startBlockID ID
+	err := startBlockID.Parse(rawStartBlockID)
+	if err != nil {
+		return fmt.Errorf("invalid start block ID: %w", err)
+	}
+	s.StartBlockID = startBlockID.Flow()
+
+	var height Height
+	err = height.Parse(rawStartHeight)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+
+	switch height.Flow() {
+	case EmptyHeight:
+		s.StartHeight = 0
+	case FinalHeight, SealedHeight:
+		// streams start at the latest block by default, so only explicit heights are accepted
+		return fmt.Errorf("invalid start height: must be an explicit height")
+	default:
+		s.StartHeight = height.Flow()
+	}
+
+	if s.StartBlockID != flow.ZeroID && s.StartHeight > 0 {
+		return fmt.Errorf("can only provide either start block ID or start height")
+	}
+
+	return nil
+}
+
+type SubscribeBlockHeaders struct {
+	Subscribe
+	Sealed bool
+}
+
+func (s *SubscribeBlockHeaders) Build(r *Request) error {
+	err := s.Subscribe.Build(r)
+	if err != nil {
+		return err
+	}
+
+	switch status := r.GetQueryParam(blockStatusQuery); status {
+	case "", final:
+		s.Sealed = false
+	case sealed:
+		s.Sealed = true
+	default:
+		return fmt.Errorf("invalid block status: %s, must be either %s or %s", status, final, sealed)
+	}
+
+	return nil
+}
+
+// SubscribeEvents holds the raw filter values, which are validated against the chain by the
+// state stream backend's event filter.
+type SubscribeEvents struct {
+	Subscribe
+	EventTypes []string
+	Addresses  []string
+	Contracts  []string
+}
+
+func (s *SubscribeEvents) Build(r *Request) error {
+	err := s.Subscribe.Build(r)
+	if err != nil {
+		return err
+	}
+
+	s.EventTypes = r.GetQueryParams(eventTypesQuery)
+	s.Addresses = r.GetQueryParams(addressesQuery)
+	s.Contracts = r.GetQueryParams(contractsQuery)
+
+	return nil
+}
+
+// SubscribeTransactionStatuses identifies the transaction whose status transitions are streamed.
+type SubscribeTransactionStatuses struct {
+	TransactionID flow.Identifier
+}
+
+func (s *SubscribeTransactionStatuses) Build(r *Request) error {
+	return s.Parse(r.GetQueryParam(transactionIDQuery))
+}
+
+func (s *SubscribeTransactionStatuses) Parse(rawTransactionID string) error {
+	var txID ID
+	err := txID.Parse(rawTransactionID)
+	if err != nil {
+		return fmt.Errorf("invalid transaction ID: %w", err)
+	}
+	s.TransactionID = txID.Flow()
+
+	if s.TransactionID == flow.ZeroID {
+		return fmt.Errorf("transaction ID must be provided")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestSubscribe_InvalidParse(t *testing.T) {
+	var subscribe Subscribe
+
+	tests := []struct {
+		startBlockID string
+		startHeight  string
+		err          string
+	}{
+		{"invalid", "", "invalid start block ID: invalid ID format"},
+		{"", "foo", "invalid start height: invalid height format"},
+		{"", "sealed", "invalid start height: must be an explicit height"},
+		{"", "final", "invalid start height: must be an explicit height"},
+		{"7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7", "10", "can only provide either start block ID or start height"},
+	}
+
+	for i, test := range tests {
+		err := subscribe.Parse(test.startBlockID, test.startHeight)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestSubscribe_ValidParse(t *testing.T) {
+	var subscribe Subscribe
+
+	err := subscribe.Parse("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.ZeroID, subscribe.StartBlockID)
+	assert.Equal(t, uint64(0), subscribe.StartHeight)
+
+	err = subscribe.Parse("", "100")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.ZeroID, subscribe.StartBlockID)
+	assert.Equal(t, uint64(100), subscribe.StartHeight)
+
+	id := "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7"
+	err = subscribe.Parse(id, "")
+	assert.NoError(t, err)
+	assert.Equal(t, id, subscribe.StartBlockID.String())
+	assert.Equal(t, uint64(0), subscribe.StartHeight)
+}
+
+func TestSubscribeTransactionStatuses_Parse(t *testing.T) {
+	var subscribe SubscribeTransactionStatuses
+
+	err := subscribe.Parse("")
+	assert.EqualError(t, err, "transaction ID must be provided")
+
+	err = subscribe.Parse("invalid")
+	assert.EqualError(t, err, "invalid transaction ID: invalid ID format")
+
+	id := "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7"
+	err = subscribe.Parse(id)
+	assert.NoError(t, err)
+	assert.Equal(t, id, subscribe.TransactionID.String())
+}
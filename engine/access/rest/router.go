@@ -5,14 +5,22 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/middleware"
 	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/model/flow"
 )
 
-func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain) (*mux.Router, error) {
+func newRouter(
+	backend access.API,
+	logger zerolog.Logger,
+	chain flow.Chain,
+	stateStreamApi state_stream.API,
+	stateStreamConfig state_stream.Config,
+) (*mux.Router, error) {
 	router := mux.NewRouter().StrictSlash(true)
 	v1SubRouter := router.PathPrefix("/v1").Subrouter()
 
@@ -31,9 +39,66 @@ func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain) (*mu
 			Name(r.Name).
 			Handler(h)
 	}
+
+	// the streaming endpoints are only available if the state stream API is enabled
+	if stateStreamApi != nil {
+		maxStreams := stateStreamConfig.MaxGlobalStreams
+		if maxStreams == 0 {
+			maxStreams = state_stream.DefaultMaxGlobalStreams
+		}
+		// the limit is shared by all streaming endpoints
+		streamCount := atomic.NewInt32(0)
+
+		for _, r := range SubscribeRoutes {
+			h := NewWebsocketHandler(logger, stateStreamApi, r.Handler, r.BodyFromMessage, chain, maxStreams, streamCount)
+			v1SubRouter.
+				Methods(r.Method).
+				Path(r.Pattern).
+				Name(r.Name).
+				Handler(h)
+		}
+	}
+
 	return router, nil
 }
 
+type subscribeRoute struct {
+	Name    string
+	Method  string
+	Pattern string
+	Handler SubscribeHandlerFunc
+	// BodyFromMessage is set if the request body is sent as the first websocket message
+	BodyFromMessage bool
+}
+
+var SubscribeRoutes = []subscribeRoute{{
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_block_headers",
+	Name:    "subscribeBlockHeaders",
+	Handler: SubscribeBlockHeaders,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_events",
+	Name:    "subscribeEvents",
+	Handler: SubscribeEvents,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_execution_data",
+	Name:    "subscribeExecutionData",
+	Handler: SubscribeExecutionData,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_transaction_statuses",
+	Name:    "subscribeTransactionStatuses",
+	Handler: SubscribeTransactionStatuses,
+}, {
+	Method:          http.MethodGet,
+	Pattern:         "/send_and_subscribe_transaction_statuses",
+	Name:            "sendAndSubscribeTransactionStatuses",
+	Handler:         SendAndSubscribeTransactionStatuses,
+	BodyFromMessage: true,
+}}
+
 type route struct {
 	Name    string
 	Method  string
@@ -96,6 +161,26 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}",
 	Name:    "getAccount",
 	Handler: GetAccount,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/proof",
+	Name:    "getAccountProof",
+	Handler: GetAccountProof,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/transactions",
+	Name:    "getAccountTransactions",
+	Handler: GetAccountTransactions,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/events",
+	Name:    "getAccountEvents",
+	Handler: GetAccountEvents,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/epochs/{counter}/transition_proof",
+	Name:    "getEpochTransitionProof",
+	Handler: GetEpochTransitionProof,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/events",
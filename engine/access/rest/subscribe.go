@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SubscribeBlockHeaders streams the headers of finalized or sealed blocks.
+func SubscribeBlockHeaders(ctx context.Context, r *request.Request, api state_stream.API) (state_stream.Subscription, error) {
+	req, err := r.SubscribeBlockHeadersRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return api.SubscribeBlockHeaders(ctx, req.StartBlockID, req.StartHeight, req.Sealed), nil
+}
+
+// SubscribeEvents streams the events of sealed blocks matching the provided filter.
+func SubscribeEvents(ctx context.Context, r *request.Request, api state_stream.API) (state_stream.Subscription, error) {
+	req, err := r.SubscribeEventsRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	filter, err := state_stream.NewEventFilter(r.Chain, req.EventTypes, req.Addresses, req.Contracts)
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return api.SubscribeEvents(ctx, req.StartBlockID, req.StartHeight, filter), nil
+}
+
+// SubscribeExecutionData streams the execution data of sealed blocks.
+func SubscribeExecutionData(ctx context.Context, r *request.Request, api state_stream.API) (state_stream.Subscription, error) {
+	req, err := r.SubscribeRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return api.SubscribeExecutionData(ctx, req.StartBlockID, req.StartHeight), nil
+}
+
+// SubscribeTransactionStatuses streams the status transitions of a transaction.
+func SubscribeTransactionStatuses(ctx context.Context, r *request.Request, api state_stream.API) (state_stream.Subscription, error) {
+	req, err := r.SubscribeTransactionStatusesRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return api.SubscribeTransactionStatuses(ctx, req.TransactionID), nil
+}
+
+// SendAndSubscribeTransactionStatuses sends the transaction of the request body, and streams its
+// status transitions.
+func SendAndSubscribeTransactionStatuses(ctx context.Context, r *request.Request, api state_stream.API) (state_stream.Subscription, error) {
+	req, err := r.CreateTransactionRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return api.SendAndSubscribeTransactionStatuses(ctx, &req.Transaction), nil
+}
+
+// buildSubscribeResponse converts a response received from a state stream subscription into
+// the corresponding response model.
+func buildSubscribeResponse(v interface{}) (interface{}, error) {
+	switch resp := v.(type) {
+	case *flow.Header:
+		var header models.BlockHeader
+		header.Build(resp)
+		return header, nil
+
+	case *state_stream.EventsResponse:
+		// unlike the events endpoint, a response is sent for every block, even without events
+		var blockEvents models.BlockEvents
+		blockEvents.Build(flow.BlockEvents{
+			BlockID:        resp.BlockID,
+			BlockHeight:    resp.Height,
+			BlockTimestamp: resp.BlockTimestamp,
+			Events:         resp.Events,
+		})
+		return blockEvents, nil
+
+	case *state_stream.ExecutionDataResponse:
+		var executionData models.ExecutionData
+		executionData.Build(resp.Height, resp.ExecutionData)
+		return executionData, nil
+
+	case *access.TransactionResult:
+		var update models.TransactionStatusUpdate
+		update.Build(resp)
+		return update, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected response type: %T", v)
+	}
+}
@@ -0,0 +1,291 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// newStreamingServer starts a test server serving the REST API with the streaming endpoints enabled
+func newStreamingServer(t *testing.T, api *mock.API, config state_stream.Config) *httptest.Server {
+	var b bytes.Buffer
+	logger := zerolog.New(&b)
+
+	router, err := newRouter(&accessmock.API{}, logger, flow.Testnet.Chain(), api, config)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func subscribeURL(server *httptest.Server, path string) string {
+	return fmt.Sprintf("ws%s/v1/%s", strings.TrimPrefix(server.URL, "http"), path)
+}
+
+// TestSubscribeBlockHeaders tests that all responses of a subscription are sent over the websocket
+// connection, followed by an error message if the subscription failed.
+func TestSubscribeBlockHeaders(t *testing.T) {
+	api := &mock.API{}
+	server := newStreamingServer(t, api, state_stream.DefaultConfig())
+
+	headers := []*flow.Header{
+		unittest.BlockHeaderFixture(),
+		unittest.BlockHeaderFixture(),
+	}
+
+	sub := state_stream.NewSubscription(uint(len(headers)))
+	for _, header := range headers {
+		require.NoError(t, sub.Send(context.Background(), header, time.Second))
+	}
+	sub.Fail(status.Error(codes.NotFound, "block not found"))
+
+	api.On("SubscribeBlockHeaders", mocks.Anything, flow.ZeroID, headers[0].Height, true).Return(sub)
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		subscribeURL(server, fmt.Sprintf("subscribe_block_headers?start_height=%d&block_status=sealed", headers[0].Height)),
+		nil,
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, header := range headers {
+		var expected, actual models.BlockHeader
+		expected.Build(header)
+
+		require.NoError(t, conn.ReadJSON(&actual))
+		assert.Equal(t, expected.Id, actual.Id)
+		assert.Equal(t, expected.Height, actual.Height)
+	}
+
+	var modelError models.ModelError
+	require.NoError(t, conn.ReadJSON(&modelError))
+	assert.Equal(t, int32(http.StatusNotFound), modelError.Code)
+	assert.Contains(t, modelError.Message, "block not found")
+
+	// the server closes the connection after the error
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseInternalServerErr), "unexpected error: %v", err)
+}
+
+// TestSubscribeEvents tests that the events of each block are sent as block events.
+func TestSubscribeEvents(t *testing.T) {
+	api := &mock.API{}
+	server := newStreamingServer(t, api, state_stream.DefaultConfig())
+
+	header := unittest.BlockHeaderFixture()
+	resp := &state_stream.EventsResponse{
+		BlockID:        header.ID(),
+		Height:         header.Height,
+		BlockTimestamp: header.Timestamp,
+		Events: flow.EventsList{
+			unittest.EventFixture("flow.AccountCreated", 0, 0, unittest.IdentifierFixture(), 0),
+		},
+	}
+
+	sub := state_stream.NewSubscription(1)
+	require.NoError(t, sub.Send(context.Background(), resp, time.Second))
+	sub.Close()
+
+	api.On("SubscribeEvents", mocks.Anything, header.ID(), uint64(0), mocks.AnythingOfType("state_stream.EventFilter")).
+		Return(sub)
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		subscribeURL(server, fmt.Sprintf("subscribe_events?start_block_id=%s&event_types=flow.AccountCreated", header.ID())),
+		nil,
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var actual models.BlockEvents
+	require.NoError(t, conn.ReadJSON(&actual))
+	assert.Equal(t, header.ID().String(), actual.BlockId)
+	require.Len(t, actual.Events, 1)
+	assert.Equal(t, "flow.AccountCreated", actual.Events[0].Type_)
+
+	// the server closes the connection gracefully once the subscription ends
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error: %v", err)
+}
+
+// TestSubscribeTransactionStatuses tests that every status transition is sent as a transaction
+// status update.
+func TestSubscribeTransactionStatuses(t *testing.T) {
+	api := &mock.API{}
+	server := newStreamingServer(t, api, state_stream.DefaultConfig())
+
+	txID := unittest.IdentifierFixture()
+	header := unittest.BlockHeaderFixture()
+	results := []*access.TransactionResult{{
+		TransactionID: txID,
+		Status:        flow.TransactionStatusPending,
+	}, {
+		TransactionID: txID,
+		Status:        flow.TransactionStatusSealed,
+		BlockID:       header.ID(),
+		BlockHeight:   header.Height,
+		Events: []flow.Event{
+			unittest.EventFixture("flow.AccountCreated", 0, 0, txID, 0),
+		},
+	}}
+
+	sub := state_stream.NewSubscription(uint(len(results)))
+	for _, result := range results {
+		require.NoError(t, sub.Send(context.Background(), result, time.Second))
+	}
+	sub.Close()
+
+	api.On("SubscribeTransactionStatuses", mocks.Anything, txID).Return(sub)
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		subscribeURL(server, fmt.Sprintf("subscribe_transaction_statuses?transaction_id=%s", txID)),
+		nil,
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var pending models.TransactionStatusUpdate
+	require.NoError(t, conn.ReadJSON(&pending))
+	assert.Equal(t, txID.String(), pending.TransactionId)
+	assert.Equal(t, models.PENDING, *pending.Status)
+	assert.Empty(t, pending.BlockId)
+
+	var sealed models.TransactionStatusUpdate
+	require.NoError(t, conn.ReadJSON(&sealed))
+	assert.Equal(t, models.SEALED, *sealed.Status)
+	assert.Equal(t, header.ID().String(), sealed.BlockId)
+	assert.Equal(t, fmt.Sprintf("%d", header.Height), sealed.BlockHeight)
+	require.Len(t, sealed.Events, 1)
+	assert.Equal(t, "flow.AccountCreated", sealed.Events[0].Type_)
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error: %v", err)
+}
+
+// TestSendAndSubscribeTransactionStatuses tests that the transaction is read from the first message
+// sent by the client, and that its status transitions are streamed afterwards.
+func TestSendAndSubscribeTransactionStatuses(t *testing.T) {
+	api := &mock.API{}
+	server := newStreamingServer(t, api, state_stream.DefaultConfig())
+
+	t.Run("valid transaction", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture()
+		tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+		tx.Arguments = [][]uint8{}
+
+		sub := state_stream.NewSubscription(1)
+		require.NoError(t, sub.Send(context.Background(), &access.TransactionResult{
+			TransactionID: tx.ID(),
+			Status:        flow.TransactionStatusPending,
+		}, time.Second))
+		sub.Close()
+
+		api.On("SendAndSubscribeTransactionStatuses", mocks.Anything, &tx).Return(sub).Once()
+
+		conn, _, err := websocket.DefaultDialer.Dial(subscribeURL(server, "send_and_subscribe_transaction_statuses"), nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(validCreateBody(tx)))
+
+		var pending models.TransactionStatusUpdate
+		require.NoError(t, conn.ReadJSON(&pending))
+		assert.Equal(t, tx.ID().String(), pending.TransactionId)
+		assert.Equal(t, models.PENDING, *pending.Status)
+
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error: %v", err)
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(subscribeURL(server, "send_and_subscribe_transaction_statuses"), nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"script": "foo"}`)))
+
+		var modelError models.ModelError
+		require.NoError(t, conn.ReadJSON(&modelError))
+		assert.Equal(t, int32(http.StatusBadRequest), modelError.Code)
+
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseInternalServerErr), "unexpected error: %v", err)
+	})
+
+	api.AssertExpectations(t)
+}
+
+// TestSubscribe_InvalidRequest tests that invalid requests are rejected before the connection is upgraded.
+func TestSubscribe_InvalidRequest(t *testing.T) {
+	api := &mock.API{}
+	server := newStreamingServer(t, api, state_stream.DefaultConfig())
+
+	tests := []struct {
+		description string
+		path        string
+	}{
+		{"invalid start height", "subscribe_block_headers?start_height=foo"},
+		{"invalid block status", "subscribe_block_headers?block_status=executed"},
+		{"start block ID and height", fmt.Sprintf("subscribe_execution_data?start_block_id=%s&start_height=10", unittest.IdentifierFixture())},
+		{"invalid event type", "subscribe_events?event_types=foo"},
+		{"missing transaction ID", "subscribe_transaction_statuses"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, resp, err := websocket.DefaultDialer.Dial(subscribeURL(server, test.path), nil)
+			require.ErrorIs(t, err, websocket.ErrBadHandshake)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+
+	api.AssertNotCalled(t, "SubscribeBlockHeaders", mocks.Anything, mocks.Anything, mocks.Anything, mocks.Anything)
+	api.AssertNotCalled(t, "SubscribeEvents", mocks.Anything, mocks.Anything, mocks.Anything, mocks.Anything)
+	api.AssertNotCalled(t, "SubscribeExecutionData", mocks.Anything, mocks.Anything, mocks.Anything)
+	api.AssertNotCalled(t, "SubscribeTransactionStatuses", mocks.Anything, mocks.Anything)
+}
+
+// TestSubscribe_MaxStreams tests that new streams are rejected once the max number of streams is reached.
+func TestSubscribe_MaxStreams(t *testing.T) {
+	api := &mock.API{}
+
+	config := state_stream.DefaultConfig()
+	config.MaxGlobalStreams = 1
+	server := newStreamingServer(t, api, config)
+
+	// the subscription stays open until the test ends
+	sub := state_stream.NewSubscription(1)
+	defer sub.Close()
+
+	api.On("SubscribeExecutionData", mocks.Anything, flow.ZeroID, uint64(0)).Return(sub)
+
+	conn, _, err := websocket.DefaultDialer.Dial(subscribeURL(server, "subscribe_execution_data"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(subscribeURL(server, "subscribe_execution_data"), nil)
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// cursorLength is the length of an encoded account index cursor: the block height followed by the
+// transaction index and the event index.
+const cursorLength = 8 + 4 + 4
+
+// EncodeCursor encodes the position of a paginated account history query as an opaque string.
+func EncodeCursor(cursor *flow.AccountIndexCursor) string {
+	raw := make([]byte, cursorLength)
+	binary.BigEndian.PutUint64(raw[0:8], cursor.BlockHeight)
+	binary.BigEndian.PutUint32(raw[8:12], cursor.TransactionIndex)
+	binary.BigEndian.PutUint32(raw[12:16], cursor.EventIndex)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor decodes a cursor encoded by EncodeCursor.
+func DecodeCursor(encoded string) (*flow.AccountIndexCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != cursorLength {
+		return nil, fmt.Errorf("invalid cursor") // hide error from user
+	}
+
+	return &flow.AccountIndexCursor{
+		BlockHeight:      binary.BigEndian.Uint64(raw[0:8]),
+		TransactionIndex: binary.BigEndian.Uint32(raw[8:12]),
+		EventIndex:       binary.BigEndian.Uint32(raw[12:16]),
+	}, nil
+}
@@ -0,0 +1,282 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// writeWait is the time allowed to write a single message to the client.
+	writeWait = 10 * time.Second
+
+	// pongWait is the time allowed to read the next pong message from the client.
+	pongWait = 60 * time.Second
+
+	// pingPeriod is the period at which pings are sent to the client. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+)
+
+// SubscribeHandlerFunc is a function that contains the streaming endpoint handling logic. It
+// builds the request, and returns a subscription whose responses are streamed to the client.
+// The subscription must stop when the given context is cancelled.
+type SubscribeHandlerFunc func(
+	ctx context.Context,
+	r *request.Request,
+	api state_stream.API,
+) (state_stream.Subscription, error)
+
+// WebsocketHandler is a http handler serving the streaming endpoints over websocket connections.
+// The request is validated before the connection is upgraded, so malformed requests receive a
+// regular error response. Once upgraded, every response of the subscription is sent to the client
+// as a JSON message. If the subscription fails, a final error message is sent before the
+// connection is closed.
+//
+// As websocket handshakes cannot carry a request body, endpoints which require a body read it from
+// the first message sent by the client instead. Such requests are validated after the connection
+// is upgraded, and are rejected with an error message.
+type WebsocketHandler struct {
+	*Handler
+	api                  state_stream.API
+	subscribeHandlerFunc SubscribeHandlerFunc
+	bodyFromMessage      bool
+	upgrader             websocket.Upgrader
+
+	maxStreams  int32
+	streamCount *atomic.Int32
+}
+
+func NewWebsocketHandler(
+	logger zerolog.Logger,
+	api state_stream.API,
+	handlerFunc SubscribeHandlerFunc,
+	bodyFromMessage bool,
+	chain flow.Chain,
+	maxStreams uint32,
+	streamCount *atomic.Int32,
+) *WebsocketHandler {
+	return &WebsocketHandler{
+		Handler: &Handler{
+			logger: logger,
+			chain:  chain,
+		},
+		api:                  api,
+		subscribeHandlerFunc: handlerFunc,
+		bodyFromMessage:      bodyFromMessage,
+		upgrader: websocket.Upgrader{
+			// the API is public, and is served to all origins like the other endpoints
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		maxStreams:  int32(maxStreams),
+		streamCount: streamCount,
+	}
+}
+
+// ServeHTTP validates the request, upgrades the connection and streams the subscription's
+// responses to the client until either side closes the stream.
+func (h *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	errLog := h.logger.With().Str("request_url", r.URL.String()).Logger()
+
+	if h.streamCount.Inc() > h.maxStreams {
+		h.streamCount.Dec()
+		h.errorResponse(w, http.StatusServiceUnavailable, "maximum number of streams reached", errLog)
+		return
+	}
+	defer h.streamCount.Dec()
+
+	err := r.ParseForm()
+	if err != nil {
+		h.errorHandler(w, err, errLog)
+		return
+	}
+
+	// the connection is hijacked when it is upgraded, so the request context is not cancelled
+	// when the client goes away. The subscription uses its own context, which is cancelled once
+	// the connection is closed.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if h.bodyFromMessage {
+		h.serveBodyFromMessage(ctx, cancel, w, r, errLog)
+		return
+	}
+
+	sub, err := h.subscribeHandlerFunc(ctx, request.Decorate(r, h.chain), h.api)
+	if err != nil {
+		h.errorHandler(w, err, errLog)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error response
+		errLog.Debug().Err(err).Msg("could not upgrade connection")
+		return
+	}
+	defer conn.Close()
+
+	h.stream(conn, sub, cancel, errLog)
+}
+
+// serveBodyFromMessage upgrades the connection, reads the request body from the first message sent
+// by the client, and streams the subscription's responses to the client.
+func (h *WebsocketHandler) serveBodyFromMessage(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	w http.ResponseWriter,
+	r *http.Request,
+	errLog zerolog.Logger,
+) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error response
+		errLog.Debug().Err(err).Msg("could not upgrade connection")
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(MaxRequestSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	_, body, err := conn.ReadMessage()
+	if err != nil {
+		errLog.Debug().Err(err).Msg("could not read request body")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	sub, err := h.subscribeHandlerFunc(ctx, request.Decorate(r, h.chain), h.api)
+	if err != nil {
+		err = h.closeStream(conn, err)
+		errLog.Debug().Err(err).Msg("invalid request")
+		return
+	}
+
+	h.stream(conn, sub, cancel, errLog)
+}
+
+// stream sends the responses of the subscription to the client until either side closes the stream.
+func (h *WebsocketHandler) stream(conn *websocket.Conn, sub state_stream.Subscription, cancel context.CancelFunc, errLog zerolog.Logger) {
+	go h.readMessages(conn, cancel)
+
+	err := h.writeMessages(conn, sub)
+	if err != nil {
+		errLog.Debug().Err(err).Str("sub_id", sub.ID()).Msg("stream closed")
+	}
+}
+
+// readMessages reads and discards all messages sent by the client, which is needed to process
+// control messages. It cancels the subscription once the connection is closed.
+func (h *WebsocketHandler) readMessages(conn *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// writeMessages sends all responses of the subscription to the client, and pings the client
+// periodically to detect broken connections.
+func (h *WebsocketHandler) writeMessages(conn *websocket.Conn, sub state_stream.Subscription) error {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case v, ok := <-sub.Channel():
+			if !ok {
+				return h.closeStream(conn, sub.Err())
+			}
+
+			response, err := buildSubscribeResponse(v)
+			if err != nil {
+				return h.closeStream(conn, status.Error(codes.Internal, err.Error()))
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			err = conn.WriteJSON(response)
+			if err != nil {
+				return fmt.Errorf("could not write response: %w", err)
+			}
+
+		case <-ticker.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			if err != nil {
+				return fmt.Errorf("could not ping client: %w", err)
+			}
+		}
+	}
+}
+
+// closeStream sends an error message to the client if the subscription failed, and closes the
+// connection gracefully.
+func (h *WebsocketHandler) closeStream(conn *websocket.Conn, subErr error) error {
+	closeCode := websocket.CloseNormalClosure
+	if subErr != nil {
+		modelError := models.ModelError{
+			Code:    int32(httpStatusFromError(subErr)),
+			Message: subErr.Error(),
+		}
+		// errors of invalid requests carry their own status and message
+		var statusErr StatusError
+		if errors.As(subErr, &statusErr) {
+			modelError.Code = int32(statusErr.Status())
+			modelError.Message = statusErr.UserMessage()
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := conn.WriteJSON(modelError); err != nil {
+			return fmt.Errorf("could not write error response: %w", err)
+		}
+		closeCode = websocket.CloseInternalServerErr
+	}
+
+	msg := websocket.FormatCloseMessage(closeCode, "")
+	err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+	if err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+		return fmt.Errorf("could not close stream: %w", err)
+	}
+	return subErr
+}
+
+// httpStatusFromError maps the grpc status of a subscription error to a http status code.
+func httpStatusFromError(err error) int {
+	se, ok := status.FromError(err)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	switch se.Code() {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unavailable, codes.ResourceExhausted:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
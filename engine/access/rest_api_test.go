@@ -100,7 +100,7 @@ func (suite *RestAPITestSuite) SetupTest() {
 	}
 
 	rpcEngBuilder, err := rpc.NewBuilder(suite.log, suite.state, config, suite.collClient, nil, suite.blocks, suite.headers, suite.collections, suite.transactions,
-		nil, suite.executionResults, suite.chainID, suite.metrics, suite.metrics, 0, 0, false, false, nil, nil)
+		nil, suite.executionResults, nil, suite.chainID, suite.metrics, suite.metrics, 0, 0, false, false, nil, nil, nil, nil)
 	rpcEngBuilder.WithLegacy()
 	suite.rpcEng = rpcEngBuilder.Build()
 	assert.NoError(suite.T(), err)
@@ -18,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -60,6 +61,7 @@ var SnapshotHistoryLimitErr = fmt.Errorf("reached the snapshot history limit")
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Account transaction and event history calls are handled by backendAccountIndex.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -70,6 +72,9 @@ type Backend struct {
 	backendBlockDetails
 	backendAccounts
 	backendExecutionResults
+	backendAccountIndex
+	backendRegisterProofs
+	backendEpochProofs
 
 	state                protocol.State
 	chainID              flow.ChainID
@@ -89,6 +94,7 @@ func New(
 	transactions storage.Transactions,
 	executionReceipts storage.ExecutionReceipts,
 	executionResults storage.ExecutionResults,
+	seals storage.Seals,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
 	connFactory ConnectionFactory,
@@ -98,6 +104,9 @@ func New(
 	fixedExecutionNodeIDs []string,
 	log zerolog.Logger,
 	snapshotHistoryLimit int,
+	accountIndex storage.AccountIndex,
+	scriptExecutor execution.ScriptExecutor,
+	scriptExecMode ScriptExecutionMode,
 ) *Backend {
 	retry := newRetry()
 	if retryEnabled {
@@ -122,6 +131,8 @@ func New(
 			log:               log,
 			metrics:           transactionMetrics,
 			loggedScripts:     loggedScripts,
+			scriptExecutor:    scriptExecutor,
+			scriptExecMode:    scriptExecMode,
 		},
 		backendTransactions: backendTransactions{
 			staticCollectionRPC:  collectionRPC,
@@ -160,10 +171,31 @@ func New(
 			executionReceipts: executionReceipts,
 			connFactory:       connFactory,
 			log:               log,
+			scriptExecutor:    scriptExecutor,
+			scriptExecMode:    scriptExecMode,
 		},
 		backendExecutionResults: backendExecutionResults{
 			executionResults: executionResults,
 		},
+		backendAccountIndex: backendAccountIndex{
+			index: accountIndex,
+		},
+		backendRegisterProofs: backendRegisterProofs{
+			state:             state,
+			headers:           headers,
+			blocks:            blocks,
+			executionResults:  executionResults,
+			executionReceipts: executionReceipts,
+			seals:             seals,
+			connFactory:       connFactory,
+			log:               log,
+		},
+		backendEpochProofs: backendEpochProofs{
+			state:            state,
+			headers:          headers,
+			blocks:           blocks,
+			executionResults: executionResults,
+		},
 		collections:          collections,
 		executionReceipts:    executionReceipts,
 		connFactory:          connFactory,
@@ -347,6 +379,11 @@ func convertStorageError(err error) error {
 	if errors.Is(err, storage.ErrNotFound) {
 		return status.Errorf(codes.NotFound, "not found: %v", err)
 	}
+	// pruned data existed, so it is reported with a distinct code, which clients can tell apart
+	// from data that is not known to the network
+	if errors.Is(err, storage.ErrPruned) {
+		return status.Errorf(codes.FailedPrecondition, "pruned: %v", err)
+	}
 
 	return status.Errorf(codes.Internal, "failed to find: %v", err)
 }
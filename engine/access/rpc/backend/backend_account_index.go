@@ -0,0 +1,127 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultMaxAccountIndexPageSize is the default maximum number of results per page of account
+// transactions and events.
+const DefaultMaxAccountIndexPageSize = 100
+
+type backendAccountIndex struct {
+	index storage.AccountIndex // nil if account indexing is disabled
+}
+
+// GetAccountTransactions returns a page of the transactions signed by the given account within the
+// given height range.
+func (b *backendAccountIndex) GetAccountTransactions(
+	_ context.Context,
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountIndexCursor,
+	limit uint,
+) (*access.AccountTransactionsPage, error) {
+	startHeight, endHeight, err := b.validateRequest(startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, next, err := b.index.TransactionsByAddress(address, startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account transactions: %v", err)
+	}
+
+	return &access.AccountTransactionsPage{
+		Transactions: transactions,
+		NextCursor:   next,
+	}, nil
+}
+
+// GetAccountEvents returns a page of the events emitted by contracts deployed to the given account
+// within the given height range.
+func (b *backendAccountIndex) GetAccountEvents(
+	_ context.Context,
+	address flow.Address,
+	contract string,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountIndexCursor,
+	limit uint,
+) (*access.AccountEventsPage, error) {
+	startHeight, endHeight, err := b.validateRequest(startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events, next, err := b.index.EventsByAddress(address, contract, startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account events: %v", err)
+	}
+
+	return &access.AccountEventsPage{
+		Events:     events,
+		NextCursor: next,
+	}, nil
+}
+
+// validateRequest checks the parameters of a paginated account index request against the indexed
+// height range, and returns the effective start and end heights of the request.
+func (b *backendAccountIndex) validateRequest(
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountIndexCursor,
+	limit uint,
+) (uint64, uint64, error) {
+	if b.index == nil {
+		return 0, 0, status.Error(codes.Unavailable, "account indexing is disabled")
+	}
+
+	if limit == 0 || limit > DefaultMaxAccountIndexPageSize {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", DefaultMaxAccountIndexPageSize)
+	}
+
+	firstHeight, err := b.index.FirstHeight()
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return 0, 0, status.Error(codes.Unavailable, "no blocks have been indexed yet")
+		}
+		return 0, 0, status.Errorf(codes.Internal, "failed to get first indexed height: %v", err)
+	}
+
+	latestHeight, err := b.index.LatestHeight()
+	if err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "failed to get latest indexed height: %v", err)
+	}
+
+	if startHeight == 0 {
+		startHeight = firstHeight
+	}
+	if endHeight == 0 || endHeight > latestHeight {
+		endHeight = latestHeight
+	}
+
+	if startHeight < firstHeight {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "start height must be greater than or equal to the first indexed height %d", firstHeight)
+	}
+	if startHeight > latestHeight {
+		return 0, 0, status.Errorf(codes.OutOfRange, "start height must be less than or equal to the latest indexed height %d", latestHeight)
+	}
+	if startHeight > endHeight {
+		return 0, 0, status.Error(codes.InvalidArgument, "start height must be less than or equal to end height")
+	}
+
+	if cursor != nil && (cursor.BlockHeight < startHeight || cursor.BlockHeight > endHeight) {
+		return 0, 0, status.Error(codes.InvalidArgument, "cursor is outside of the requested height range")
+	}
+
+	return startHeight, endHeight, nil
+}
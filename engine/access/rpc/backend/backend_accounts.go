@@ -2,8 +2,10 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
@@ -11,7 +13,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -22,6 +26,8 @@ type backendAccounts struct {
 	executionReceipts storage.ExecutionReceipts
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
+	scriptExecutor    execution.ScriptExecutor // nil if accounts are only queried from execution nodes
+	scriptExecMode    ScriptExecutionMode
 }
 
 func (b *backendAccounts) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
@@ -39,7 +45,7 @@ func (b *backendAccounts) GetAccountAtLatestBlock(ctx context.Context, address f
 	// get the block id of the latest sealed header
 	latestBlockID := latestHeader.ID()
 
-	account, err := b.getAccountAtBlockID(ctx, address, latestBlockID)
+	account, err := b.getAccountAtBlock(ctx, address, latestBlockID, latestHeader.Height)
 	if err != nil {
 		b.log.Error().Err(err).Msgf("failed to get account at blockID: %v", latestBlockID)
 		return nil, err
@@ -63,7 +69,7 @@ func (b *backendAccounts) GetAccountAtBlockHeight(
 	// get block ID of the header at the given height
 	blockID := header.ID()
 
-	account, err := b.getAccountAtBlockID(ctx, address, blockID)
+	account, err := b.getAccountAtBlock(ctx, address, blockID, height)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +77,109 @@ func (b *backendAccounts) GetAccountAtBlockHeight(
 	return account, nil
 }
 
+// getAccountAtBlock returns the account at the given block according to the script execution mode.
+func (b *backendAccounts) getAccountAtBlock(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	height uint64,
+) (*flow.Account, error) {
+	switch b.scriptExecMode {
+	case ScriptExecutionModeLocal:
+		return b.getAccountLocally(ctx, address, blockID, height)
+
+	case ScriptExecutionModeFailover:
+		account, err := b.getAccountLocally(ctx, address, blockID, height)
+		// an account which does not exist would also not be found on the execution nodes
+		if err == nil || status.Code(err) == codes.NotFound {
+			return account, err
+		}
+		b.log.Debug().Err(err).
+			Hex("block_id", blockID[:]).
+			Uint64("height", height).
+			Msg("failed to get account locally, falling back to execution nodes")
+		return b.getAccountAtBlockID(ctx, address, blockID)
+
+	case ScriptExecutionModeCompare:
+		account, err := b.getAccountAtBlockID(ctx, address, blockID)
+		localAccount, localErr := b.getAccountLocally(ctx, address, blockID, height)
+		b.compareAccounts(address, blockID, height, account, err, localAccount, localErr)
+		return account, err
+
+	default:
+		return b.getAccountAtBlockID(ctx, address, blockID)
+	}
+}
+
+// getAccountLocally gets the account from the local register index, and converts errors to the
+// access node api error format.
+func (b *backendAccounts) getAccountLocally(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	height uint64,
+) (*flow.Account, error) {
+	account, err := b.scriptExecutor.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		if errors.Is(err, execution.ErrDataNotAvailable) {
+			return nil, status.Errorf(codes.OutOfRange, "failed to get account at block %v: %v", blockID, err)
+		}
+		if fvmerrors.IsAccountNotFoundError(err) {
+			return nil, status.Errorf(codes.NotFound, "account with address %s does not exist", address)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account locally: %v", err)
+	}
+
+	return account, nil
+}
+
+// compareAccounts logs a mismatch between the accounts returned by the execution nodes and by the
+// local register index. Failures to get the account on either side for reasons other than the
+// account not existing are not considered a mismatch.
+func (b *backendAccounts) compareAccounts(
+	address flow.Address,
+	blockID flow.Identifier,
+	height uint64,
+	account *flow.Account,
+	err error,
+	localAccount *flow.Account,
+	localErr error,
+) {
+	code, localCode := status.Code(err), status.Code(localErr)
+	comparable := func(code codes.Code) bool {
+		return code == codes.OK || code == codes.NotFound
+	}
+	if !comparable(code) || !comparable(localCode) {
+		return
+	}
+
+	match := code == localCode
+	if match && code == codes.OK {
+		// accounts are compared in their message format, since decoded public keys can't be compared directly
+		msg, convertErr := convert.AccountToMessage(account)
+		localMsg, localConvertErr := convert.AccountToMessage(localAccount)
+		if convertErr != nil || localConvertErr != nil {
+			b.log.Error().
+				AnErr("error", convertErr).
+				AnErr("local_error", localConvertErr).
+				Msg("failed to convert accounts for comparison")
+			return
+		}
+		match = proto.Equal(msg, localMsg)
+	}
+	if match {
+		return
+	}
+
+	b.log.Warn().
+		Str("address", address.String()).
+		Hex("block_id", blockID[:]).
+		Uint64("height", height).
+		AnErr("error", err).
+		AnErr("local_error", localErr).
+		Msg("accounts returned by execution nodes and local execution do not match")
+}
+
 func (b *backendAccounts) getAccountAtBlockID(
 	ctx context.Context,
 	address flow.Address,
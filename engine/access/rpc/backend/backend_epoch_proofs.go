@@ -0,0 +1,184 @@
+package backend
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+type backendEpochProofs struct {
+	state            protocol.State
+	headers          storage.Headers
+	blocks           storage.Blocks
+	executionResults storage.ExecutionResults
+}
+
+// GetEpochTransitionProof returns the EpochSetup and EpochCommit service events of the epoch with the given
+// counter, together with the proofs that they were sealed in finalized blocks of the previous epoch.
+func (b *backendEpochProofs) GetEpochTransitionProof(_ context.Context, counter uint64) (*lightclient.EpochTransitionProof, error) {
+	if counter == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "epoch %d has no previous epoch", counter)
+	}
+
+	setupProof, setupEvent, err := b.serviceEventProof(counter, flow.EpochPhaseSetup, flow.ServiceEventSetup)
+	if err != nil {
+		return nil, err
+	}
+	commitProof, commitEvent, err := b.serviceEventProof(counter, flow.EpochPhaseCommitted, flow.ServiceEventCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lightclient.EpochTransitionProof{
+		Setup:       setupEvent.Event.(*flow.EpochSetup),
+		Commit:      commitEvent.Event.(*flow.EpochCommit),
+		SetupProof:  setupProof,
+		CommitProof: commitProof,
+	}, nil
+}
+
+// serviceEventProof returns the proof of the service event of the given type for the epoch with the given counter,
+// which moved the previous epoch into the given phase.
+//
+// The protocol state applies service events in the child of the block sealing them, which contains the QC of the
+// sealing block. Hence, we search for the first finalized block whose epoch phase is the given phase, and prove
+// the event against its parent.
+func (b *backendEpochProofs) serviceEventProof(counter uint64, phase flow.EpochPhase, eventType string) (*lightclient.ServiceEventProof, *flow.ServiceEvent, error) {
+	target := epochProgress{counter: counter - 1, phase: phase}
+
+	child, err := b.firstFinalizedReaching(target)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealingBlock, err := b.blocks.ByID(child.ParentID)
+	if err != nil {
+		return nil, nil, convertStorageError(err)
+	}
+
+	for _, seal := range sealingBlock.Payload.Seals {
+		result, err := b.executionResults.ByID(seal.ResultID)
+		if err != nil {
+			return nil, nil, convertStorageError(err)
+		}
+		for i, event := range result.ServiceEvents {
+			eventCounter, ok := serviceEventCounter(event)
+			if event.Type != eventType || !ok || eventCounter != counter {
+				continue
+			}
+			proof := &lightclient.ServiceEventProof{
+				Result:         result,
+				Seal:           seal,
+				SealingHeader:  sealingBlock.Header,
+				SealingPayload: sealingBlock.Payload.Index(),
+				QC: &flow.QuorumCertificate{
+					View:          sealingBlock.Header.View,
+					BlockID:       child.ParentID,
+					SignerIndices: child.ParentVoterIndices,
+					SigData:       child.ParentVoterSigData,
+				},
+			}
+			return proof, &result.ServiceEvents[i], nil
+		}
+	}
+
+	return nil, nil, status.Errorf(codes.Internal, "could not find %s service event for epoch %d in block %v", eventType, counter, child.ParentID)
+}
+
+// firstFinalizedReaching returns the header of the lowest finalized block above the root block, at which the
+// epoch progress reaches the target.
+func (b *backendEpochProofs) firstFinalizedReaching(target epochProgress) (*flow.Header, error) {
+	root, err := b.state.Params().Root()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get root header: %v", err)
+	}
+	finalized, err := b.state.Final().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest finalized header: %v", err)
+	}
+
+	reached, err := b.reached(root.Height, target)
+	if err != nil {
+		return nil, err
+	}
+	if reached {
+		return nil, status.Errorf(codes.NotFound, "epoch %d reached %s phase before the root block", target.counter, target.phase)
+	}
+	reached, err = b.reached(finalized.Height, target)
+	if err != nil {
+		return nil, err
+	}
+	if !reached {
+		return nil, status.Errorf(codes.NotFound, "epoch %d has not reached %s phase yet", target.counter, target.phase)
+	}
+
+	// the epoch progress never decreases along the finalized chain, hence we can binary search for the lowest
+	// height reaching the target within (root, finalized]
+	var searchErr error
+	offset := sort.Search(int(finalized.Height-root.Height), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		reached, err := b.reached(root.Height+uint64(i)+1, target)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return reached
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	header, err := b.headers.ByHeight(root.Height + uint64(offset) + 1)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+	return header, nil
+}
+
+// reached returns whether the epoch progress at the finalized block with the given height reaches the target.
+func (b *backendEpochProofs) reached(height uint64, target epochProgress) (bool, error) {
+	snapshot := b.state.AtHeight(height)
+	counter, err := snapshot.Epochs().Current().Counter()
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to get epoch counter at height %d: %v", height, err)
+	}
+	phase, err := snapshot.Phase()
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to get epoch phase at height %d: %v", height, err)
+	}
+	return !(epochProgress{counter: counter, phase: phase}).before(target), nil
+}
+
+// epochProgress is the epoch and epoch phase of a block.
+type epochProgress struct {
+	counter uint64
+	phase   flow.EpochPhase
+}
+
+func (p epochProgress) before(other epochProgress) bool {
+	if p.counter != other.counter {
+		return p.counter < other.counter
+	}
+	return p.phase < other.phase
+}
+
+// serviceEventCounter returns the counter of the epoch configured by the given epoch service event, and false if
+// the event is not an epoch service event.
+func serviceEventCounter(event flow.ServiceEvent) (uint64, bool) {
+	switch ev := event.Event.(type) {
+	case *flow.EpochSetup:
+		return ev.Counter, true
+	case *flow.EpochCommit:
+		return ev.Counter, true
+	default:
+		return 0, false
+	}
+}
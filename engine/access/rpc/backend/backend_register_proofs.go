@@ -0,0 +1,259 @@
+package backend
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	fvmstate "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxRegisterProofKeys is the maximum number of registers which can be proven in a single request.
+const MaxRegisterProofKeys = 100
+
+// DefaultAccountRegisterKeys are the keys of the registers proven if no keys are requested: the registers
+// describing the status, storage and keys of the account.
+var DefaultAccountRegisterKeys = []string{
+	fvmstate.KeyAccountStatus,
+	fvmstate.KeyStorageUsed,
+	fvmstate.KeyStorageIndex,
+	fvmstate.KeyPublicKeyCount,
+	fvmstate.KeyContractNames,
+}
+
+type backendRegisterProofs struct {
+	state             protocol.State
+	headers           storage.Headers
+	blocks            storage.Blocks
+	executionResults  storage.ExecutionResults
+	executionReceipts storage.ExecutionReceipts
+	seals             storage.Seals
+	connFactory       ConnectionFactory
+	log               zerolog.Logger
+}
+
+// GetAccountRegisterProof returns the values of the given registers of the account after executing the sealed
+// block at the given height, together with the proof linking the values to the finalized block which includes
+// the seal of the block. The proof is verified before it is returned.
+func (b *backendRegisterProofs) GetAccountRegisterProof(
+	ctx context.Context,
+	address flow.Address,
+	keys []string,
+	height uint64,
+) (*lightclient.RegisterProof, error) {
+	if len(keys) == 0 {
+		keys = DefaultAccountRegisterKeys
+	}
+	if len(keys) > MaxRegisterProofKeys {
+		return nil, status.Errorf(codes.InvalidArgument, "too many register keys: %d (max %d)", len(keys), MaxRegisterProofKeys)
+	}
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+	if height > sealed.Height {
+		return nil, status.Errorf(codes.OutOfRange, "block at height %d is not sealed yet (latest sealed height %d)", height, sealed.Height)
+	}
+
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+	blockID := header.ID()
+
+	seal, sealingBlock, err := b.sealingBlock(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.executionResults.ByID(seal.ResultID)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	registerIDs := make([]flow.RegisterID, len(keys))
+	for i, key := range keys {
+		registerIDs[i] = flow.NewRegisterID(string(address.Bytes()), key)
+	}
+
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find execution nodes for block %v: %v", blockID, err)
+	}
+
+	proof := &lightclient.RegisterProof{
+		Header:         header,
+		Result:         result,
+		Seal:           seal,
+		SealingHeader:  sealingBlock.Header,
+		SealingPayload: sealingBlock.Payload.Index(),
+	}
+	err = b.proveFromAnyExeNode(ctx, execNodes, blockID, registerIDs, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// sealingBlock returns the seal of the given sealed block, and the finalized block including the seal.
+func (b *backendRegisterProofs) sealingBlock(header *flow.Header) (*flow.Seal, *flow.Block, error) {
+	blockID := header.ID()
+
+	seal, err := b.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return nil, nil, convertStorageError(err)
+	}
+
+	finalized, err := b.state.Final().Head()
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get latest finalized header: %v", err)
+	}
+
+	// the highest sealed height never decreases along the finalized chain, and seals are included in the order
+	// of the heights of the sealed blocks, hence we can binary search for the lowest height at which the block
+	// is sealed within (sealed height, finalized]
+	var searchErr error
+	offset := sort.Search(int(finalized.Height-header.Height), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		sealedHeight, err := b.highestSealedHeightAt(header.Height + uint64(i) + 1)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return sealedHeight >= header.Height
+	})
+	if searchErr != nil {
+		return nil, nil, searchErr
+	}
+
+	block, err := b.blocks.ByHeight(header.Height + uint64(offset) + 1)
+	if err != nil {
+		return nil, nil, convertStorageError(err)
+	}
+	sealID := seal.ID()
+	for _, included := range block.Payload.Seals {
+		if included.ID() == sealID {
+			return seal, block, nil
+		}
+	}
+
+	return nil, nil, status.Errorf(codes.Internal, "finalized block %v does not include the seal of block %v", block.ID(), blockID)
+}
+
+// highestSealedHeightAt returns the height of the highest block sealed in the fork of the finalized block at the
+// given height.
+func (b *backendRegisterProofs) highestSealedHeightAt(height uint64) (uint64, error) {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return 0, convertStorageError(err)
+	}
+	seal, err := b.seals.HighestInFork(header.ID())
+	if err != nil {
+		return 0, convertStorageError(err)
+	}
+	sealed, err := b.headers.ByBlockID(seal.BlockID)
+	if err != nil {
+		return 0, convertStorageError(err)
+	}
+	return sealed.Height, nil
+}
+
+// proveFromAnyExeNode fills the proof with the register values and trie proof of the first execution node whose
+// response verifies against the sealing block.
+func (b *backendRegisterProofs) proveFromAnyExeNode(
+	ctx context.Context,
+	execNodes flow.IdentityList,
+	blockID flow.Identifier,
+	registerIDs []flow.RegisterID,
+	proof *lightclient.RegisterProof,
+) error {
+	req := &registerproof.GetRegisterProofAtBlockIDRequest{
+		BlockId:     blockID[:],
+		RegisterIds: make([]*registerproof.RegisterID, len(registerIDs)),
+	}
+	for i, registerID := range registerIDs {
+		req.RegisterIds[i] = &registerproof.RegisterID{
+			Owner: []byte(registerID.Owner),
+			Key:   []byte(registerID.Key),
+		}
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		start := time.Now()
+
+		err := b.tryProve(ctx, execNode, req, registerIDs, proof)
+		duration := time.Since(start)
+		if err == nil {
+			b.log.Debug().
+				Str("execution_node", execNode.String()).
+				Hex("block_id", blockID[:]).
+				Int64("rtt_ms", duration.Milliseconds()).
+				Msg("Successfully got register proof")
+			return nil
+		}
+		b.log.Error().
+			Str("execution_node", execNode.String()).
+			Hex("block_id", blockID[:]).
+			Int64("rtt_ms", duration.Milliseconds()).
+			Err(err).
+			Msg("failed to get register proof")
+		errors = multierror.Append(errors, err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to get register proof from the execution nodes: %v", errors.ErrorOrNil())
+}
+
+func (b *backendRegisterProofs) tryProve(
+	ctx context.Context,
+	execNode *flow.Identity,
+	req *registerproof.GetRegisterProofAtBlockIDRequest,
+	registerIDs []flow.RegisterID,
+	proof *lightclient.RegisterProof,
+) error {
+	client, err := b.connFactory.GetRegisterProofAPIClient(execNode.Address)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetRegisterProofAtBlockID(ctx, req)
+	if err != nil {
+		b.connFactory.InvalidateExecutionAPIClient(execNode.Address)
+		return err
+	}
+
+	values := resp.GetValues()
+	if len(values) != len(registerIDs) {
+		return status.Errorf(codes.Internal, "execution node returned %d register values for %d registers", len(values), len(registerIDs))
+	}
+	registers := make([]lightclient.Register, len(registerIDs))
+	for i, registerID := range registerIDs {
+		registers[i] = lightclient.Register{ID: registerID, Value: values[i]}
+	}
+
+	// execution nodes may have diverged from the sealed result, hence their responses are verified the same
+	// way as by light clients
+	candidate := *proof
+	candidate.Registers = registers
+	candidate.Proof = resp.GetProof()
+	err = candidate.Verify(proof.SealingHeader.ID())
+	if err != nil {
+		return err
+	}
+
+	*proof = candidate
+	return nil
+}
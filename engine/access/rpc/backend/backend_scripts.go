@@ -1,8 +1,10 @@
 package backend
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5" //nolint:gosec
+	"errors"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -30,6 +33,8 @@ type backendScripts struct {
 	log               zerolog.Logger
 	metrics           module.BackendScriptsMetrics
 	loggedScripts     *lru.Cache
+	scriptExecutor    execution.ScriptExecutor // nil if scripts are only executed on execution nodes
+	scriptExecMode    ScriptExecutionMode
 }
 
 func (b *backendScripts) ExecuteScriptAtLatestBlock(
@@ -44,11 +49,8 @@ func (b *backendScripts) ExecuteScriptAtLatestBlock(
 		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
 	}
 
-	// get the block id of the latest sealed header
-	latestBlockID := latestHeader.ID()
-
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, latestBlockID, script, arguments)
+	// execute script at the latest sealed block
+	return b.executeScript(ctx, latestHeader.ID(), latestHeader.Height, script, arguments)
 }
 
 func (b *backendScripts) ExecuteScriptAtBlockID(
@@ -57,8 +59,19 @@ func (b *backendScripts) ExecuteScriptAtBlockID(
 	script []byte,
 	arguments [][]byte,
 ) ([]byte, error) {
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	if b.scriptExecMode == ScriptExecutionModeRemote {
+		// execute script on the execution node at that block id
+		return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	}
+
+	// the local register index is indexed by height
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		err = convertStorageError(err)
+		return nil, err
+	}
+
+	return b.executeScript(ctx, blockID, header.Height, script, arguments)
 }
 
 func (b *backendScripts) ExecuteScriptAtBlockHeight(
@@ -74,10 +87,114 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 		return nil, err
 	}
 
-	blockID := header.ID()
+	return b.executeScript(ctx, header.ID(), blockHeight, script, arguments)
+}
+
+// executeScript executes the script at the given block according to the script execution mode.
+func (b *backendScripts) executeScript(
+	ctx context.Context,
+	blockID flow.Identifier,
+	height uint64,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, error) {
+	switch b.scriptExecMode {
+	case ScriptExecutionModeLocal:
+		return b.executeScriptLocally(ctx, blockID, height, script, arguments)
+
+	case ScriptExecutionModeFailover:
+		result, err := b.executeScriptLocally(ctx, blockID, height, script, arguments)
+		// errors of the script itself would also occur on the execution nodes
+		if err == nil || status.Code(err) == codes.InvalidArgument {
+			return result, err
+		}
+		b.log.Debug().Err(err).
+			Hex("block_id", blockID[:]).
+			Uint64("height", height).
+			Msg("failed to execute script locally, falling back to execution nodes")
+		return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+
+	case ScriptExecutionModeCompare:
+		result, err := b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+		localResult, localErr := b.executeScriptLocally(ctx, blockID, height, script, arguments)
+		b.compareScriptResults(blockID, height, script, result, err, localResult, localErr)
+		return result, err
+
+	default:
+		// execute script on the execution node at that block id
+		return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	}
+}
+
+// executeScriptLocally executes the script against the local register index, and converts errors to
+// the access node api error format.
+func (b *backendScripts) executeScriptLocally(
+	ctx context.Context,
+	blockID flow.Identifier,
+	height uint64,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, error) {
+	execStartTime := time.Now()
+
+	result, err := b.scriptExecutor.ExecuteAtBlockHeight(ctx, script, arguments, height)
+	if err != nil {
+		if errors.Is(err, execution.ErrDataNotAvailable) {
+			return nil, status.Errorf(codes.OutOfRange, "failed to execute script at block %v: %v", blockID, err)
+		}
+		if execution.IsScriptExecutionError(err) {
+			b.log.Debug().Err(err).
+				Hex("block_id", blockID[:]).
+				Uint64("height", height).
+				Str("script", string(script)).
+				Msg("script failed to execute locally")
+			return nil, status.Errorf(codes.InvalidArgument, "failed to execute script: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to execute script locally: %v", err)
+	}
+
+	b.metrics.ScriptExecuted(time.Since(execStartTime), len(script))
+
+	return result, nil
+}
+
+// compareScriptResults logs a mismatch between the results of a script executed on the execution
+// nodes and locally. Failures to execute the script on either side for reasons other than an error of
+// the script are not considered a mismatch.
+func (b *backendScripts) compareScriptResults(
+	blockID flow.Identifier,
+	height uint64,
+	script []byte,
+	result []byte,
+	err error,
+	localResult []byte,
+	localErr error,
+) {
+	code, localCode := status.Code(err), status.Code(localErr)
+	comparable := func(code codes.Code) bool {
+		return code == codes.OK || code == codes.InvalidArgument
+	}
+	if !comparable(code) || !comparable(localCode) {
+		return
+	}
+
+	if code == localCode && bytes.Equal(result, localResult) {
+		return
+	}
+
+	// encode to MD5 as low compute/memory lookup key, see executeScriptOnExecutionNode
+	insecureScriptHash := md5.Sum(script) //nolint:gosec
 
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	b.log.Warn().
+		Hex("block_id", blockID[:]).
+		Uint64("height", height).
+		Hex("script_hash", insecureScriptHash[:]).
+		Str("script", string(script)).
+		Str("result", string(result)).
+		AnErr("error", err).
+		Str("local_result", string(localResult)).
+		AnErr("local_error", localErr).
+		Msg("script execution results on execution nodes and local execution do not match")
 }
 
 // executeScriptOnExecutionNode forwards the request to the execution node using the execution node
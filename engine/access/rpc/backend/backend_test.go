@@ -22,11 +22,20 @@ import (
 	bprotocol "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/util"
 
+	"github.com/onflow/flow-go/access/lightclient"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	execmock "github.com/onflow/flow-go/module/execution/mock"
 	"github.com/onflow/flow-go/module/metrics"
+	realprotocol "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/storage"
 	storagemock "github.com/onflow/flow-go/storage/mock"
@@ -46,6 +55,7 @@ type Suite struct {
 	transactions           *storagemock.Transactions
 	receipts               *storagemock.ExecutionReceipts
 	results                *storagemock.ExecutionResults
+	seals                  *storagemock.Seals
 	colClient              *access.AccessAPIClient
 	execClient             *access.ExecutionAPIClient
 	historicalAccessClient *access.AccessAPIClient
@@ -72,6 +82,7 @@ func (suite *Suite) SetupTest() {
 	suite.collections = new(storagemock.Collections)
 	suite.receipts = new(storagemock.ExecutionReceipts)
 	suite.results = new(storagemock.ExecutionResults)
+	suite.seals = new(storagemock.Seals)
 	suite.colClient = new(access.AccessAPIClient)
 	suite.execClient = new(access.ExecutionAPIClient)
 	suite.chainID = flow.Testnet
@@ -98,6 +109,7 @@ func (suite *Suite) TestPing() {
 		nil,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -107,6 +119,9 @@ func (suite *Suite) TestPing() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	err := backend.Ping(context.Background())
@@ -130,6 +145,7 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		nil,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -139,6 +155,9 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// query the handler for the latest finalized block
@@ -193,6 +212,7 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_NoTransitionSpan() {
 			nil,
 			nil,
 			nil,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			nil,
@@ -202,6 +222,9 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_NoTransitionSpan() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -264,6 +287,7 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_TransitionSpans() {
 			nil,
 			nil,
 			nil,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			nil,
@@ -273,6 +297,9 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_TransitionSpans() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -328,6 +355,7 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_PhaseTransitionSpan() {
 			nil,
 			nil,
 			nil,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			nil,
@@ -337,6 +365,9 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_PhaseTransitionSpan() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -403,6 +434,7 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_EpochTransitionSpan() {
 			nil,
 			nil,
 			nil,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			nil,
@@ -412,6 +444,9 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_EpochTransitionSpan() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -462,6 +497,7 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_HistoryLimit() {
 			nil,
 			nil,
 			nil,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			nil,
@@ -471,6 +507,9 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_HistoryLimit() {
 			nil,
 			suite.log,
 			snapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// the handler should return a snapshot history limit error
@@ -496,6 +535,7 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		nil,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -505,6 +545,9 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// query the handler for the latest sealed block
@@ -540,6 +583,7 @@ func (suite *Suite) TestGetTransaction() {
 		suite.transactions,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -549,6 +593,9 @@ func (suite *Suite) TestGetTransaction() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	actual, err := backend.GetTransaction(context.Background(), transaction.ID())
@@ -559,6 +606,47 @@ func (suite *Suite) TestGetTransaction() {
 	suite.assertAllExpectations()
 }
 
+// TestGetTransaction_Pruned tests that pruned transactions are reported with a code distinct from
+// unknown transactions.
+func (suite *Suite) TestGetTransaction_Pruned() {
+	txID := unittest.IdentifierFixture()
+	suite.transactions.
+		On("ByID", txID).
+		Return(nil, fmt.Errorf("could not get transaction: %w", storage.ErrPruned)).
+		Once()
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.transactions,
+		nil,
+		nil,
+		nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
+	)
+
+	_, err := backend.GetTransaction(context.Background(), txID)
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.FailedPrecondition, status.Code(err))
+
+	suite.assertAllExpectations()
+}
+
 func (suite *Suite) TestGetCollection() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 
@@ -579,6 +667,7 @@ func (suite *Suite) TestGetCollection() {
 		suite.transactions,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -588,6 +677,9 @@ func (suite *Suite) TestGetCollection() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	actual, err := backend.GetCollectionByID(context.Background(), expected.ID())
@@ -641,6 +733,7 @@ func (suite *Suite) TestGetTransactionResultByIndex() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -650,6 +743,9 @@ func (suite *Suite) TestGetTransactionResultByIndex() {
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 	suite.execClient.
 		On("GetTransactionResultByIndex", ctx, &exeEventReq).
@@ -703,6 +799,7 @@ func (suite *Suite) TestGetTransactionResultsByBlockID() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -712,6 +809,9 @@ func (suite *Suite) TestGetTransactionResultsByBlockID() {
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 	suite.execClient.
 		On("GetTransactionResultsByBlockID", ctx, &exeEventReq).
@@ -788,6 +888,7 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -797,6 +898,9 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// Successfully return empty event list
@@ -907,6 +1011,7 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		suite.transactions,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -916,6 +1021,9 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// should return pending status when we have not observed an expiry block
@@ -1065,6 +1173,7 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -1074,6 +1183,9 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 		flow.IdentifierList(enIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
@@ -1122,6 +1234,7 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		suite.transactions,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -1131,6 +1244,9 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// first call - when block under test is greater height than the sealed head, but execution node does not know about Tx
@@ -1169,6 +1285,7 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		nil,
 		nil,
 		nil,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -1178,6 +1295,9 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// query the handler for the latest finalized header
@@ -1297,6 +1417,7 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			nil,
 			suite.receipts,
 			suite.results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1306,6 +1427,9 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request
@@ -1328,6 +1452,7 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			nil,
 			receipts,
 			nil,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1337,6 +1462,9 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request with an empty block id list and expect an empty list of events and no error
@@ -1386,6 +1514,7 @@ func (suite *Suite) TestGetExecutionResultByID() {
 			nil,
 			suite.receipts,
 			results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1395,6 +1524,9 @@ func (suite *Suite) TestGetExecutionResultByID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request
@@ -1415,6 +1547,7 @@ func (suite *Suite) TestGetExecutionResultByID() {
 			nil,
 			nil,
 			results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1424,6 +1557,9 @@ func (suite *Suite) TestGetExecutionResultByID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request
@@ -1477,6 +1613,7 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			nil,
 			suite.receipts,
 			results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1486,6 +1623,9 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request
@@ -1507,6 +1647,7 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			nil,
 			nil,
 			results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1516,6 +1657,9 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request
@@ -1656,6 +1800,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.receipts,
 			suite.results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1665,6 +1810,9 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), maxHeight, minHeight)
@@ -1694,6 +1842,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.receipts,
 			suite.results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1703,6 +1852,9 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		// execute request
@@ -1731,6 +1883,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.receipts,
 			suite.results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1740,6 +1893,9 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		actualResp, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, maxHeight)
@@ -1767,6 +1923,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.receipts,
 			suite.results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1776,6 +1933,9 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, minHeight+1)
@@ -1803,6 +1963,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.receipts,
 			suite.results,
+			nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			connFactory, // the connection factory should be used to get the execution node client
@@ -1812,6 +1973,9 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			nil,
+			nil,
+			ScriptExecutionModeRemote,
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, maxHeight)
@@ -1879,6 +2043,7 @@ func (suite *Suite) TestGetAccount() {
 		nil,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -1888,6 +2053,9 @@ func (suite *Suite) TestGetAccount() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
@@ -1959,6 +2127,7 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		nil,
 		suite.receipts,
 		suite.results,
+		nil,
 		flow.Testnet,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -1968,6 +2137,9 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
@@ -1996,6 +2168,7 @@ func (suite *Suite) TestGetNetworkParameters() {
 		nil,
 		nil,
 		nil,
+		nil,
 		flow.Mainnet,
 		metrics.NewNoopCollector(),
 		nil,
@@ -2005,6 +2178,9 @@ func (suite *Suite) TestGetNetworkParameters() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	params := backend.GetNetworkParameters(context.Background())
@@ -2175,6 +2351,7 @@ func (suite *Suite) TestExecuteScriptOnExecutionNode() {
 		nil,
 		suite.receipts,
 		suite.results,
+		nil,
 		flow.Mainnet,
 		metrics.NewNoopCollector(),
 		connFactory, // the connection factory should be used to get the execution node client
@@ -2184,6 +2361,9 @@ func (suite *Suite) TestExecuteScriptOnExecutionNode() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// mock parameters
@@ -2228,6 +2408,537 @@ func (suite *Suite) TestExecuteScriptOnExecutionNode() {
 	})
 }
 
+// TestExecuteScriptWithScriptExecutionMode tests that scripts are executed locally or on the execution
+// nodes according to the script execution mode
+func (suite *Suite) TestExecuteScriptWithScriptExecutionMode() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+	block := unittest.BlockFixture()
+	header := block.Header
+	blockID := header.ID()
+	script := []byte("dummy script")
+	arguments := [][]byte(nil)
+
+	suite.headers.On("ByHeight", header.Height).Return(header, nil)
+	_, ids := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	execReq := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Script:    script,
+		Arguments: arguments,
+	}
+	remoteResult := []byte{4, 5, 6}
+	localResult := []byte{1, 2, 3}
+
+	newBackend := func(scriptExecutor execution.ScriptExecutor, mode ScriptExecutionMode) *Backend {
+		return New(
+			suite.state,
+			nil,
+			nil,
+			nil,
+			suite.headers,
+			nil,
+			nil,
+			suite.receipts,
+			suite.results,
+			nil,
+			flow.Mainnet,
+			metrics.NewNoopCollector(),
+			suite.setupConnectionFactory(),
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			suite.log,
+			DefaultSnapshotHistoryLimit,
+			nil,
+			scriptExecutor,
+			mode,
+		)
+	}
+
+	suite.Run("local mode executes the script locally", func() {
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", ctx, script, arguments, header.Height).Return(localResult, nil).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeLocal)
+		res, err := backend.ExecuteScriptAtBlockHeight(ctx, header.Height, script, arguments)
+		suite.Require().NoError(err)
+		suite.Require().Equal(localResult, res)
+	})
+
+	suite.Run("local mode returns OutOfRange if the data is not available", func() {
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", ctx, script, arguments, header.Height).Return(nil, execution.ErrDataNotAvailable).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeLocal)
+		_, err := backend.ExecuteScriptAtBlockHeight(ctx, header.Height, script, arguments)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.OutOfRange, status.Code(err))
+	})
+
+	suite.Run("failover mode falls back to the execution nodes", func() {
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", ctx, script, arguments, header.Height).Return(nil, execution.ErrDataNotAvailable).Once()
+		suite.execClient.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: remoteResult}, nil).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeFailover)
+		res, err := backend.ExecuteScriptAtBlockHeight(ctx, header.Height, script, arguments)
+		suite.Require().NoError(err)
+		suite.Require().Equal(remoteResult, res)
+		suite.execClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("failover mode does not fall back on script errors", func() {
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", ctx, script, arguments, header.Height).Return(nil, execution.NewScriptExecutionErrorf("script failed")).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeFailover)
+		_, err := backend.ExecuteScriptAtBlockHeight(ctx, header.Height, script, arguments)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("failover mode falls back on internal errors", func() {
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", ctx, script, arguments, header.Height).Return(nil, fmt.Errorf("storage failure")).Once()
+		suite.execClient.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: remoteResult}, nil).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeFailover)
+		res, err := backend.ExecuteScriptAtBlockHeight(ctx, header.Height, script, arguments)
+		suite.Require().NoError(err)
+		suite.Require().Equal(remoteResult, res)
+		suite.execClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("compare mode returns the result of the execution nodes", func() {
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", ctx, script, arguments, header.Height).Return(localResult, nil).Once()
+		suite.execClient.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: remoteResult}, nil).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeCompare)
+		res, err := backend.ExecuteScriptAtBlockHeight(ctx, header.Height, script, arguments)
+		suite.Require().NoError(err)
+		suite.Require().Equal(remoteResult, res)
+		suite.execClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("local mode gets accounts locally", func() {
+		address := unittest.AddressFixture()
+		scriptExecutor := execmock.NewScriptExecutor(suite.T())
+		scriptExecutor.On("GetAccountAtBlockHeight", ctx, address, header.Height).Return(&flow.Account{Address: address}, nil).Once()
+
+		backend := newBackend(scriptExecutor, ScriptExecutionModeLocal)
+		account, err := backend.GetAccountAtBlockHeight(ctx, address, header.Height)
+		suite.Require().NoError(err)
+		suite.Require().Equal(address, account.Address)
+	})
+}
+
+func (suite *Suite) TestGetAccountTransactions() {
+	ctx := context.Background()
+	address := unittest.AddressFixture()
+
+	newBackend := func(index storage.AccountIndex) *Backend {
+		return New(
+			suite.state,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			flow.Testnet,
+			metrics.NewNoopCollector(),
+			nil,
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			suite.log,
+			DefaultSnapshotHistoryLimit,
+			index,
+			nil,
+			ScriptExecutionModeRemote,
+		)
+	}
+
+	suite.Run("account indexing disabled", func() {
+		_, err := newBackend(nil).GetAccountTransactions(ctx, address, 0, 0, nil, 10)
+		suite.Require().Equal(codes.Unavailable, status.Code(err))
+	})
+
+	index := new(storagemock.AccountIndex)
+	index.On("FirstHeight").Return(uint64(10), nil)
+	index.On("LatestHeight").Return(uint64(20), nil)
+	backend := newBackend(index)
+
+	suite.Run("default height range", func() {
+		entries := []flow.AccountTransaction{{Address: address, BlockHeight: 10}}
+		next := &flow.AccountIndexCursor{BlockHeight: 12}
+		index.On("TransactionsByAddress", address, uint64(10), uint64(20), (*flow.AccountIndexCursor)(nil), uint(1)).
+			Return(entries, next, nil).
+			Once()
+
+		page, err := backend.GetAccountTransactions(ctx, address, 0, 0, nil, 1)
+		suite.checkResponse(page, err)
+		suite.Require().Equal(entries, page.Transactions)
+		suite.Require().Equal(next, page.NextCursor)
+	})
+
+	suite.Run("end height is capped at the latest indexed height", func() {
+		cursor := &flow.AccountIndexCursor{BlockHeight: 12}
+		index.On("TransactionsByAddress", address, uint64(11), uint64(20), cursor, uint(5)).
+			Return(nil, nil, nil).
+			Once()
+
+		page, err := backend.GetAccountTransactions(ctx, address, 11, 100, cursor, 5)
+		suite.checkResponse(page, err)
+		suite.Require().Empty(page.Transactions)
+		suite.Require().Nil(page.NextCursor)
+	})
+
+	suite.Run("invalid requests", func() {
+		_, err := backend.GetAccountTransactions(ctx, address, 0, 0, nil, 0)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 0, 0, nil, DefaultMaxAccountIndexPageSize+1)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 9, 0, nil, 10)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 21, 0, nil, 10)
+		suite.Require().Equal(codes.OutOfRange, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 15, 12, nil, 10)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 15, 18, &flow.AccountIndexCursor{BlockHeight: 19}, 10)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	index.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestGetAccountEvents() {
+	ctx := context.Background()
+	address := unittest.AddressFixture()
+
+	index := new(storagemock.AccountIndex)
+	index.On("FirstHeight").Return(uint64(10), nil)
+	index.On("LatestHeight").Return(uint64(20), nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		flow.Testnet,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		index,
+		nil,
+		ScriptExecutionModeRemote,
+	)
+
+	event := unittest.EventFixture(flow.EventType("A."+address.Hex()+".Foo.Bar"), 0, 0, unittest.IdentifierFixture(), 0)
+	entries := []flow.AccountEvent{{Address: address, BlockHeight: 15, Event: event}}
+	index.On("EventsByAddress", address, "Foo", uint64(12), uint64(18), (*flow.AccountIndexCursor)(nil), uint(10)).
+		Return(entries, nil, nil).
+		Once()
+
+	page, err := backend.GetAccountEvents(ctx, address, "Foo", 12, 18, nil, 10)
+	suite.checkResponse(page, err)
+	suite.Require().Equal(entries, page.Events)
+	suite.Require().Nil(page.NextCursor)
+
+	index.AssertExpectations(suite.T())
+}
+
+// TestGetAccountRegisterProof tests that register proofs are assembled from the sealing block and the execution
+// node responses, and that responses which do not verify are rejected.
+func (suite *Suite) TestGetAccountRegisterProof() {
+	ctx := context.Background()
+	address := unittest.AddressFixture()
+	keys := []string{"storage_used", "public_key_count"}
+	values := []flow.RegisterValue{{0, 0, 0, 1}, {1}}
+
+	// store the registers in a ledger, to generate a proof of their values
+	led, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	suite.Require().NoError(err)
+	ledgerKeys := make([]ledger.Key, len(keys))
+	ledgerValues := make([]ledger.Value, len(keys))
+	for i, key := range keys {
+		ledgerKeys[i] = state.RegisterIDToKey(flow.NewRegisterID(string(address.Bytes()), key))
+		ledgerValues[i] = values[i]
+	}
+	update, err := ledger.NewUpdate(led.InitialState(), ledgerKeys, ledgerValues)
+	suite.Require().NoError(err)
+	commit, _, err := led.Set(update)
+	suite.Require().NoError(err)
+	query, err := ledger.NewQuery(commit, ledgerKeys)
+	suite.Require().NoError(err)
+	proof, err := led.Prove(query)
+	suite.Require().NoError(err)
+
+	// the block at the requested height is sealed by the third finalized block above it, and the fork of the
+	// blocks in between only seals its parent
+	parent := unittest.BlockHeaderFixture()
+	parent.Height = 4
+	parentSeal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(parent.ID()))
+	block := unittest.BlockWithParentFixture(parent)
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(block))
+	result.Chunks[len(result.Chunks)-1].EndState = flow.StateCommitment(commit)
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+	finalized := unittest.ChainFixtureFrom(4, block.Header)
+	sealingBlock := finalized[2]
+	sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+	finalized[3].Header.ParentID = sealingBlock.ID()
+
+	sealedSnapshot := new(protocol.Snapshot)
+	sealedSnapshot.On("Head").Return(block.Header, nil)
+	suite.state.On("Sealed").Return(sealedSnapshot, nil)
+	suite.state.On("Final").Return(suite.snapshot, nil)
+	suite.snapshot.On("Head").Return(finalized[3].Header, nil)
+	suite.headers.On("ByHeight", parent.Height).Return(parent, nil)
+	suite.headers.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+	suite.headers.On("ByBlockID", parent.ID()).Return(parent, nil)
+	suite.headers.On("ByBlockID", block.ID()).Return(block.Header, nil)
+	for i, b := range finalized {
+		suite.headers.On("ByHeight", b.Header.Height).Return(b.Header, nil)
+		if i < 2 {
+			suite.seals.On("HighestInFork", b.ID()).Return(parentSeal, nil)
+		} else {
+			suite.seals.On("HighestInFork", b.ID()).Return(seal, nil)
+		}
+	}
+	suite.seals.On("FinalizedSealForBlock", block.ID()).Return(seal, nil)
+	suite.seals.On("FinalizedSealForBlock", parent.ID()).Return(nil, storage.ErrNotFound)
+	suite.blocks.On("ByHeight", sealingBlock.Header.Height).Return(sealingBlock, nil)
+	suite.results.On("ByID", seal.ResultID).Return(result, nil)
+
+	_, ids := suite.setupReceipts(block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	client := new(access.RegisterProofAPIClient)
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetRegisterProofAPIClient", mock.Anything).Return(client, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		suite.results,
+		suite.seals,
+		flow.Testnet,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
+	)
+
+	blockID := block.ID()
+	exeReq := &registerproof.GetRegisterProofAtBlockIDRequest{
+		BlockId: blockID[:],
+		RegisterIds: []*registerproof.RegisterID{
+			{Owner: address.Bytes(), Key: []byte(keys[0])},
+			{Owner: address.Bytes(), Key: []byte(keys[1])},
+		},
+	}
+
+	suite.Run("happy path - valid proof", func() {
+		client.
+			On("GetRegisterProofAtBlockID", ctx, exeReq).
+			Return(&registerproof.GetRegisterProofAtBlockIDResponse{StateCommitment: commit[:], Values: values, Proof: proof}, nil).
+			Once()
+
+		registerProof, err := backend.GetAccountRegisterProof(ctx, address, keys, block.Header.Height)
+		suite.checkResponse(registerProof, err)
+
+		suite.Require().NoError(registerProof.Verify(sealingBlock.ID()))
+		suite.Require().Equal(seal, registerProof.Seal)
+		suite.Require().Len(registerProof.Registers, len(keys))
+		for i, register := range registerProof.Registers {
+			suite.Assert().Equal(keys[i], register.ID.Key)
+			suite.Assert().Equal(values[i], register.Value)
+		}
+	})
+
+	suite.Run("invalid proofs are rejected", func() {
+		client.
+			On("GetRegisterProofAtBlockID", ctx, exeReq).
+			Return(&registerproof.GetRegisterProofAtBlockIDResponse{StateCommitment: commit[:], Values: []flow.RegisterValue{{0, 0, 0, 2}, {1}}, Proof: proof}, nil).
+			Times(len(ids))
+
+		_, err := backend.GetAccountRegisterProof(ctx, address, keys, block.Header.Height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+
+	suite.Run("block not sealed", func() {
+		_, err := backend.GetAccountRegisterProof(ctx, address, keys, block.Header.Height+1)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.OutOfRange, status.Code(err))
+	})
+
+	suite.Run("seal not finalized", func() {
+		_, err := backend.GetAccountRegisterProof(ctx, address, keys, parent.Height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	client.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestGetEpochTransitionProof() {
+	ctx := context.Background()
+
+	// epoch 1 enters the setup phase at height 15 and the committed phase at height 20, hence the service events
+	// are sealed in the parents of these blocks
+	root := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(10))
+	final := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(30))
+	phases := map[uint64]flow.EpochPhase{15: flow.EpochPhaseSetup, 20: flow.EpochPhaseCommitted}
+
+	setup := &flow.EpochSetup{Counter: 2, FirstView: 1000, FinalView: 2000}
+	commit := &flow.EpochCommit{Counter: 2}
+	events := map[uint64]flow.ServiceEvent{15: setup.ServiceEvent(), 20: commit.ServiceEvent()}
+
+	params := new(protocol.Params)
+	params.On("Root").Return(root, nil)
+	epoch := new(protocol.Epoch)
+	epoch.On("Counter").Return(uint64(1), nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Current").Return(epoch)
+	finalSnapshot := new(protocol.Snapshot)
+	finalSnapshot.On("Head").Return(final, nil)
+
+	state := new(protocol.State)
+	state.On("Params").Return(params)
+	state.On("Final").Return(finalSnapshot)
+	state.On("AtHeight", mock.Anything).Return(func(height uint64) realprotocol.Snapshot {
+		phase := flow.EpochPhaseStaking
+		for start, p := range phases {
+			if height >= start && p > phase {
+				phase = p
+			}
+		}
+		snapshot := new(protocol.Snapshot)
+		snapshot.On("Epochs").Return(epochs)
+		snapshot.On("Phase").Return(phase, nil)
+		return snapshot
+	})
+
+	sealingBlocks := make(map[uint64]*flow.Block)
+	for height, event := range events {
+		sealed := unittest.BlockHeaderFixture()
+		result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(sealed.ID()))
+		result.ServiceEvents = []flow.ServiceEvent{event}
+		seal := &flow.Seal{BlockID: sealed.ID(), ResultID: unittest.IdentifierFixture()}
+
+		sealingBlock := unittest.BlockFixture()
+		sealingBlock.Header.Height = height - 1
+		sealingBlock.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+		child := unittest.BlockWithParentFixture(sealingBlock.Header)
+
+		sealingBlocks[height] = &sealingBlock
+		suite.headers.On("ByHeight", height).Return(child.Header, nil)
+		suite.blocks.On("ByID", sealingBlock.ID()).Return(&sealingBlock, nil)
+		suite.results.On("ByID", seal.ResultID).Return(result, nil)
+	}
+
+	backend := New(
+		state,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		suite.results,
+		nil,
+		flow.Testnet,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
+	)
+
+	suite.Run("happy path", func() {
+		proof, err := backend.GetEpochTransitionProof(ctx, 2)
+		suite.checkResponse(proof, err)
+
+		suite.Assert().Equal(setup, proof.Setup)
+		suite.Assert().Equal(commit, proof.Commit)
+		for height, eventProof := range map[uint64]*lightclient.ServiceEventProof{15: proof.SetupProof, 20: proof.CommitProof} {
+			sealingBlock := sealingBlocks[height]
+			suite.Assert().Equal(sealingBlock.Header, eventProof.SealingHeader)
+			suite.Assert().Equal(sealingBlock.Payload.Seals[0], eventProof.Seal)
+			suite.Assert().Equal(sealingBlock.ID(), eventProof.QC.BlockID)
+			suite.Assert().Equal(sealingBlock.Header.View, eventProof.QC.View)
+		}
+	})
+
+	suite.Run("epoch not committed", func() {
+		_, err := backend.GetEpochTransitionProof(ctx, 3)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("epoch configured before root block", func() {
+		_, err := backend.GetEpochTransitionProof(ctx, 1)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("first epoch", func() {
+		_, err := backend.GetEpochTransitionProof(ctx, 0)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func (suite *Suite) assertAllExpectations() {
 	suite.snapshot.AssertExpectations(suite.T())
 	suite.state.AssertExpectations(suite.T())
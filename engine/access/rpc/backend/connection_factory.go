@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/utils/grpcutils"
 )
@@ -25,6 +26,7 @@ type ConnectionFactory interface {
 	InvalidateAccessAPIClient(address string) bool
 	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, error)
 	InvalidateExecutionAPIClient(address string) bool
+	GetRegisterProofAPIClient(address string) (registerproof.RegisterProofAPIClient, error)
 }
 
 type ProxyConnectionFactory struct {
@@ -40,6 +42,10 @@ func (p *ProxyConnectionFactory) GetExecutionAPIClient(address string) (executio
 	return p.ConnectionFactory.GetExecutionAPIClient(p.targetAddress)
 }
 
+func (p *ProxyConnectionFactory) GetRegisterProofAPIClient(address string) (registerproof.RegisterProofAPIClient, error) {
+	return p.ConnectionFactory.GetRegisterProofAPIClient(p.targetAddress)
+}
+
 type ConnectionFactoryImpl struct {
 	CollectionGRPCPort        uint
 	ExecutionGRPCPort         uint
@@ -144,6 +150,23 @@ func (cf *ConnectionFactoryImpl) InvalidateExecutionAPIClient(address string) bo
 	return cf.ConnectionsCache.Remove(grpcAddress)
 }
 
+// GetRegisterProofAPIClient returns a client of the register proof API of the execution node. The client shares
+// the connection of the execution API client, which is invalidated with InvalidateExecutionAPIClient.
+func (cf *ConnectionFactoryImpl) GetRegisterProofAPIClient(address string) (registerproof.RegisterProofAPIClient, error) {
+
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := cf.retrieveConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return registerproof.NewRegisterProofAPIClient(conn), nil
+}
+
 // getExecutionNodeAddress translates flow.Identity address to the GRPC address of the node by switching the port to the
 // GRPC port from the libp2p port
 func getGRPCAddress(address string, grpcPort uint) (string, error) {
@@ -46,6 +46,7 @@ func (suite *Suite) TestHistoricalTransactionResult() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -55,6 +56,9 @@ func (suite *Suite) TestHistoricalTransactionResult() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// Successfully return the transaction from the historical node
@@ -103,6 +107,7 @@ func (suite *Suite) TestHistoricalTransaction() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -112,6 +117,9 @@ func (suite *Suite) TestHistoricalTransaction() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 
 	// Successfully return the transaction from the historical node
@@ -8,6 +8,8 @@ import (
 	execution "github.com/onflow/flow/protobuf/go/flow/execution"
 
 	mock "github.com/stretchr/testify/mock"
+
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 )
 
 // ConnectionFactory is an autogenerated mock type for the ConnectionFactory type
@@ -61,6 +63,29 @@ func (_m *ConnectionFactory) GetExecutionAPIClient(address string) (execution.Ex
 	return r0, r1
 }
 
+// GetRegisterProofAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetRegisterProofAPIClient(address string) (registerproof.RegisterProofAPIClient, error) {
+	ret := _m.Called(address)
+
+	var r0 registerproof.RegisterProofAPIClient
+	if rf, ok := ret.Get(0).(func(string) registerproof.RegisterProofAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(registerproof.RegisterProofAPIClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InvalidateAccessAPIClient provides a mock function with given fields: address
 func (_m *ConnectionFactory) InvalidateAccessAPIClient(address string) bool {
 	ret := _m.Called(address)
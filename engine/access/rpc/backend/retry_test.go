@@ -51,6 +51,7 @@ func (suite *Suite) TestTransactionRetry() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		nil,
@@ -60,6 +61,9 @@ func (suite *Suite) TestTransactionRetry() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
@@ -131,6 +135,7 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 		suite.transactions,
 		suite.receipts,
 		suite.results,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		connFactory,
@@ -140,6 +145,9 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		nil,
+		nil,
+		ScriptExecutionModeRemote,
 	)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
@@ -0,0 +1,55 @@
+package backend
+
+import (
+	"fmt"
+)
+
+// ScriptExecutionMode determines where scripts and account queries are executed.
+type ScriptExecutionMode int
+
+const (
+	// ScriptExecutionModeRemote executes scripts and account queries on execution nodes.
+	ScriptExecutionModeRemote ScriptExecutionMode = iota
+
+	// ScriptExecutionModeLocal executes scripts and account queries against the local register index.
+	ScriptExecutionModeLocal
+
+	// ScriptExecutionModeFailover executes scripts and account queries locally, and falls back to
+	// execution nodes if the local execution fails for a reason other than an error of the script.
+	ScriptExecutionModeFailover
+
+	// ScriptExecutionModeCompare executes scripts and account queries both locally and on execution
+	// nodes, logs any mismatch between the results, and returns the result of the execution nodes.
+	ScriptExecutionModeCompare
+)
+
+// ParseScriptExecutionMode parses the name of a script execution mode.
+func ParseScriptExecutionMode(s string) (ScriptExecutionMode, error) {
+	switch s {
+	case ScriptExecutionModeRemote.String():
+		return ScriptExecutionModeRemote, nil
+	case ScriptExecutionModeLocal.String():
+		return ScriptExecutionModeLocal, nil
+	case ScriptExecutionModeFailover.String():
+		return ScriptExecutionModeFailover, nil
+	case ScriptExecutionModeCompare.String():
+		return ScriptExecutionModeCompare, nil
+	default:
+		return 0, fmt.Errorf("invalid script execution mode: %s", s)
+	}
+}
+
+func (m ScriptExecutionMode) String() string {
+	switch m {
+	case ScriptExecutionModeRemote:
+		return "remote"
+	case ScriptExecutionModeLocal:
+		return "local"
+	case ScriptExecutionModeFailover:
+		return "failover"
+	case ScriptExecutionModeCompare:
+		return "compare"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,25 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScriptExecutionMode(t *testing.T) {
+	modes := []ScriptExecutionMode{
+		ScriptExecutionModeRemote,
+		ScriptExecutionModeLocal,
+		ScriptExecutionModeFailover,
+		ScriptExecutionModeCompare,
+	}
+	for _, mode := range modes {
+		parsed, err := ParseScriptExecutionMode(mode.String())
+		require.NoError(t, err)
+		assert.Equal(t, mode, parsed)
+	}
+
+	_, err := ParseScriptExecutionMode("invalid")
+	assert.Error(t, err)
+}
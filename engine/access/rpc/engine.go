@@ -19,9 +19,11 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/grpcutils"
@@ -45,6 +47,7 @@ type Config struct {
 	MaxHeightRange            uint                             // max size of height range requests
 	PreferredExecutionNodeIDs []string                         // preferred list of upstream execution node IDs
 	FixedExecutionNodeIDs     []string                         // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	ScriptExecutionMode       backend.ScriptExecutionMode      // where scripts and account queries are executed
 }
 
 // Engine exposes the server with a simplified version of the Access API.
@@ -61,6 +64,9 @@ type Engine struct {
 	config             Config
 	chain              flow.Chain
 
+	stateStreamApi    state_stream.API // the streaming API, or nil if it is not enabled
+	stateStreamConfig state_stream.Config
+
 	addrLock            sync.RWMutex
 	unsecureGrpcAddress net.Addr
 	secureGrpcAddress   net.Addr
@@ -79,6 +85,7 @@ func NewBuilder(log zerolog.Logger,
 	transactions storage.Transactions,
 	executionReceipts storage.ExecutionReceipts,
 	executionResults storage.ExecutionResults,
+	seals storage.Seals,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
 	accessMetrics module.AccessMetrics,
@@ -88,6 +95,8 @@ func NewBuilder(log zerolog.Logger,
 	rpcMetricsEnabled bool,
 	apiRatelimits map[string]int, // the api rate limit (max calls per second) for each of the Access API e.g. Ping->100, GetTransaction->300
 	apiBurstLimits map[string]int, // the api burst limit (max calls at the same time) for each of the Access API e.g. Ping->50, GetTransaction->10
+	accountIndex storage.AccountIndex, // the account index of transactions and events, or nil if account indexing is disabled
+	scriptExecutor execution.ScriptExecutor, // the local script executor, or nil if scripts are only executed on execution nodes
 ) (*RPCEngineBuilder, error) {
 
 	log = log.With().Str("engine", "rpc").Logger()
@@ -122,6 +131,11 @@ func NewBuilder(log zerolog.Logger,
 	chainedInterceptors := grpc.ChainUnaryInterceptor(interceptors...)
 	grpcOpts = append(grpcOpts, chainedInterceptors)
 
+	// streaming calls are only instrumented with metrics. They are limited by the streaming API itself
+	if rpcMetricsEnabled {
+		grpcOpts = append(grpcOpts, grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor))
+	}
+
 	// create an unsecured grpc server
 	unsecureGrpcServer := grpc.NewServer(grpcOpts...)
 
@@ -162,6 +176,7 @@ func NewBuilder(log zerolog.Logger,
 		transactions,
 		executionReceipts,
 		executionResults,
+		seals,
 		chainID,
 		transactionMetrics,
 		connectionFactory,
@@ -171,6 +186,9 @@ func NewBuilder(log zerolog.Logger,
 		config.FixedExecutionNodeIDs,
 		log,
 		backend.DefaultSnapshotHistoryLimit,
+		accountIndex,
+		scriptExecutor,
+		config.ScriptExecutionMode,
 	)
 
 	eng := &Engine{
@@ -338,7 +356,7 @@ func (e *Engine) serveREST() {
 
 	e.log.Info().Str("rest_api_address", e.config.RESTListenAddr).Msg("starting REST server on address")
 
-	r, err := rest.NewServer(e.backend, e.config.RESTListenAddr, e.log, e.chain)
+	r, err := rest.NewServer(e.backend, e.config.RESTListenAddr, e.log, e.chain, e.stateStreamApi, e.stateStreamConfig)
 	if err != nil {
 		e.log.Err(err).Msg("failed to initialize the REST server")
 		return
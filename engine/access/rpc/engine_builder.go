@@ -10,6 +10,8 @@ import (
 	"github.com/onflow/flow-go/apiproxy"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/signature"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	statestream "github.com/onflow/flow-go/engine/access/state_stream/protobuf"
 )
 
 // NewRPCEngineBuilder helps to build a new RPC engine.
@@ -57,6 +59,21 @@ func (builder *RPCEngineBuilder) WithLegacy() *RPCEngineBuilder {
 	return builder
 }
 
+// WithStateStream specifies that the streaming API should be served by the gRPC servers, and by
+// the REST server if it is enabled.
+// Returns self-reference for chaining.
+func (builder *RPCEngineBuilder) WithStateStream(api state_stream.API, config state_stream.Config) *RPCEngineBuilder {
+	builder.stateStreamApi = api
+	builder.stateStreamConfig = config
+	return builder
+}
+
+// Backend returns the access API backend of the engine, which other APIs served by the engine can
+// build upon.
+func (builder *RPCEngineBuilder) Backend() access.API {
+	return builder.backend
+}
+
 // WithMetrics specifies the metrics should be collected.
 // Returns self-reference for chaining.
 func (builder *RPCEngineBuilder) WithMetrics() *RPCEngineBuilder {
@@ -78,5 +95,16 @@ func (builder *RPCEngineBuilder) Build() *Engine {
 	accessproto.RegisterAccessAPIServer(builder.unsecureGrpcServer, localAPIServer)
 	accessproto.RegisterAccessAPIServer(builder.secureGrpcServer, localAPIServer)
 
+	if builder.stateStreamApi != nil {
+		stateStreamHandler := state_stream.NewHandler(
+			builder.stateStreamApi,
+			builder.chain,
+			builder.stateStreamConfig,
+			state_stream.WithBlockSignerDecoder(builder.signerIndicesDecoder),
+		)
+		statestream.RegisterStateStreamAPIServer(builder.unsecureGrpcServer, stateStreamHandler)
+		statestream.RegisterStateStreamAPIServer(builder.secureGrpcServer, stateStreamHandler)
+	}
+
 	return builder.Engine
 }
@@ -0,0 +1,295 @@
+package state_stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// DefaultSendBufferSize is the default buffer size for the subscription's send channel.
+	// The size is kept small so a slow client quickly exerts backpressure on its streamer.
+	DefaultSendBufferSize = 10
+
+	// DefaultMaxGlobalStreams defines the default max number of streams that can be open at the same time.
+	DefaultMaxGlobalStreams = 1000
+
+	// DefaultCacheSize defines the default max number of objects for the execution data cache.
+	DefaultCacheSize = 100
+
+	// DefaultSendTimeout is the default timeout for sending a message to the client. After the timeout
+	// expires, the connection is closed.
+	DefaultSendTimeout = 30 * time.Second
+
+	// DefaultResponseLimit is the default max responses per second allowed on a stream. 0 means no limit.
+	DefaultResponseLimit = float64(0)
+)
+
+// Config defines the configurable options for the state stream API.
+type Config struct {
+	// ClientSendTimeout is the timeout for sending a message to the client. After the timeout
+	// expires, the connection is closed.
+	ClientSendTimeout time.Duration
+
+	// ClientSendBufferSize is the size of the response buffer for sending messages to the client.
+	ClientSendBufferSize uint
+
+	// MaxGlobalStreams is the max number of streams that can be open at the same time.
+	MaxGlobalStreams uint32
+
+	// ExecutionDataCacheSize is the max number of objects for the execution data cache.
+	ExecutionDataCacheSize uint32
+
+	// ResponseLimit is the max responses per second allowed on a stream. After exceeding the
+	// limit, the stream is paused until more capacity is available.
+	ResponseLimit float64
+}
+
+// DefaultConfig returns the default configuration for the state stream API.
+func DefaultConfig() Config {
+	return Config{
+		ClientSendTimeout:      DefaultSendTimeout,
+		ClientSendBufferSize:   DefaultSendBufferSize,
+		MaxGlobalStreams:       DefaultMaxGlobalStreams,
+		ExecutionDataCacheSize: DefaultCacheSize,
+		ResponseLimit:          DefaultResponseLimit,
+	}
+}
+
+// API provides the streaming functionality of the Access API. All subscriptions start at the
+// block identified by either startBlockID or startHeight (at most one of them may be set). If
+// neither is set, the subscription starts at the latest block for which the data is available.
+type API interface {
+	// SubscribeBlockHeaders streams the headers of all finalized (or sealed, if sealed is true)
+	// blocks, in ascending height order.
+	SubscribeBlockHeaders(ctx context.Context, startBlockID flow.Identifier, startHeight uint64, sealed bool) Subscription
+
+	// SubscribeEvents streams the events matching the filter for all sealed blocks, in ascending
+	// height order. A response is sent for each block, even if no events matched.
+	SubscribeEvents(ctx context.Context, startBlockID flow.Identifier, startHeight uint64, filter EventFilter) Subscription
+
+	// SubscribeExecutionData streams the execution data for all sealed blocks, in ascending height order.
+	SubscribeExecutionData(ctx context.Context, startBlockID flow.Identifier, startHeight uint64) Subscription
+
+	// SubscribeTransactionStatuses streams the status transitions of the given transaction, starting
+	// with its current status. The subscription is closed after the transaction is sealed or expired,
+	// and fails if the transaction is still unknown once it would have expired.
+	SubscribeTransactionStatuses(ctx context.Context, txID flow.Identifier) Subscription
+
+	// SendAndSubscribeTransactionStatuses sends the transaction to the network, and streams its
+	// status transitions like SubscribeTransactionStatuses.
+	SendAndSubscribeTransactionStatuses(ctx context.Context, tx *flow.TransactionBody) Subscription
+}
+
+// Backend implements the state stream API.
+//
+// Block header subscriptions are driven by block finalization events, which are delivered through
+// OnFinalizedBlock. Transaction status subscriptions are driven by block finalization events as well.
+// Event and execution data subscriptions are driven by the execution data
+// requester, which delivers the execution data for each sealed block in consecutive height order
+// through OnExecutionData.
+type Backend struct {
+	log             zerolog.Logger
+	config          Config
+	state           protocol.State
+	headers         storage.Headers
+	seals           storage.Seals
+	results         storage.ExecutionResults
+	execDataService state_synchronization.ExecutionDataService // nil if execution data sync is disabled
+	execDataCache   *lru.Cache
+	transactions    TransactionsAPI // nil until SetTransactionsAPI is called
+
+	// transactionResults shares the result lookups of transaction status subscriptions
+	transactionResults *transactionResults
+
+	// rootHeight is the lowest height for which block headers can be streamed
+	rootHeight uint64
+
+	// execDataStartHeight is the lowest height for which execution data can be streamed
+	execDataStartHeight uint64
+
+	// highestExecDataHeight is the highest height for which execution data was received
+	highestExecDataHeight *atomic.Uint64
+
+	finalizedBroadcaster     *engine.Broadcaster
+	executionDataBroadcaster *engine.Broadcaster
+}
+
+var _ API = (*Backend)(nil)
+
+// New creates a new state stream backend. execDataService may be nil if the node does not sync
+// execution data, in which case only block header subscriptions are supported.
+// execDataStartHeight is the first height synced by the execution data requester, and
+// highestExecDataHeight is the highest height for which execution data was already downloaded
+// and notified by the execution data requester.
+func New(
+	log zerolog.Logger,
+	config Config,
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	results storage.ExecutionResults,
+	execDataService state_synchronization.ExecutionDataService,
+	rootHeight uint64,
+	execDataStartHeight uint64,
+	highestExecDataHeight uint64,
+) (*Backend, error) {
+	cacheSize := config.ExecutionDataCacheSize
+	if cacheSize == 0 {
+		cacheSize = DefaultCacheSize
+	}
+	execDataCache, err := lru.New(int(cacheSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not create execution data cache: %w", err)
+	}
+
+	if config.ClientSendTimeout == 0 {
+		config.ClientSendTimeout = DefaultSendTimeout
+	}
+
+	return &Backend{
+		log:                      log.With().Str("module", "state_stream_backend").Logger(),
+		config:                   config,
+		state:                    state,
+		headers:                  headers,
+		seals:                    seals,
+		results:                  results,
+		execDataService:          execDataService,
+		execDataCache:            execDataCache,
+		rootHeight:               rootHeight,
+		execDataStartHeight:      execDataStartHeight,
+		highestExecDataHeight:    atomic.NewUint64(highestExecDataHeight),
+		finalizedBroadcaster:     engine.NewBroadcaster(),
+		executionDataBroadcaster: engine.NewBroadcaster(),
+	}, nil
+}
+
+// Config returns the backend's configuration.
+func (b *Backend) Config() Config {
+	return b.config
+}
+
+// SetTransactionsAPI sets the API used to send transactions and look up their results for
+// transaction status subscriptions. Until it is called, transaction status subscriptions are not
+// supported. It must be called before the backend is used by any other goroutine.
+func (b *Backend) SetTransactionsAPI(transactions TransactionsAPI) {
+	b.transactions = transactions
+	b.transactionResults = newTransactionResults(transactions.GetTransactionResult)
+}
+
+// OnFinalizedBlock is called when a new block is finalized. It notifies all block header and
+// transaction status subscriptions that new data may be available.
+func (b *Backend) OnFinalizedBlock(*model.Block) {
+	b.finalizedBroadcaster.Publish()
+}
+
+// OnExecutionData is called when the execution data for a new sealed block is available. It caches
+// the execution data and notifies all event and execution data subscriptions.
+func (b *Backend) OnExecutionData(executionData *state_synchronization.ExecutionData) {
+	lg := b.log.With().Hex("block_id", executionData.BlockID[:]).Logger()
+
+	header, err := b.headers.ByBlockID(executionData.BlockID)
+	if err != nil {
+		// if the execution data is available, the block must be locally finalized
+		lg.Error().Err(err).Msg("could not get header for execution data")
+		return
+	}
+
+	b.execDataCache.Add(executionData.BlockID, executionData)
+
+	// execution data is delivered in consecutive height order, but may be delivered more than once
+	// after a restart. Never move the highest height backwards.
+	for {
+		highest := b.highestExecDataHeight.Load()
+		if header.Height <= highest || b.highestExecDataHeight.CAS(highest, header.Height) {
+			break
+		}
+	}
+
+	b.executionDataBroadcaster.Publish()
+}
+
+// getExecutionData returns the execution data for the given block.
+// Expected errors:
+// - storage.ErrNotFound: if the execution data for the block is not available yet
+func (b *Backend) getExecutionData(ctx context.Context, header *flow.Header) (*state_synchronization.ExecutionData, error) {
+	if header.Height > b.highestExecDataHeight.Load() {
+		return nil, fmt.Errorf("execution data for block %v is not available yet: %w", header.ID(), storage.ErrNotFound)
+	}
+
+	blockID := header.ID()
+	if cached, ok := b.execDataCache.Get(blockID); ok {
+		return cached.(*state_synchronization.ExecutionData), nil
+	}
+
+	seal, err := b.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get finalized seal for block: %w", err)
+	}
+
+	result, err := b.results.ByID(seal.ResultID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution result (id: %s): %w", seal.ResultID, err)
+	}
+
+	// the requester already downloaded the execution data for all heights up to the highest
+	// height, so this is served from the local blobstore
+	execData, err := b.execDataService.Get(ctx, result.ExecutionDataID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution data (id: %s): %w", result.ExecutionDataID, err)
+	}
+
+	b.execDataCache.Add(blockID, execData)
+
+	return execData, nil
+}
+
+// getStartHeight returns the start height to use when searching.
+// Only one of startBlockID and startHeight may be set. Otherwise, an InvalidArgument error is returned.
+// If a block is provided and does not exist, a NotFound error is returned.
+// If neither startBlockID nor startHeight is provided, latestHeight is returned, or minHeight if no
+// data is available yet. Start heights below minHeight result in an InvalidArgument error.
+func (b *Backend) getStartHeight(startBlockID flow.Identifier, startHeight uint64, minHeight uint64, latestHeight uint64) (uint64, error) {
+	// make sure only one of start block ID and start height is provided
+	if startBlockID != flow.ZeroID && startHeight > 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "only one of start block ID and start height may be provided")
+	}
+
+	if startBlockID != flow.ZeroID {
+		header, err := b.headers.ByBlockID(startBlockID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return 0, status.Errorf(codes.NotFound, "could not get header for block %v: %v", startBlockID, err)
+			}
+			return 0, status.Errorf(codes.Internal, "could not get header for block %v: %v", startBlockID, err)
+		}
+		startHeight = header.Height
+	}
+
+	if startHeight == 0 {
+		if latestHeight < minHeight {
+			return minHeight, nil
+		}
+		return latestHeight, nil
+	}
+
+	if startHeight < minHeight {
+		return 0, status.Errorf(codes.InvalidArgument, "start height must be greater than or equal to %d", minHeight)
+	}
+
+	return startHeight, nil
+}
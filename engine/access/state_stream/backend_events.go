@@ -0,0 +1,72 @@
+package state_stream
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventsResponse is the response sent to event subscriptions for each sealed block.
+type EventsResponse struct {
+	BlockID        flow.Identifier
+	Height         uint64
+	BlockTimestamp time.Time
+	Events         flow.EventsList
+}
+
+// SubscribeEvents streams the events matching the filter for all sealed blocks, starting at the
+// given block, in ascending height order. Events are sourced from the execution data downloaded
+// by the execution data requester.
+func (b *Backend) SubscribeEvents(ctx context.Context, startBlockID flow.Identifier, startHeight uint64, filter EventFilter) Subscription {
+	if b.execDataService == nil {
+		return NewFailedSubscription(status.Error(codes.Unavailable, "execution data sync is disabled"), "could not subscribe to events")
+	}
+
+	nextHeight, err := b.getStartHeight(startBlockID, startHeight, b.execDataStartHeight, b.highestExecDataHeight.Load())
+	if err != nil {
+		return NewFailedSubscription(err, "could not get start height")
+	}
+
+	sub := NewHeightBasedSubscription(b.config.ClientSendBufferSize, nextHeight, b.getEvents(filter))
+
+	go NewStreamer(b.log, b.executionDataBroadcaster, b.config.ClientSendTimeout, b.config.ResponseLimit, sub).Stream(ctx)
+
+	return sub
+}
+
+// getEvents returns a GetDataByHeightFunc that retrieves the events of the sealed block at the given
+// height which match the filter.
+func (b *Backend) getEvents(filter EventFilter) GetDataByHeightFunc {
+	return func(ctx context.Context, height uint64) (interface{}, error) {
+		header, err := b.getSealedHeader(height)
+		if err != nil {
+			return nil, err
+		}
+
+		executionData, err := b.getExecutionData(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+
+		events := flow.EventsList{}
+		for _, chunkEvents := range executionData.Events {
+			events = append(events, filter.Filter(chunkEvents)...)
+		}
+
+		b.log.Trace().
+			Hex("block_id", executionData.BlockID[:]).
+			Uint64("height", header.Height).
+			Msgf("sending %d events", len(events))
+
+		return &EventsResponse{
+			BlockID:        header.ID(),
+			Height:         header.Height,
+			BlockTimestamp: header.Timestamp,
+			Events:         events,
+		}, nil
+	}
+}
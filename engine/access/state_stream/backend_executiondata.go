@@ -0,0 +1,73 @@
+package state_stream
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+)
+
+// ExecutionDataResponse is the response sent to execution data subscriptions for each sealed block.
+type ExecutionDataResponse struct {
+	Height        uint64
+	ExecutionData *state_synchronization.ExecutionData
+}
+
+// SubscribeExecutionData streams the execution data for all sealed blocks, starting at the given
+// block, in ascending height order.
+func (b *Backend) SubscribeExecutionData(ctx context.Context, startBlockID flow.Identifier, startHeight uint64) Subscription {
+	if b.execDataService == nil {
+		return NewFailedSubscription(status.Error(codes.Unavailable, "execution data sync is disabled"), "could not subscribe to execution data")
+	}
+
+	nextHeight, err := b.getStartHeight(startBlockID, startHeight, b.execDataStartHeight, b.highestExecDataHeight.Load())
+	if err != nil {
+		return NewFailedSubscription(err, "could not get start height")
+	}
+
+	sub := NewHeightBasedSubscription(b.config.ClientSendBufferSize, nextHeight, b.getExecutionDataResponse)
+
+	go NewStreamer(b.log, b.executionDataBroadcaster, b.config.ClientSendTimeout, b.config.ResponseLimit, sub).Stream(ctx)
+
+	return sub
+}
+
+// getExecutionDataResponse retrieves the execution data of the sealed block at the given height.
+func (b *Backend) getExecutionDataResponse(ctx context.Context, height uint64) (interface{}, error) {
+	header, err := b.getSealedHeader(height)
+	if err != nil {
+		return nil, err
+	}
+
+	executionData, err := b.getExecutionData(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecutionDataResponse{
+		Height:        header.Height,
+		ExecutionData: executionData,
+	}, nil
+}
+
+// getSealedHeader returns the header of the block at the given height, if its execution data is
+// available.
+// Expected errors:
+// - storage.ErrNotFound: if the execution data for the block is not available yet
+func (b *Backend) getSealedHeader(height uint64) (*flow.Header, error) {
+	if height > b.highestExecDataHeight.Load() {
+		return nil, fmt.Errorf("execution data for block %d is not available yet: %w", height, storage.ErrNotFound)
+	}
+
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block header: %w", err)
+	}
+
+	return header, nil
+}
@@ -0,0 +1,59 @@
+package state_stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// SubscribeBlockHeaders streams the headers of all finalized (or sealed, if sealed is true) blocks,
+// starting at the given block, in ascending height order.
+func (b *Backend) SubscribeBlockHeaders(ctx context.Context, startBlockID flow.Identifier, startHeight uint64, sealed bool) Subscription {
+	latest, err := b.latestHeader(sealed)
+	if err != nil {
+		return NewFailedSubscription(err, "could not get latest block header")
+	}
+
+	nextHeight, err := b.getStartHeight(startBlockID, startHeight, b.rootHeight, latest.Height)
+	if err != nil {
+		return NewFailedSubscription(err, "could not get start height")
+	}
+
+	sub := NewHeightBasedSubscription(b.config.ClientSendBufferSize, nextHeight, b.getBlockHeader(sealed))
+
+	go NewStreamer(b.log, b.finalizedBroadcaster, b.config.ClientSendTimeout, b.config.ResponseLimit, sub).Stream(ctx)
+
+	return sub
+}
+
+// getBlockHeader returns a GetDataByHeightFunc that retrieves the header of the finalized (or sealed)
+// block at the given height.
+func (b *Backend) getBlockHeader(sealed bool) GetDataByHeightFunc {
+	return func(_ context.Context, height uint64) (interface{}, error) {
+		latest, err := b.latestHeader(sealed)
+		if err != nil {
+			return nil, fmt.Errorf("could not get latest block header: %w", err)
+		}
+
+		if height > latest.Height {
+			return nil, fmt.Errorf("block %d is not available yet: %w", height, storage.ErrNotFound)
+		}
+
+		header, err := b.headers.ByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not get block header: %w", err)
+		}
+
+		return header, nil
+	}
+}
+
+// latestHeader returns the header of the latest finalized or sealed block.
+func (b *Backend) latestHeader(sealed bool) (*flow.Header, error) {
+	if sealed {
+		return b.state.Sealed().Head()
+	}
+	return b.state.Final().Head()
+}
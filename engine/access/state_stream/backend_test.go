@@ -0,0 +1,481 @@
+package state_stream_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type BackendSuite struct {
+	suite.Suite
+
+	state           *protocol.State
+	snapshot        *protocol.Snapshot
+	headers         *storagemock.Headers
+	seals           *storagemock.Seals
+	results         *storagemock.ExecutionResults
+	execDataService *syncmock.ExecutionDataService
+	transactions    *accessmock.API
+
+	blocks      []*flow.Header
+	blocksByID  map[flow.Identifier]*flow.Header
+	execData    map[flow.Identifier]*state_synchronization.ExecutionData
+	latestIndex *atomic.Int64
+
+	backend *state_stream.Backend
+}
+
+func TestBackendSuite(t *testing.T) {
+	suite.Run(t, new(BackendSuite))
+}
+
+func (s *BackendSuite) SetupTest() {
+	s.state = new(protocol.State)
+	s.snapshot = new(protocol.Snapshot)
+	s.headers = new(storagemock.Headers)
+	s.seals = new(storagemock.Seals)
+	s.results = new(storagemock.ExecutionResults)
+	s.execDataService = new(syncmock.ExecutionDataService)
+	s.transactions = new(accessmock.API)
+
+	// the first block is the root block
+	parent := unittest.BlockHeaderFixture()
+	s.blocks = []*flow.Header{parent}
+	s.blocksByID = map[flow.Identifier]*flow.Header{parent.ID(): parent}
+	s.execData = map[flow.Identifier]*state_synchronization.ExecutionData{}
+	for i := 0; i < 10; i++ {
+		header := unittest.BlockHeaderWithParentFixture(parent)
+		s.blocks = append(s.blocks, header)
+		s.blocksByID[header.ID()] = header
+		s.execData[header.ID()] = &state_synchronization.ExecutionData{
+			BlockID: header.ID(),
+			Events: []flow.EventsList{{
+				unittest.EventFixture("flow.AccountCreated", 0, 0, unittest.IdentifierFixture(), 0),
+				unittest.EventFixture("A.0000000000000001.Contract1.EventA", 0, 1, unittest.IdentifierFixture(), 0),
+			}},
+		}
+		parent = header
+	}
+	s.latestIndex = atomic.NewInt64(5)
+
+	// streamers of previous tests may still be running, so the mocks only use this test's data
+	blocks, blocksByID, latestIndex := s.blocks, s.blocksByID, s.latestIndex
+
+	s.state.On("Final").Return(s.snapshot)
+	s.state.On("Sealed").Return(s.snapshot)
+	s.snapshot.On("Head").Return(
+		func() *flow.Header {
+			return blocks[latestIndex.Load()]
+		},
+		func() error { return nil },
+	)
+
+	s.headers.On("ByHeight", mock.AnythingOfType("uint64")).Return(
+		func(height uint64) *flow.Header {
+			index := height - blocks[0].Height
+			if index >= uint64(len(blocks)) {
+				return nil
+			}
+			return blocks[index]
+		},
+		func(height uint64) error {
+			index := height - blocks[0].Height
+			if index >= uint64(len(blocks)) {
+				return storage.ErrNotFound
+			}
+			return nil
+		},
+	)
+	s.headers.On("ByBlockID", mock.AnythingOfType("flow.Identifier")).Return(
+		func(blockID flow.Identifier) *flow.Header {
+			return blocksByID[blockID]
+		},
+		func(blockID flow.Identifier) error {
+			if _, ok := blocksByID[blockID]; !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		},
+	)
+
+	var err error
+	s.backend, err = state_stream.New(
+		unittest.Logger(),
+		state_stream.DefaultConfig(),
+		s.state,
+		s.headers,
+		s.seals,
+		s.results,
+		s.execDataService,
+		s.blocks[0].Height,
+		s.blocks[1].Height,
+		s.blocks[0].Height,
+	)
+	require.NoError(s.T(), err)
+	s.backend.SetTransactionsAPI(s.transactions)
+}
+
+// finalize advances the latest finalized (and sealed) block by one, and notifies the backend
+func (s *BackendSuite) finalize() {
+	s.latestIndex.Inc()
+	s.backend.OnFinalizedBlock(nil)
+}
+
+// TestSubscribeBlockHeaders tests that all headers from the start height are streamed in order,
+// including the headers of blocks finalized after subscribing.
+func (s *BackendSuite) TestSubscribeBlockHeaders() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := s.backend.SubscribeBlockHeaders(ctx, flow.ZeroID, s.blocks[1].Height, false)
+
+	// all blocks up to the latest finalized block are available immediately
+	for i := 1; i <= 5; i++ {
+		s.requireHeader(sub, s.blocks[i])
+	}
+
+	// blocks are streamed as they are finalized
+	for i := 6; i < len(s.blocks); i++ {
+		s.finalize()
+		s.requireHeader(sub, s.blocks[i])
+	}
+
+	// the subscription is closed with an error once the client goes away
+	cancel()
+	unittest.RequireReturnsBefore(s.T(), func() {
+		for range sub.Channel() {
+		}
+	}, time.Second, "subscription was not closed")
+	assert.ErrorIs(s.T(), sub.Err(), context.Canceled)
+}
+
+// TestSubscribeBlockHeaders_FromBlockID tests that streaming starts at the provided block
+func (s *BackendSuite) TestSubscribeBlockHeaders_FromBlockID() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := s.backend.SubscribeBlockHeaders(ctx, s.blocks[3].ID(), 0, true)
+
+	s.requireHeader(sub, s.blocks[3])
+	s.requireHeader(sub, s.blocks[4])
+}
+
+// TestSubscribeExecutionData tests that the execution data is streamed once it was notified by the
+// execution data requester.
+func (s *BackendSuite) TestSubscribeExecutionData() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := s.backend.SubscribeExecutionData(ctx, flow.ZeroID, s.blocks[1].Height)
+
+	for i := 1; i < len(s.blocks); i++ {
+		expected := s.execData[s.blocks[i].ID()]
+		s.backend.OnExecutionData(expected)
+
+		v := s.receive(sub)
+		resp, ok := v.(*state_stream.ExecutionDataResponse)
+		require.True(s.T(), ok, "unexpected response type: %T", v)
+		assert.Equal(s.T(), s.blocks[i].Height, resp.Height)
+		assert.Equal(s.T(), expected, resp.ExecutionData)
+	}
+}
+
+// TestSubscribeEvents tests that only the events matching the filter are streamed, and that a
+// response is sent for every block.
+func (s *BackendSuite) TestSubscribeEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 1; i < len(s.blocks); i++ {
+		s.backend.OnExecutionData(s.execData[s.blocks[i].ID()])
+	}
+
+	filter, err := state_stream.NewEventFilter(flow.MonotonicEmulator.Chain(), []string{"flow.AccountCreated"}, nil, nil)
+	require.NoError(s.T(), err)
+
+	sub := s.backend.SubscribeEvents(ctx, s.blocks[2].ID(), 0, filter)
+
+	for i := 2; i < len(s.blocks); i++ {
+		v := s.receive(sub)
+		resp, ok := v.(*state_stream.EventsResponse)
+		require.True(s.T(), ok, "unexpected response type: %T", v)
+		assert.Equal(s.T(), s.blocks[i].ID(), resp.BlockID)
+		assert.Equal(s.T(), s.blocks[i].Height, resp.Height)
+		require.Len(s.T(), resp.Events, 1)
+		assert.Equal(s.T(), s.execData[s.blocks[i].ID()].Events[0][0], resp.Events[0])
+	}
+}
+
+// TestSubscribeTransactionStatuses tests that a response is sent for every status transition of the
+// transaction, and that the subscription is closed after the transaction is sealed.
+func (s *BackendSuite) TestSubscribeTransactionStatuses() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txID := unittest.IdentifierFixture()
+	block := s.blocks[6]
+	events := []flow.Event{
+		unittest.EventFixture("flow.AccountCreated", 0, 0, txID, 0),
+		unittest.EventFixture("A.0000000000000001.Contract1.EventA", 0, 1, txID, 0),
+	}
+
+	current := atomic.NewUint32(uint32(flow.TransactionStatusPending))
+	checks := atomic.NewInt32(0)
+	s.transactions.On("GetTransactionResult", mock.Anything, txID).Return(
+		func(context.Context, flow.Identifier) *access.TransactionResult {
+			defer checks.Inc()
+
+			result := &access.TransactionResult{Status: flow.TransactionStatus(current.Load())}
+			if result.Status >= flow.TransactionStatusFinalized {
+				result.BlockID = block.ID()
+				result.BlockHeight = block.Height
+			}
+			if result.Status >= flow.TransactionStatusExecuted {
+				result.Events = events
+			}
+			return result
+		},
+		nil,
+	)
+
+	// setStatus changes the status of the transaction, and waits until the backend checked it
+	setStatus := func(txStatus flow.TransactionStatus) {
+		current.Store(uint32(txStatus))
+		before := checks.Load()
+		s.finalize()
+		require.Eventually(s.T(), func() bool {
+			return checks.Load() > before
+		}, time.Second, time.Millisecond)
+	}
+
+	sub := s.backend.SubscribeTransactionStatuses(ctx, txID)
+
+	// the current status is sent immediately
+	s.requireTransactionStatus(sub, txID, flow.TransactionStatusPending, nil)
+
+	setStatus(flow.TransactionStatusFinalized)
+	finalized := s.requireTransactionStatus(sub, txID, flow.TransactionStatusFinalized, block)
+	assert.Empty(s.T(), finalized.Events)
+
+	// an execution node which did not execute the block yet must not move the status backwards
+	setStatus(flow.TransactionStatusPending)
+
+	// the transition to executed happened between two checks, and is sent before the sealed status
+	setStatus(flow.TransactionStatusSealed)
+	executed := s.requireTransactionStatus(sub, txID, flow.TransactionStatusExecuted, block)
+	assert.Equal(s.T(), events, executed.Events)
+	sealed := s.requireTransactionStatus(sub, txID, flow.TransactionStatusSealed, block)
+	assert.Equal(s.T(), events, sealed.Events)
+
+	// the subscription is closed gracefully after the final status
+	unittest.RequireReturnsBefore(s.T(), func() {
+		for range sub.Channel() {
+		}
+	}, time.Second, "subscription was not closed")
+	assert.NoError(s.T(), sub.Err())
+}
+
+// TestSendAndSubscribeTransactionStatuses tests that the transaction is sent before its status is
+// streamed, and that errors while sending fail the subscription.
+func (s *BackendSuite) TestSendAndSubscribeTransactionStatuses() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Run("happy path", func() {
+		tx := unittest.TransactionBodyFixture()
+		s.transactions.On("SendTransaction", mock.Anything, &tx).Return(nil).Once()
+		s.transactions.On("GetTransactionResult", mock.Anything, tx.ID()).Return(
+			&access.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+
+		sub := s.backend.SendAndSubscribeTransactionStatuses(ctx, &tx)
+		s.requireTransactionStatus(sub, tx.ID(), flow.TransactionStatusPending, nil)
+	})
+
+	s.Run("invalid transaction", func() {
+		tx := unittest.TransactionBodyFixture()
+		s.transactions.On("SendTransaction", mock.Anything, &tx).
+			Return(status.Error(codes.InvalidArgument, "invalid transaction")).Once()
+
+		sub := s.backend.SendAndSubscribeTransactionStatuses(ctx, &tx)
+		s.requireFailed(sub, codes.InvalidArgument)
+	})
+
+	s.transactions.AssertExpectations(s.T())
+}
+
+// TestSubscribeTransactionStatuses_SharedLookups tests that the result of a transaction is looked up
+// once per finalized block, regardless of the number of subscriptions of the transaction.
+func (s *BackendSuite) TestSubscribeTransactionStatuses_SharedLookups() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txID := unittest.IdentifierFixture()
+	checks := atomic.NewInt32(0)
+	s.transactions.On("GetTransactionResult", mock.Anything, txID).Return(
+		func(context.Context, flow.Identifier) *access.TransactionResult {
+			checks.Inc()
+			return &access.TransactionResult{Status: flow.TransactionStatusPending}
+		},
+		nil,
+	)
+
+	subs := []state_stream.Subscription{
+		s.backend.SubscribeTransactionStatuses(ctx, txID),
+		s.backend.SubscribeTransactionStatuses(ctx, txID),
+		s.backend.SubscribeTransactionStatuses(ctx, txID),
+	}
+	for _, sub := range subs {
+		s.requireTransactionStatus(sub, txID, flow.TransactionStatusPending, nil)
+	}
+	assert.Equal(s.T(), int32(1), checks.Load())
+
+	s.finalize()
+	require.Eventually(s.T(), func() bool {
+		return checks.Load() == 2
+	}, time.Second, time.Millisecond)
+	// all subscriptions check the status of the new block
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(s.T(), int32(2), checks.Load())
+}
+
+// TestTransactionStatusSubscription_UnknownExpired tests that a subscription of a transaction which
+// is still unknown after its expiry height fails.
+func TestTransactionStatusSubscription_UnknownExpired(t *testing.T) {
+	txID := unittest.IdentifierFixture()
+	height := atomic.NewUint64(100)
+	getResult := func(context.Context, flow.Identifier, uint64) (*access.TransactionResult, error) {
+		return &access.TransactionResult{Status: flow.TransactionStatusUnknown}, nil
+	}
+	finalizedHeight := func() (uint64, error) {
+		return height.Load(), nil
+	}
+	sub := state_stream.NewTransactionStatusSubscription(1, txID, getResult, finalizedHeight, 100)
+
+	// the initial status is sent while the transaction may still become known
+	result, err := sub.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, flow.TransactionStatusUnknown, result.(*access.TransactionResult).Status)
+
+	height.Inc()
+	_, err = sub.Next(context.Background())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestSlowClient tests that a client which does not read its responses is disconnected
+func (s *BackendSuite) TestSlowClient() {
+	config := state_stream.DefaultConfig()
+	config.ClientSendBufferSize = 1
+	config.ClientSendTimeout = 10 * time.Millisecond
+
+	backend, err := state_stream.New(unittest.Logger(), config, s.state, s.headers, s.seals, s.results, s.execDataService, s.blocks[0].Height, s.blocks[1].Height, s.blocks[0].Height)
+	require.NoError(s.T(), err)
+
+	sub := backend.SubscribeBlockHeaders(context.Background(), flow.ZeroID, s.blocks[1].Height, false)
+
+	// the client never reads its responses, so the streamer gives up once the buffer is full
+	require.Eventually(s.T(), func() bool {
+		return sub.Err() != nil
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(s.T(), codes.DeadlineExceeded, status.Code(sub.Err()))
+}
+
+// TestInvalidStart tests that invalid start parameters result in a failed subscription
+func (s *BackendSuite) TestInvalidStart() {
+	ctx := context.Background()
+
+	s.Run("both start block ID and height", func() {
+		sub := s.backend.SubscribeBlockHeaders(ctx, s.blocks[1].ID(), s.blocks[1].Height, false)
+		s.requireFailed(sub, codes.InvalidArgument)
+	})
+
+	s.Run("unknown start block", func() {
+		sub := s.backend.SubscribeExecutionData(ctx, unittest.IdentifierFixture(), 0)
+		s.requireFailed(sub, codes.NotFound)
+	})
+
+	s.Run("below execution data start height", func() {
+		sub := s.backend.SubscribeEvents(ctx, flow.ZeroID, s.blocks[0].Height, state_stream.EventFilter{})
+		s.requireFailed(sub, codes.InvalidArgument)
+	})
+
+	s.Run("execution data sync disabled", func() {
+		backend, err := state_stream.New(unittest.Logger(), state_stream.DefaultConfig(), s.state, s.headers, s.seals, s.results, nil, s.blocks[0].Height, s.blocks[1].Height, s.blocks[0].Height)
+		require.NoError(s.T(), err)
+
+		sub := backend.SubscribeExecutionData(ctx, flow.ZeroID, 0)
+		s.requireFailed(sub, codes.Unavailable)
+	})
+
+	s.Run("transactions API not set", func() {
+		backend, err := state_stream.New(unittest.Logger(), state_stream.DefaultConfig(), s.state, s.headers, s.seals, s.results, s.execDataService, s.blocks[0].Height, s.blocks[1].Height, s.blocks[0].Height)
+		require.NoError(s.T(), err)
+
+		sub := backend.SubscribeTransactionStatuses(ctx, unittest.IdentifierFixture())
+		s.requireFailed(sub, codes.Unimplemented)
+	})
+}
+
+func (s *BackendSuite) receive(sub state_stream.Subscription) interface{} {
+	select {
+	case v, ok := <-sub.Channel():
+		require.True(s.T(), ok, "subscription closed unexpectedly: %v", sub.Err())
+		return v
+	case <-time.After(time.Second):
+		require.FailNow(s.T(), "timed out waiting for response")
+		return nil
+	}
+}
+
+func (s *BackendSuite) requireHeader(sub state_stream.Subscription, expected *flow.Header) {
+	v := s.receive(sub)
+	header, ok := v.(*flow.Header)
+	require.True(s.T(), ok, "unexpected response type: %T", v)
+	assert.Equal(s.T(), expected.ID(), header.ID(), fmt.Sprintf("unexpected header at height %d", header.Height))
+}
+
+func (s *BackendSuite) requireTransactionStatus(
+	sub state_stream.Subscription,
+	txID flow.Identifier,
+	expected flow.TransactionStatus,
+	block *flow.Header,
+) *access.TransactionResult {
+	v := s.receive(sub)
+	result, ok := v.(*access.TransactionResult)
+	require.True(s.T(), ok, "unexpected response type: %T", v)
+	assert.Equal(s.T(), txID, result.TransactionID)
+	require.Equal(s.T(), expected, result.Status)
+	if block != nil {
+		assert.Equal(s.T(), block.ID(), result.BlockID)
+		assert.Equal(s.T(), block.Height, result.BlockHeight)
+	}
+	return result
+}
+
+func (s *BackendSuite) requireFailed(sub state_stream.Subscription, code codes.Code) {
+	unittest.RequireReturnsBefore(s.T(), func() {
+		for range sub.Channel() {
+		}
+	}, time.Second, "subscription was not closed")
+	assert.Equal(s.T(), code, status.Code(sub.Err()))
+}
@@ -0,0 +1,331 @@
+package state_stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// TransactionsAPI provides the transaction functionality of the Access API used by transaction
+// status subscriptions.
+type TransactionsAPI interface {
+	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
+	GetTransactionResult(ctx context.Context, txID flow.Identifier) (*access.TransactionResult, error)
+}
+
+// transactionResultTimeout is the timeout for looking up the result of a transaction. As lookups
+// are shared by all subscriptions of the transaction, they are not bound to a single client.
+const transactionResultTimeout = 10 * time.Second
+
+// SubscribeTransactionStatuses streams the status transitions of the given transaction.
+func (b *Backend) SubscribeTransactionStatuses(ctx context.Context, txID flow.Identifier) Subscription {
+	if b.transactions == nil {
+		return NewFailedSubscription(status.Error(codes.Unimplemented, "transaction status streaming is not available"), "could not subscribe to transaction statuses")
+	}
+
+	// the reference block of an unknown transaction is not known, but it can at most be the
+	// latest finalized block
+	finalized, err := b.state.Final().Head()
+	if err != nil {
+		return NewFailedSubscription(err, "could not get latest finalized block")
+	}
+
+	return b.subscribeTransactionStatuses(ctx, txID, finalized.Height)
+}
+
+// SendAndSubscribeTransactionStatuses sends the transaction to the network, and streams its status
+// transitions.
+func (b *Backend) SendAndSubscribeTransactionStatuses(ctx context.Context, tx *flow.TransactionBody) Subscription {
+	if b.transactions == nil {
+		return NewFailedSubscription(status.Error(codes.Unimplemented, "transaction status streaming is not available"), "could not subscribe to transaction statuses")
+	}
+
+	err := b.transactions.SendTransaction(ctx, tx)
+	if err != nil {
+		return NewFailedSubscription(err, "could not send transaction")
+	}
+
+	refBlock, err := b.headers.ByBlockID(tx.ReferenceBlockID)
+	if err == nil {
+		return b.subscribeTransactionStatuses(ctx, tx.ID(), refBlock.Height)
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return NewFailedSubscription(err, "could not get reference block")
+	}
+
+	// the reference block is not known locally, hence the latest finalized block is used instead
+	return b.SubscribeTransactionStatuses(ctx, tx.ID())
+}
+
+// subscribeTransactionStatuses streams the status transitions of the given transaction, whose
+// reference block has at most the given height.
+func (b *Backend) subscribeTransactionStatuses(ctx context.Context, txID flow.Identifier, refHeight uint64) Subscription {
+	b.transactionResults.subscribe(txID)
+	sub := NewTransactionStatusSubscription(
+		b.config.ClientSendBufferSize,
+		txID,
+		b.transactionResults.get,
+		b.finalizedHeight,
+		refHeight+flow.DefaultTransactionExpiry,
+	)
+
+	// the status of a transaction can only change when a new block is finalized
+	go func() {
+		defer b.transactionResults.unsubscribe(txID)
+		NewStreamer(b.log, b.finalizedBroadcaster, b.config.ClientSendTimeout, b.config.ResponseLimit, sub).Stream(ctx)
+	}()
+
+	return sub
+}
+
+// finalizedHeight returns the height of the latest finalized block.
+func (b *Backend) finalizedHeight() (uint64, error) {
+	finalized, err := b.state.Final().Head()
+	if err != nil {
+		return 0, fmt.Errorf("could not get latest finalized block: %w", err)
+	}
+	return finalized.Height, nil
+}
+
+// GetTransactionResultFunc is a callback used by transaction status subscriptions to retrieve the
+// result of a transaction as of the given finalized height.
+type GetTransactionResultFunc func(ctx context.Context, txID flow.Identifier, height uint64) (*access.TransactionResult, error)
+
+// transactionResults shares the result lookups between all subscriptions of the same transaction,
+// so that the result of a transaction is looked up at most once per finalized block, regardless
+// of the number of subscribers.
+type transactionResults struct {
+	getResult func(ctx context.Context, txID flow.Identifier) (*access.TransactionResult, error)
+	lock      sync.Mutex
+	lookups   map[flow.Identifier]*transactionResultLookup
+}
+
+// transactionResultLookup is the latest result lookup of a transaction.
+type transactionResultLookup struct {
+	subscribers int           // number of subscriptions of the transaction
+	height      uint64        // finalized height at which the result was looked up
+	done        chan struct{} // closed once the lookup finished, nil if no lookup was started yet
+	result      *access.TransactionResult
+	err         error
+}
+
+func newTransactionResults(getResult func(ctx context.Context, txID flow.Identifier) (*access.TransactionResult, error)) *transactionResults {
+	return &transactionResults{
+		getResult: getResult,
+		lookups:   make(map[flow.Identifier]*transactionResultLookup),
+	}
+}
+
+// subscribe registers a subscription of the transaction.
+func (r *transactionResults) subscribe(txID flow.Identifier) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	lookup, ok := r.lookups[txID]
+	if !ok {
+		lookup = &transactionResultLookup{}
+		r.lookups[txID] = lookup
+	}
+	lookup.subscribers++
+}
+
+// unsubscribe removes a subscription of the transaction. The result of the transaction is
+// discarded once it has no subscriptions left.
+func (r *transactionResults) unsubscribe(txID flow.Identifier) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	lookup := r.lookups[txID]
+	lookup.subscribers--
+	if lookup.subscribers == 0 {
+		delete(r.lookups, txID)
+	}
+}
+
+// get returns the result of the subscribed transaction as of the given finalized height. If the
+// result was already looked up for this height, or is being looked up, that result is returned.
+func (r *transactionResults) get(ctx context.Context, txID flow.Identifier, height uint64) (*access.TransactionResult, error) {
+	r.lock.Lock()
+	lookup := r.lookups[txID]
+	if lookup.done == nil || lookup.height < height {
+		done := make(chan struct{})
+		lookup.height = height
+		lookup.done = done
+		r.lock.Unlock()
+
+		lookupCtx, cancel := context.WithTimeout(context.Background(), transactionResultTimeout)
+		result, err := r.getResult(lookupCtx, txID)
+		cancel()
+
+		r.lock.Lock()
+		lookup.result, lookup.err = result, err
+		r.lock.Unlock()
+		close(done)
+		return result, err
+	}
+	done := lookup.done
+	r.lock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-done:
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return lookup.result, lookup.err
+}
+
+var _ Subscription = (*TransactionStatusSubscription)(nil)
+var _ Streamable = (*TransactionStatusSubscription)(nil)
+
+// TransactionStatusSubscription is a subscription that streams the status transitions of a single
+// transaction.
+//
+// The first response contains the status of the transaction at the time of subscribing. After that,
+// a response is sent for every status transition, including transitions that happened between two
+// checks: e.g. a transaction going from pending to sealed produces a finalized, executed and sealed
+// response, in that order. The subscription is closed after the transaction is sealed or expired,
+// and the last response contains the final result including its events. A transaction which is
+// still unknown once the latest finalized height exceeds its expiry height never becomes known,
+// hence the subscription fails with a NotFound error.
+type TransactionStatusSubscription struct {
+	*SubscriptionImpl
+	txID            flow.Identifier
+	getResult       GetTransactionResultFunc
+	finalizedHeight func() (uint64, error)
+	expiryHeight    uint64
+
+	// lastStatus is the status of the last queued response, or nil if no response was queued yet
+	lastStatus *flow.TransactionStatus
+
+	// queued contains the responses which were derived from the last result, but not sent yet
+	queued []*access.TransactionResult
+}
+
+// NewTransactionStatusSubscription returns a subscription that streams the status transitions of the
+// given transaction, and uses getResult to check its status as of the latest finalized height.
+// expiryHeight is the height after which the transaction has expired if it is still unknown.
+func NewTransactionStatusSubscription(
+	bufferSize uint,
+	txID flow.Identifier,
+	getResult GetTransactionResultFunc,
+	finalizedHeight func() (uint64, error),
+	expiryHeight uint64,
+) *TransactionStatusSubscription {
+	return &TransactionStatusSubscription{
+		SubscriptionImpl: NewSubscription(bufferSize),
+		txID:             txID,
+		getResult:        getResult,
+		finalizedHeight:  finalizedHeight,
+		expiryHeight:     expiryHeight,
+	}
+}
+
+// Next returns the response for the next status transition of the transaction.
+// Expected errors:
+// - storage.ErrNotFound: if the status did not change since the last response
+// - ErrEndOfData: if the final status was already returned
+// - codes.NotFound: if the transaction is still unknown after its expiry height
+func (s *TransactionStatusSubscription) Next(ctx context.Context) (interface{}, error) {
+	if len(s.queued) == 0 {
+		if s.lastStatus != nil && isFinalTransactionStatus(*s.lastStatus) {
+			return nil, ErrEndOfData
+		}
+
+		height, err := s.finalizedHeight()
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.getResult(ctx, s.txID, height)
+		if err != nil {
+			return nil, fmt.Errorf("could not get transaction result: %w", err)
+		}
+
+		if result.Status == flow.TransactionStatusUnknown && height > s.expiryHeight {
+			return nil, status.Errorf(codes.NotFound, "transaction %v is unknown, and expired at height %d", s.txID, s.expiryHeight)
+		}
+
+		s.queued = s.transitions(result)
+		if len(s.queued) == 0 {
+			return nil, fmt.Errorf("status of transaction %v did not change: %w", s.txID, storage.ErrNotFound)
+		}
+		s.lastStatus = &s.queued[len(s.queued)-1].Status
+	}
+
+	next := s.queued[0]
+	s.queued = s.queued[1:]
+	return next, nil
+}
+
+// transitions returns the responses for all status transitions between the last response and the
+// given result, in order.
+func (s *TransactionStatusSubscription) transitions(result *access.TransactionResult) []*access.TransactionResult {
+	// the transaction ID is not set for unknown transactions
+	withID := *result
+	withID.TransactionID = s.txID
+	result = &withID
+
+	// the initial response contains the current status, there is no previous status to transition from
+	if s.lastStatus == nil {
+		return []*access.TransactionResult{result}
+	}
+
+	last := *s.lastStatus
+
+	// a transaction may only be expired while it is pending, which is not followed by any other status
+	if result.Status == flow.TransactionStatusExpired {
+		return []*access.TransactionResult{result}
+	}
+
+	// the result is looked up on a different execution node for every check, and a node that did not
+	// execute the block yet reports an older status. Statuses never move backwards.
+	if result.Status <= last {
+		return nil
+	}
+
+	var transitions []*access.TransactionResult
+	for txStatus := last + 1; txStatus < result.Status; txStatus++ {
+		transitions = append(transitions, intermediateResult(result, txStatus))
+	}
+	return append(transitions, result)
+}
+
+// intermediateResult returns the response for a status transition that was skipped between two
+// checks, containing only the information that was available at the given status.
+func intermediateResult(result *access.TransactionResult, txStatus flow.TransactionStatus) *access.TransactionResult {
+	intermediate := &access.TransactionResult{
+		Status:        txStatus,
+		TransactionID: result.TransactionID,
+	}
+
+	if txStatus >= flow.TransactionStatusFinalized {
+		intermediate.BlockID = result.BlockID
+		intermediate.BlockHeight = result.BlockHeight
+		intermediate.CollectionID = result.CollectionID
+	}
+
+	if txStatus >= flow.TransactionStatusExecuted {
+		intermediate.StatusCode = result.StatusCode
+		intermediate.ErrorMessage = result.ErrorMessage
+		intermediate.Events = result.Events
+	}
+
+	return intermediate
+}
+
+// isFinalTransactionStatus returns true if the transaction status cannot change anymore.
+func isFinalTransactionStatus(txStatus flow.TransactionStatus) bool {
+	return txStatus == flow.TransactionStatusSealed || txStatus == flow.TransactionStatusExpired
+}
@@ -0,0 +1,85 @@
+package state_stream
+
+import (
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go/access"
+	statestream "github.com/onflow/flow-go/engine/access/state_stream/protobuf"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/module/state_synchronization"
+)
+
+// ExecutionDataToMessage converts the given execution data to its protobuf representation.
+func ExecutionDataToMessage(data *state_synchronization.ExecutionData) *statestream.ExecutionData {
+	collections := make([]*statestream.CollectionData, len(data.Collections))
+	for i, collection := range data.Collections {
+		collections[i] = &statestream.CollectionData{
+			Transactions: convert.TransactionsToMessages(collection.Transactions),
+		}
+	}
+
+	events := make([]*statestream.EventsList, len(data.Events))
+	for i, chunkEvents := range data.Events {
+		events[i] = &statestream.EventsList{
+			Events: convert.EventsToMessages(chunkEvents),
+		}
+	}
+
+	trieUpdates := make([]*statestream.TrieUpdate, len(data.TrieUpdates))
+	for i, update := range data.TrieUpdates {
+		trieUpdates[i] = TrieUpdateToMessage(update)
+	}
+
+	return &statestream.ExecutionData{
+		BlockId:     convert.IdentifierToMessage(data.BlockID),
+		Collections: collections,
+		Events:      events,
+		TrieUpdates: trieUpdates,
+	}
+}
+
+// TrieUpdateToMessage converts the given trie update to its protobuf representation.
+func TrieUpdateToMessage(update *ledger.TrieUpdate) *statestream.TrieUpdate {
+	paths := make([][]byte, len(update.Paths))
+	for i, path := range update.Paths {
+		// copy the array to avoid referencing the loop variable
+		p := path
+		paths[i] = p[:]
+	}
+
+	payloads := make([]*statestream.Payload, len(update.Payloads))
+	for i, payload := range update.Payloads {
+		keyParts := make([]*statestream.KeyPart, len(payload.Key.KeyParts))
+		for j, keyPart := range payload.Key.KeyParts {
+			keyParts[j] = &statestream.KeyPart{
+				Type:  uint32(keyPart.Type),
+				Value: keyPart.Value,
+			}
+		}
+		payloads[i] = &statestream.Payload{
+			KeyPart: keyParts,
+			Value:   payload.Value,
+		}
+	}
+
+	return &statestream.TrieUpdate{
+		RootHash: update.RootHash[:],
+		Paths:    paths,
+		Payloads: payloads,
+	}
+}
+
+// TransactionStatusToMessage converts the given transaction result to a transaction status response.
+func TransactionStatusToMessage(result *access.TransactionResult) *statestream.SubscribeTransactionStatusesResponse {
+	return &statestream.SubscribeTransactionStatusesResponse{
+		TransactionId: convert.IdentifierToMessage(result.TransactionID),
+		Status:        entities.TransactionStatus(result.Status),
+		BlockId:       convert.IdentifierToMessage(result.BlockID),
+		BlockHeight:   result.BlockHeight,
+		CollectionId:  convert.IdentifierToMessage(result.CollectionID),
+		StatusCode:    uint32(result.StatusCode),
+		ErrorMessage:  result.ErrorMessage,
+		Events:        convert.EventsToMessages(result.Events),
+	}
+}
@@ -0,0 +1,25 @@
+package state_stream
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcStatusError is implemented by the errors returned by the grpc status package
+type grpcStatusError interface {
+	GRPCStatus() *status.Status
+}
+
+// wrapStatusError returns a grpc status error with the given message prepended. If err is (or wraps)
+// a grpc status error, its status code is preserved. Otherwise, the error is treated as an internal error.
+func wrapStatusError(err error, msg string) error {
+	var se grpcStatusError
+	if errors.As(err, &se) {
+		st := se.GRPCStatus()
+		return status.Errorf(st.Code(), "%s: %s", msg, st.Message())
+	}
+	return status.Error(codes.Internal, fmt.Sprintf("%s: %v", msg, err))
+}
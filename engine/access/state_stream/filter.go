@@ -0,0 +1,177 @@
+package state_stream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventFilter represents a filter applied to events for a given subscription.
+// An event matches the filter if it matches any of the configured event types, addresses or
+// contracts. An empty filter matches all events.
+type EventFilter struct {
+	hasFilters bool
+	EventTypes map[flow.EventType]struct{}
+	Addresses  map[string]struct{}
+	Contracts  map[string]struct{}
+}
+
+// NewEventFilter creates a new EventFilter from the given event types, addresses and contracts.
+// Addresses are normalized to their hex representation without prefix.
+// Expected errors:
+// - an error if any of the event types, addresses or contracts is malformed, or if an address is
+// not valid for the given chain
+func NewEventFilter(
+	chain flow.Chain,
+	eventTypes []string,
+	addresses []string,
+	contracts []string,
+) (EventFilter, error) {
+	f := EventFilter{
+		EventTypes: make(map[flow.EventType]struct{}, len(eventTypes)),
+		Addresses:  make(map[string]struct{}, len(addresses)),
+		Contracts:  make(map[string]struct{}, len(contracts)),
+	}
+
+	// Check all of the filters to ensure they are correctly formatted. This helps avoid searching
+	// with criteria that will never match.
+	for _, event := range eventTypes {
+		eventType := flow.EventType(event)
+		if err := validateEventType(eventType); err != nil {
+			return EventFilter{}, err
+		}
+		f.EventTypes[eventType] = struct{}{}
+	}
+
+	for _, address := range addresses {
+		addr := flow.HexToAddress(address)
+		if err := validateAddress(addr, chain); err != nil {
+			return EventFilter{}, err
+		}
+		// use the parsed address to make sure it will match the event address string exactly
+		f.Addresses[addr.String()] = struct{}{}
+	}
+
+	for _, contract := range contracts {
+		if err := validateContract(contract); err != nil {
+			return EventFilter{}, err
+		}
+		f.Contracts[contract] = struct{}{}
+	}
+
+	f.hasFilters = len(f.EventTypes) > 0 || len(f.Addresses) > 0 || len(f.Contracts) > 0
+	return f, nil
+}
+
+// Filter applies the filter to the given events, and returns a new slice containing only the
+// events that match, preserving their order.
+func (f *EventFilter) Filter(events flow.EventsList) flow.EventsList {
+	var filteredEvents flow.EventsList
+	for _, event := range events {
+		if f.Match(event) {
+			filteredEvents = append(filteredEvents, event)
+		}
+	}
+	return filteredEvents
+}
+
+// Match returns true if the event matches any of the filter's criteria, or if the filter is empty.
+func (f *EventFilter) Match(event flow.Event) bool {
+	if !f.hasFilters {
+		return true
+	}
+
+	if _, ok := f.EventTypes[event.Type]; ok {
+		return true
+	}
+
+	parsed, err := parseEventType(event.Type)
+	if err != nil {
+		// events produced by the execution are always well-formed. An event type that cannot
+		// be parsed can only match by its full type, which was checked above.
+		return false
+	}
+
+	if _, ok := f.Contracts[parsed.Contract]; ok {
+		return true
+	}
+
+	if parsed.Type == accountEventType {
+		if _, ok := f.Addresses[parsed.Address]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	protocolEventType = "flow"
+	accountEventType  = "A"
+)
+
+// parsedEventType is the decomposition of an event type string. Protocol events have the form
+// flow.[EventName], and account events have the form A.[Address].[Contract].[EventName].
+type parsedEventType struct {
+	Type     string
+	Address  string
+	Contract string
+	Name     string
+}
+
+// parseEventType splits the given event type into its components.
+// Expected errors:
+// - an error if the event type does not have one of the supported formats
+func parseEventType(eventType flow.EventType) (parsedEventType, error) {
+	parts := strings.Split(string(eventType), ".")
+
+	switch parts[0] {
+	case protocolEventType:
+		if len(parts) == 2 && parts[1] != "" {
+			return parsedEventType{
+				Type:     protocolEventType,
+				Contract: parts[0],
+				Name:     parts[1],
+			}, nil
+		}
+	case accountEventType:
+		if len(parts) == 4 && parts[1] != "" && parts[2] != "" && parts[3] != "" {
+			return parsedEventType{
+				Type:     accountEventType,
+				Address:  parts[1],
+				Contract: strings.Join(parts[0:3], "."),
+				Name:     parts[3],
+			}, nil
+		}
+	}
+
+	return parsedEventType{}, fmt.Errorf("invalid event type: %s", eventType)
+}
+
+// validateEventType ensures that the event type matches the expected format
+func validateEventType(eventType flow.EventType) error {
+	_, err := parseEventType(eventType)
+	return err
+}
+
+// validateAddress ensures that the address is valid for the given chain
+func validateAddress(address flow.Address, chain flow.Chain) error {
+	if !chain.IsValid(address) {
+		return fmt.Errorf("invalid address for chain: %s", address)
+	}
+	return nil
+}
+
+// validateContract ensures that the contract is in the correct format
+func validateContract(contract string) error {
+	if contract == protocolEventType {
+		return nil
+	}
+
+	parts := strings.Split(contract, ".")
+	if len(parts) != 3 || parts[0] != accountEventType || parts[1] == "" || parts[2] == "" {
+		return fmt.Errorf("invalid contract: %s", contract)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package state_stream_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+var eventTypes = []string{
+	"flow.AccountCreated",
+	"flow.AccountKeyAdded",
+	"A.0000000000000001.Contract1.EventA",
+	"A.0000000000000001.Contract1.EventB",
+	"A.0000000000000001.Contract2.EventA",
+	"A.0000000000000001.Contract3.EventA",
+	"A.0000000000000002.Contract1.EventA",
+	"A.0000000000000002.Contract4.EventC",
+	"A.0000000000000003.Contract5.EventA",
+	"A.0000000000000003.Contract5.EventD",
+	"A.0000000000000004.Contract6.EventE",
+}
+
+func TestConstructor(t *testing.T) {
+	chain := flow.MonotonicEmulator.Chain()
+
+	tests := []struct {
+		name       string
+		eventTypes []string
+		addresses  []string
+		contracts  []string
+		err        bool
+	}{
+		{
+			name: "no filters",
+		},
+		{
+			name:       "valid filters",
+			eventTypes: []string{"flow.AccountCreated", "A.0000000000000001.Contract1.EventA"},
+			addresses:  []string{"0000000000000001", "0000000000000002"},
+			contracts:  []string{"flow", "A.0000000000000001.Contract1"},
+		},
+		{
+			name:       "invalid event type",
+			eventTypes: []string{"invalid"},
+			err:        true,
+		},
+		{
+			name:       "invalid account event type",
+			eventTypes: []string{"A.0000000000000001.Contract1"},
+			err:        true,
+		},
+		{
+			name:      "invalid address",
+			addresses: []string{"invalid"},
+			err:       true,
+		},
+		{
+			name:      "invalid contract",
+			contracts: []string{"A.0000000000000001"},
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := state_stream.NewEventFilter(chain, test.eventTypes, test.addresses, test.contracts)
+			if test.err {
+				assert.Error(t, err)
+				assert.Equal(t, state_stream.EventFilter{}, filter)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, filter.EventTypes, len(test.eventTypes))
+			assert.Len(t, filter.Addresses, len(test.addresses))
+			assert.Len(t, filter.Contracts, len(test.contracts))
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	chain := flow.MonotonicEmulator.Chain()
+
+	filter, err := state_stream.NewEventFilter(chain, []string{"flow.AccountCreated", "A.0000000000000001.Contract1.EventA"}, nil, nil)
+	require.NoError(t, err)
+
+	events := flow.EventsList{
+		unittest.EventFixture("A.0000000000000001.Contract1.EventA", 0, 0, unittest.IdentifierFixture(), 0),
+		unittest.EventFixture("A.0000000000000001.Contract2.EventA", 0, 0, unittest.IdentifierFixture(), 0),
+		unittest.EventFixture("flow.AccountCreated", 0, 0, unittest.IdentifierFixture(), 0),
+	}
+
+	matched := filter.Filter(events)
+
+	require.Len(t, matched, 2)
+	assert.Equal(t, events[0], matched[0])
+	assert.Equal(t, events[2], matched[1])
+}
+
+func TestMatch(t *testing.T) {
+	chain := flow.MonotonicEmulator.Chain()
+
+	tests := []struct {
+		name       string
+		eventTypes []string
+		addresses  []string
+		contracts  []string
+		matches    map[string]bool
+	}{
+		{
+			name:    "no filters",
+			matches: map[string]bool{},
+		},
+		{
+			name:       "eventtype filter",
+			eventTypes: []string{"flow.AccountCreated", "A.0000000000000001.Contract1.EventA"},
+			matches: map[string]bool{
+				"flow.AccountCreated":                 true,
+				"A.0000000000000001.Contract1.EventA": true,
+			},
+		},
+		{
+			name:      "address filter",
+			addresses: []string{"0000000000000001", "0000000000000002"},
+			matches: map[string]bool{
+				"A.0000000000000001.Contract1.EventA": true,
+				"A.0000000000000001.Contract1.EventB": true,
+				"A.0000000000000001.Contract2.EventA": true,
+				"A.0000000000000001.Contract3.EventA": true,
+				"A.0000000000000002.Contract1.EventA": true,
+				"A.0000000000000002.Contract4.EventC": true,
+			},
+		},
+		{
+			name:      "contract filter",
+			contracts: []string{"A.0000000000000001.Contract1", "A.0000000000000002.Contract4"},
+			matches: map[string]bool{
+				"A.0000000000000001.Contract1.EventA": true,
+				"A.0000000000000001.Contract1.EventB": true,
+				"A.0000000000000002.Contract4.EventC": true,
+			},
+		},
+		{
+			name:      "protocol contract filter",
+			contracts: []string{"flow"},
+			matches: map[string]bool{
+				"flow.AccountCreated":  true,
+				"flow.AccountKeyAdded": true,
+			},
+		},
+		{
+			name:       "multiple filters",
+			eventTypes: []string{"A.0000000000000001.Contract1.EventA"},
+			addresses:  []string{"0000000000000002"},
+			contracts:  []string{"flow", "A.0000000000000001.Contract1", "A.0000000000000003.Contract5"},
+			matches: map[string]bool{
+				"flow.AccountCreated":                 true,
+				"flow.AccountKeyAdded":                true,
+				"A.0000000000000001.Contract1.EventA": true,
+				"A.0000000000000001.Contract1.EventB": true,
+				"A.0000000000000002.Contract1.EventA": true,
+				"A.0000000000000002.Contract4.EventC": true,
+				"A.0000000000000003.Contract5.EventA": true,
+				"A.0000000000000003.Contract5.EventD": true,
+			},
+		},
+	}
+
+	events := make([]flow.Event, len(eventTypes))
+	for i, eventType := range eventTypes {
+		events[i] = unittest.EventFixture(flow.EventType(eventType), 0, 0, unittest.IdentifierFixture(), 0)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := state_stream.NewEventFilter(chain, test.eventTypes, test.addresses, test.contracts)
+			require.NoError(t, err)
+
+			emptyFilter := len(test.eventTypes) == 0 && len(test.addresses) == 0 && len(test.contracts) == 0
+			for _, event := range events {
+				expected := emptyFilter || test.matches[string(event.Type)]
+				assert.Equal(t, expected, filter.Match(event), "event type: %s", event.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package state_stream
+
+import (
+	"fmt"
+
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/signature"
+	statestream "github.com/onflow/flow-go/engine/access/state_stream/protobuf"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Handler implements the gRPC StateStreamAPI service on top of the state stream API.
+type Handler struct {
+	statestream.UnimplementedStateStreamAPIServer
+
+	api                  API
+	chain                flow.Chain
+	signerIndicesDecoder hotstuff.BlockSignerDecoder
+
+	maxStreams  int32
+	streamCount *atomic.Int32
+}
+
+var _ statestream.StateStreamAPIServer = (*Handler)(nil)
+
+// HandlerOption is used to hand over optional constructor parameters
+type HandlerOption func(*Handler)
+
+// WithBlockSignerDecoder configures the Handler to decode signer indices
+// via the provided hotstuff.BlockSignerDecoder
+func WithBlockSignerDecoder(signerIndicesDecoder hotstuff.BlockSignerDecoder) HandlerOption {
+	return func(handler *Handler) {
+		handler.signerIndicesDecoder = signerIndicesDecoder
+	}
+}
+
+// NewHandler creates a new gRPC handler. At most conf.MaxGlobalStreams streams are served concurrently.
+func NewHandler(api API, chain flow.Chain, conf Config, options ...HandlerOption) *Handler {
+	maxStreams := conf.MaxGlobalStreams
+	if maxStreams == 0 {
+		maxStreams = DefaultMaxGlobalStreams
+	}
+
+	h := &Handler{
+		api:                  api,
+		chain:                chain,
+		signerIndicesDecoder: signature.NewNoopBlockSignerDecoder(),
+		maxStreams:           int32(maxStreams),
+		streamCount:          atomic.NewInt32(0),
+	}
+	for _, opt := range options {
+		opt(h)
+	}
+	return h
+}
+
+// SubscribeBlockHeaders streams the headers of finalized or sealed blocks.
+func (h *Handler) SubscribeBlockHeaders(request *statestream.SubscribeBlockHeadersRequest, stream statestream.StateStreamAPI_SubscribeBlockHeadersServer) error {
+	release, err := h.acquireStream()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	startBlockID, err := startBlockIDFromMessage(request.GetStartBlockId())
+	if err != nil {
+		return err
+	}
+
+	sealed := request.GetBlockStatus() == statestream.BlockStatus_BLOCK_SEALED
+	sub := h.api.SubscribeBlockHeaders(stream.Context(), startBlockID, request.GetStartHeight(), sealed)
+
+	return sendAll(sub, func(v interface{}) error {
+		header, ok := v.(*flow.Header)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected response type: %T", v)
+		}
+
+		signerIDs, err := h.signerIndicesDecoder.DecodeSignerIDs(header)
+		if err != nil {
+			return status.Errorf(codes.Internal, "could not decode signer indices: %v", err)
+		}
+
+		msg, err := convert.BlockHeaderToMessage(header, signerIDs)
+		if err != nil {
+			return status.Errorf(codes.Internal, "could not convert block header: %v", err)
+		}
+
+		return stream.Send(&statestream.SubscribeBlockHeadersResponse{
+			Header: msg,
+		})
+	})
+}
+
+// SubscribeEvents streams the events of sealed blocks matching the request's filter.
+func (h *Handler) SubscribeEvents(request *statestream.SubscribeEventsRequest, stream statestream.StateStreamAPI_SubscribeEventsServer) error {
+	release, err := h.acquireStream()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	startBlockID, err := startBlockIDFromMessage(request.GetStartBlockId())
+	if err != nil {
+		return err
+	}
+
+	filter := EventFilter{}
+	if f := request.GetFilter(); f != nil {
+		filter, err = NewEventFilter(h.chain, f.GetEventType(), f.GetAddress(), f.GetContract())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid event filter: %v", err)
+		}
+	}
+
+	sub := h.api.SubscribeEvents(stream.Context(), startBlockID, request.GetStartHeight(), filter)
+
+	return sendAll(sub, func(v interface{}) error {
+		resp, ok := v.(*EventsResponse)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected response type: %T", v)
+		}
+
+		return stream.Send(&statestream.SubscribeEventsResponse{
+			BlockId:        convert.IdentifierToMessage(resp.BlockID),
+			BlockHeight:    resp.Height,
+			BlockTimestamp: timestamppb.New(resp.BlockTimestamp),
+			Events:         convert.EventsToMessages(resp.Events),
+		})
+	})
+}
+
+// SubscribeExecutionData streams the execution data of sealed blocks.
+func (h *Handler) SubscribeExecutionData(request *statestream.SubscribeExecutionDataRequest, stream statestream.StateStreamAPI_SubscribeExecutionDataServer) error {
+	release, err := h.acquireStream()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	startBlockID, err := startBlockIDFromMessage(request.GetStartBlockId())
+	if err != nil {
+		return err
+	}
+
+	sub := h.api.SubscribeExecutionData(stream.Context(), startBlockID, request.GetStartHeight())
+
+	return sendAll(sub, func(v interface{}) error {
+		resp, ok := v.(*ExecutionDataResponse)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected response type: %T", v)
+		}
+
+		return stream.Send(&statestream.SubscribeExecutionDataResponse{
+			BlockHeight:   resp.Height,
+			ExecutionData: ExecutionDataToMessage(resp.ExecutionData),
+		})
+	})
+}
+
+// SubscribeTransactionStatuses streams the status transitions of a transaction.
+func (h *Handler) SubscribeTransactionStatuses(request *statestream.SubscribeTransactionStatusesRequest, stream statestream.StateStreamAPI_SubscribeTransactionStatusesServer) error {
+	release, err := h.acquireStream()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	txID, err := convert.TransactionID(request.GetTransactionId())
+	if err != nil {
+		return err
+	}
+
+	sub := h.api.SubscribeTransactionStatuses(stream.Context(), txID)
+
+	return sendAll(sub, sendTransactionStatus(stream))
+}
+
+// SendAndSubscribeTransactionStatuses sends a transaction to the network, and streams its status transitions.
+func (h *Handler) SendAndSubscribeTransactionStatuses(request *statestream.SendAndSubscribeTransactionStatusesRequest, stream statestream.StateStreamAPI_SendAndSubscribeTransactionStatusesServer) error {
+	release, err := h.acquireStream()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	tx, err := convert.MessageToTransaction(request.GetTransaction(), h.chain)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sub := h.api.SendAndSubscribeTransactionStatuses(stream.Context(), &tx)
+
+	return sendAll(sub, sendTransactionStatus(stream))
+}
+
+// sendTransactionStatus returns a function that sends a transaction status response on the given stream.
+func sendTransactionStatus(stream statestream.StateStreamAPI_SubscribeTransactionStatusesServer) func(interface{}) error {
+	return func(v interface{}) error {
+		result, ok := v.(*access.TransactionResult)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected response type: %T", v)
+		}
+
+		return stream.Send(TransactionStatusToMessage(result))
+	}
+}
+
+// acquireStream reserves one of the available streams, and returns a function that releases it.
+// Expected errors:
+// - codes.ResourceExhausted: if the max number of streams is already open
+func (h *Handler) acquireStream() (func(), error) {
+	if h.streamCount.Inc() > h.maxStreams {
+		h.streamCount.Dec()
+		return nil, status.Errorf(codes.ResourceExhausted, "maximum number of streams reached")
+	}
+	return func() { h.streamCount.Dec() }, nil
+}
+
+// sendAll reads all responses from the subscription and sends them using the given send function.
+// It returns when the subscription is closed or sending fails.
+func sendAll(sub Subscription, send func(interface{}) error) error {
+	for v := range sub.Channel() {
+		err := send(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := sub.Err(); err != nil {
+		return wrapStatusError(err, fmt.Sprintf("stream %s encountered an error", sub.ID()))
+	}
+	return nil
+}
+
+// startBlockIDFromMessage converts the optional start block ID of a request.
+func startBlockIDFromMessage(msg []byte) (flow.Identifier, error) {
+	if len(msg) == 0 {
+		return flow.ZeroID, nil
+	}
+
+	blockID, err := convert.BlockID(msg)
+	if err != nil {
+		return flow.ZeroID, status.Errorf(codes.InvalidArgument, "invalid start block ID: %v", err)
+	}
+	return blockID, nil
+}
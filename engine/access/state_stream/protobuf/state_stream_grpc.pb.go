@@ -0,0 +1,408 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package statestream
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// StateStreamAPIClient is the client API for StateStreamAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StateStreamAPIClient interface {
+	// SubscribeBlockHeaders streams the headers of finalized or sealed blocks in
+	// ascending height order.
+	SubscribeBlockHeaders(ctx context.Context, in *SubscribeBlockHeadersRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeBlockHeadersClient, error)
+	// SubscribeEvents streams the events of sealed blocks in ascending height order,
+	// filtered by the given event filter. A response is sent for every block, even if
+	// no events matched the filter, so clients can track their progress.
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeEventsClient, error)
+	// SubscribeExecutionData streams the execution data of sealed blocks in ascending
+	// height order.
+	SubscribeExecutionData(ctx context.Context, in *SubscribeExecutionDataRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeExecutionDataClient, error)
+	// SubscribeTransactionStatuses streams the status transitions of a transaction.
+	// The first response contains the current status of the transaction, and a
+	// response is sent for every following transition. The stream is closed after the
+	// transaction is sealed or expired, and the last response contains the final
+	// result including its events.
+	SubscribeTransactionStatuses(ctx context.Context, in *SubscribeTransactionStatusesRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeTransactionStatusesClient, error)
+	// SendAndSubscribeTransactionStatuses sends a transaction to the network, and
+	// streams its status transitions like SubscribeTransactionStatuses.
+	SendAndSubscribeTransactionStatuses(ctx context.Context, in *SendAndSubscribeTransactionStatusesRequest, opts ...grpc.CallOption) (StateStreamAPI_SendAndSubscribeTransactionStatusesClient, error)
+}
+
+type stateStreamAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStateStreamAPIClient(cc grpc.ClientConnInterface) StateStreamAPIClient {
+	return &stateStreamAPIClient{cc}
+}
+
+func (c *stateStreamAPIClient) SubscribeBlockHeaders(ctx context.Context, in *SubscribeBlockHeadersRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeBlockHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StateStreamAPI_ServiceDesc.Streams[0], "/statestream.StateStreamAPI/SubscribeBlockHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateStreamAPISubscribeBlockHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateStreamAPI_SubscribeBlockHeadersClient interface {
+	Recv() (*SubscribeBlockHeadersResponse, error)
+	grpc.ClientStream
+}
+
+type stateStreamAPISubscribeBlockHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateStreamAPISubscribeBlockHeadersClient) Recv() (*SubscribeBlockHeadersResponse, error) {
+	m := new(SubscribeBlockHeadersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stateStreamAPIClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StateStreamAPI_ServiceDesc.Streams[1], "/statestream.StateStreamAPI/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateStreamAPISubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateStreamAPI_SubscribeEventsClient interface {
+	Recv() (*SubscribeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type stateStreamAPISubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateStreamAPISubscribeEventsClient) Recv() (*SubscribeEventsResponse, error) {
+	m := new(SubscribeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stateStreamAPIClient) SubscribeExecutionData(ctx context.Context, in *SubscribeExecutionDataRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeExecutionDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StateStreamAPI_ServiceDesc.Streams[2], "/statestream.StateStreamAPI/SubscribeExecutionData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateStreamAPISubscribeExecutionDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateStreamAPI_SubscribeExecutionDataClient interface {
+	Recv() (*SubscribeExecutionDataResponse, error)
+	grpc.ClientStream
+}
+
+type stateStreamAPISubscribeExecutionDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateStreamAPISubscribeExecutionDataClient) Recv() (*SubscribeExecutionDataResponse, error) {
+	m := new(SubscribeExecutionDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stateStreamAPIClient) SubscribeTransactionStatuses(ctx context.Context, in *SubscribeTransactionStatusesRequest, opts ...grpc.CallOption) (StateStreamAPI_SubscribeTransactionStatusesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StateStreamAPI_ServiceDesc.Streams[3], "/statestream.StateStreamAPI/SubscribeTransactionStatuses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateStreamAPISubscribeTransactionStatusesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateStreamAPI_SubscribeTransactionStatusesClient interface {
+	Recv() (*SubscribeTransactionStatusesResponse, error)
+	grpc.ClientStream
+}
+
+type stateStreamAPISubscribeTransactionStatusesClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateStreamAPISubscribeTransactionStatusesClient) Recv() (*SubscribeTransactionStatusesResponse, error) {
+	m := new(SubscribeTransactionStatusesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stateStreamAPIClient) SendAndSubscribeTransactionStatuses(ctx context.Context, in *SendAndSubscribeTransactionStatusesRequest, opts ...grpc.CallOption) (StateStreamAPI_SendAndSubscribeTransactionStatusesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StateStreamAPI_ServiceDesc.Streams[4], "/statestream.StateStreamAPI/SendAndSubscribeTransactionStatuses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateStreamAPISendAndSubscribeTransactionStatusesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateStreamAPI_SendAndSubscribeTransactionStatusesClient interface {
+	Recv() (*SubscribeTransactionStatusesResponse, error)
+	grpc.ClientStream
+}
+
+type stateStreamAPISendAndSubscribeTransactionStatusesClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateStreamAPISendAndSubscribeTransactionStatusesClient) Recv() (*SubscribeTransactionStatusesResponse, error) {
+	m := new(SubscribeTransactionStatusesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StateStreamAPIServer is the server API for StateStreamAPI service.
+// All implementations must embed UnimplementedStateStreamAPIServer
+// for forward compatibility
+type StateStreamAPIServer interface {
+	// SubscribeBlockHeaders streams the headers of finalized or sealed blocks in
+	// ascending height order.
+	SubscribeBlockHeaders(*SubscribeBlockHeadersRequest, StateStreamAPI_SubscribeBlockHeadersServer) error
+	// SubscribeEvents streams the events of sealed blocks in ascending height order,
+	// filtered by the given event filter. A response is sent for every block, even if
+	// no events matched the filter, so clients can track their progress.
+	SubscribeEvents(*SubscribeEventsRequest, StateStreamAPI_SubscribeEventsServer) error
+	// SubscribeExecutionData streams the execution data of sealed blocks in ascending
+	// height order.
+	SubscribeExecutionData(*SubscribeExecutionDataRequest, StateStreamAPI_SubscribeExecutionDataServer) error
+	// SubscribeTransactionStatuses streams the status transitions of a transaction.
+	// The first response contains the current status of the transaction, and a
+	// response is sent for every following transition. The stream is closed after the
+	// transaction is sealed or expired, and the last response contains the final
+	// result including its events.
+	SubscribeTransactionStatuses(*SubscribeTransactionStatusesRequest, StateStreamAPI_SubscribeTransactionStatusesServer) error
+	// SendAndSubscribeTransactionStatuses sends a transaction to the network, and
+	// streams its status transitions like SubscribeTransactionStatuses.
+	SendAndSubscribeTransactionStatuses(*SendAndSubscribeTransactionStatusesRequest, StateStreamAPI_SendAndSubscribeTransactionStatusesServer) error
+	mustEmbedUnimplementedStateStreamAPIServer()
+}
+
+// UnimplementedStateStreamAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedStateStreamAPIServer struct {
+}
+
+func (UnimplementedStateStreamAPIServer) SubscribeBlockHeaders(*SubscribeBlockHeadersRequest, StateStreamAPI_SubscribeBlockHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlockHeaders not implemented")
+}
+func (UnimplementedStateStreamAPIServer) SubscribeEvents(*SubscribeEventsRequest, StateStreamAPI_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedStateStreamAPIServer) SubscribeExecutionData(*SubscribeExecutionDataRequest, StateStreamAPI_SubscribeExecutionDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeExecutionData not implemented")
+}
+func (UnimplementedStateStreamAPIServer) SubscribeTransactionStatuses(*SubscribeTransactionStatusesRequest, StateStreamAPI_SubscribeTransactionStatusesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransactionStatuses not implemented")
+}
+func (UnimplementedStateStreamAPIServer) SendAndSubscribeTransactionStatuses(*SendAndSubscribeTransactionStatusesRequest, StateStreamAPI_SendAndSubscribeTransactionStatusesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SendAndSubscribeTransactionStatuses not implemented")
+}
+func (UnimplementedStateStreamAPIServer) mustEmbedUnimplementedStateStreamAPIServer() {}
+
+// UnsafeStateStreamAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StateStreamAPIServer will
+// result in compilation errors.
+type UnsafeStateStreamAPIServer interface {
+	mustEmbedUnimplementedStateStreamAPIServer()
+}
+
+func RegisterStateStreamAPIServer(s grpc.ServiceRegistrar, srv StateStreamAPIServer) {
+	s.RegisterService(&StateStreamAPI_ServiceDesc, srv)
+}
+
+func _StateStreamAPI_SubscribeBlockHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlockHeadersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateStreamAPIServer).SubscribeBlockHeaders(m, &stateStreamAPISubscribeBlockHeadersServer{stream})
+}
+
+type StateStreamAPI_SubscribeBlockHeadersServer interface {
+	Send(*SubscribeBlockHeadersResponse) error
+	grpc.ServerStream
+}
+
+type stateStreamAPISubscribeBlockHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateStreamAPISubscribeBlockHeadersServer) Send(m *SubscribeBlockHeadersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StateStreamAPI_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateStreamAPIServer).SubscribeEvents(m, &stateStreamAPISubscribeEventsServer{stream})
+}
+
+type StateStreamAPI_SubscribeEventsServer interface {
+	Send(*SubscribeEventsResponse) error
+	grpc.ServerStream
+}
+
+type stateStreamAPISubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateStreamAPISubscribeEventsServer) Send(m *SubscribeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StateStreamAPI_SubscribeExecutionData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeExecutionDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateStreamAPIServer).SubscribeExecutionData(m, &stateStreamAPISubscribeExecutionDataServer{stream})
+}
+
+type StateStreamAPI_SubscribeExecutionDataServer interface {
+	Send(*SubscribeExecutionDataResponse) error
+	grpc.ServerStream
+}
+
+type stateStreamAPISubscribeExecutionDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateStreamAPISubscribeExecutionDataServer) Send(m *SubscribeExecutionDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StateStreamAPI_SubscribeTransactionStatuses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTransactionStatusesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateStreamAPIServer).SubscribeTransactionStatuses(m, &stateStreamAPISubscribeTransactionStatusesServer{stream})
+}
+
+type StateStreamAPI_SubscribeTransactionStatusesServer interface {
+	Send(*SubscribeTransactionStatusesResponse) error
+	grpc.ServerStream
+}
+
+type stateStreamAPISubscribeTransactionStatusesServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateStreamAPISubscribeTransactionStatusesServer) Send(m *SubscribeTransactionStatusesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StateStreamAPI_SendAndSubscribeTransactionStatuses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SendAndSubscribeTransactionStatusesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateStreamAPIServer).SendAndSubscribeTransactionStatuses(m, &stateStreamAPISendAndSubscribeTransactionStatusesServer{stream})
+}
+
+type StateStreamAPI_SendAndSubscribeTransactionStatusesServer interface {
+	Send(*SubscribeTransactionStatusesResponse) error
+	grpc.ServerStream
+}
+
+type stateStreamAPISendAndSubscribeTransactionStatusesServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateStreamAPISendAndSubscribeTransactionStatusesServer) Send(m *SubscribeTransactionStatusesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// StateStreamAPI_ServiceDesc is the grpc.ServiceDesc for StateStreamAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StateStreamAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "statestream.StateStreamAPI",
+	HandlerType: (*StateStreamAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlockHeaders",
+			Handler:       _StateStreamAPI_SubscribeBlockHeaders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _StateStreamAPI_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeExecutionData",
+			Handler:       _StateStreamAPI_SubscribeExecutionData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactionStatuses",
+			Handler:       _StateStreamAPI_SubscribeTransactionStatuses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SendAndSubscribeTransactionStatuses",
+			Handler:       _StateStreamAPI_SendAndSubscribeTransactionStatuses_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "state_stream.proto",
+}
@@ -0,0 +1,132 @@
+package state_stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Streamer streams data from a Streamable subscription to its receiver. It sends all data that is
+// currently available, then waits for a notification from the broadcaster before looking for more.
+//
+// The Streamer applies backpressure to slow clients: it only retrieves the data for the next height
+// once the previous response was accepted into the subscription's buffer. A client that does not
+// accept a response within the send timeout is disconnected. Optionally, the number of responses per
+// second can be limited.
+type Streamer struct {
+	log         zerolog.Logger
+	broadcaster *engine.Broadcaster
+	sendTimeout time.Duration
+	limiter     *rate.Limiter
+	sub         Streamable
+}
+
+// NewStreamer creates a new Streamer for the given subscription.
+// If limit is greater than 0, at most limit responses per second are sent.
+func NewStreamer(
+	log zerolog.Logger,
+	broadcaster *engine.Broadcaster,
+	sendTimeout time.Duration,
+	limit float64,
+	sub Streamable,
+) *Streamer {
+	var limiter *rate.Limiter
+	if limit > 0 {
+		// allows for 1 response per call, averaging `limit` responses per second over longer time frames
+		limiter = rate.NewLimiter(rate.Limit(limit), 1)
+	}
+
+	return &Streamer{
+		log:         log.With().Str("sub_id", sub.ID()).Logger(),
+		broadcaster: broadcaster,
+		sendTimeout: sendTimeout,
+		limiter:     limiter,
+		sub:         sub,
+	}
+}
+
+// Stream is a blocking method that streams data to the subscription until either the context is
+// cancelled, all data was sent, or it encounters an error. The subscription is always closed when Stream returns.
+func (s *Streamer) Stream(ctx context.Context) {
+	s.log.Debug().Msg("starting streaming")
+	defer s.log.Debug().Msg("finished streaming")
+
+	notifier := engine.NewNotifier()
+	s.broadcaster.Subscribe(notifier)
+	defer s.broadcaster.Unsubscribe(notifier)
+
+	// always check the first time. This sends all data that is already available (e.g. when a
+	// client resumes from a past height) without waiting for the next notification.
+	notifier.Notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.sub.Fail(fmt.Errorf("client disconnected: %w", ctx.Err()))
+			return
+		case <-notifier.Channel():
+			s.log.Debug().Msg("received broadcast notification")
+		}
+
+		err := s.sendAllAvailable(ctx)
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, ErrEndOfData) {
+			s.log.Debug().Msg("sent all data")
+			s.sub.Close()
+			return
+		}
+
+		if ctx.Err() != nil {
+			s.sub.Fail(fmt.Errorf("client disconnected: %w", ctx.Err()))
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			s.sub.Fail(status.Errorf(codes.DeadlineExceeded, "client did not accept response within %s", s.sendTimeout))
+			return
+		}
+
+		s.log.Err(err).Msg("error sending response")
+		s.sub.Fail(wrapStatusError(err, "could not send response"))
+		return
+	}
+}
+
+// sendAllAvailable reads data from the subscription and sends it to the receiver until no more
+// data is available.
+func (s *Streamer) sendAllAvailable(ctx context.Context) error {
+	for {
+		response, err := s.sub.Next(ctx)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				// no more data available to send
+				return nil
+			}
+			return fmt.Errorf("could not get response: %w", err)
+		}
+
+		if s.limiter != nil {
+			err = s.limiter.Wait(ctx)
+			if err != nil {
+				return fmt.Errorf("rate limit wait failed: %w", err)
+			}
+		}
+
+		err = s.sub.Send(ctx, response, s.sendTimeout)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package state_stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrEndOfData is returned by a Streamable's Next method when all of the subscription's data was
+// returned. The subscription is then closed without an error.
+var ErrEndOfData = errors.New("end of data")
+
+// GetDataByHeightFunc is a callback used by subscriptions to retrieve data for a given height.
+// Expected errors:
+// - storage.ErrNotFound: if the data for the given height is not available yet
+// All other errors are considered exceptions
+type GetDataByHeightFunc func(ctx context.Context, height uint64) (interface{}, error)
+
+// Subscription represents a streaming request, and handles the communication between the grpc
+// (or websocket) handler and the backend implementation.
+type Subscription interface {
+	// ID returns the unique identifier for this subscription used for logging
+	ID() string
+
+	// Channel returns the channel from which subscription data can be read
+	Channel() <-chan interface{}
+
+	// Err returns the error that caused the subscription to fail, or nil if the subscription
+	// was closed without an error
+	Err() error
+}
+
+// Streamable represents a subscription that can be streamed by a Streamer.
+type Streamable interface {
+	// ID returns the subscription ID
+	ID() string
+
+	// Close closes the subscription
+	Close()
+
+	// Fail registers an error and closes the subscription
+	Fail(error)
+
+	// Send sends a value to the subscription channel, or returns an error if the value
+	// could not be sent before the timeout elapsed
+	Send(context.Context, interface{}, time.Duration) error
+
+	// Next returns the next value from the subscription
+	// Expected errors:
+	// - storage.ErrNotFound: if the next value is not available yet
+	// - ErrEndOfData: if there are no more values to stream
+	Next(context.Context) (interface{}, error)
+}
+
+var _ Subscription = (*SubscriptionImpl)(nil)
+
+// SubscriptionImpl is the base implementation of a Subscription. The channel is buffered
+// with the configured send buffer size, so a client can fall behind by at most that many
+// responses before the backend starts blocking on Send.
+type SubscriptionImpl struct {
+	id string
+
+	// ch is the channel used to pass data to the receiver
+	ch chan interface{}
+
+	// err is the error that caused the subscription to fail
+	err error
+
+	// once is used to ensure that the channel is only closed once
+	once sync.Once
+
+	// closed tracks whether or not the subscription has been closed
+	closed bool
+
+	// mu protects closed and err, and ensures no values are sent on the channel after it is closed
+	mu sync.RWMutex
+}
+
+// NewSubscription returns a new subscription with a channel buffered with the given size.
+func NewSubscription(bufferSize uint) *SubscriptionImpl {
+	return &SubscriptionImpl{
+		id: uuid.New().String(),
+		ch: make(chan interface{}, bufferSize),
+	}
+}
+
+// ID returns the subscription ID
+func (sub *SubscriptionImpl) ID() string {
+	return sub.id
+}
+
+// Channel returns the channel from which subscription data can be read
+func (sub *SubscriptionImpl) Channel() <-chan interface{} {
+	return sub.ch
+}
+
+// Err returns the error that caused the subscription to fail
+func (sub *SubscriptionImpl) Err() error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+
+	return sub.err
+}
+
+// Fail registers an error and closes the subscription channel
+func (sub *SubscriptionImpl) Fail(err error) {
+	sub.mu.Lock()
+	sub.err = err
+	sub.mu.Unlock()
+
+	sub.Close()
+}
+
+// Close is called when a subscription ends gracefully, and closes the subscription channel
+func (sub *SubscriptionImpl) Close() {
+	sub.once.Do(func() {
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
+
+		close(sub.ch)
+		sub.closed = true
+	})
+}
+
+// Send sends a value to the subscription channel or returns an error.
+// This is the mechanism used to apply backpressure: the call blocks while the subscription's
+// buffer is full, and fails if the receiver does not make room before the timeout elapses.
+// Expected errors:
+// - context.DeadlineExceeded if the send timed out
+// - context.Canceled if the client disconnected
+func (sub *SubscriptionImpl) Send(ctx context.Context, v interface{}, timeout time.Duration) error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+
+	if sub.closed {
+		return fmt.Errorf("subscription closed")
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case <-waitCtx.Done():
+		return waitCtx.Err()
+	case sub.ch <- v:
+		return nil
+	}
+}
+
+// NewFailedSubscription returns a new subscription that has already failed with the given error and
+// message. This is useful to return an error that occurred during subscription setup.
+func NewFailedSubscription(err error, msg string) *SubscriptionImpl {
+	sub := NewSubscription(0)
+
+	// if error is a grpc error, wrap it to preserve the error code
+	sub.Fail(wrapStatusError(err, msg))
+
+	return sub
+}
+
+var _ Subscription = (*HeightBasedSubscription)(nil)
+var _ Streamable = (*HeightBasedSubscription)(nil)
+
+// HeightBasedSubscription is a subscription that retrieves data sequentially by block height
+type HeightBasedSubscription struct {
+	*SubscriptionImpl
+	nextHeight uint64
+	getData    GetDataByHeightFunc
+}
+
+// NewHeightBasedSubscription returns a subscription that starts streaming at the given height
+// and uses getData to retrieve the data for each height.
+func NewHeightBasedSubscription(bufferSize uint, firstHeight uint64, getData GetDataByHeightFunc) *HeightBasedSubscription {
+	return &HeightBasedSubscription{
+		SubscriptionImpl: NewSubscription(bufferSize),
+		nextHeight:       firstHeight,
+		getData:          getData,
+	}
+}
+
+// Next returns the value for the next height from the subscription.
+// The height is only advanced if the data was retrieved successfully, so a height that is
+// not available yet is retried on the next call.
+func (s *HeightBasedSubscription) Next(ctx context.Context) (interface{}, error) {
+	v, err := s.getData(ctx, s.nextHeight)
+	if err != nil {
+		return nil, fmt.Errorf("could not get data for height %d: %w", s.nextHeight, err)
+	}
+	s.nextHeight++
+	return v, nil
+}
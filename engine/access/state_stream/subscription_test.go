@@ -0,0 +1,146 @@
+package state_stream_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSubscription_SendReceive tests that data sent to a subscription can be received
+func TestSubscription_SendReceive(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sub := state_stream.NewSubscription(1)
+
+	assert.NotEmpty(t, sub.ID())
+
+	messageCount := 20
+	messages := []string{}
+	for i := 0; i < messageCount; i++ {
+		messages = append(messages, fmt.Sprintf("test messages %d", i))
+	}
+	receivedCount := 0
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	// receive each message and validate it has the expected value
+	go func() {
+		defer wg.Done()
+
+		for v := range sub.Channel() {
+			assert.Equal(t, messages[receivedCount], v)
+			receivedCount++
+		}
+	}()
+
+	// send all messages in order
+	for _, d := range messages {
+		err := sub.Send(ctx, d, 10*time.Second)
+		require.NoError(t, err)
+	}
+	sub.Close()
+
+	unittest.RequireReturnsBefore(t, wg.Wait, 100*time.Millisecond, "received never returned")
+
+	require.NoError(t, sub.Err())
+	assert.Equal(t, messageCount, receivedCount)
+}
+
+// TestSubscription_Failures tests closing and failing subscriptions behaves as expected
+func TestSubscription_Failures(t *testing.T) {
+	t.Parallel()
+
+	testErr := fmt.Errorf("test error")
+
+	// make sure closing a subscription twice does not cause a panic
+	t.Run("close only called once", func(t *testing.T) {
+		sub := state_stream.NewSubscription(1)
+		sub.Close()
+		sub.Close()
+
+		assert.NoError(t, sub.Err())
+	})
+
+	// make sure failing and closing the same subscription does not cause a panic
+	t.Run("close only called once with fail", func(t *testing.T) {
+		sub := state_stream.NewSubscription(1)
+		sub.Fail(testErr)
+		sub.Close()
+
+		assert.ErrorIs(t, sub.Err(), testErr)
+	})
+
+	// make sure an error is returned when sending on a closed subscription
+	t.Run("send after closed returns an error", func(t *testing.T) {
+		sub := state_stream.NewSubscription(1)
+		sub.Fail(testErr)
+
+		err := sub.Send(context.Background(), "test", 10*time.Millisecond)
+		assert.Error(t, err, "expected subscription closed error")
+
+		assert.ErrorIs(t, sub.Err(), testErr)
+	})
+
+	// make sure send times out if the receiver does not accept the value
+	t.Run("send times out when buffer is full", func(t *testing.T) {
+		sub := state_stream.NewSubscription(1)
+
+		err := sub.Send(context.Background(), "first", 10*time.Millisecond)
+		require.NoError(t, err)
+
+		err = sub.Send(context.Background(), "second", 10*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// TestHeightBasedSubscription tests that the height based subscription only advances the height
+// after data was successfully retrieved
+func TestHeightBasedSubscription(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := uint64(3)
+	last := uint64(10)
+
+	errNoData := fmt.Errorf("test error: %w", storage.ErrNotFound)
+
+	getData := func(_ context.Context, height uint64) (interface{}, error) {
+		if height >= last {
+			return nil, errNoData
+		}
+		return height, nil
+	}
+
+	sub := state_stream.NewHeightBasedSubscription(1, start, getData)
+
+	// loop over all heights that have data
+	for i := start; i < last; i++ {
+		data, err := sub.Next(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, i, data.(uint64))
+	}
+
+	// the height is not advanced while no data is available
+	for i := 0; i < 2; i++ {
+		data, err := sub.Next(ctx)
+		assert.Nil(t, data)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	}
+
+	last++
+	data, err := sub.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, last-1, data.(uint64))
+}
@@ -0,0 +1,11 @@
+package wrapper
+
+import (
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+)
+
+// RegisterProofAPIClient allows for generation of a mock (via mockery) for the RegisterProofAPIClient generated from
+// the register proof protobuf definitions of the execution node
+type RegisterProofAPIClient interface {
+	registerproof.RegisterProofAPIClient
+}
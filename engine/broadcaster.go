@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"sync"
+)
+
+// Broadcaster is a concurrency primitive for informing an arbitrary number of
+// worker routines about the arrival of new work unit(s). Each worker registers
+// its own Notifier, and every call to Publish activates all registered Notifiers.
+// As with the Notifier, notifications are not queued: a worker that is busy while
+// several notifications are published observes a single pending notification.
+type Broadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[Notifier]struct{}
+}
+
+// NewBroadcaster creates a new Broadcaster without any subscribers.
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscribers: make(map[Notifier]struct{}),
+	}
+}
+
+// Subscribe adds the given Notifier to the set of notifiers activated on Publish.
+// Subscribing the same Notifier multiple times is a no-op.
+func (b *Broadcaster) Subscribe(n Notifier) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[n] = struct{}{}
+}
+
+// Unsubscribe removes the given Notifier. Unsubscribing a Notifier that
+// was never subscribed is a no-op.
+func (b *Broadcaster) Unsubscribe(n Notifier) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers, n)
+}
+
+// Publish sends a notification to all subscribers. It never blocks.
+func (b *Broadcaster) Publish() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for n := range b.subscribers {
+		n.Notify()
+	}
+}
+
+// Size returns the number of current subscribers.
+func (b *Broadcaster) Size() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers)
+}
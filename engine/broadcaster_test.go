@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcaster_PublishNotifiesAll verifies that every subscribed Notifier receives
+// a notification when Publish is called.
+func TestBroadcaster_PublishNotifiesAll(t *testing.T) {
+	t.Parallel()
+	b := NewBroadcaster()
+
+	notifiers := make([]Notifier, 10)
+	for i := range notifiers {
+		notifiers[i] = NewNotifier()
+		b.Subscribe(notifiers[i])
+	}
+	require.Equal(t, len(notifiers), b.Size())
+
+	b.Publish()
+
+	for _, n := range notifiers {
+		select {
+		case <-n.Channel(): // expected
+		default:
+			t.Fail()
+		}
+	}
+}
+
+// TestBroadcaster_Unsubscribe verifies that unsubscribed notifiers no longer receive notifications.
+func TestBroadcaster_Unsubscribe(t *testing.T) {
+	t.Parallel()
+	b := NewBroadcaster()
+
+	n1 := NewNotifier()
+	n2 := NewNotifier()
+	b.Subscribe(n1)
+	b.Subscribe(n2)
+	b.Subscribe(n2) // duplicate subscriptions are ignored
+	assert.Equal(t, 2, b.Size())
+
+	b.Unsubscribe(n1)
+	assert.Equal(t, 1, b.Size())
+
+	b.Publish()
+
+	select {
+	case <-n1.Channel():
+		t.Fail()
+	default: // expected
+	}
+
+	select {
+	case <-n2.Channel(): // expected
+	default:
+		t.Fail()
+	}
+}
+
+// TestBroadcaster_ConcurrentAccess verifies that concurrent subscriptions and publications are safe.
+func TestBroadcaster_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+	b := NewBroadcaster()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			n := NewNotifier()
+			b.Subscribe(n)
+			b.Unsubscribe(n)
+		}()
+		go func() {
+			defer wg.Done()
+			b.Publish()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, b.Size())
+}
@@ -332,8 +332,8 @@ func (e *Engine) SendVote(blockID flow.Identifier, view uint64, sigData []byte,
 
 	// TODO: this is a hot-fix to mitigate the effects of the following Unicast call blocking occasionally
 	e.unit.Launch(func() {
-		// send the vote the desired recipient
-		err := e.con.Unicast(vote, recipientID)
+		// send the vote the desired recipient, votes are latency-critical and bypass the outbound queues
+		err := e.con.UnicastLatencyCritical(vote, recipientID)
 		if err != nil {
 			log.Warn().Err(err).Msg("could not send vote")
 			return
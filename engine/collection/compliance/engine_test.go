@@ -117,6 +117,7 @@ func (cs *ComplianceSuite) SetupTest() {
 	cs.con.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	cs.con.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	cs.con.On("Unicast", mock.Anything, mock.Anything).Return(nil)
+	cs.con.On("UnicastLatencyCritical", mock.Anything, mock.Anything).Return(nil)
 
 	// set up network module mock
 	cs.net = &mocknetwork.Network{}
@@ -172,7 +173,7 @@ func (cs *ComplianceSuite) TestSendVote() {
 		View:    view,
 		SigData: sig,
 	}
-	cs.con.AssertCalled(cs.T(), "Unicast", &vote, recipientID)
+	cs.con.AssertCalled(cs.T(), "UnicastLatencyCritical", &vote, recipientID)
 }
 
 // TestBroadcastProposalWithDelay tests broadcasting proposals with different
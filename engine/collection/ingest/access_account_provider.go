@@ -0,0 +1,56 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccessClient is the subset of the Access API client used to retrieve accounts.
+type AccessClient interface {
+	GetAccountAtLatestBlock(ctx context.Context, address sdk.Address) (*sdk.Account, error)
+}
+
+// AccessAccountProvider is an AccountProvider which retrieves accounts as of the latest
+// block from an Access node.
+type AccessAccountProvider struct {
+	client AccessClient
+}
+
+var _ AccountProvider = (*AccessAccountProvider)(nil)
+
+// NewAccessAccountProvider creates a new account provider using the given Access API client.
+func NewAccessAccountProvider(client AccessClient) *AccessAccountProvider {
+	return &AccessAccountProvider{client: client}
+}
+
+// GetAccount returns the account with the given address as of the latest block known to the
+// Access node. Only the address, balance and keys of the account are populated.
+func (p *AccessAccountProvider) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := p.client.GetAccountAtLatestBlock(ctx, sdk.Address(address))
+	if err != nil {
+		return nil, fmt.Errorf("could not get account %s from access node: %w", address, err)
+	}
+
+	keys := make([]flow.AccountPublicKey, 0, len(account.Keys))
+	for _, key := range account.Keys {
+		keys = append(keys, flow.AccountPublicKey{
+			Index:     key.Index,
+			PublicKey: key.PublicKey,
+			SignAlgo:  key.SigAlgo,
+			HashAlgo:  key.HashAlgo,
+			SeqNumber: key.SequenceNumber,
+			Weight:    key.Weight,
+			Revoked:   key.Revoked,
+		})
+	}
+
+	return &flow.Account{
+		Address: address,
+		Balance: account.Balance,
+		Keys:    keys,
+	}, nil
+}
@@ -0,0 +1,166 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountProvider provides the latest known state of accounts, for example from an
+// Access node or a local execution state view.
+type AccountProvider interface {
+	// GetAccount returns the account with the given address.
+	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// AccountValidator performs the validation of transactions which requires account
+// state, which is not available to the access.TransactionValidator: it checks that
+// the proposal key exists with a sequence number that has not been used yet, and that
+// the payer has enough balance to pay for the transaction.
+//
+// Accounts are cached for a configurable time, hence the validation is best-effort:
+// transactions may still fail at execution if the account state changed in the meantime.
+// Since sequence numbers only increase, a cached sequence number never causes a valid
+// transaction to be rejected.
+//
+// Retrieving accounts must not hold up transaction ingestion: the validation of a transaction
+// is bounded by a short timeout, and if the configured number of account requests is already
+// in flight, the validation fails immediately rather than waiting.
+type AccountValidator struct {
+	accounts        AccountProvider
+	cache           *lru.Cache
+	cacheTTL        time.Duration
+	timeout         time.Duration
+	requests        chan struct{} // semaphore limiting the account requests in flight
+	minPayerBalance uint64
+	now             func() time.Time
+}
+
+// errTooManyAccountRequests is returned if an account is not cached and can't be retrieved,
+// as the maximum number of account requests is already in flight.
+var errTooManyAccountRequests = errors.New("too many concurrent account requests")
+
+// cachedAccount is an account in the cache of the validator, with the time it was retrieved.
+type cachedAccount struct {
+	account   *flow.Account
+	retrieved time.Time
+}
+
+// NewAccountValidator creates a new account validator, which retrieves accounts from the
+// given provider.
+func NewAccountValidator(accounts AccountProvider, config Config) (*AccountValidator, error) {
+	cache, err := lru.New(int(config.AccountCacheSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not create account cache: %w", err)
+	}
+
+	return &AccountValidator{
+		accounts:        accounts,
+		cache:           cache,
+		cacheTTL:        config.AccountCacheTTL,
+		timeout:         config.AccountRequestTimeout,
+		requests:        make(chan struct{}, config.MaxAccountRequests),
+		minPayerBalance: config.MinPayerBalance,
+		now:             time.Now,
+	}, nil
+}
+
+// Validate checks the transaction against the state of its proposer and payer accounts.
+//
+// Returns:
+// * access.InvalidProposalKeyError if the proposal key does not exist or is revoked.
+// * access.InvalidProposalSeqNumberError if the proposal sequence number has already been used.
+// * access.InsufficientBalanceError if the payer balance is below the configured minimum.
+// * other error if the accounts could not be retrieved within the timeout.
+func (v *AccountValidator) Validate(ctx context.Context, tx *flow.TransactionBody) error {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	proposer, err := v.account(ctx, tx.ProposalKey.Address)
+	if err != nil {
+		return fmt.Errorf("could not get proposer account: %w", err)
+	}
+	err = v.checkProposalKey(tx, proposer)
+	if err != nil {
+		return err
+	}
+
+	payer, err := v.account(ctx, tx.Payer)
+	if err != nil {
+		return fmt.Errorf("could not get payer account: %w", err)
+	}
+	if payer.Balance < v.minPayerBalance {
+		return access.InsufficientBalanceError{
+			Payer:   tx.Payer,
+			Actual:  payer.Balance,
+			Minimum: v.minPayerBalance,
+		}
+	}
+
+	return nil
+}
+
+func (v *AccountValidator) checkProposalKey(tx *flow.TransactionBody, proposer *flow.Account) error {
+	for _, key := range proposer.Keys {
+		if uint64(key.Index) != tx.ProposalKey.KeyIndex {
+			continue
+		}
+		if key.Revoked {
+			break
+		}
+		if tx.ProposalKey.SequenceNumber < key.SeqNumber {
+			return access.InvalidProposalSeqNumberError{
+				Address:  tx.ProposalKey.Address,
+				KeyIndex: tx.ProposalKey.KeyIndex,
+				Actual:   tx.ProposalKey.SequenceNumber,
+				Minimum:  key.SeqNumber,
+			}
+		}
+		return nil
+	}
+
+	return access.InvalidProposalKeyError{
+		Address:  tx.ProposalKey.Address,
+		KeyIndex: tx.ProposalKey.KeyIndex,
+	}
+}
+
+// account returns the account with the given address from the cache, or retrieves it from
+// the provider if it is not cached or the cached account has expired. It returns
+// errTooManyAccountRequests without waiting if no more account requests are allowed.
+func (v *AccountValidator) account(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	cached, ok := v.cache.Get(address)
+	if ok && v.now().Sub(cached.(cachedAccount).retrieved) < v.cacheTTL {
+		return cached.(cachedAccount).account, nil
+	}
+
+	select {
+	case v.requests <- struct{}{}:
+		defer func() { <-v.requests }()
+	default:
+		return nil, errTooManyAccountRequests
+	}
+
+	account, err := v.accounts.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	v.cache.Add(address, cachedAccount{account: account, retrieved: v.now()})
+	return account, nil
+}
+
+// isAccountValidationError returns whether the error returned by AccountValidator.Validate
+// indicates that the transaction is invalid.
+func isAccountValidationError(err error) bool {
+	var keyErr access.InvalidProposalKeyError
+	var seqNumErr access.InvalidProposalSeqNumberError
+	var balanceErr access.InsufficientBalanceError
+	return errors.As(err, &keyErr) || errors.As(err, &seqNumErr) || errors.As(err, &balanceErr)
+}
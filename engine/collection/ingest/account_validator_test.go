@@ -0,0 +1,152 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	mockingest "github.com/onflow/flow-go/engine/collection/ingest/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestAccountValidator tests that transactions are rejected if their proposal key is unknown, revoked or has a higher
+// sequence number, or if their payer balance is too low.
+func TestAccountValidator(t *testing.T) {
+	proposer := &flow.Account{
+		Address: unittest.RandomAddressFixture(),
+		Balance: 1000,
+		Keys: []flow.AccountPublicKey{
+			{Index: 0, SeqNumber: 5},
+			{Index: 1, SeqNumber: 0, Revoked: true},
+		},
+	}
+	payer := &flow.Account{
+		Address: unittest.RandomAddressFixture(),
+		Balance: 50,
+	}
+
+	config := DefaultConfig()
+	config.MinPayerBalance = 100
+
+	transaction := func(keyIndex uint64, seqNumber uint64, payer flow.Address) *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.ProposalKey = flow.ProposalKey{Address: proposer.Address, KeyIndex: keyIndex, SequenceNumber: seqNumber}
+			tx.Payer = payer
+		})
+		return &tx
+	}
+
+	accounts := mockingest.NewAccountProvider(t)
+	accounts.On("GetAccount", mock.Anything, proposer.Address).Return(proposer, nil).Once()
+	accounts.On("GetAccount", mock.Anything, payer.Address).Return(payer, nil).Once()
+	validator, err := NewAccountValidator(accounts, config)
+	require.NoError(t, err)
+
+	t.Run("valid transaction", func(t *testing.T) {
+		require.NoError(t, validator.Validate(context.Background(), transaction(0, 5, proposer.Address)))
+		// pending transactions of the proposer may use higher sequence numbers
+		require.NoError(t, validator.Validate(context.Background(), transaction(0, 7, proposer.Address)))
+	})
+
+	t.Run("used sequence number", func(t *testing.T) {
+		err := validator.Validate(context.Background(), transaction(0, 4, proposer.Address))
+		assert.True(t, errors.As(err, &access.InvalidProposalSeqNumberError{}))
+		assert.True(t, isAccountValidationError(err))
+	})
+
+	t.Run("unknown proposal key", func(t *testing.T) {
+		err := validator.Validate(context.Background(), transaction(2, 0, proposer.Address))
+		assert.True(t, errors.As(err, &access.InvalidProposalKeyError{}))
+	})
+
+	t.Run("revoked proposal key", func(t *testing.T) {
+		err := validator.Validate(context.Background(), transaction(1, 0, proposer.Address))
+		assert.True(t, errors.As(err, &access.InvalidProposalKeyError{}))
+	})
+
+	t.Run("insufficient payer balance", func(t *testing.T) {
+		err := validator.Validate(context.Background(), transaction(0, 5, payer.Address))
+		assert.True(t, errors.As(err, &access.InsufficientBalanceError{}))
+		assert.True(t, isAccountValidationError(err))
+	})
+}
+
+// TestAccountValidator_Cache tests that accounts are cached until the cache TTL has passed, and that failures to
+// retrieve accounts are not reported as invalid transactions.
+func TestAccountValidator_Cache(t *testing.T) {
+	account := &flow.Account{
+		Address: unittest.RandomAddressFixture(),
+		Keys:    []flow.AccountPublicKey{{Index: 0}},
+	}
+	tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.ProposalKey = flow.ProposalKey{Address: account.Address}
+		tx.Payer = account.Address
+	})
+
+	accounts := mockingest.NewAccountProvider(t)
+	validator, err := NewAccountValidator(accounts, DefaultConfig())
+	require.NoError(t, err)
+	now := time.Now()
+	validator.now = func() time.Time { return now }
+
+	// the account is retrieved once for the proposer and the payer
+	accounts.On("GetAccount", mock.Anything, account.Address).Return(account, nil).Once()
+	require.NoError(t, validator.Validate(context.Background(), &tx))
+	require.NoError(t, validator.Validate(context.Background(), &tx))
+
+	// once the TTL has passed, the account is retrieved again
+	now = now.Add(DefaultConfig().AccountCacheTTL)
+	accounts.On("GetAccount", mock.Anything, account.Address).Return(nil, errors.New("unavailable")).Once()
+	err = validator.Validate(context.Background(), &tx)
+	require.Error(t, err)
+	assert.False(t, isAccountValidationError(err))
+}
+
+// TestAccountValidator_MaxRequests tests that the validation fails immediately, rather than waiting, if the maximum
+// number of account requests is in flight.
+func TestAccountValidator_MaxRequests(t *testing.T) {
+	account := &flow.Account{
+		Address: unittest.RandomAddressFixture(),
+		Keys:    []flow.AccountPublicKey{{Index: 0}},
+	}
+	transaction := func() *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.ProposalKey = flow.ProposalKey{Address: unittest.RandomAddressFixture()}
+			tx.Payer = account.Address
+		})
+		return &tx
+	}
+
+	config := DefaultConfig()
+	config.MaxAccountRequests = 1
+	accounts := mockingest.NewAccountProvider(t)
+	validator, err := NewAccountValidator(accounts, config)
+	require.NoError(t, err)
+
+	// the first request blocks until its context times out
+	requested := make(chan struct{})
+	accounts.On("GetAccount", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		close(requested)
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil, context.DeadlineExceeded).Once()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := validator.Validate(context.Background(), transaction())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}()
+	unittest.RequireCloseBefore(t, requested, time.Second, "account was not requested")
+
+	err = validator.Validate(context.Background(), transaction())
+	require.ErrorIs(t, err, errTooManyAccountRequests)
+	assert.False(t, isAccountValidationError(err))
+
+	unittest.RequireCloseBefore(t, done, time.Second, "validation did not time out")
+}
@@ -1,6 +1,8 @@
 package ingest
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -22,6 +24,21 @@ type Config struct {
 	MaxCollectionByteSize uint64
 	// maximum number of un-processed transaction messages to hold in the queue.
 	MaxMessageQueueSize uint
+	// whether or not we check transactions against the state of their proposer and
+	// payer accounts, which requires an AccountProvider
+	CheckAccounts bool
+	// maximum number of accounts to cache for account checks
+	AccountCacheSize uint
+	// how long retrieved accounts are cached for account checks
+	AccountCacheTTL time.Duration
+	// timeout for retrieving the accounts of a transaction for account checks, after
+	// which the transaction is accepted without checks
+	AccountRequestTimeout time.Duration
+	// maximum number of account requests in flight for account checks, beyond which
+	// transactions are accepted without checks
+	MaxAccountRequests uint
+	// minimum balance of the payer of a transaction, if account checks are enabled
+	MinPayerBalance uint64
 }
 
 func DefaultConfig() Config {
@@ -33,5 +50,11 @@ func DefaultConfig() Config {
 		CheckScriptsParse:      true,
 		PropagationRedundancy:  2,
 		MaxMessageQueueSize:    10_000,
+		CheckAccounts:          false,
+		AccountCacheSize:       10_000,
+		AccountCacheTTL:        10 * time.Second,
+		AccountRequestTimeout:  200 * time.Millisecond,
+		MaxAccountRequests:     16,
+		MinPayerBalance:        0,
 	}
 }
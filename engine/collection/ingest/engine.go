@@ -38,6 +38,7 @@ type Engine struct {
 	messageHandler       *engine.MessageHandler
 	pools                *epochs.TransactionPools
 	transactionValidator *access.TransactionValidator
+	accountValidator     *AccountValidator // nil if account checks are disabled
 
 	config Config
 }
@@ -53,6 +54,7 @@ func New(
 	me module.Local,
 	chain flow.Chain,
 	pools *epochs.TransactionPools,
+	accounts AccountProvider,
 	config Config,
 ) (*Engine, error) {
 
@@ -71,6 +73,19 @@ func New(
 		},
 	)
 
+	// optionally, validate transactions against the state of their accounts
+	var accountValidator *AccountValidator
+	if config.CheckAccounts {
+		if accounts == nil {
+			return nil, fmt.Errorf("account checks require an account provider")
+		}
+		var err error
+		accountValidator, err = NewAccountValidator(accounts, config)
+		if err != nil {
+			return nil, fmt.Errorf("could not create account validator: %w", err)
+		}
+	}
+
 	// FIFO queue for transactions
 	queue, err := fifoqueue.NewFifoQueue(
 		fifoqueue.WithCapacity(int(config.MaxMessageQueueSize)),
@@ -110,6 +125,7 @@ func New(
 		pools:                pools,
 		config:               config,
 		transactionValidator: transactionValidator,
+		accountValidator:     accountValidator,
 	}
 
 	e.ComponentManager = component.NewComponentManagerBuilder().
@@ -149,7 +165,9 @@ func (e *Engine) Process(channel network.Channel, originID flow.Identifier, even
 
 // ProcessTransaction processes a transaction message submitted from another
 // local component. The transaction is validated and ingested synchronously.
-// This is used by the GRPC API, for transactions from Access nodes.
+// This is used by the GRPC API, for transactions from Access nodes. If the
+// transaction is rejected, the returned engine.InvalidInputError contains the
+// reason, which is reported back to the Access node.
 func (e *Engine) ProcessTransaction(tx *flow.TransactionBody) error {
 	// do not process transactions after the engine has shut down
 	select {
@@ -268,8 +286,11 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 		Logger()
 
 	// validate and ingest the transaction, so it is eligible for inclusion in
-	// a future collection proposed by this node
-	err = e.ingestTransaction(log, refEpoch, tx, txID, localClusterFingerPrint, txClusterFingerPrint)
+	// a future collection proposed by this node. Transactions from other collection
+	// nodes were already checked against their accounts by the node they were
+	// submitted to, so only transactions submitted by Access nodes are checked.
+	submitted := originID == e.me.NodeID()
+	err = e.ingestTransaction(log, refEpoch, tx, txID, localClusterFingerPrint, txClusterFingerPrint, submitted)
 	if err != nil {
 		return fmt.Errorf("could not ingest transaction: %w", err)
 	}
@@ -277,7 +298,7 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 	// if the message was submitted internally (ie. via the Access API)
 	// propagate it to members of the responsible cluster (either our cluster
 	// or a different cluster)
-	if originID == e.me.NodeID() {
+	if submitted {
 		e.propagateTransaction(log, tx, txCluster)
 	}
 
@@ -325,7 +346,9 @@ func (e *Engine) getLocalCluster(refEpoch protocol.Epoch) (flow.IdentityList, er
 }
 
 // ingestTransaction validates and ingests the transaction, if it is routed to
-// our local cluster, is valid, and has not been seen previously.
+// our local cluster, is valid, and has not been seen previously. If account checks
+// are enabled, transactions for our local cluster are checked against the state of
+// their accounts if checkAccounts is set.
 //
 // Returns:
 // * engine.InvalidInputError if the transaction is invalid.
@@ -337,6 +360,7 @@ func (e *Engine) ingestTransaction(
 	txID flow.Identifier,
 	localClusterFingerprint flow.Identifier,
 	txClusterFingerprint flow.Identifier,
+	checkAccounts bool,
 ) error {
 	epochCounter, err := refEpoch.Counter()
 	if err != nil {
@@ -358,8 +382,23 @@ func (e *Engine) ingestTransaction(
 		return engine.NewInvalidInputErrorf("invalid transaction (%x): %w", txID, err)
 	}
 
+	// check the transaction against the state of its accounts, if enabled and if we are
+	// going to include it in a collection
+	localTx := localClusterFingerprint == txClusterFingerprint
+	if localTx && checkAccounts && e.accountValidator != nil {
+		err = e.accountValidator.Validate(context.Background(), tx)
+		if isAccountValidationError(err) {
+			return engine.NewInvalidInputErrorf("invalid transaction (%x): %w", txID, err)
+		}
+		if err != nil {
+			// the account checks are best-effort, we accept the transaction if we
+			// can't retrieve the account state in time
+			log.Warn().Err(err).Msg("could not check transaction against account state")
+		}
+	}
+
 	// if our cluster is responsible for the transaction, add it to our local mempool
-	if localClusterFingerprint == txClusterFingerprint {
+	if localTx {
 		_ = pool.Add(tx)
 		e.colMetrics.TransactionIngested(txID)
 	}
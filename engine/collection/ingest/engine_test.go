@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/onflow/flow-go/access"
+	mockingest "github.com/onflow/flow-go/engine/collection/ingest/mock"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/factory"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -124,7 +125,7 @@ func (suite *Suite) SetupTest() {
 
 	suite.conf = DefaultConfig()
 	chain := flow.Testnet.Chain()
-	suite.engine, err = New(log, net, suite.state, metrics, metrics, metrics, suite.me, chain, suite.pools, suite.conf)
+	suite.engine, err = New(log, net, suite.state, metrics, metrics, metrics, suite.me, chain, suite.pools, nil, suite.conf)
 	suite.Require().NoError(err)
 }
 
@@ -286,6 +287,76 @@ func (suite *Suite) TestComponentShutdown() {
 	suite.Assert().ErrorIs(err, component.ErrComponentShutdown)
 }
 
+// should reject transactions which are invalid with respect to the state of their accounts, and
+// accept transactions if the account state is unavailable
+func (suite *Suite) TestAccountChecks() {
+
+	local, _, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+	suite.Require().True(ok)
+
+	accounts := mockingest.NewAccountProvider(suite.T())
+	net := new(mocknetwork.Network)
+	net.On("Register", mock.Anything, mock.Anything).Return(suite.conduit, nil).Once()
+	metrics := metrics.NewNoopCollector()
+	suite.conf.CheckAccounts = true
+	engine, err := New(zerolog.New(ioutil.Discard), net, suite.state, metrics, metrics, metrics, suite.me, flow.Testnet.Chain(), suite.pools, accounts, suite.conf)
+	suite.Require().NoError(err)
+
+	counter, err := suite.epochQuery.Current().Counter()
+	suite.Require().NoError(err)
+	suite.conduit.On("Multicast", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	suite.Run("used sequence number", func() {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+		accounts.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(&flow.Account{
+			Address: tx.ProposalKey.Address,
+			Keys:    []flow.AccountPublicKey{{Index: int(tx.ProposalKey.KeyIndex), SeqNumber: tx.ProposalKey.SequenceNumber + 1}},
+		}, nil).Once()
+
+		err := engine.ProcessTransaction(&tx)
+		suite.Assert().True(errors.As(err, &access.InvalidProposalSeqNumberError{}))
+		suite.Assert().False(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	})
+
+	suite.Run("account state unavailable", func() {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+		accounts.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(nil, errors.New("unavailable")).Once()
+
+		err := engine.ProcessTransaction(&tx)
+		suite.Assert().NoError(err)
+		suite.Assert().True(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	})
+
+	// the account provider mock fails the test on any unexpected account request
+	suite.Run("transaction for other cluster", func() {
+		_, index, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+		suite.Require().True(ok)
+		remote, ok := suite.clusters.ByIndex((index + 1) % suite.N_CLUSTERS)
+		suite.Require().True(ok)
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx = unittest.AlterTransactionForCluster(tx, suite.clusters, remote, func(transaction *flow.TransactionBody) {})
+
+		err := engine.ProcessTransaction(&tx)
+		suite.Assert().NoError(err)
+	})
+
+	suite.Run("transaction from other collection node", func() {
+		sender := local.Filter(filter.Not(filter.HasNodeID(suite.me.NodeID())))[0]
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+
+		err := engine.onTransaction(sender.NodeID, &tx)
+		suite.Assert().NoError(err)
+		suite.Assert().True(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	})
+}
+
 // should store transactions for local cluster and propagate to other cluster members
 func (suite *Suite) TestRoutingLocalCluster() {
 
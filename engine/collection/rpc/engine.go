@@ -159,6 +159,10 @@ func (h *handler) SendTransaction(_ context.Context, req *access.SendTransaction
 	}
 
 	err = h.backend.ProcessTransaction(&tx)
+	if engine.IsInvalidInputError(err) {
+		// report the reason the transaction was rejected back to the submitter
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
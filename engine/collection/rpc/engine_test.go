@@ -8,8 +8,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/onflow/flow/protobuf/go/flow/access"
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine"
 	rpcmock "github.com/onflow/flow-go/engine/collection/rpc/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
@@ -29,7 +33,7 @@ func TestSubmitTransaction(t *testing.T) {
 	t.Run("should submit transaction to engine", func(t *testing.T) {
 		backend.On("ProcessTransaction", &tx).Return(nil).Once()
 
-		res, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
+		res, err := h.SendTransaction(context.Background(), &accessproto.SendTransactionRequest{
 			Transaction: convert.TransactionToMessage(tx),
 		})
 		require.NoError(t, err)
@@ -45,7 +49,7 @@ func TestSubmitTransaction(t *testing.T) {
 		expected := errors.New("error")
 		backend.On("ProcessTransaction", &tx).Return(expected).Once()
 
-		res, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
+		res, err := h.SendTransaction(context.Background(), &accessproto.SendTransactionRequest{
 			Transaction: convert.TransactionToMessage(tx),
 		})
 		if assert.Error(t, err) {
@@ -58,4 +62,16 @@ func TestSubmitTransaction(t *testing.T) {
 		// should only return the error
 		assert.Nil(t, res)
 	})
+	t.Run("should report rejection reason", func(t *testing.T) {
+		rejected := engine.NewInvalidInputErrorf("invalid transaction: %w", access.InsufficientBalanceError{Payer: tx.Payer})
+		backend.On("ProcessTransaction", &tx).Return(rejected).Once()
+
+		res, err := h.SendTransaction(context.Background(), &accessproto.SendTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "balance")
+		assert.Nil(t, res)
+	})
 }
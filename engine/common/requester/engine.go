@@ -415,6 +415,7 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 			return fmt.Errorf("could not get providers: %w", err)
 		}
 		if len(providers) == 0 {
+			e.con.ReportMisbehavior(originID, network.OffenseUnauthorizedSender)
 			return engine.NewInvalidInputErrorf("invalid provider origin (%x)", originID)
 		}
 	}
@@ -432,6 +433,7 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 
 	// ensure the response is correctly formed
 	if len(res.Blobs) != len(res.EntityIDs) {
+		e.con.ReportMisbehavior(originID, network.OffenseInvalidMessage)
 		return engine.NewInvalidInputErrorf("invalid response with %d blobs, %d IDs", len(res.Blobs), len(res.EntityIDs))
 	}
 
@@ -452,6 +454,7 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 		entity := e.create()
 		err := msgpack.Unmarshal(blob, &entity)
 		if err != nil {
+			e.con.ReportMisbehavior(originID, network.OffenseMalformedMessage)
 			return fmt.Errorf("could not decode entity: %w", err)
 		}
 
@@ -464,6 +467,7 @@ func (e *Engine) onEntityResponse(originID flow.Identifier, res *messages.Entity
 					Hex("stated_entity_id", logging.ID(entityID)).
 					Hex("provided_entity", logging.ID(actualEntityID)).
 					Msg("provided entity does not match stated ID")
+				e.con.ReportMisbehavior(originID, network.OffenseInvalidMessage)
 				continue
 			}
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/mocknetwork"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -363,10 +364,15 @@ func TestOnEntityIntegrityCheck(t *testing.T) {
 		EntityIDs: []flow.Identifier{wanted.ID()},
 	}
 
+	// the provider of a mismatching entity is reported
+	con := &mocknetwork.Conduit{}
+	con.On("ReportMisbehavior", targetID, network.OffenseInvalidMessage).Once()
+
 	called := make(chan struct{})
 	request := Engine{
 		unit:     engine.NewUnit(),
 		metrics:  metrics.NewNoopCollector(),
+		con:      con,
 		state:    state,
 		items:    make(map[flow.Identifier]*Item),
 		requests: make(map[uint64]*messages.EntityRequest),
@@ -397,6 +403,7 @@ func TestOnEntityIntegrityCheck(t *testing.T) {
 
 	// make sure we process item without checking integrity
 	unittest.AssertClosesBefore(t, called, time.Second)
+	con.AssertExpectations(t)
 }
 
 // Verify that the origin should not be checked when ValidateStaking config is set to false
@@ -448,13 +455,17 @@ func TestOriginValidation(t *testing.T) {
 		EntityIDs: []flow.Identifier{wanted.ID()},
 	}
 
-	network := &mocknetwork.Network{}
-	network.On("Register", mock.Anything, mock.Anything).Return(nil, nil)
+	// the wrong origin is reported as unauthorized sender while staking is validated
+	con := &mocknetwork.Conduit{}
+	con.On("ReportMisbehavior", wrongID, network.OffenseUnauthorizedSender).Once()
+
+	net := &mocknetwork.Network{}
+	net.On("Register", mock.Anything, mock.Anything).Return(con, nil)
 
 	e, err := New(
 		zerolog.Nop(),
 		metrics.NewNoopCollector(),
-		network,
+		net,
 		me,
 		state,
 		"",
@@ -485,4 +496,5 @@ func TestOriginValidation(t *testing.T) {
 
 	// handler are called async, but this should be extremely quick
 	unittest.AssertClosesBefore(t, called, time.Second)
+	con.AssertExpectations(t)
 }
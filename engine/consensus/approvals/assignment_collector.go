@@ -93,4 +93,9 @@ type AssignmentCollectorState interface {
 
 	// ProcessingStatus returns the AssignmentCollector's ProcessingStatus (state descriptor).
 	ProcessingStatus() ProcessingStatus
+
+	// Snapshot returns the current approval progress of the result for inspection. The
+	// finalized height is used to determine which incorporated results qualify for
+	// emergency sealing.
+	Snapshot(finalizedBlockHeight uint64) AssignmentCollectorSnapshot
 }
@@ -29,6 +29,7 @@ type AssignmentCollectorBase struct {
 	seals                                mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
 	approvalConduit                      network.Conduit                 // used to request missing approvals from verification nodes
 	requestTracker                       *RequestTracker                 // used to keep track of number of approval requests, and blackout periods, by chunk
+	verifierTracker                      *VerifierTracker                // used to select verifiers for approval requests, and keep track of their responsiveness
 	requiredApprovalsForSealConstruction uint                            // number of approvals that are required for each chunk to be sealed
 
 	result        *flow.ExecutionResult // execution result
@@ -46,6 +47,7 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 	sigHasher hash.Hasher,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
+	verifierTracker *VerifierTracker,
 	requiredApprovalsForSealConstruction uint,
 ) (AssignmentCollectorBase, error) {
 	executedBlock, err := headers.ByBlockID(result.BlockID)
@@ -63,6 +65,7 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 		seals:                                seals,
 		approvalConduit:                      approvalConduit,
 		requestTracker:                       requestTracker,
+		verifierTracker:                      verifierTracker,
 		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
 		result:                               result,
 		resultID:                             result.ID(),
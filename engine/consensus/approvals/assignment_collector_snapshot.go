@@ -0,0 +1,107 @@
+package approvals
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AssignmentCollectorTreeSnapshot is a point-in-time view of the AssignmentCollectorTree for a
+// range of heights, intended for inspecting the sealing progress, e.g. via the admin API.
+type AssignmentCollectorTreeSnapshot struct {
+	LastSealedHeight    uint64           `json:"last_sealed_height"`
+	LastFinalizedHeight uint64           `json:"last_finalized_height"`
+	LowestHeight        uint64           `json:"lowest_height"` // lowest height of executed blocks tracked by the tree
+	Heights             []HeightSnapshot `json:"heights"`
+}
+
+// HeightSnapshot holds the assignment collectors for all results of blocks at one height.
+// For finalized heights, it includes the ID of the finalized block. MissingResult is set if
+// the height is finalized, but no result for the finalized block is known yet, which means
+// sealing is stalled on missing execution receipts.
+type HeightSnapshot struct {
+	Height           uint64                        `json:"height"`
+	FinalizedBlockID *flow.Identifier              `json:"finalized_block_id,omitempty"`
+	MissingResult    bool                          `json:"missing_result"`
+	Collectors       []AssignmentCollectorSnapshot `json:"collectors"`
+}
+
+// AssignmentCollectorSnapshot describes the approval progress of one execution result.
+// Collectors for results of orphaned forks have the status `Orphaned`. Collectors in status
+// `CachingApprovals` have not computed any assignments yet, hence only report the number of
+// cached approvals and the blocks incorporating the result.
+type AssignmentCollectorSnapshot struct {
+	ResultID            flow.Identifier              `json:"result_id"`
+	PreviousResultID    flow.Identifier              `json:"previous_result_id"`
+	BlockID             flow.Identifier              `json:"block_id"`
+	BlockHeight         uint64                       `json:"block_height"`
+	Status              string                       `json:"status"`
+	CachedApprovals     int                          `json:"cached_approvals"`
+	IncorporatedResults []IncorporatedResultSnapshot `json:"incorporated_results"`
+}
+
+// IncorporatedResultSnapshot describes the approval progress of an execution result for the
+// verifier assignment of one incorporating block.
+type IncorporatedResultSnapshot struct {
+	IncorporatedBlockID     flow.Identifier          `json:"incorporated_block_id"`
+	IncorporatedBlockHeight uint64                   `json:"incorporated_block_height"`
+	Sealable                bool                     `json:"sealable"`           // all chunks have sufficient approvals
+	EmergencySealable       bool                     `json:"emergency_sealable"` // result qualifies for emergency sealing
+	Chunks                  []ChunkApprovalsSnapshot `json:"chunks"`
+}
+
+// ChunkApprovalsSnapshot describes the approvals collected for one chunk. MissingVerifiers
+// lists the verifiers assigned to the chunk which have not provided an approval yet.
+type ChunkApprovalsSnapshot struct {
+	ChunkIndex        uint64              `json:"chunk_index"`
+	Approvals         uint                `json:"approvals"`
+	RequiredApprovals uint                `json:"required_approvals"`
+	Approved          bool                `json:"approved"`
+	MissingVerifiers  flow.IdentifierList `json:"missing_verifiers"`
+}
+
+// snapshot returns the snapshot of the result tracked by the collector, without any
+// incorporated results.
+func (cb *AssignmentCollectorBase) snapshot(status ProcessingStatus) AssignmentCollectorSnapshot {
+	return AssignmentCollectorSnapshot{
+		ResultID:            cb.resultID,
+		PreviousResultID:    cb.result.PreviousResultID,
+		BlockID:             cb.BlockID(),
+		BlockHeight:         cb.executedBlock.Height,
+		Status:              status.String(),
+		IncorporatedResults: []IncorporatedResultSnapshot{},
+	}
+}
+
+// Snapshot returns the approval progress for the incorporated result. The flags for sealing
+// are left to the caller, as the ApprovalCollector is not aware of emergency sealing.
+func (c *ApprovalCollector) Snapshot() IncorporatedResultSnapshot {
+	chunks := make([]ChunkApprovalsSnapshot, 0, len(c.chunkCollectors))
+	for i, collector := range c.chunkCollectors {
+		chunkIndex := uint64(i)
+		chunk := collector.Snapshot()
+		chunk.ChunkIndex = chunkIndex
+		chunk.Approved = c.aggregatedSignatures.HasSignature(chunkIndex)
+		chunks = append(chunks, chunk)
+	}
+
+	return IncorporatedResultSnapshot{
+		IncorporatedBlockID:     c.IncorporatedBlockID(),
+		IncorporatedBlockHeight: c.incorporatedBlock.Height,
+		Sealable:                len(c.aggregatedSignatures.ChunksWithoutAggregatedSignature()) == 0,
+		Chunks:                  chunks,
+	}
+}
+
+// Snapshot returns the number of approvals collected for the chunk and the assigned
+// verifiers which have not provided an approval yet.
+func (c *ChunkApprovalCollector) Snapshot() ChunkApprovalsSnapshot {
+	missing := c.GetMissingSigners()
+	c.lock.Lock()
+	approvals := c.chunkApprovals.NumberSignatures()
+	c.lock.Unlock()
+
+	return ChunkApprovalsSnapshot{
+		Approvals:         approvals,
+		RequiredApprovals: c.requiredApprovalsForSealConstruction,
+		MissingVerifiers:  missing,
+	}
+}
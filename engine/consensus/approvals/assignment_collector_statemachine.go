@@ -127,6 +127,11 @@ func (asm *AssignmentCollectorStateMachine) ProcessingStatus() ProcessingStatus
 	return collector.ProcessingStatus()
 }
 
+// Snapshot returns the approval progress of the result, as reported by the collector's current state.
+func (asm *AssignmentCollectorStateMachine) Snapshot(finalizedBlockHeight uint64) AssignmentCollectorSnapshot {
+	return asm.atomicLoadCollector().Snapshot(finalizedBlockHeight)
+}
+
 // ChangeProcessingStatus changes the AssignmentCollector's internal processing
 // status. The operation is implemented as an atomic compare-and-swap, i.e. the
 // state transition is only executed if AssignmentCollector's internal state is
@@ -223,6 +223,84 @@ func (t *AssignmentCollectorTree) GetCollectorsByInterval(from, to uint64) []Ass
 	return vertices
 }
 
+// Snapshot returns the state of all collectors whose executed block has height in
+// [fromHeight, toHeight], including the collectors of orphaned forks. Heights below the
+// lowest height in the tree (i.e. sealed heights, which are pruned) are omitted.
+// Error returns:
+// * storage.ErrNotFound if the finalized block at one of the heights is unknown
+// * all other errors are unexpected and potential symptoms of internal bugs or state corruption (fatal)
+func (t *AssignmentCollectorTree) Snapshot(fromHeight, toHeight uint64) (*AssignmentCollectorTreeSnapshot, error) {
+	// collect the collectors while holding the lock, the finalized blocks are read from the
+	// database and the collector states are taken after releasing it
+	snapshot, collectors := t.collectorsByHeight(fromHeight, toHeight)
+
+	for i, heightCollectors := range collectors {
+		heightSnapshot := &snapshot.Heights[i]
+
+		var finalizedBlockID flow.Identifier
+		finalized := heightSnapshot.Height <= snapshot.LastFinalizedHeight
+		if finalized {
+			finalizedBlock, err := t.headers.ByHeight(heightSnapshot.Height)
+			if err != nil {
+				return nil, fmt.Errorf("could not retrieve finalized block at height %d: %w", heightSnapshot.Height, err)
+			}
+			finalizedBlockID = finalizedBlock.ID()
+			heightSnapshot.FinalizedBlockID = &finalizedBlockID
+		}
+
+		finalizedResultFound := false
+		for _, collector := range heightCollectors {
+			if collector.BlockID() == finalizedBlockID {
+				finalizedResultFound = true
+			}
+			heightSnapshot.Collectors = append(heightSnapshot.Collectors, collector.Snapshot(snapshot.LastFinalizedHeight))
+		}
+		// the latest sealed result is not tracked by the tree
+		heightSnapshot.MissingResult = finalized && heightSnapshot.Height > snapshot.LastSealedHeight && !finalizedResultFound
+	}
+
+	return snapshot, nil
+}
+
+// collectorsByHeight returns a snapshot of the tree with empty heights in [fromHeight, toHeight],
+// starting at the lowest height in the tree, together with the collectors at each height.
+func (t *AssignmentCollectorTree) collectorsByHeight(fromHeight, toHeight uint64) (*AssignmentCollectorTreeSnapshot, [][]AssignmentCollector) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	snapshot := &AssignmentCollectorTreeSnapshot{
+		LastSealedHeight:    t.lastSealedHeight,
+		LastFinalizedHeight: t.lastFinalizedHeight,
+		LowestHeight:        t.forest.LowestLevel,
+		Heights:             []HeightSnapshot{},
+	}
+	if fromHeight < t.forest.LowestLevel {
+		fromHeight = t.forest.LowestLevel
+	}
+
+	var collectors [][]AssignmentCollector
+	for height := fromHeight; height <= toHeight; height++ {
+		snapshot.Heights = append(snapshot.Heights, HeightSnapshot{
+			Height:     height,
+			Collectors: []AssignmentCollectorSnapshot{},
+		})
+
+		var heightCollectors []AssignmentCollector
+		iter := t.forest.GetVerticesAtLevel(height)
+		for iter.HasNext() {
+			heightCollectors = append(heightCollectors, iter.NextVertex().(*assignmentCollectorVertex).collector)
+		}
+		collectors = append(collectors, heightCollectors)
+
+		// prevent overflow if the range ends at the maximum height
+		if height == toHeight {
+			break
+		}
+	}
+
+	return snapshot, collectors
+}
+
 // LazyInitCollector is a helper structure that is used to return collector which is lazy initialized
 type LazyInitCollector struct {
 	Collector AssignmentCollector
@@ -331,3 +331,63 @@ func (s *AssignmentCollectorTreeSuite) TestFinalizeForkAtLevel_ProcessableAfterS
 		}
 	}
 }
+
+// TestSnapshot tests that the snapshot of the tree includes the collectors of all results for the requested
+// heights, including orphaned results, and reports finalized heights for which no result is known.
+func (s *AssignmentCollectorTreeSuite) TestSnapshot() {
+	// result for a block conflicting with the finalized block at the same height
+	conflictingBlock := unittest.BlockHeaderWithParentFixture(s.ParentBlock)
+	s.Blocks[conflictingBlock.ID()] = conflictingBlock
+	conflictingResult := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(conflictingBlock.ID()))
+
+	for _, result := range []*flow.ExecutionResult{s.IncorporatedResult.Result, conflictingResult} {
+		wrapper, found := s.mockedCollectors[result.ID()]
+		if !found {
+			wrapper = s.prepareMockedCollector(result)
+		}
+		resultID := result.ID()
+		wrapper.collector.On("Snapshot", mocktestify.Anything).Return(func(uint64) approvals.AssignmentCollectorSnapshot {
+			return approvals.AssignmentCollectorSnapshot{ResultID: resultID, Status: wrapper.status.String()}
+		})
+		requireStateTransition(wrapper, approvals.CachingApprovals, approvals.VerifyingApprovals)
+		_, err := s.collectorTree.GetOrCreateCollector(result)
+		require.NoError(s.T(), err)
+	}
+
+	// finalizing the incorporating block orphans the conflicting result
+	requireStateTransition(s.mockedCollectors[conflictingResult.ID()], approvals.VerifyingApprovals, approvals.Orphaned)
+	s.MarkFinalized(s.IncorporatedBlock)
+	err := s.collectorTree.FinalizeForkAtLevel(s.IncorporatedBlock, s.ParentBlock)
+	require.NoError(s.T(), err)
+
+	snapshot, err := s.collectorTree.Snapshot(0, s.IncorporatedBlock.Height+1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), s.ParentBlock.Height, snapshot.LastSealedHeight)
+	require.Equal(s.T(), s.IncorporatedBlock.Height, snapshot.LastFinalizedHeight)
+	require.Equal(s.T(), s.ParentBlock.Height, snapshot.LowestHeight)
+	// heights below the lowest height of the tree are omitted
+	require.Len(s.T(), snapshot.Heights, 4)
+
+	sealed := snapshot.Heights[0]
+	require.Equal(s.T(), s.ParentBlock.Height, sealed.Height)
+	require.False(s.T(), sealed.MissingResult)
+	require.Empty(s.T(), sealed.Collectors)
+
+	executed := snapshot.Heights[1]
+	require.Equal(s.T(), s.Block.ID(), *executed.FinalizedBlockID)
+	require.False(s.T(), executed.MissingResult)
+	require.ElementsMatch(s.T(), []approvals.AssignmentCollectorSnapshot{
+		{ResultID: s.IncorporatedResult.Result.ID(), Status: approvals.VerifyingApprovals.String()},
+		{ResultID: conflictingResult.ID(), Status: approvals.Orphaned.String()},
+	}, executed.Collectors)
+
+	// the result for the finalized incorporating block is not known yet
+	incorporated := snapshot.Heights[2]
+	require.Equal(s.T(), s.IncorporatedBlock.ID(), *incorporated.FinalizedBlockID)
+	require.True(s.T(), incorporated.MissingResult)
+	require.Empty(s.T(), incorporated.Collectors)
+
+	pending := snapshot.Heights[3]
+	require.Nil(s.T(), pending.FinalizedBlockID)
+	require.False(s.T(), pending.MissingResult)
+}
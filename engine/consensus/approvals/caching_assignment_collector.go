@@ -95,3 +95,23 @@ func (ac *CachingAssignmentCollector) GetIncorporatedResults() []*flow.Incorpora
 func (ac *CachingAssignmentCollector) GetApprovals() []*flow.ResultApproval {
 	return ac.approvalsCache.All()
 }
+
+// Snapshot returns the incorporated results and the number of approvals cached so far. As the
+// collector does not compute verifier assignments, no per-chunk progress is available.
+func (ac *CachingAssignmentCollector) Snapshot(uint64) AssignmentCollectorSnapshot {
+	snapshot := ac.snapshot(CachingApprovals)
+	snapshot.CachedApprovals = len(ac.GetApprovals())
+	for _, incorporatedResult := range ac.GetIncorporatedResults() {
+		incorporatedResultSnapshot := IncorporatedResultSnapshot{
+			IncorporatedBlockID: incorporatedResult.IncorporatedBlockID,
+			Chunks:              []ChunkApprovalsSnapshot{},
+		}
+		// the snapshot is informational only, hence we don't fail if the header is unknown
+		incorporatedBlock, err := ac.headers.ByBlockID(incorporatedResult.IncorporatedBlockID)
+		if err == nil {
+			incorporatedResultSnapshot.IncorporatedBlockHeight = incorporatedBlock.Height
+		}
+		snapshot.IncorporatedResults = append(snapshot.IncorporatedResults, incorporatedResultSnapshot)
+	}
+	return snapshot
+}
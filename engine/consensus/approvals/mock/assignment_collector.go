@@ -171,6 +171,20 @@ func (_m *AssignmentCollector) ResultID() flow.Identifier {
 	return r0
 }
 
+// Snapshot provides a mock function with given fields: finalizedBlockHeight
+func (_m *AssignmentCollector) Snapshot(finalizedBlockHeight uint64) approvals.AssignmentCollectorSnapshot {
+	ret := _m.Called(finalizedBlockHeight)
+
+	var r0 approvals.AssignmentCollectorSnapshot
+	if rf, ok := ret.Get(0).(func(uint64) approvals.AssignmentCollectorSnapshot); ok {
+		r0 = rf(finalizedBlockHeight)
+	} else {
+		r0 = ret.Get(0).(approvals.AssignmentCollectorSnapshot)
+	}
+
+	return r0
+}
+
 type NewAssignmentCollectorT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -29,3 +29,6 @@ func (oc *OrphanAssignmentCollector) ProcessIncorporatedResult(*flow.Incorporate
 func (oc *OrphanAssignmentCollector) ProcessApproval(*flow.ResultApproval) error {
 	return nil
 }
+func (oc *OrphanAssignmentCollector) Snapshot(uint64) AssignmentCollectorSnapshot {
+	return oc.snapshot(Orphaned)
+}
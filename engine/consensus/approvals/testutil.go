@@ -9,6 +9,7 @@ import (
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
 	mempool "github.com/onflow/flow-go/module/mempool/mock"
+	"github.com/onflow/flow-go/module/metrics"
 	module "github.com/onflow/flow-go/module/mock"
 	msig "github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/network/mocknetwork"
@@ -91,6 +92,8 @@ type BaseAssignmentCollectorTestSuite struct {
 	FinalizedAtHeight map[uint64]*flow.Header
 	IdentitiesCache   map[flow.Identifier]map[flow.Identifier]*flow.Identity // helper map to store identities for given block
 	RequestTracker    *RequestTracker
+	VerifierStats     *storage.VerifierStats
+	VerifierTracker   *VerifierTracker
 }
 
 func (s *BaseAssignmentCollectorTestSuite) SetupTest() {
@@ -105,6 +108,13 @@ func (s *BaseAssignmentCollectorTestSuite) SetupTest() {
 
 	s.RequestTracker = NewRequestTracker(s.Headers, 1, 3)
 
+	s.VerifierStats = &storage.VerifierStats{}
+	s.VerifierStats.On("All").Return(nil, nil)
+	s.VerifierStats.On("Store", mock.Anything).Return(nil).Maybe()
+	var err error
+	s.VerifierTracker, err = NewVerifierTracker(s.VerifierStats, metrics.NewNoopCollector())
+	s.Require().NoError(err)
+
 	s.FinalizedAtHeight = make(map[uint64]*flow.Header)
 	s.FinalizedAtHeight[s.ParentBlock.Height] = s.ParentBlock
 	s.FinalizedAtHeight[s.Block.Height] = s.Block
@@ -0,0 +1,256 @@
+package approvals
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultVerifierBackoffMin is the time a verifier is not sent approval requests after it has missed
+// a single request. The backoff doubles with every further consecutive miss.
+const DefaultVerifierBackoffMin = time.Minute
+
+// DefaultVerifierBackoffMax is the maximum time a verifier is not sent approval requests.
+const DefaultVerifierBackoffMax = 30 * time.Minute
+
+// DefaultApprovalResponseTimeout is the time after which an unanswered approval request is
+// considered missed. It is well above the time verifiers usually take to verify a chunk, so
+// that slow verifications are not counted as missed.
+const DefaultApprovalResponseTimeout = 10 * time.Minute
+
+// verifierRequest identifies an approval request sent to a verifier.
+type verifierRequest struct {
+	verifierID flow.Identifier
+	resultID   flow.Identifier
+	chunkIndex uint64
+}
+
+// VerifierTracker tracks how responsive verification nodes are to approval requests, and uses
+// this information to decide which verifiers approvals are requested from. A request counts as
+// missed if the verifier has not provided the approval within DefaultApprovalResponseTimeout of
+// the first request for it; requesting the same approval again before does not count as a miss,
+// but does not extend the timeout either. A verifier which has missed requests is backed
+// off: it is not sent further requests for a backoff period after the latest miss, which doubles
+// with every consecutive miss. A single response resets the backoff.
+// The statistics are persisted by Persist, so that they are retained across restarts.
+// Is concurrency-safe.
+type VerifierTracker struct {
+	lock       sync.Mutex
+	stats      map[flow.Identifier]*flow.VerifierStats
+	dirty      map[flow.Identifier]struct{}  // verifiers whose statistics changed since they were last persisted
+	pending    map[verifierRequest]time.Time // time of the first request for outstanding approvals
+	storage    storage.VerifierStats
+	metrics    module.ConsensusMetrics
+	backoffMin time.Duration
+	backoffMax time.Duration
+	timeout    time.Duration
+	now        func() time.Time
+}
+
+// NewVerifierTracker instantiates a new VerifierTracker, restoring the statistics of verifiers
+// from the given storage. No errors are expected during normal operations.
+func NewVerifierTracker(store storage.VerifierStats, metrics module.ConsensusMetrics) (*VerifierTracker, error) {
+	stored, err := store.All()
+	if err != nil {
+		return nil, fmt.Errorf("could not load verifier statistics: %w", err)
+	}
+
+	stats := make(map[flow.Identifier]*flow.VerifierStats, len(stored))
+	for _, entry := range stored {
+		stats[entry.VerifierID] = entry
+	}
+
+	return &VerifierTracker{
+		stats:      stats,
+		dirty:      make(map[flow.Identifier]struct{}),
+		pending:    make(map[verifierRequest]time.Time),
+		storage:    store,
+		metrics:    metrics,
+		backoffMin: DefaultVerifierBackoffMin,
+		backoffMax: DefaultVerifierBackoffMax,
+		timeout:    DefaultApprovalResponseTimeout,
+		now:        time.Now,
+	}, nil
+}
+
+// SelectVerifiers returns the verifiers which approvals should be requested from, out of the
+// given assigned verifiers which have not provided their approval yet. Verifiers which are
+// backed off are skipped, unless all verifiers are backed off, in which case the verifier
+// whose backoff expires first is returned, so that the chunk is never left without requests.
+func (t *VerifierTracker) SelectVerifiers(verifiers flow.IdentifierList) flow.IdentifierList {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	selected := make(flow.IdentifierList, 0, len(verifiers))
+	var earliestID flow.Identifier
+	var earliest time.Time
+	for _, verifierID := range verifiers {
+		backoffUntil := t.backoffUntil(verifierID)
+		if !now.Before(backoffUntil) {
+			selected = append(selected, verifierID)
+			continue
+		}
+		if earliest.IsZero() || backoffUntil.Before(earliest) {
+			earliestID = verifierID
+			earliest = backoffUntil
+		}
+	}
+
+	if len(selected) == 0 && len(verifiers) > 0 {
+		selected = append(selected, earliestID)
+	}
+	return selected
+}
+
+// OnApprovalsRequested records that the approval for the given chunk was requested from the
+// verifiers. For approvals which are already outstanding, the time of the first request is kept,
+// so that they are recorded as missed once the response timeout has passed since then.
+func (t *VerifierTracker) OnApprovalsRequested(resultID flow.Identifier, chunkIndex uint64, verifiers flow.IdentifierList) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	for _, verifierID := range verifiers {
+		request := verifierRequest{verifierID: verifierID, resultID: resultID, chunkIndex: chunkIndex}
+		if _, ok := t.pending[request]; !ok {
+			t.pending[request] = now
+		}
+
+		stats := t.statsOf(verifierID)
+		stats.Requests++
+		stats.LastRequest = now
+		t.metrics.OnApprovalRequested(verifierID)
+	}
+}
+
+// OnApproval records that the verifier provided the approval for the given chunk. Approvals
+// which were not requested are ignored.
+func (t *VerifierTracker) OnApproval(verifierID flow.Identifier, resultID flow.Identifier, chunkIndex uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	request := verifierRequest{verifierID: verifierID, resultID: resultID, chunkIndex: chunkIndex}
+	requested, ok := t.pending[request]
+	if !ok {
+		return
+	}
+	delete(t.pending, request)
+
+	now := t.now()
+	responseTime := now.Sub(requested)
+	stats := t.statsOf(verifierID)
+	stats.Responses++
+	stats.ConsecutiveMisses = 0
+	stats.TotalResponseTime += responseTime
+	stats.LastResponse = now
+	t.metrics.OnApprovalResponse(verifierID, responseTime)
+}
+
+// VerifierStatus is the responsiveness of a verifier to approval requests, as tracked by the
+// VerifierTracker. BackoffUntil is the zero time if the verifier is not backed off.
+type VerifierStatus struct {
+	flow.VerifierStats
+	BackoffUntil time.Time
+}
+
+// Stats returns the status of all verifiers which approvals were requested from, ordered by
+// verifier ID.
+func (t *VerifierTracker) Stats() []VerifierStatus {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	stats := make([]VerifierStatus, 0, len(t.stats))
+	for verifierID, entry := range t.stats {
+		stats = append(stats, VerifierStatus{
+			VerifierStats: *entry,
+			BackoffUntil:  t.backoffUntil(verifierID),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return bytes.Compare(stats[i].VerifierID[:], stats[j].VerifierID[:]) < 0
+	})
+	return stats
+}
+
+// Persist records outstanding requests which have timed out as missed, and stores the statistics
+// of all verifiers which have changed since the last call. No errors are expected during normal
+// operations.
+func (t *VerifierTracker) Persist() error {
+	t.lock.Lock()
+	now := t.now()
+	for request, requested := range t.pending {
+		if now.Sub(requested) >= t.timeout {
+			delete(t.pending, request)
+			t.onMissed(request.verifierID, now)
+		}
+	}
+	changed := make([]*flow.VerifierStats, 0, len(t.dirty))
+	for verifierID := range t.dirty {
+		stats := *t.stats[verifierID]
+		changed = append(changed, &stats)
+	}
+	t.dirty = make(map[flow.Identifier]struct{})
+	t.lock.Unlock()
+
+	if len(changed) == 0 {
+		return nil
+	}
+	err := t.storage.Store(changed)
+	if err != nil {
+		// retry storing the statistics with the next call
+		t.lock.Lock()
+		for _, stats := range changed {
+			t.dirty[stats.VerifierID] = struct{}{}
+		}
+		t.lock.Unlock()
+		return fmt.Errorf("could not persist verifier statistics: %w", err)
+	}
+	return nil
+}
+
+// backoffUntil returns the time until which the verifier is backed off.
+// NOT concurrency safe.
+func (t *VerifierTracker) backoffUntil(verifierID flow.Identifier) time.Time {
+	stats, ok := t.stats[verifierID]
+	if !ok || stats.ConsecutiveMisses == 0 {
+		return time.Time{}
+	}
+
+	backoff := t.backoffMin
+	for i := uint64(1); i < stats.ConsecutiveMisses && backoff < t.backoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > t.backoffMax {
+		backoff = t.backoffMax
+	}
+	return stats.LastMiss.Add(backoff)
+}
+
+// onMissed records that the verifier did not respond to a request.
+// NOT concurrency safe.
+func (t *VerifierTracker) onMissed(verifierID flow.Identifier, now time.Time) {
+	stats := t.statsOf(verifierID)
+	stats.Misses++
+	stats.ConsecutiveMisses++
+	stats.LastMiss = now
+	t.metrics.OnApprovalRequestMissed(verifierID)
+}
+
+// statsOf returns the statistics of the verifier for modification, creating them if necessary.
+// NOT concurrency safe.
+func (t *VerifierTracker) statsOf(verifierID flow.Identifier) *flow.VerifierStats {
+	t.dirty[verifierID] = struct{}{}
+	stats, ok := t.stats[verifierID]
+	if !ok {
+		stats = &flow.VerifierStats{VerifierID: verifierID}
+		t.stats[verifierID] = stats
+	}
+	return stats
+}
@@ -0,0 +1,229 @@
+package approvals
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/model/flow"
+	module "github.com/onflow/flow-go/module/mock"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestVerifierTracker performs isolated testing of VerifierTracker.
+// VerifierTracker has to record responses and missed requests of verifiers.
+// VerifierTracker has to back off verifiers which missed requests, with exponentially increasing backoff.
+// VerifierTracker has to restore and persist the statistics of verifiers.
+func TestVerifierTracker(t *testing.T) {
+	suite.Run(t, new(VerifierTrackerTestSuite))
+}
+
+type VerifierTrackerTestSuite struct {
+	suite.Suite
+
+	store     *storage.VerifierStats
+	metrics   *module.ConsensusMetrics
+	tracker   *VerifierTracker
+	now       time.Time
+	resultID  flow.Identifier
+	verifiers flow.IdentifierList
+}
+
+func (s *VerifierTrackerTestSuite) SetupTest() {
+	s.store = storage.NewVerifierStats(s.T())
+	s.store.On("All").Return(nil, nil).Once()
+	s.metrics = module.NewConsensusMetrics(s.T())
+	s.metrics.On("OnApprovalRequested", mock.Anything).Maybe()
+	s.metrics.On("OnApprovalResponse", mock.Anything, mock.Anything).Maybe()
+	s.metrics.On("OnApprovalRequestMissed", mock.Anything).Maybe()
+
+	var err error
+	s.tracker, err = NewVerifierTracker(s.store, s.metrics)
+	require.NoError(s.T(), err)
+	s.now = time.Now()
+	s.tracker.now = func() time.Time { return s.now }
+
+	s.resultID = unittest.IdentifierFixture()
+	s.verifiers = unittest.IdentifierListFixture(3)
+}
+
+// stats returns the tracked statistics of the verifier.
+func (s *VerifierTrackerTestSuite) stats(verifierID flow.Identifier) VerifierStatus {
+	for _, status := range s.tracker.Stats() {
+		if status.VerifierID == verifierID {
+			return status
+		}
+	}
+	s.T().Fatalf("no statistics for verifier %v", verifierID)
+	return VerifierStatus{}
+}
+
+// TestOnApproval tests that responses to requests are recorded with their response time,
+// while approvals which were not requested are ignored.
+func (s *VerifierTrackerTestSuite) TestOnApproval() {
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers)
+	s.now = s.now.Add(4 * time.Second)
+	s.tracker.OnApproval(s.verifiers[0], s.resultID, 0)
+	// duplicated and unrequested approvals are ignored
+	s.tracker.OnApproval(s.verifiers[0], s.resultID, 0)
+	s.tracker.OnApproval(s.verifiers[1], s.resultID, 1)
+
+	stats := s.stats(s.verifiers[0])
+	require.Equal(s.T(), uint64(1), stats.Requests)
+	require.Equal(s.T(), uint64(1), stats.Responses)
+	require.Equal(s.T(), 4*time.Second, stats.AverageResponseTime())
+	require.Equal(s.T(), s.now, stats.LastResponse)
+
+	stats = s.stats(s.verifiers[1])
+	require.Equal(s.T(), uint64(1), stats.Requests)
+	require.Equal(s.T(), uint64(0), stats.Responses)
+	require.Len(s.T(), s.tracker.Stats(), len(s.verifiers))
+}
+
+// miss lets the response timeout pass, so that all outstanding requests are missed.
+func (s *VerifierTrackerTestSuite) miss() {
+	s.now = s.now.Add(DefaultApprovalResponseTimeout)
+	s.store.On("Store", mock.Anything).Return(nil).Once()
+	require.NoError(s.T(), s.tracker.Persist())
+}
+
+// TestResend tests that requesting an outstanding approval again does not count as a miss, and
+// does not extend the response timeout.
+func (s *VerifierTrackerTestSuite) TestResend() {
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers)
+	s.now = s.now.Add(DefaultApprovalResponseTimeout / 2)
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers)
+	stats := s.stats(s.verifiers[0])
+	require.Equal(s.T(), uint64(2), stats.Requests)
+	require.Equal(s.T(), uint64(0), stats.Misses)
+	require.Equal(s.T(), s.verifiers, s.tracker.SelectVerifiers(s.verifiers))
+
+	// the response time is measured from the first request
+	s.tracker.OnApproval(s.verifiers[0], s.resultID, 0)
+	require.Equal(s.T(), DefaultApprovalResponseTimeout/2, s.stats(s.verifiers[0]).AverageResponseTime())
+
+	// the timeout has passed since the first request
+	s.now = s.now.Add(DefaultApprovalResponseTimeout / 2)
+	s.store.On("Store", mock.Anything).Return(nil).Once()
+	require.NoError(s.T(), s.tracker.Persist())
+	require.Equal(s.T(), uint64(0), s.stats(s.verifiers[0]).Misses)
+	require.Equal(s.T(), uint64(1), s.stats(s.verifiers[1]).Misses)
+}
+
+// TestBackoff tests that verifiers which missed requests are not selected until their backoff,
+// which doubles with every consecutive miss, has expired. A response resets the backoff.
+func (s *VerifierTrackerTestSuite) TestBackoff() {
+	verifierID := s.verifiers[0]
+
+	// the request is missed once the response timeout has passed
+	s.tracker.OnApprovalsRequested(s.resultID, 0, flow.IdentifierList{verifierID})
+	s.miss()
+	stats := s.stats(verifierID)
+	require.Equal(s.T(), uint64(1), stats.Misses)
+	require.Equal(s.T(), s.now.Add(DefaultVerifierBackoffMin), stats.BackoffUntil)
+	require.Equal(s.T(), s.verifiers[1:], s.tracker.SelectVerifiers(s.verifiers))
+
+	s.now = s.now.Add(DefaultVerifierBackoffMin)
+	require.Equal(s.T(), s.verifiers, s.tracker.SelectVerifiers(s.verifiers))
+
+	// the backoff doubles with every consecutive miss, up to the maximum backoff
+	s.tracker.OnApprovalsRequested(s.resultID, 0, flow.IdentifierList{verifierID})
+	s.miss()
+	require.Equal(s.T(), s.now.Add(2*DefaultVerifierBackoffMin), s.stats(verifierID).BackoffUntil)
+	for i := 0; i < 10; i++ {
+		s.tracker.OnApprovalsRequested(s.resultID, 0, flow.IdentifierList{verifierID})
+		s.miss()
+	}
+	require.Equal(s.T(), s.now.Add(DefaultVerifierBackoffMax), s.stats(verifierID).BackoffUntil)
+
+	// a single response resets the backoff
+	s.tracker.OnApprovalsRequested(s.resultID, 0, flow.IdentifierList{verifierID})
+	s.tracker.OnApproval(verifierID, s.resultID, 0)
+	stats = s.stats(verifierID)
+	require.Equal(s.T(), uint64(0), stats.ConsecutiveMisses)
+	require.True(s.T(), stats.BackoffUntil.IsZero())
+	require.Equal(s.T(), s.verifiers, s.tracker.SelectVerifiers(s.verifiers))
+}
+
+// TestSelectVerifiers_AllBackedOff tests that if all verifiers are backed off, the verifier whose
+// backoff expires first is selected.
+func (s *VerifierTrackerTestSuite) TestSelectVerifiers_AllBackedOff() {
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers[1:2])
+	s.now = s.now.Add(time.Second)
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers[2:])
+	s.now = s.now.Add(time.Second)
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers[:1])
+
+	// the requests are missed one after the other, in the order they were sent
+	s.now = s.now.Add(DefaultApprovalResponseTimeout - 2*time.Second)
+	for i := 0; i < 3; i++ {
+		s.store.On("Store", mock.Anything).Return(nil).Once()
+		require.NoError(s.T(), s.tracker.Persist())
+		s.now = s.now.Add(time.Second)
+	}
+
+	require.Equal(s.T(), flow.IdentifierList{s.verifiers[1]}, s.tracker.SelectVerifiers(s.verifiers))
+	require.Empty(s.T(), s.tracker.SelectVerifiers(flow.IdentifierList{}))
+}
+
+// TestPersist tests that requests are missed after the response timeout, and that only the
+// statistics which changed since the last call are stored. Failed stores are retried.
+func (s *VerifierTrackerTestSuite) TestPersist() {
+	s.tracker.OnApprovalsRequested(s.resultID, 0, s.verifiers[:2])
+	s.store.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+		require.Len(s.T(), args.Get(0), 2)
+	}).Return(nil).Once()
+	require.NoError(s.T(), s.tracker.Persist())
+
+	// no changes, nothing to store
+	require.NoError(s.T(), s.tracker.Persist())
+
+	// after the timeout, the outstanding requests are missed
+	s.tracker.OnApproval(s.verifiers[0], s.resultID, 0)
+	s.now = s.now.Add(DefaultApprovalResponseTimeout)
+	exception := errors.New("exception")
+	s.store.On("Store", mock.Anything).Return(exception).Once()
+	require.ErrorIs(s.T(), s.tracker.Persist(), exception)
+	require.Equal(s.T(), uint64(1), s.stats(s.verifiers[1]).Misses)
+
+	s.store.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+		stored := args.Get(0).([]*flow.VerifierStats)
+		require.Len(s.T(), stored, 2)
+		for _, stats := range stored {
+			if stats.VerifierID == s.verifiers[1] {
+				require.Equal(s.T(), uint64(1), stats.Misses)
+			}
+		}
+	}).Return(nil).Once()
+	require.NoError(s.T(), s.tracker.Persist())
+}
+
+// TestRestore tests that the statistics are restored from storage.
+func (s *VerifierTrackerTestSuite) TestRestore() {
+	stored := &flow.VerifierStats{
+		VerifierID:        s.verifiers[0],
+		Requests:          5,
+		Misses:            5,
+		ConsecutiveMisses: 1,
+		LastRequest:       s.now,
+		LastMiss:          s.now,
+	}
+	store := storage.NewVerifierStats(s.T())
+	store.On("All").Return([]*flow.VerifierStats{stored}, nil).Once()
+	tracker, err := NewVerifierTracker(store, s.metrics)
+	require.NoError(s.T(), err)
+	tracker.now = func() time.Time { return s.now }
+
+	require.Equal(s.T(), s.verifiers[1:], tracker.SelectVerifiers(s.verifiers))
+	require.Equal(s.T(), uint64(5), tracker.Stats()[0].Requests)
+
+	store = storage.NewVerifierStats(s.T())
+	store.On("All").Return(nil, errors.New("exception")).Once()
+	_, err = NewVerifierTracker(store, s.metrics)
+	require.Error(s.T(), err)
+}
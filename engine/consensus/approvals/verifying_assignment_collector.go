@@ -3,6 +3,7 @@ package approvals
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 
 	"github.com/rs/zerolog"
@@ -112,6 +113,22 @@ func (ac *VerifyingAssignmentCollector) ProcessingStatus() ProcessingStatus {
 	return VerifyingApprovals
 }
 
+// Snapshot returns the approval progress of the result for each of the incorporating blocks,
+// ordered by the height of the incorporating block.
+func (ac *VerifyingAssignmentCollector) Snapshot(finalizedBlockHeight uint64) AssignmentCollectorSnapshot {
+	snapshot := ac.snapshot(VerifyingApprovals)
+	snapshot.CachedApprovals = len(ac.verifiedApprovalsCache.All())
+	for _, collector := range ac.allCollectors() {
+		incorporatedResult := collector.Snapshot()
+		incorporatedResult.EmergencySealable = ac.emergencySealable(collector, finalizedBlockHeight)
+		snapshot.IncorporatedResults = append(snapshot.IncorporatedResults, incorporatedResult)
+	}
+	sort.Slice(snapshot.IncorporatedResults, func(i, j int) bool {
+		return snapshot.IncorporatedResults[i].IncorporatedBlockHeight < snapshot.IncorporatedResults[j].IncorporatedBlockHeight
+	})
+	return snapshot
+}
+
 // ProcessIncorporatedResult starts tracking the approval for IncorporatedResult.
 // Method is idempotent.
 // Error Returns:
@@ -306,6 +323,7 @@ func (ac *VerifyingAssignmentCollector) ProcessApproval(approval *flow.ResultApp
 	if !newlyAdded {
 		return nil
 	}
+	ac.verifierTracker.OnApproval(approval.Body.ApproverID, approval.Body.ExecutionResultID, approval.Body.ChunkIndex)
 
 	for _, collector := range ac.allCollectors() {
 		// approvals are verified already and shouldn't yield any errors
@@ -320,9 +338,13 @@ func (ac *VerifyingAssignmentCollector) ProcessApproval(approval *flow.ResultApp
 
 // RequestMissingApprovals traverses all collectors and requests missing approval
 // for every chunk that didn't get enough approvals from verifiers.
+// As an approval for a chunk counts towards all incorporated results of the execution
+// result, each chunk is requested at most once per call, from the missing verifiers
+// of all incorporated results whose blackout period has expired.
 // Returns number of requests made and error in case something goes wrong.
 func (ac *VerifyingAssignmentCollector) RequestMissingApprovals(observation consensus.SealingObservation, maxHeightForRequesting uint64) (uint, error) {
-	overallRequestCount := uint(0) // number of approval requests for all different assignments for this result
+	// verifiers to request the approval for each chunk from
+	chunkVerifiers := make(map[uint64]flow.IdentifierList)
 	for _, collector := range ac.allCollectors() {
 		if collector.IncorporatedBlock().Height > maxHeightForRequesting {
 			continue
@@ -359,23 +381,33 @@ func (ac *VerifyingAssignmentCollector) RequestMissingApprovals(observation cons
 				)
 			}
 
-			// prepare the request
-			req := &messages.ApprovalRequest{
-				Nonce:      rand.Uint64(),
-				ResultID:   ac.ResultID(),
-				ChunkIndex: chunkIndex,
-			}
-
 			requestCount++
-			err = ac.approvalConduit.Publish(req, verifiers...)
-			if err != nil {
-				log.Error().Err(err).
-					Msgf("could not publish approval request for chunk %d", chunkIndex)
-			}
+			chunkVerifiers[chunkIndex] = chunkVerifiers[chunkIndex].Union(verifiers)
 		}
 
 		observation.ApprovalsRequested(collector.IncorporatedResult(), requestCount)
-		overallRequestCount += requestCount
+	}
+
+	overallRequestCount := uint(0) // number of approval requests for all chunks of this result
+	for chunkIndex, verifiers := range chunkVerifiers {
+		// prepare the request
+		req := &messages.ApprovalRequest{
+			Nonce:      rand.Uint64(),
+			ResultID:   ac.ResultID(),
+			ChunkIndex: chunkIndex,
+		}
+
+		// skip verifiers which have been unresponsive recently
+		targets := ac.verifierTracker.SelectVerifiers(verifiers)
+
+		overallRequestCount++
+		err := ac.approvalConduit.Publish(req, targets...)
+		if err != nil {
+			log.Error().Err(err).
+				Msgf("could not publish approval request for chunk %d", chunkIndex)
+			continue
+		}
+		ac.verifierTracker.OnApprovalsRequested(ac.ResultID(), chunkIndex, targets)
 	}
 
 	return overallRequestCount, nil
@@ -49,10 +49,11 @@ func newVerifyingAssignmentCollector(logger zerolog.Logger,
 	sigHasher hash.Hasher,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
+	verifierTracker *VerifierTracker,
 	requiredApprovalsForSealConstruction uint,
 ) (*VerifyingAssignmentCollector, error) {
 	b, err := NewAssignmentCollectorBase(logger, workerPool, result, state, headers, assigner, seals, sigHasher,
-		approvalConduit, requestTracker, requiredApprovalsForSealConstruction)
+		approvalConduit, requestTracker, verifierTracker, requiredApprovalsForSealConstruction)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +70,7 @@ func (s *AssignmentCollectorTestSuite) SetupTest() {
 
 	var err error
 	s.collector, err = newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
-		s.Assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, uint(len(s.AuthorizedVerifiers)))
+		s.Assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, s.VerifierTracker, uint(len(s.AuthorizedVerifiers)))
 	require.NoError(s.T(), err)
 }
 
@@ -207,7 +208,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult() {
 		assigner.On("Assign", mock.Anything, mock.Anything).Return(nil, fmt.Errorf(""))
 
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
-			assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, s.VerifierTracker, 1)
 		require.NoError(s.T(), err)
 
 		err = collector.ProcessIncorporatedResult(s.IncorporatedResult)
@@ -219,7 +220,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult() {
 		delete(s.IdentitiesCache, s.IncorporatedResult.Result.BlockID)
 		s.Snapshots[s.IncorporatedResult.Result.BlockID] = unittest.StateSnapshotForKnownBlock(s.Block, nil)
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
-			s.Assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			s.Assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, s.VerifierTracker, 1)
 		require.Error(s.T(), err)
 		require.Nil(s.T(), collector)
 	})
@@ -244,7 +245,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult_InvalidIden
 		)
 
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, state, s.Headers, s.Assigner, s.SealsPL,
-			s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			s.SigHasher, s.Conduit, s.RequestTracker, s.VerifierTracker, 1)
 		require.Error(s.T(), err)
 		require.Nil(s.T(), collector)
 	})
@@ -264,7 +265,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult_InvalidIden
 		)
 
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, state, s.Headers, s.Assigner, s.SealsPL,
-			s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			s.SigHasher, s.Conduit, s.RequestTracker, s.VerifierTracker, 1)
 		require.Nil(s.T(), collector)
 		require.Error(s.T(), err)
 	})
@@ -283,7 +284,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult_InvalidIden
 		)
 
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, state, s.Headers, s.Assigner, s.SealsPL,
-			s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			s.SigHasher, s.Conduit, s.RequestTracker, s.VerifierTracker, 1)
 		require.Nil(s.T(), collector)
 		require.Error(s.T(), err)
 	})
@@ -304,7 +305,8 @@ func (s *AssignmentCollectorTestSuite) TestProcessApproval_BeforeIncorporatedRes
 // TestRequestMissingApprovals checks that requests are sent only for chunks
 // that have not collected enough approvals yet, and are sent only to the
 // verifiers assigned to those chunks. It also checks that the threshold and
-// rate limiting is respected.
+// rate limiting is respected, and that each chunk is requested once for all
+// incorporated results.
 func (s *AssignmentCollectorTestSuite) TestRequestMissingApprovals() {
 	// build new assignment with 2 verifiers
 	assignment := chunks.NewAssignment()
@@ -369,8 +371,8 @@ func (s *AssignmentCollectorTestSuite) TestRequestMissingApprovals() {
 	requestCount, err = s.collector.RequestMissingApprovals(&tracker.NoopSealingTracker{}, lastHeight)
 	s.Require().NoError(err)
 
-	require.Equal(s.T(), int(requestCount), s.Chunks.Len()*len(s.collector.collectors))
-	require.Len(s.T(), requests, s.Chunks.Len()*len(s.collector.collectors))
+	require.Equal(s.T(), int(requestCount), s.Chunks.Len())
+	require.Len(s.T(), requests, s.Chunks.Len())
 
 	result := s.IncorporatedResult.Result
 	for _, chunk := range s.Chunks {
@@ -382,6 +384,65 @@ func (s *AssignmentCollectorTestSuite) TestRequestMissingApprovals() {
 	}
 }
 
+// TestSnapshot tests that the snapshot reports the approvals collected for each chunk, and the assigned
+// verifiers which have not provided an approval yet.
+func (s *AssignmentCollectorTestSuite) TestSnapshot() {
+	err := s.collector.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	s.SealsPL.On("Add", mock.Anything).Return(true, nil).Maybe()
+	s.PublicKey.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	// first chunk collects all required approvals, second chunk collects a single approval
+	blockID := s.Block.ID()
+	resultID := s.IncorporatedResult.Result.ID()
+	for verID := range s.AuthorizedVerifiers {
+		approval := unittest.ResultApprovalFixture(unittest.WithChunk(0),
+			unittest.WithApproverID(verID),
+			unittest.WithBlockID(blockID),
+			unittest.WithExecutionResultID(resultID))
+		err = s.collector.ProcessApproval(approval)
+		require.NoError(s.T(), err)
+	}
+	approval := unittest.ResultApprovalFixture(unittest.WithChunk(1),
+		unittest.WithApproverID(s.VerID),
+		unittest.WithBlockID(blockID),
+		unittest.WithExecutionResultID(resultID))
+	err = s.collector.ProcessApproval(approval)
+	require.NoError(s.T(), err)
+
+	snapshot := s.collector.Snapshot(s.IncorporatedBlock.Height)
+	require.Equal(s.T(), resultID, snapshot.ResultID)
+	require.Equal(s.T(), blockID, snapshot.BlockID)
+	require.Equal(s.T(), VerifyingApprovals.String(), snapshot.Status)
+	require.Equal(s.T(), len(s.AuthorizedVerifiers)+1, snapshot.CachedApprovals)
+	require.Len(s.T(), snapshot.IncorporatedResults, 1)
+
+	incorporatedResult := snapshot.IncorporatedResults[0]
+	require.Equal(s.T(), s.IncorporatedBlock.ID(), incorporatedResult.IncorporatedBlockID)
+	require.Equal(s.T(), s.IncorporatedBlock.Height, incorporatedResult.IncorporatedBlockHeight)
+	require.False(s.T(), incorporatedResult.Sealable)
+	require.False(s.T(), incorporatedResult.EmergencySealable)
+	require.Len(s.T(), incorporatedResult.Chunks, s.Chunks.Len())
+
+	approved := incorporatedResult.Chunks[0]
+	require.True(s.T(), approved.Approved)
+	require.Equal(s.T(), uint(len(s.AuthorizedVerifiers)), approved.Approvals)
+	require.Equal(s.T(), uint(len(s.AuthorizedVerifiers)), approved.RequiredApprovals)
+	require.Empty(s.T(), approved.MissingVerifiers)
+
+	pending := incorporatedResult.Chunks[1]
+	require.Equal(s.T(), uint64(1), pending.ChunkIndex)
+	require.False(s.T(), pending.Approved)
+	require.Equal(s.T(), uint(1), pending.Approvals)
+	require.Len(s.T(), pending.MissingVerifiers, len(s.AuthorizedVerifiers)-1)
+	require.NotContains(s.T(), pending.MissingVerifiers, s.VerID)
+
+	// once enough blocks are finalized, the result qualifies for emergency sealing
+	snapshot = s.collector.Snapshot(s.Block.Height + DefaultEmergencySealingThresholdForFinalization)
+	require.True(s.T(), snapshot.IncorporatedResults[0].EmergencySealable)
+}
+
 // TestCheckEmergencySealing tests that currently tracked incorporated results can be emergency sealed
 // when height difference reached the emergency sealing threshold.
 func (s *AssignmentCollectorTestSuite) TestCheckEmergencySealing() {
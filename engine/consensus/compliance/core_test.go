@@ -181,6 +181,7 @@ func (cs *ComplianceCoreSuite) SetupTest() {
 	cs.con.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	cs.con.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	cs.con.On("Unicast", mock.Anything, mock.Anything).Return(nil)
+	cs.con.On("UnicastLatencyCritical", mock.Anything, mock.Anything).Return(nil)
 
 	// set up network module mock
 	cs.net = &mocknetwork.Network{}
@@ -76,7 +76,7 @@ func (cs *ComplianceSuite) TestSendVote() {
 		View:    view,
 		SigData: sig,
 	}
-	cs.con.AssertCalled(cs.T(), "Unicast", &vote, recipientID)
+	cs.con.AssertCalled(cs.T(), "UnicastLatencyCritical", &vote, recipientID)
 }
 
 // TestBroadcastProposalWithDelay tests broadcasting proposals with different
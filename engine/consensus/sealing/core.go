@@ -48,6 +48,7 @@ type Core struct {
 	seals                      storage.Seals                      // used to get last sealed block
 	sealsMempool               mempool.IncorporatedResultSeals    // used by tracker.SealingObservation to log info
 	requestTracker             *approvals.RequestTracker          // used to keep track of number of approval requests, and blackout periods, by chunk
+	verifierTracker            *approvals.VerifierTracker         // used to keep track of responsiveness of verifiers to approval requests
 	metrics                    module.ConsensusMetrics            // used to track consensus metrics
 	sealingTracker             consensus.SealingTracker           // logic-aware component for tracking sealing progress.
 	tracer                     module.Tracer                      // used to trace execution
@@ -69,12 +70,18 @@ func NewCore(
 	sealsMempool mempool.IncorporatedResultSeals,
 	approvalConduit network.Conduit,
 	sealingConfigsGetter module.SealingConfigsGetter,
+	verifierStats storage.VerifierStats,
 ) (*Core, error) {
 	lastSealed, err := state.Sealed().Head()
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve last sealed block: %w", err)
 	}
 
+	verifierTracker, err := approvals.NewVerifierTracker(verifierStats, conMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("could not create verifier tracker: %w", err)
+	}
+
 	core := &Core{
 		log:                        log.With().Str("engine", "sealing.Core").Logger(),
 		workerPool:                 workerPool,
@@ -90,6 +97,7 @@ func NewCore(
 		seals:                      sealsDB,
 		sealsMempool:               sealsMempool,
 		requestTracker:             approvals.NewRequestTracker(headers, 10, 30),
+		verifierTracker:            verifierTracker,
 		sealingConfigsGetter:       sealingConfigsGetter,
 	}
 
@@ -97,7 +105,7 @@ func NewCore(
 		requiredApprovalsForSealConstruction := sealingConfigsGetter.RequireApprovalsForSealConstructionDynamicValue()
 		base, err := approvals.NewAssignmentCollectorBase(core.log, core.workerPool, result, core.state, core.headers,
 			assigner, sealsMempool, signatureHasher,
-			approvalConduit, core.requestTracker, requiredApprovalsForSealConstruction)
+			approvalConduit, core.requestTracker, core.verifierTracker, requiredApprovalsForSealConstruction)
 		if err != nil {
 			return nil, fmt.Errorf("could not create base collector: %w", err)
 		}
@@ -557,6 +565,13 @@ func (c *Core) ProcessFinalizedBlock(finalizedBlockID flow.Identifier) error {
 		return fmt.Errorf("internal error while requesting pending approvals: %w", err)
 	}
 
+	// the verifier statistics are only used to target approval requests, hence failing to
+	// persist them should not interrupt sealing
+	err = c.verifierTracker.Persist()
+	if err != nil {
+		c.log.Error().Err(err).Msg("could not persist verifier statistics")
+	}
+
 	// While SealingObservation is not intrinsically concurrency safe, running the following operation
 	// asynchronously is still safe for the following reason:
 	// * The `sealingObservation` is thread-local: created and mutated only by this goroutine.
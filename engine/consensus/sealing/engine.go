@@ -10,6 +10,7 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/fifoqueue"
 	"github.com/onflow/flow-go/engine/consensus"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
@@ -54,6 +55,8 @@ type Engine struct {
 	unit                       *engine.Unit
 	workerPool                 *workerpool.WorkerPool
 	core                       consensus.SealingCore
+	collectorTree              *approvals.AssignmentCollectorTree // assignment collectors of core, exposed for inspection
+	verifierTracker            *approvals.VerifierTracker         // responsiveness of verifiers tracked by core, exposed for inspection
 	log                        zerolog.Logger
 	me                         module.Local
 	headers                    storage.Headers
@@ -91,6 +94,7 @@ func NewEngine(log zerolog.Logger,
 	assigner module.ChunkAssigner,
 	sealsMempool mempool.IncorporatedResultSeals,
 	requiredApprovalsForSealConstructionGetter module.SealingConfigsGetter,
+	verifierStats storage.VerifierStats,
 ) (*Engine, error) {
 	rootHeader, err := state.Params().Root()
 	if err != nil {
@@ -135,7 +139,7 @@ func NewEngine(log zerolog.Logger,
 	}
 
 	signatureHasher := msig.NewBLSHasher(msig.ResultApprovalTag)
-	core, err := NewCore(log, e.workerPool, tracer, conMetrics, sealingTracker, unit, headers, state, sealsDB, assigner, signatureHasher, sealsMempool, approvalConduit, requiredApprovalsForSealConstructionGetter)
+	core, err := NewCore(log, e.workerPool, tracer, conMetrics, sealingTracker, unit, headers, state, sealsDB, assigner, signatureHasher, sealsMempool, approvalConduit, requiredApprovalsForSealConstructionGetter, verifierStats)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init sealing engine: %w", err)
 	}
@@ -145,6 +149,8 @@ func NewEngine(log zerolog.Logger,
 		return nil, fmt.Errorf("could not repopulate assignment collectors tree: %w", err)
 	}
 	e.core = core
+	e.collectorTree = core.collectorTree
+	e.verifierTracker = core.verifierTracker
 
 	return e, nil
 }
@@ -437,6 +443,19 @@ func (e *Engine) OnBlockIncorporated(incorporatedBlock *model.Block) {
 	e.blockIncorporatedNotifier.Notify()
 }
 
+// AssignmentCollectors returns a snapshot of the approval progress of all execution results for
+// blocks with height in [fromHeight, toHeight], including results of orphaned forks. Heights which
+// are already sealed and pruned are omitted.
+// No errors are expected during normal operations.
+func (e *Engine) AssignmentCollectors(fromHeight, toHeight uint64) (*approvals.AssignmentCollectorTreeSnapshot, error) {
+	return e.collectorTree.Snapshot(fromHeight, toHeight)
+}
+
+// VerifierStats returns the responsiveness of all verifiers which approvals were requested from.
+func (e *Engine) VerifierStats() []approvals.VerifierStatus {
+	return e.verifierTracker.Stats()
+}
+
 // processIncorporatedBlock selects receipts that were included into incorporated block and submits them
 // for further processing to sealing core.
 func (e *Engine) processIncorporatedBlock(incorporatedBlockID flow.Identifier) error {
@@ -1086,6 +1086,30 @@ func (e *Engine) GetRegisterAtBlockID(ctx context.Context, owner, key []byte, bl
 	return data, nil
 }
 
+func (e *Engine) GetRegistersWithProofAtBlockID(ctx context.Context, registerIDs []flow.RegisterID, blockID flow.Identifier) (flow.StateCommitment, []flow.RegisterValue, flow.StorageProof, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return flow.DummyStateCommitment, nil, nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	// return early if state with the given state commitment is not in memory and already purged
+	if !e.execState.HasState(stateCommit) {
+		return flow.DummyStateCommitment, nil, nil, fmt.Errorf("failed to get registers at block (%s): state commitment not found (%s)", blockID, hex.EncodeToString(stateCommit[:]))
+	}
+
+	values, err := e.execState.GetRegisters(ctx, stateCommit, registerIDs)
+	if err != nil {
+		return flow.DummyStateCommitment, nil, nil, fmt.Errorf("failed to get registers at block (%s): %w", blockID, err)
+	}
+
+	proof, err := e.execState.GetProof(ctx, stateCommit, registerIDs)
+	if err != nil {
+		return flow.DummyStateCommitment, nil, nil, fmt.Errorf("failed to get register proof at block (%s): %w", blockID, err)
+	}
+
+	return stateCommit, values, proof, nil
+}
+
 func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow.Identifier) (*flow.Account, error) {
 	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
 	if err != nil {
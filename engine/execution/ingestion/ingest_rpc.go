@@ -17,4 +17,8 @@ type IngestRPC interface {
 
 	// GetRegisterAtBlockID returns the value of a register at the given Block id (if available)
 	GetRegisterAtBlockID(ctx context.Context, owner, key []byte, blockID flow.Identifier) ([]byte, error)
+
+	// GetRegistersWithProofAtBlockID returns the state commitment after executing the given Block id, together with
+	// the values of the registers and a proof of the values against the state commitment (if available)
+	GetRegistersWithProofAtBlockID(ctx context.Context, registerIDs []flow.RegisterID, blockID flow.Identifier) (flow.StateCommitment, []flow.RegisterValue, flow.StorageProof, error)
 }
@@ -84,6 +84,47 @@ func (_m *IngestRPC) GetRegisterAtBlockID(ctx context.Context, owner []byte, key
 	return r0, r1
 }
 
+// GetRegistersWithProofAtBlockID provides a mock function with given fields: ctx, registerIDs, blockID
+func (_m *IngestRPC) GetRegistersWithProofAtBlockID(ctx context.Context, registerIDs []flow.RegisterID, blockID flow.Identifier) (flow.StateCommitment, []flow.RegisterValue, flow.StorageProof, error) {
+	ret := _m.Called(ctx, registerIDs, blockID)
+
+	var r0 flow.StateCommitment
+	if rf, ok := ret.Get(0).(func(context.Context, []flow.RegisterID, flow.Identifier) flow.StateCommitment); ok {
+		r0 = rf(ctx, registerIDs, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(flow.StateCommitment)
+		}
+	}
+
+	var r1 []flow.RegisterValue
+	if rf, ok := ret.Get(1).(func(context.Context, []flow.RegisterID, flow.Identifier) []flow.RegisterValue); ok {
+		r1 = rf(ctx, registerIDs, blockID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]flow.RegisterValue)
+		}
+	}
+
+	var r2 flow.StorageProof
+	if rf, ok := ret.Get(2).(func(context.Context, []flow.RegisterID, flow.Identifier) flow.StorageProof); ok {
+		r2 = rf(ctx, registerIDs, blockID)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(flow.StorageProof)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, []flow.RegisterID, flow.Identifier) error); ok {
+		r3 = rf(ctx, registerIDs, blockID)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 type NewIngestRPCT interface {
 	mock.TestingT
 	Cleanup(func())
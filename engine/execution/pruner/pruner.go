@@ -0,0 +1,333 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/jobqueue"
+	"github.com/onflow/flow-go/module/util"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// DefaultRetentionHeights is the default number of sealed heights for which the execution data
+	// of blocks is kept.
+	DefaultRetentionHeights = 100_000
+
+	// DefaultMaxHeightsPerSecond is the default maximum number of heights pruned per second.
+	DefaultMaxHeightsPerSecond = 10
+)
+
+// Config contains configuration options for the Pruner
+type Config struct {
+	// Enabled determines whether the pruner starts pruning on startup. Pruning can be toggled at
+	// runtime with SetEnabled.
+	Enabled bool
+
+	// RetentionHeights is the number of heights below the highest sealed and executed height for
+	// which the execution data of blocks is kept.
+	RetentionHeights uint64
+
+	// MaxHeightsPerSecond is the maximum number of heights pruned per second. 0 means no limit.
+	MaxHeightsPerSecond float64
+}
+
+// DefaultConfig returns the default configuration of the pruner, which is disabled.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:             false,
+		RetentionHeights:    DefaultRetentionHeights,
+		MaxHeightsPerSecond: DefaultMaxHeightsPerSecond,
+	}
+}
+
+// Pruner removes the chunk data packs, events, service events, transaction results and execution
+// receipts of blocks which were sealed and executed more than the configured number of heights ago.
+//
+// The pruner uses a job queue to walk through the prunable heights block by block. The last pruned
+// height is persisted, so that pruning resumes from the next height after a restart. Pruning a
+// height is idempotent, so a height that was partially pruned before a crash is pruned again.
+// Only blocks above the root block height are pruned, and the execution state itself is not
+// affected.
+type Pruner struct {
+	component.Component
+	cm  *component.ComponentManager
+	log zerolog.Logger
+
+	state          protocol.State
+	headers        storage.Headers
+	executionState state.ReadOnlyExecutionState
+
+	chunkDataPacks     storage.ChunkDataPacks
+	events             storage.Events
+	serviceEvents      storage.ServiceEvents
+	transactionResults storage.TransactionResults
+	receipts           storage.ExecutionReceipts
+	myReceipts         storage.MyExecutionReceipts
+
+	consumer         *jobqueue.ComponentConsumer
+	notifier         engine.Notifier
+	limiter          *rate.Limiter
+	enabled          *atomic.Bool
+	retentionHeights uint64
+}
+
+// New creates a new pruner. The root height is the height of the first block that is never pruned,
+// pruning starts at the next height.
+func New(
+	log zerolog.Logger,
+	config Config,
+	progress storage.ConsumerProgress,
+	state protocol.State,
+	headers storage.Headers,
+	executionState state.ReadOnlyExecutionState,
+	chunkDataPacks storage.ChunkDataPacks,
+	events storage.Events,
+	serviceEvents storage.ServiceEvents,
+	transactionResults storage.TransactionResults,
+	receipts storage.ExecutionReceipts,
+	myReceipts storage.MyExecutionReceipts,
+	rootHeight uint64,
+) *Pruner {
+	limit := rate.Inf
+	if config.MaxHeightsPerSecond > 0 {
+		limit = rate.Limit(config.MaxHeightsPerSecond)
+	}
+
+	p := &Pruner{
+		log:                log.With().Str("component", "execution_pruner").Logger(),
+		state:              state,
+		headers:            headers,
+		executionState:     executionState,
+		chunkDataPacks:     chunkDataPacks,
+		events:             events,
+		serviceEvents:      serviceEvents,
+		transactionResults: transactionResults,
+		receipts:           receipts,
+		myReceipts:         myReceipts,
+		notifier:           engine.NewNotifier(),
+		limiter:            rate.NewLimiter(limit, 1),
+		enabled:            atomic.NewBool(config.Enabled),
+		retentionHeights:   config.RetentionHeights,
+	}
+
+	// the consumer is notified on block finalization, and prunes all heights up to the prunable
+	// height one by one. A single worker is used, since the pruned heights are consecutive anyway.
+	p.consumer = jobqueue.NewComponentConsumer(
+		p.log.With().Str("module", "pruner_consumer").Logger(),
+		p.notifier.Channel(),
+		progress,
+		&prunableBlockReader{pruner: p},
+		rootHeight,
+		p.processPruneJob,
+		1,
+		0,
+	)
+
+	p.cm = component.NewComponentManagerBuilder().
+		AddWorker(p.runConsumer).
+		Build()
+	p.Component = p.cm
+
+	return p
+}
+
+// OnBlockFinalized accepts block finalization notifications from the FinalizationDistributor, and
+// checks for newly prunable heights.
+func (p *Pruner) OnBlockFinalized(*model.Block) {
+	p.notifier.Notify()
+}
+
+// SetEnabled enables or disables pruning. Pruning a height which was already started when pruning
+// is disabled is completed.
+func (p *Pruner) SetEnabled(enabled bool) {
+	p.enabled.Store(enabled)
+	p.log.Info().Bool("enabled", enabled).Msg("pruning toggled")
+
+	if enabled {
+		p.notifier.Notify()
+	}
+}
+
+// Enabled returns true if pruning is enabled.
+func (p *Pruner) Enabled() bool {
+	return p.enabled.Load()
+}
+
+// RetentionHeights returns the number of heights for which the execution data of blocks is kept.
+func (p *Pruner) RetentionHeights() uint64 {
+	return p.retentionHeights
+}
+
+// LastPrunedHeight returns the highest height up to which all heights were pruned.
+func (p *Pruner) LastPrunedHeight() uint64 {
+	return p.consumer.LastProcessedIndex()
+}
+
+// PrunableHeight returns the highest height which can be pruned, based on the highest sealed and
+// executed height, and the retention.
+// No errors are expected during normal operation.
+func (p *Pruner) PrunableHeight() (uint64, error) {
+	sealed, err := p.state.Sealed().Head()
+	if err != nil {
+		return 0, fmt.Errorf("could not get last sealed block: %w", err)
+	}
+
+	executedHeight, _, err := p.executionState.GetHighestExecutedBlockID(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("could not get highest executed block: %w", err)
+	}
+
+	// blocks which were sealed but not yet executed by this node must not be pruned, since their
+	// data is stored once they are executed
+	height := sealed.Height
+	if executedHeight < height {
+		height = executedHeight
+	}
+
+	if height <= p.retentionHeights {
+		return 0, nil
+	}
+	return height - p.retentionHeights, nil
+}
+
+// runConsumer runs the job consumer
+func (p *Pruner) runConsumer(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	p.consumer.Start(ctx)
+
+	err := util.WaitClosed(ctx, p.consumer.Ready())
+	if err == nil {
+		ready()
+		// check for prunable heights on startup, instead of waiting for the next finalized block
+		p.notifier.Notify()
+	}
+
+	<-p.consumer.Done()
+}
+
+// processPruneJob prunes the block of the given job once the rate limit allows it.
+func (p *Pruner) processPruneJob(ctx irrecoverable.SignalerContext, job module.Job, done func()) {
+	header, err := jobqueue.JobToBlockHeader(job)
+	if err != nil {
+		ctx.Throw(fmt.Errorf("failed to convert job to block header: %w", err))
+	}
+
+	err = p.limiter.Wait(ctx)
+	if err != nil {
+		// the context was cancelled, the height is pruned again after a restart
+		return
+	}
+
+	blockID := header.ID()
+	err = p.pruneBlock(header)
+	if err != nil {
+		ctx.Throw(fmt.Errorf("could not prune block %v at height %d: %w", blockID, header.Height, err))
+	}
+
+	p.log.Debug().
+		Uint64("height", header.Height).
+		Hex("block_id", blockID[:]).
+		Msg("pruned block")
+
+	done()
+}
+
+// pruneBlock removes all execution data of the given block. Data which was already removed is
+// skipped, so a partially pruned block can be pruned again.
+// No errors are expected during normal operation.
+func (p *Pruner) pruneBlock(header *flow.Header) error {
+	blockID := header.ID()
+
+	// the chunk data packs are found through the own receipt, which is therefore removed last
+	receipt, err := p.myReceipts.MyReceipt(blockID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get own execution receipt: %w", err)
+	}
+
+	if receipt != nil {
+		for _, chunk := range receipt.ExecutionResult.Chunks {
+			err = p.chunkDataPacks.Remove(chunk.ID())
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("could not remove chunk data pack of chunk %d: %w", chunk.Index, err)
+			}
+		}
+	}
+
+	err = p.events.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove events: %w", err)
+	}
+
+	err = p.serviceEvents.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove service events: %w", err)
+	}
+
+	err = p.transactionResults.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove transaction results: %w", err)
+	}
+
+	err = p.myReceipts.RemoveIndexByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove own execution receipt index: %w", err)
+	}
+
+	err = p.receipts.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove execution receipts: %w", err)
+	}
+
+	return nil
+}
+
+// prunableBlockReader provides the finalized block headers up to the prunable height as jobs. No
+// jobs are available while pruning is disabled.
+type prunableBlockReader struct {
+	pruner *Pruner
+}
+
+var _ module.Jobs = (*prunableBlockReader)(nil)
+
+// AtIndex returns the block header job at the given height.
+// Error returns:
+//   - storage.ErrNotFound if pruning is disabled, or the height is not prunable yet
+func (r *prunableBlockReader) AtIndex(index uint64) (module.Job, error) {
+	if !r.pruner.Enabled() {
+		return nil, fmt.Errorf("pruning is disabled: %w", storage.ErrNotFound)
+	}
+
+	prunable, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	if index > prunable {
+		return nil, fmt.Errorf("block at height %d is not prunable: %w", index, storage.ErrNotFound)
+	}
+
+	header, err := r.pruner.headers.ByHeight(index)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block by height %d: %w", index, err)
+	}
+
+	return jobqueue.BlockHeaderToJob(header), nil
+}
+
+// Head returns the prunable height as job index.
+func (r *prunableBlockReader) Head() (uint64, error) {
+	return r.pruner.PrunableHeight()
+}
@@ -0,0 +1,285 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testStorages contains the storages which are pruned by the pruner
+type testStorages struct {
+	chunkDataPacks     *bstorage.ChunkDataPacks
+	events             *bstorage.Events
+	serviceEvents      *bstorage.ServiceEvents
+	transactionResults *bstorage.TransactionResults
+	receipts           *bstorage.ExecutionReceipts
+	myReceipts         *bstorage.MyExecutionReceipts
+}
+
+func newTestStorages(db *badger.DB) *testStorages {
+	collector := metrics.NewNoopCollector()
+	transactions := bstorage.NewTransactions(collector, db)
+	collections := bstorage.NewCollections(db, transactions)
+	results := bstorage.NewExecutionResults(collector, db)
+	receipts := bstorage.NewExecutionReceipts(collector, db, results, bstorage.DefaultCacheSize)
+
+	return &testStorages{
+		chunkDataPacks:     bstorage.NewChunkDataPacks(collector, db, collections, bstorage.DefaultCacheSize),
+		events:             bstorage.NewEvents(collector, db),
+		serviceEvents:      bstorage.NewServiceEvents(collector, db),
+		transactionResults: bstorage.NewTransactionResults(collector, db, bstorage.DefaultCacheSize),
+		receipts:           receipts,
+		myReceipts:         bstorage.NewMyExecutionReceipts(collector, db, receipts),
+	}
+}
+
+// newTestPruner creates a pruner for the given storages, with the sealed and executed heights
+// provided by the given functions.
+func newTestPruner(
+	t *testing.T,
+	db *badger.DB,
+	s *testStorages,
+	config Config,
+	headers storage.Headers,
+	sealedHeight func() uint64,
+	executedHeight func() uint64,
+) *Pruner {
+	snapshot := protocolmock.NewSnapshot(t)
+	snapshot.On("Head").Return(
+		func() *flow.Header {
+			header := unittest.BlockHeaderFixture()
+			header.Height = sealedHeight()
+			return header
+		},
+		nil,
+	).Maybe()
+
+	state := protocolmock.NewState(t)
+	state.On("Sealed").Return(snapshot).Maybe()
+
+	executionState := statemock.NewReadOnlyExecutionState(t)
+	executionState.On("GetHighestExecutedBlockID", mock.Anything).Return(
+		func(context.Context) uint64 { return executedHeight() },
+		func(context.Context) flow.Identifier { return unittest.IdentifierFixture() },
+		nil,
+	).Maybe()
+
+	return New(
+		unittest.Logger(),
+		config,
+		bstorage.NewConsumerProgress(db, module.ConsumeProgressExecutionPruner),
+		state,
+		headers,
+		executionState,
+		s.chunkDataPacks,
+		s.events,
+		s.serviceEvents,
+		s.transactionResults,
+		s.receipts,
+		s.myReceipts,
+		0,
+	)
+}
+
+// storeBlockData stores a receipt with chunk data packs, events, service events and transaction
+// results for the given block.
+func storeBlockData(t *testing.T, db *badger.DB, s *testStorages, blockID flow.Identifier) *flow.ExecutionReceipt {
+	receipt := unittest.ExecutionReceiptFixture(
+		unittest.WithResult(unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(blockID))),
+	)
+	require.NoError(t, s.myReceipts.StoreMyReceipt(receipt))
+
+	for _, chunk := range receipt.ExecutionResult.Chunks {
+		// system chunk data packs have no collection
+		cdp := unittest.ChunkDataPackFixture(chunk.ID())
+		cdp.Collection = nil
+		require.NoError(t, s.chunkDataPacks.Store(cdp))
+	}
+
+	txID := unittest.IdentifierFixture()
+	batch := bstorage.NewBatch(db)
+	events := []flow.EventsList{{unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID, 0)}}
+	require.NoError(t, s.events.BatchStore(blockID, events, batch))
+	serviceEvents := []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 1, txID, 0)}
+	require.NoError(t, s.serviceEvents.BatchStore(blockID, serviceEvents, batch))
+	require.NoError(t, s.transactionResults.BatchStore(blockID, []flow.TransactionResult{{TransactionID: txID}}, batch))
+	require.NoError(t, batch.Flush())
+
+	return receipt
+}
+
+// requirePruned checks that none of the pruned data of the given block is available anymore.
+func requirePruned(t *testing.T, s *testStorages, receipt *flow.ExecutionReceipt) {
+	blockID := receipt.ExecutionResult.BlockID
+
+	for _, chunk := range receipt.ExecutionResult.Chunks {
+		_, err := s.chunkDataPacks.ByChunkID(chunk.ID())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	}
+
+	events, err := s.events.ByBlockID(blockID)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	serviceEvents, err := s.serviceEvents.ByBlockID(blockID)
+	require.NoError(t, err)
+	assert.Empty(t, serviceEvents)
+
+	results, err := s.transactionResults.ByBlockID(blockID)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = s.myReceipts.MyReceipt(blockID)
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+	receipts, err := s.receipts.ByBlockID(blockID)
+	require.NoError(t, err)
+	assert.Empty(t, receipts)
+}
+
+func TestPruneBlock(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newTestStorages(db)
+		pruner := newTestPruner(t, db, s, DefaultConfig(), storagemock.NewHeaders(t),
+			func() uint64 { return 0 }, func() uint64 { return 0 })
+
+		pruned := unittest.BlockHeaderFixture()
+		kept := unittest.BlockHeaderFixture()
+		prunedReceipt := storeBlockData(t, db, s, pruned.ID())
+		keptReceipt := storeBlockData(t, db, s, kept.ID())
+
+		require.NoError(t, pruner.pruneBlock(pruned))
+		requirePruned(t, s, prunedReceipt)
+
+		// pruning is idempotent
+		require.NoError(t, pruner.pruneBlock(pruned))
+
+		// the data of other blocks is kept
+		receipt, err := s.myReceipts.MyReceipt(kept.ID())
+		require.NoError(t, err)
+		assert.Equal(t, keptReceipt.ID(), receipt.ID())
+
+		for _, chunk := range keptReceipt.ExecutionResult.Chunks {
+			_, err := s.chunkDataPacks.ByChunkID(chunk.ID())
+			require.NoError(t, err)
+		}
+
+		events, err := s.events.ByBlockID(kept.ID())
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+
+		results, err := s.transactionResults.ByBlockID(kept.ID())
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+
+		// blocks without any stored data can be pruned
+		require.NoError(t, pruner.pruneBlock(unittest.BlockHeaderFixture()))
+	})
+}
+
+func TestPrunableHeight(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newTestStorages(db)
+
+		sealed := uint64(100)
+		executed := uint64(120)
+		config := DefaultConfig()
+		config.RetentionHeights = 10
+		pruner := newTestPruner(t, db, s, config, storagemock.NewHeaders(t),
+			func() uint64 { return sealed }, func() uint64 { return executed })
+
+		// pruning is bounded by the sealed height
+		height, err := pruner.PrunableHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(90), height)
+
+		// pruning is bounded by the executed height
+		executed = 50
+		height, err = pruner.PrunableHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(40), height)
+
+		// nothing is prunable within the retention
+		executed = 10
+		height, err = pruner.PrunableHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), height)
+	})
+}
+
+func TestPruner(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newTestStorages(db)
+
+		blocks := make([]*flow.Header, 20)
+		receipts := make([]*flow.ExecutionReceipt, len(blocks))
+		headers := storagemock.NewHeaders(t)
+		for i := range blocks {
+			blocks[i] = unittest.BlockHeaderFixture()
+			blocks[i].Height = uint64(i)
+			receipts[i] = storeBlockData(t, db, s, blocks[i].ID())
+			headers.On("ByHeight", uint64(i)).Return(blocks[i], nil).Maybe()
+		}
+
+		config := Config{
+			Enabled:             false,
+			RetentionHeights:    5,
+			MaxHeightsPerSecond: 0,
+		}
+		pruner := newTestPruner(t, db, s, config, headers,
+			func() uint64 { return 15 }, func() uint64 { return 19 })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+		go func() {
+			select {
+			case err := <-errChan:
+				t.Errorf("unexpected error: %v", err)
+			case <-ctx.Done():
+			}
+		}()
+
+		pruner.Start(signalerCtx)
+		unittest.RequireComponentsReadyBefore(t, time.Second, pruner)
+
+		// nothing is pruned while pruning is disabled
+		pruner.OnBlockFinalized(nil)
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, uint64(0), pruner.LastPrunedHeight())
+
+		pruner.SetEnabled(true)
+		require.Eventually(t, func() bool {
+			return pruner.LastPrunedHeight() == 10
+		}, time.Second, 10*time.Millisecond)
+
+		// the root block and the blocks within the retention are kept
+		for i := range blocks {
+			if i >= 1 && i <= 10 {
+				requirePruned(t, s, receipts[i])
+				continue
+			}
+			_, err := s.myReceipts.MyReceipt(blocks[i].ID())
+			require.NoError(t, err)
+		}
+
+		cancel()
+		unittest.RequireComponentsDoneBefore(t, time.Second, pruner)
+	})
+}
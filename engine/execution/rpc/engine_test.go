@@ -17,6 +17,7 @@ import (
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -336,6 +337,68 @@ func (suite *Suite) TestGetRegisterAtBlockID() {
 	})
 }
 
+// TestGetRegisterProofAtBlockID tests the GetRegisterProofAtBlockID API call
+func (suite *Suite) TestGetRegisterProofAtBlockID() {
+
+	id := unittest.IdentifierFixture()
+	serviceAddress := flow.Mainnet.Chain().ServiceAddress()
+	registerIDs := []flow.RegisterID{
+		flow.NewRegisterID(string(serviceAddress.Bytes()), "storage_used"),
+		flow.NewRegisterID(string(serviceAddress.Bytes()), "public_key_count"),
+	}
+	commit := unittest.StateCommitmentFixture()
+	values := []flow.RegisterValue{{1}, {2}}
+	proof := flow.StorageProof{3}
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &registerProofHandler{
+		engine: mockEngine,
+	}
+
+	createReq := func(id []byte, registerIDs ...flow.RegisterID) *registerproof.GetRegisterProofAtBlockIDRequest {
+		req := &registerproof.GetRegisterProofAtBlockIDRequest{
+			BlockId: id,
+		}
+		for _, registerID := range registerIDs {
+			req.RegisterIds = append(req.RegisterIds, &registerproof.RegisterID{
+				Owner: []byte(registerID.Owner),
+				Key:   []byte(registerID.Key),
+			})
+		}
+		return req
+	}
+
+	suite.Run("happy path with valid request", func() {
+
+		// setup mock expectations
+		mockEngine.On("GetRegistersWithProofAtBlockID", mock.Anything, registerIDs, id).Return(commit, values, proof, nil).Once()
+
+		resp, err := handler.GetRegisterProofAtBlockID(context.Background(), createReq(id[:], registerIDs...))
+		suite.Require().NoError(err)
+		suite.Require().Equal(commit[:], resp.GetStateCommitment())
+		suite.Require().Equal(values, resp.GetValues())
+		suite.Require().Equal(proof, resp.GetProof())
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid request without register ids", func() {
+		_, err := handler.GetRegisterProofAtBlockID(context.Background(), createReq(id[:]))
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("execution state not available", func() {
+		mockEngine.On("GetRegistersWithProofAtBlockID", mock.Anything, registerIDs, id).
+			Return(flow.DummyStateCommitment, nil, nil, errors.New("state commitment not found")).Once()
+
+		_, err := handler.GetRegisterProofAtBlockID(context.Background(), createReq(id[:], registerIDs...))
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+}
+
 // TestGetTransactionResult tests the GetTransactionResult and GetTransactionResultByIndex API calls
 func (suite *Suite) TestGetTransactionResult() {
 
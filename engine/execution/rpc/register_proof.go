@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/execution/ingestion"
+	registerproof "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// maxRegisterProofIDs is the maximum number of registers which can be proven in a single request.
+const maxRegisterProofIDs = 100
+
+// registerProofHandler implements the RegisterProofAPI, which serves register values together with a proof of
+// the values against the final state commitment of a block.
+type registerProofHandler struct {
+	registerproof.UnimplementedRegisterProofAPIServer
+	engine ingestion.IngestRPC
+}
+
+var _ registerproof.RegisterProofAPIServer = &registerProofHandler{}
+
+// GetRegisterProofAtBlockID returns the values of the requested registers after executing the given block, and a
+// batch proof of the values against the final state commitment of the block.
+func (h *registerProofHandler) GetRegisterProofAtBlockID(
+	ctx context.Context,
+	req *registerproof.GetRegisterProofAtBlockIDRequest,
+) (*registerproof.GetRegisterProofAtBlockIDResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := req.GetRegisterIds()
+	if len(ids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no register ids provided")
+	}
+	if len(ids) > maxRegisterProofIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "too many register ids provided: %d (max %d)", len(ids), maxRegisterProofIDs)
+	}
+
+	registerIDs := make([]flow.RegisterID, len(ids))
+	for i, id := range ids {
+		registerIDs[i] = flow.NewRegisterID(string(id.GetOwner()), string(id.GetKey()))
+	}
+
+	commit, values, proof, err := h.engine.GetRegistersWithProofAtBlockID(ctx, registerIDs, blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get register proof at block %s: %v", blockID, err)
+	}
+
+	return &registerproof.GetRegisterProofAtBlockIDResponse{
+		StateCommitment: commit[:],
+		Values:          values,
+		Proof:           proof,
+	}, nil
+}
@@ -40,7 +40,8 @@ type ReadOnlyExecutionState interface {
 	// StateCommitmentByBlockID returns the final state commitment for the provided block ID.
 	StateCommitmentByBlockID(context.Context, flow.Identifier) (flow.StateCommitment, error)
 
-	// HasState returns true if the state with the given state commitment exists in memory
+	// HasState returns true if the state with the given state commitment exists in memory,
+	// or in the historical store of the ledger if it is enabled.
 	HasState(flow.StateCommitment) bool
 
 	// ChunkDataPackByChunkID retrieve a chunk data pack given the chunk ID.
@@ -103,6 +104,20 @@ func RegisterIDToKey(reg flow.RegisterID) ledger.Key {
 	})
 }
 
+// KeyToRegisterID converts a ledger key back to the ID of the register it was created from.
+func KeyToRegisterID(key ledger.Key) (flow.RegisterID, error) {
+	if len(key.KeyParts) != 2 ||
+		key.KeyParts[0].Type != KeyPartOwner ||
+		key.KeyParts[1].Type != KeyPartKey {
+		return flow.RegisterID{}, fmt.Errorf("key not in expected format: %s", key.String())
+	}
+
+	return flow.NewRegisterID(
+		string(key.KeyParts[0].Value),
+		string(key.KeyParts[1].Value),
+	), nil
+}
+
 // NewExecutionState returns a new execution state access layer for the given ledger storage.
 func NewExecutionState(
 	ls ledger.Ledger,
@@ -46,6 +46,12 @@ func (c *Conduit) Unicast(event interface{}, targetID flow.Identifier) error {
 	return nil
 }
 
+// UnicastLatencyCritical sends the incoming events as unicast events to the controller of this conduit, as the
+// controller handles latency-critical events like any other event.
+func (c *Conduit) UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error {
+	return c.Unicast(event, targetID)
+}
+
 // Multicast sends the incoming events as multicast events to the controller of this conduit (i.e., its factory) to handle.
 func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Identifier) error {
 	if c.ctx.Err() != nil {
@@ -60,6 +66,9 @@ func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Ident
 	return nil
 }
 
+// ReportMisbehavior is a no-op, as the corruptible conduit does not dispatch anything to the networking layer.
+func (c *Conduit) ReportMisbehavior(_ flow.Identifier, _ network.Offense) {}
+
 // Close informs the conduit controller that the engine is not going to use this conduit anymore.
 func (c *Conduit) Close() error {
 	if c.ctx.Err() != nil {
@@ -0,0 +1,198 @@
+package history
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// Key prefixes of the records of the store. Nodes and tries are addressed by their hash.
+const (
+	codeNode byte = 1
+	codeTrie byte = 2
+)
+
+// Node types of encoded nodes.
+const (
+	leafNodeType    byte = 0
+	interimNodeType byte = 1
+)
+
+// Flags of encoded interim nodes, indicating which children exist.
+const (
+	leftChildFlag  byte = 1 << 0
+	rightChildFlag byte = 1 << 1
+)
+
+const (
+	encNodeTypeSize = 1
+	encHeightSize   = 2
+	encFlagsSize    = 1
+	encRegCountSize = 8
+	encRegSizeSize  = 8
+)
+
+// storedNode is a node as it is stored on disk. Interim nodes reference their children by hash.
+type storedNode struct {
+	height int
+
+	// leaf nodes
+	isLeaf  bool
+	path    ledger.Path
+	payload *ledger.Payload
+
+	// interim nodes, a nil hash means that the child doesn't exist
+	leftHash  *hash.Hash
+	rightHash *hash.Hash
+}
+
+// trieInfo contains the register metrics of a stored trie.
+type trieInfo struct {
+	regCount uint64
+	regSize  uint64
+}
+
+func makeNodeKey(nodeHash hash.Hash) []byte {
+	return append([]byte{codeNode}, nodeHash[:]...)
+}
+
+func makeTrieKey(rootHash ledger.RootHash) []byte {
+	return append([]byte{codeTrie}, rootHash[:]...)
+}
+
+// newStoredNode returns the stored representation of the given in-memory node.
+func newStoredNode(n *node.Node) *storedNode {
+	stored := &storedNode{
+		height: n.Height(),
+	}
+
+	if n.IsLeaf() {
+		stored.isLeaf = true
+		stored.path = *n.Path()
+		stored.payload = n.Payload()
+		return stored
+	}
+
+	if n.LeftChild() != nil {
+		leftHash := n.LeftChild().Hash()
+		stored.leftHash = &leftHash
+	}
+	if n.RightChild() != nil {
+		rightHash := n.RightChild().Hash()
+		stored.rightHash = &rightHash
+	}
+	return stored
+}
+
+// encodeNode encodes the given node:
+//   - leaf nodes: node type (1 byte) + height (2 bytes) + path (32 bytes) + encoded payload
+//   - interim nodes: node type (1 byte) + height (2 bytes) + flags (1 byte) + hashes of the
+//     existing children (32 bytes each)
+func encodeNode(n *storedNode) []byte {
+	if n.isLeaf {
+		encPayload := encoding.EncodePayload(n.payload)
+
+		buf := make([]byte, encNodeTypeSize+encHeightSize+ledger.PathLen+len(encPayload))
+		buf[0] = leafNodeType
+		binary.BigEndian.PutUint16(buf[encNodeTypeSize:], uint16(n.height))
+		copy(buf[encNodeTypeSize+encHeightSize:], n.path[:])
+		copy(buf[encNodeTypeSize+encHeightSize+ledger.PathLen:], encPayload)
+		return buf
+	}
+
+	buf := make([]byte, encNodeTypeSize+encHeightSize+encFlagsSize, encNodeTypeSize+encHeightSize+encFlagsSize+2*hash.HashLen)
+	buf[0] = interimNodeType
+	binary.BigEndian.PutUint16(buf[encNodeTypeSize:], uint16(n.height))
+	if n.leftHash != nil {
+		buf[encNodeTypeSize+encHeightSize] |= leftChildFlag
+		buf = append(buf, n.leftHash[:]...)
+	}
+	if n.rightHash != nil {
+		buf[encNodeTypeSize+encHeightSize] |= rightChildFlag
+		buf = append(buf, n.rightHash[:]...)
+	}
+	return buf
+}
+
+// decodeNode decodes a node encoded by encodeNode.
+func decodeNode(encoded []byte) (*storedNode, error) {
+	if len(encoded) < encNodeTypeSize+encHeightSize {
+		return nil, fmt.Errorf("encoded node is too short: %d bytes", len(encoded))
+	}
+
+	n := &storedNode{
+		height: int(binary.BigEndian.Uint16(encoded[encNodeTypeSize:])),
+	}
+	rest := encoded[encNodeTypeSize+encHeightSize:]
+
+	switch encoded[0] {
+	case leafNodeType:
+		if len(rest) < ledger.PathLen {
+			return nil, fmt.Errorf("encoded leaf node is too short: %d bytes", len(encoded))
+		}
+		n.isLeaf = true
+		copy(n.path[:], rest[:ledger.PathLen])
+
+		payload, err := encoding.DecodePayload(rest[ledger.PathLen:])
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode payload: %w", err)
+		}
+		n.payload = payload
+		return n, nil
+
+	case interimNodeType:
+		if len(rest) < encFlagsSize {
+			return nil, fmt.Errorf("encoded interim node is too short: %d bytes", len(encoded))
+		}
+		flags := rest[0]
+		rest = rest[encFlagsSize:]
+
+		expectedSize := 0
+		if flags&leftChildFlag != 0 {
+			expectedSize += hash.HashLen
+		}
+		if flags&rightChildFlag != 0 {
+			expectedSize += hash.HashLen
+		}
+		if len(rest) != expectedSize {
+			return nil, fmt.Errorf("encoded interim node has %d bytes of child hashes, expected %d", len(rest), expectedSize)
+		}
+
+		if flags&leftChildFlag != 0 {
+			var leftHash hash.Hash
+			copy(leftHash[:], rest[:hash.HashLen])
+			n.leftHash = &leftHash
+			rest = rest[hash.HashLen:]
+		}
+		if flags&rightChildFlag != 0 {
+			var rightHash hash.Hash
+			copy(rightHash[:], rest[:hash.HashLen])
+			n.rightHash = &rightHash
+		}
+		return n, nil
+
+	default:
+		return nil, fmt.Errorf("unknown node type %d", encoded[0])
+	}
+}
+
+func encodeTrieInfo(info trieInfo) []byte {
+	buf := make([]byte, encRegCountSize+encRegSizeSize)
+	binary.BigEndian.PutUint64(buf, info.regCount)
+	binary.BigEndian.PutUint64(buf[encRegCountSize:], info.regSize)
+	return buf
+}
+
+func decodeTrieInfo(encoded []byte) (trieInfo, error) {
+	if len(encoded) != encRegCountSize+encRegSizeSize {
+		return trieInfo{}, fmt.Errorf("encoded trie has %d bytes, expected %d", len(encoded), encRegCountSize+encRegSizeSize)
+	}
+	return trieInfo{
+		regCount: binary.BigEndian.Uint64(encoded),
+		regSize:  binary.BigEndian.Uint64(encoded[encRegCountSize:]),
+	}, nil
+}
@@ -0,0 +1,371 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+const (
+	// DefaultNodeCacheSize is the default number of decoded nodes cached in memory.
+	DefaultNodeCacheSize = 1_000_000
+
+	// DefaultTrieCacheSize is the default number of trie records cached in memory.
+	DefaultTrieCacheSize = 10_000
+)
+
+// ErrTrieNotFound is returned if the trie of the requested state was not stored.
+var ErrTrieNotFound = errors.New("trie not found in historical store")
+
+// Config contains the configuration of the historical store.
+type Config struct {
+	// NodeCacheSize is the number of decoded nodes cached in memory. Nodes are cached when
+	// they are stored and when they are read.
+	NodeCacheSize int
+
+	// TrieCacheSize is the number of trie records cached in memory.
+	TrieCacheSize int
+}
+
+// DefaultConfig returns the default configuration of the historical store.
+func DefaultConfig() Config {
+	return Config{
+		NodeCacheSize: DefaultNodeCacheSize,
+		TrieCacheSize: DefaultTrieCacheSize,
+	}
+}
+
+// Store is a disk-backed store of tries, which allows reading registers and creating proofs
+// for tries which were evicted from the in-memory forest of the ledger.
+//
+// Nodes are stored by their hash, and interim nodes reference their children by hash. As tries
+// share most of their nodes with their parent trie, only the nodes which were created by an
+// update are written when a trie is stored. The nodes of a trie are written before its trie
+// record, and children are written before their parents. Hence, a stored trie record implies
+// that all nodes of the trie are stored, and a stored node implies that its sub-trie is stored.
+//
+// To read registers of a stored trie, only the nodes on the paths of the read registers are
+// loaded from disk. The siblings of these nodes are represented by nodes without children, which
+// only carry the hash of the sibling. The resulting partial trie is read with the same algorithms
+// as the tries of the in-memory forest, so reads and proofs are identical for both.
+type Store struct {
+	db    *badger.DB
+	nodes *lru.Cache // node hash -> *storedNode
+	tries *lru.Cache // root hash -> trieInfo
+}
+
+// NewStore creates a new historical store on top of the given database.
+func NewStore(db *badger.DB, config Config) (*Store, error) {
+	nodes, err := lru.New(config.NodeCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create node cache: %w", err)
+	}
+
+	tries, err := lru.New(config.TrieCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trie cache: %w", err)
+	}
+
+	return &Store{
+		db:    db,
+		nodes: nodes,
+		tries: tries,
+	}, nil
+}
+
+// HasTrie returns true if the trie with the given root hash is stored.
+// No errors are expected during normal operation.
+func (s *Store) HasTrie(rootHash ledger.RootHash) (bool, error) {
+	_, err := s.trieInfo(rootHash)
+	if errors.Is(err, ErrTrieNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StoreTrie stores the given trie, including all its nodes which are not stored yet.
+// Storing a trie which is already stored is a no-op.
+// No errors are expected during normal operation.
+func (s *Store) StoreTrie(t *trie.MTrie) error {
+	rootHash := t.RootHash()
+
+	stored, err := s.HasTrie(rootHash)
+	if err != nil {
+		return err
+	}
+	if stored {
+		return nil
+	}
+
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	err = s.db.View(func(tx *badger.Txn) error {
+		return s.storeNode(tx, batch, t.RootNode())
+	})
+	if err != nil {
+		// the node cache might contain nodes which were not written
+		s.nodes.Purge()
+		return fmt.Errorf("cannot store nodes of trie %s: %w", rootHash, err)
+	}
+
+	info := trieInfo{
+		regCount: t.AllocatedRegCount(),
+		regSize:  t.AllocatedRegSize(),
+	}
+	err = batch.Set(makeTrieKey(rootHash), encodeTrieInfo(info))
+	if err != nil {
+		s.nodes.Purge()
+		return fmt.Errorf("cannot store trie %s: %w", rootHash, err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		s.nodes.Purge()
+		return fmt.Errorf("cannot write trie %s: %w", rootHash, err)
+	}
+
+	s.tries.Add(rootHash, info)
+
+	return nil
+}
+
+// storeNode writes the sub-trie of the given node, skipping sub-tries which are already stored.
+func (s *Store) storeNode(tx *badger.Txn, batch *badger.WriteBatch, n *node.Node) error {
+	if n == nil {
+		return nil
+	}
+
+	nodeHash := n.Hash()
+	if s.nodes.Contains(nodeHash) {
+		return nil
+	}
+
+	_, err := tx.Get(makeNodeKey(nodeHash))
+	if err == nil {
+		// the node, and therefore its sub-trie, was stored before
+		return nil
+	}
+	if !errors.Is(err, badger.ErrKeyNotFound) {
+		return fmt.Errorf("cannot check node %x: %w", nodeHash, err)
+	}
+
+	// children are written before their parent
+	err = s.storeNode(tx, batch, n.LeftChild())
+	if err != nil {
+		return err
+	}
+	err = s.storeNode(tx, batch, n.RightChild())
+	if err != nil {
+		return err
+	}
+
+	stored := newStoredNode(n)
+	err = batch.Set(makeNodeKey(nodeHash), encodeNode(stored))
+	if err != nil {
+		return fmt.Errorf("cannot store node %x: %w", nodeHash, err)
+	}
+
+	s.nodes.Add(nodeHash, stored)
+
+	return nil
+}
+
+// Read reads the values of the registers at the given paths of the stored trie.
+// Returns ErrTrieNotFound if the trie is not stored.
+func (s *Store) Read(r *ledger.TrieRead) ([]ledger.Value, error) {
+	forest, err := s.partialForest(r.RootHash, r.Paths)
+	if err != nil {
+		return nil, err
+	}
+	return forest.Read(r)
+}
+
+// ReadSingleValue reads the value of the register at the given path of the stored trie.
+// Returns ErrTrieNotFound if the trie is not stored.
+func (s *Store) ReadSingleValue(r *ledger.TrieReadSingleValue) (ledger.Value, error) {
+	forest, err := s.partialForest(r.RootHash, []ledger.Path{r.Path})
+	if err != nil {
+		return nil, err
+	}
+	return forest.ReadSingleValue(r)
+}
+
+// ValueSizes returns the value sizes of the registers at the given paths of the stored trie.
+// Returns ErrTrieNotFound if the trie is not stored.
+func (s *Store) ValueSizes(r *ledger.TrieRead) ([]int, error) {
+	forest, err := s.partialForest(r.RootHash, r.Paths)
+	if err != nil {
+		return nil, err
+	}
+	return forest.ValueSizes(r)
+}
+
+// Proofs returns a batch proof for the registers at the given paths of the stored trie.
+// Returns ErrTrieNotFound if the trie is not stored.
+func (s *Store) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
+	forest, err := s.partialForest(r.RootHash, r.Paths)
+	if err != nil {
+		return nil, err
+	}
+	return forest.Proofs(r)
+}
+
+// partialForest returns a forest which contains the partial trie of the given root hash, which
+// contains all nodes on the given paths.
+func (s *Store) partialForest(rootHash ledger.RootHash, paths []ledger.Path) (*mtrie.Forest, error) {
+	t, err := s.partialTrie(rootHash, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	forest, err := mtrie.NewForest(2, metrics.NewNoopCollector(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create forest: %w", err)
+	}
+
+	err = forest.AddTrie(t)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add trie to forest: %w", err)
+	}
+
+	return forest, nil
+}
+
+// partialTrie loads the nodes on the given paths of the trie with the given root hash.
+func (s *Store) partialTrie(rootHash ledger.RootHash, paths []ledger.Path) (*trie.MTrie, error) {
+	info, err := s.trieInfo(rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootHash == trie.EmptyTrieRootHash() {
+		return trie.NewEmptyMTrie(), nil
+	}
+
+	// paths are permuted while they are split at each interim node
+	pathsCopy := make([]ledger.Path, len(paths))
+	copy(pathsCopy, paths)
+
+	root, err := s.loadNode(hash.Hash(rootHash), ledger.NodeMaxHeight, pathsCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := trie.NewMTrie(root, info.regCount, info.regSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trie %s: %w", rootHash, err)
+	}
+	return t, nil
+}
+
+// loadNode loads the node with the given hash and its descendants on the given paths.
+// The siblings of loaded nodes are represented by nodes which only carry their hash.
+func (s *Store) loadNode(nodeHash hash.Hash, height int, paths []ledger.Path) (*node.Node, error) {
+	stored, err := s.node(nodeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.height != height {
+		return nil, fmt.Errorf("node %x has height %d, expected %d", nodeHash, stored.height, height)
+	}
+
+	if stored.isLeaf {
+		return node.NewNode(height, nil, nil, stored.path, stored.payload, nodeHash), nil
+	}
+
+	depth := ledger.NodeMaxHeight - height
+	partitionIndex := trie.SplitPaths(paths, depth)
+
+	left, err := s.loadChild(stored.leftHash, height-1, paths[:partitionIndex])
+	if err != nil {
+		return nil, err
+	}
+	right, err := s.loadChild(stored.rightHash, height-1, paths[partitionIndex:])
+	if err != nil {
+		return nil, err
+	}
+
+	return node.NewNode(height, left, right, ledger.DummyPath, nil, nodeHash), nil
+}
+
+func (s *Store) loadChild(childHash *hash.Hash, height int, paths []ledger.Path) (*node.Node, error) {
+	if childHash == nil {
+		return nil, nil
+	}
+	if len(paths) == 0 {
+		// the sibling of a node on the paths, only its hash is needed
+		return node.NewNode(height, nil, nil, ledger.DummyPath, nil, *childHash), nil
+	}
+	return s.loadNode(*childHash, height, paths)
+}
+
+// node returns the stored node with the given hash, from the cache or from disk.
+func (s *Store) node(nodeHash hash.Hash) (*storedNode, error) {
+	if cached, ok := s.nodes.Get(nodeHash); ok {
+		return cached.(*storedNode), nil
+	}
+
+	var stored *storedNode
+	err := s.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(makeNodeKey(nodeHash))
+		if err != nil {
+			return err
+		}
+		encoded, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		stored, err = decodeNode(encoded)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot load node %x: %w", nodeHash, err)
+	}
+
+	s.nodes.Add(nodeHash, stored)
+
+	return stored, nil
+}
+
+// trieInfo returns the record of the trie with the given root hash, from the cache or from disk.
+func (s *Store) trieInfo(rootHash ledger.RootHash) (trieInfo, error) {
+	if cached, ok := s.tries.Get(rootHash); ok {
+		return cached.(trieInfo), nil
+	}
+
+	var info trieInfo
+	err := s.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(makeTrieKey(rootHash))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			info, err = decodeTrieInfo(val)
+			return err
+		})
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return trieInfo{}, fmt.Errorf("trie %s: %w", rootHash, ErrTrieNotFound)
+	}
+	if err != nil {
+		return trieInfo{}, fmt.Errorf("cannot load trie %s: %w", rootHash, err)
+	}
+
+	s.tries.Add(rootHash, info)
+
+	return info, nil
+}
@@ -0,0 +1,108 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestNodeEncoding(t *testing.T) {
+	leaf := node.NewLeaf(utils.PathByUint8(1), utils.LightPayload8('A', 'a'), 254)
+	sibling := node.NewLeaf(utils.PathByUint8(2), utils.LightPayload8('B', 'b'), 254)
+
+	for name, n := range map[string]*node.Node{
+		"leaf":               leaf,
+		"interim":            node.NewInterimNode(255, leaf, sibling),
+		"interim with left":  node.NewInterimNode(255, leaf, nil),
+		"interim with right": node.NewInterimNode(255, nil, sibling),
+	} {
+		t.Run(name, func(t *testing.T) {
+			stored := newStoredNode(n)
+			decoded, err := decodeNode(encodeNode(stored))
+			require.NoError(t, err)
+			assert.Equal(t, stored, decoded)
+		})
+	}
+
+	_, err := decodeNode([]byte{interimNodeType, 0, 1, leftChildFlag})
+	require.Error(t, err)
+}
+
+func TestStoreTrie(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := NewStore(db, DefaultConfig())
+		require.NoError(t, err)
+
+		countNodes := func() int {
+			count := 0
+			err := db.View(func(tx *badger.Txn) error {
+				it := tx.NewIterator(badger.IteratorOptions{Prefix: []byte{codeNode}})
+				defer it.Close()
+				for it.Rewind(); it.Valid(); it.Next() {
+					count++
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			return count
+		}
+
+		paths := utils.RandomPaths(100)
+		payloads := utils.RandomPayloads(100, 1, 10)
+		values := make([]ledger.Payload, len(payloads))
+		for i, payload := range payloads {
+			values[i] = *payload
+		}
+
+		parent, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, values, true)
+		require.NoError(t, err)
+
+		stored, err := store.HasTrie(parent.RootHash())
+		require.NoError(t, err)
+		assert.False(t, stored)
+
+		require.NoError(t, store.StoreTrie(parent))
+		stored, err = store.HasTrie(parent.RootHash())
+		require.NoError(t, err)
+		assert.True(t, stored)
+
+		parentNodes := countNodes()
+
+		// only the nodes on the path of the updated register are stored for the child trie
+		child, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths[:1], []ledger.Payload{*utils.LightPayload8('C', 'c')}, true)
+		require.NoError(t, err)
+
+		require.NoError(t, store.StoreTrie(child))
+		assert.LessOrEqual(t, countNodes()-parentNodes, ledger.NodeMaxHeight+1)
+
+		// a fresh store without cached nodes reads the same registers
+		fresh, err := NewStore(db, DefaultConfig())
+		require.NoError(t, err)
+
+		readRequest := &ledger.TrieRead{RootHash: child.RootHash(), Paths: paths}
+		read, err := fresh.Read(readRequest)
+		require.NoError(t, err)
+		require.Len(t, read, len(paths))
+
+		for i, path := range paths {
+			expected := child.ReadSinglePayload(path).Value
+			assert.Equal(t, expected, read[i])
+
+			single, err := fresh.ReadSingleValue(&ledger.TrieReadSingleValue{RootHash: child.RootHash(), Path: path})
+			require.NoError(t, err)
+			assert.Equal(t, expected, single)
+		}
+		assert.Equal(t, utils.LightPayload8('C', 'c').Value, read[0])
+
+		_, err = fresh.Read(&ledger.TrieRead{RootHash: ledger.RootHash(unittest.StateCommitmentFixture()), Paths: paths})
+		require.ErrorIs(t, err, ErrTrieNotFound)
+	})
+}
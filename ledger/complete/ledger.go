@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -13,6 +15,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/history"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -22,6 +25,9 @@ import (
 const DefaultCacheSize = 1000
 const DefaultPathFinderVersion = 1
 
+// historyQueueCapacity is the maximum number of updated tries waiting to be stored in the historical store.
+const historyQueueCapacity = 100
+
 // Ledger (complete) is a fast memory-efficient fork-aware thread-safe trie-based key/value storage.
 // Ledger holds an array of registers (key-value pairs) and keeps tracks of changes over a limited time.
 // Each register is referenced by an ID (key) and holds a value (byte slice).
@@ -33,12 +39,64 @@ const DefaultPathFinderVersion = 1
 // In order to limit the memory usage and maintain the performance storage only keeps a limited number of
 // tries and purge the old ones (LRU-based); in other words, Ledger is not designed to be used
 // for archival usage but make it possible for other software components to reconstruct very old tries using write-ahead logs.
+// In historical mode (see WithHistory), all tries are additionally persisted in a historical store, so that
+// reads and proofs are possible for states which were evicted from the forest.
 type Ledger struct {
 	forest            *mtrie.Forest
 	wal               wal.LedgerWAL
 	metrics           module.LedgerMetrics
 	logger            zerolog.Logger
 	pathFinderVersion uint8
+	history           *history.Store
+	historyQueue      chan *trie.MTrie // updated tries waiting to be stored in the historical store
+	historyStop       chan struct{}    // closed to stop storing tries in the historical store
+	historyDone       chan struct{}    // closed once the historical store worker exited
+	stopHistory       sync.Once
+}
+
+// Option is a functional option for the Ledger
+type Option func(*Ledger)
+
+// WithHistory enables the historical mode of the ledger: every trie of the ledger is stored in the given
+// historical store, and states which are not held in the forest anymore are read from the store.
+// Tries are stored asynchronously by a background worker, so that storing them does not delay updates.
+func WithHistory(store *history.Store) Option {
+	return func(l *Ledger) {
+		l.history = store
+	}
+}
+
+// trieReader reads registers and proofs of tries. It is implemented by the in-memory forest and the historical store.
+type trieReader interface {
+	ValueSizes(r *ledger.TrieRead) ([]int, error)
+	ReadSingleValue(r *ledger.TrieReadSingleValue) (ledger.Value, error)
+	Read(r *ledger.TrieRead) ([]ledger.Value, error)
+	Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error)
+}
+
+// StateAvailability describes where the trie of a state is available.
+type StateAvailability int
+
+const (
+	// StateUnavailable means that the state is neither held in memory nor stored on disk.
+	StateUnavailable StateAvailability = iota
+	// StateInMemory means that the state is held in the in-memory forest.
+	StateInMemory
+	// StateOnDisk means that the state was evicted from the forest, but is stored in the historical store.
+	StateOnDisk
+)
+
+func (a StateAvailability) String() string {
+	switch a {
+	case StateUnavailable:
+		return "unavailable"
+	case StateInMemory:
+		return "in_memory"
+	case StateOnDisk:
+		return "on_disk"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(a))
+	}
 }
 
 // NewLedger creates a new in-memory trie-backed ledger storage with persistence.
@@ -47,7 +105,8 @@ func NewLedger(
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
-	pathFinderVer uint8) (*Ledger, error) {
+	pathFinderVer uint8,
+	opts ...Option) (*Ledger, error) {
 
 	logger := log.With().Str("ledger", "complete").Logger()
 
@@ -69,6 +128,10 @@ func NewLedger(
 		pathFinderVersion: pathFinderVer,
 	}
 
+	for _, opt := range opts {
+		opt(storage)
+	}
+
 	// pause records to prevent double logging trie removals
 	wal.PauseRecord()
 	defer wal.UnpauseRecord()
@@ -80,6 +143,19 @@ func NewLedger(
 
 	wal.UnpauseRecord()
 
+	if storage.history != nil {
+		// the tries restored from the checkpoint and the WAL are stored in the background, tries stored
+		// before are skipped
+		tries, err := forest.GetTries()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get tries of forest: %w", err)
+		}
+		storage.historyQueue = make(chan *trie.MTrie, historyQueueCapacity)
+		storage.historyStop = make(chan struct{})
+		storage.historyDone = make(chan struct{})
+		go storage.storeHistory(tries)
+	}
+
 	// TODO update to proper value once https://github.com/onflow/flow-go/pull/3720 is merged
 	metrics.ForestApproxMemorySize(0)
 
@@ -95,13 +171,64 @@ func (l *Ledger) Ready() <-chan struct{} {
 }
 
 // Done implements interface module.ReadyDoneAware
-// it closes all the open write-ahead log files.
+// it stops storing tries in the historical store. Tries which are not stored yet are stored after
+// a restart, as they are restored from the checkpoint and the WAL.
 func (l *Ledger) Done() <-chan struct{} {
 	done := make(chan struct{})
-	close(done)
+	if l.history == nil {
+		close(done)
+		return done
+	}
+
+	l.stopHistory.Do(func() {
+		close(l.historyStop)
+	})
+	go func() {
+		<-l.historyDone
+		close(done)
+	}()
 	return done
 }
 
+// storeHistory stores the given tries, and then the updated tries in the historical store, until the
+// ledger is shut down.
+func (l *Ledger) storeHistory(backfill []*trie.MTrie) {
+	defer close(l.historyDone)
+
+	for _, t := range backfill {
+		select {
+		case <-l.historyStop:
+			return
+		default:
+		}
+		l.storeTrie(t)
+	}
+	l.logger.Info().Int("tries", len(backfill)).Msg("tries of the forest stored in historical store")
+
+	for {
+		select {
+		case <-l.historyStop:
+			return
+		case t := <-l.historyQueue:
+			l.storeTrie(t)
+		}
+	}
+}
+
+// storeTrie stores the trie in the historical store. Storing a trie is best-effort, as all updates
+// are recorded in the WAL and the forest. A trie which failed to be stored is only available until
+// it is evicted from the forest; tries stored later are unaffected, as they store all their nodes
+// which are not stored yet.
+func (l *Ledger) storeTrie(t *trie.MTrie) {
+	err := l.history.StoreTrie(t)
+	if err != nil {
+		rootHash := t.RootHash()
+		l.logger.Error().Err(err).
+			Hex("state", rootHash[:]).
+			Msg("cannot store trie in historical store")
+	}
+}
+
 // InitialState returns the state of an empty ledger
 func (l *Ledger) InitialState() ledger.State {
 	return ledger.State(l.forest.GetEmptyRootHash())
@@ -116,7 +243,7 @@ func (l *Ledger) ValueSizes(query *ledger.Query) (valueSizes []int, err error) {
 		return nil, err
 	}
 	trieRead := &ledger.TrieRead{RootHash: ledger.RootHash(query.State()), Paths: paths}
-	valueSizes, err = l.forest.ValueSizes(trieRead)
+	valueSizes, err = l.reader(query.State()).ValueSizes(trieRead)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +268,7 @@ func (l *Ledger) GetSingleValue(query *ledger.QuerySingleValue) (value ledger.Va
 		return nil, err
 	}
 	trieRead := &ledger.TrieReadSingleValue{RootHash: ledger.RootHash(query.State()), Path: path}
-	value, err = l.forest.ReadSingleValue(trieRead)
+	value, err = l.reader(query.State()).ReadSingleValue(trieRead)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +292,7 @@ func (l *Ledger) Get(query *ledger.Query) (values []ledger.Value, err error) {
 		return nil, err
 	}
 	trieRead := &ledger.TrieRead{RootHash: ledger.RootHash(query.State()), Paths: paths}
-	values, err = l.forest.Read(trieRead)
+	values, err = l.reader(query.State()).Read(trieRead)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +343,26 @@ func (l *Ledger) Set(update *ledger.Update) (newState ledger.State, trieUpdate *
 		return ledger.State(hash.DummyHash), nil, fmt.Errorf("error while writing LedgerWAL: %w", walError)
 	}
 
+	if l.history != nil {
+		// The update is already recorded in the WAL and the forest, so persisting the trie in the
+		// historical store is best-effort, and the trie is dropped if the historical store falls
+		// behind, rather than delaying the update.
+		newTrie, err := l.forest.GetTrie(newRootHash)
+		if err != nil {
+			l.logger.Error().Err(err).
+				Hex("state", newRootHash[:]).
+				Msg("cannot get updated trie to store in historical store")
+		} else {
+			select {
+			case l.historyQueue <- newTrie:
+			default:
+				l.logger.Warn().
+					Hex("state", newRootHash[:]).
+					Msg("historical store queue is full, updated trie is not stored")
+			}
+		}
+	}
+
 	// TODO update to proper value once https://github.com/onflow/flow-go/pull/3720 is merged
 	l.metrics.ForestApproxMemorySize(0)
 
@@ -248,7 +395,7 @@ func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
 	}
 
 	trieRead := &ledger.TrieRead{RootHash: ledger.RootHash(query.State()), Paths: paths}
-	batchProof, err := l.forest.Proofs(trieRead)
+	batchProof, err := l.reader(query.State()).Proofs(trieRead)
 	if err != nil {
 		return nil, fmt.Errorf("could not get proofs: %w", err)
 	}
@@ -399,7 +546,8 @@ func (l *Ledger) ExportCheckpointAt(
 
 	// Writing the checkpoint takes time to write and copy.
 	// Without relying on an exit code or stdout, we need to know when the copy is complete.
-	writeStatusFileErr := writeStatusFile("checkpoint_status.json", err)
+	// The status file is written next to the checkpoint.
+	writeStatusFileErr := writeStatusFile(filepath.Join(outputDir, "checkpoint_status.json"), err)
 	if writeStatusFileErr != nil {
 		return ledger.State(hash.DummyHash), fmt.Errorf("failed to write checkpoint status file: %w", writeStatusFileErr)
 	}
@@ -433,9 +581,45 @@ func (l *Ledger) MostRecentTouchedState() (ledger.State, error) {
 	return ledger.State(root), err
 }
 
-// HasState returns true if the given state exists inside the ledger
+// HasState returns true if the given state exists inside the ledger.
+// In historical mode, states stored in the historical store exist as well.
 func (l *Ledger) HasState(state ledger.State) bool {
-	return l.forest.HasTrie(ledger.RootHash(state))
+	availability, err := l.StateAvailability(state)
+	if err != nil {
+		l.logger.Error().Err(err).Hex("state", state[:]).Msg("failed to check state availability")
+		return false
+	}
+	return availability != StateUnavailable
+}
+
+// StateAvailability returns whether the given state is held in memory, stored on disk in the
+// historical store, or not available at all.
+// No errors are expected during normal operation.
+func (l *Ledger) StateAvailability(state ledger.State) (StateAvailability, error) {
+	if l.forest.HasTrie(ledger.RootHash(state)) {
+		return StateInMemory, nil
+	}
+	if l.history == nil {
+		return StateUnavailable, nil
+	}
+
+	stored, err := l.history.HasTrie(ledger.RootHash(state))
+	if err != nil {
+		return StateUnavailable, fmt.Errorf("cannot check historical store: %w", err)
+	}
+	if stored {
+		return StateOnDisk, nil
+	}
+	return StateUnavailable, nil
+}
+
+// reader returns the forest if the trie of the given state is held in memory. Otherwise, it returns
+// the historical store in historical mode, and the forest (which fails to find the trie) if not.
+func (l *Ledger) reader(state ledger.State) trieReader {
+	if l.history == nil || l.forest.HasTrie(ledger.RootHash(state)) {
+		return l.forest
+	}
+	return l.history
 }
 
 // DumpTrieAsJSON export trie at specific state as JSONL (each line is JSON encoding of a payload)
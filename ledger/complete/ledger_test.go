@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/proof"
 	"github.com/onflow/flow-go/ledger/common/utils"
 	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/history"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
 	"github.com/onflow/flow-go/ledger/partial/ptrie"
@@ -627,6 +630,7 @@ func Test_ExportCheckpointAt(t *testing.T) {
 				newState, err := led.ExportCheckpointAt(state, []ledger.Migration{noOpMigration}, []ledger.Reporter{}, []ledger.Reporter{}, complete.DefaultPathFinderVersion, dir2, "root.checkpoint")
 				require.NoError(t, err)
 				assert.Equal(t, newState, state)
+				assert.FileExists(t, filepath.Join(dir2, "checkpoint_status.json"))
 
 				diskWal2, err := wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir2, 100, pathfinder.PathByteSize, wal.SegmentSize)
 				require.NoError(t, err)
@@ -868,3 +872,141 @@ func migrationByKey(p []ledger.Payload) ([]ledger.Payload, error) {
 	}
 	return ret, nil
 }
+
+func TestLedger_History(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := history.NewStore(db, history.DefaultConfig())
+		require.NoError(t, err)
+
+		// the forest only holds the three most recent tries
+		led, err := complete.NewLedger(&fixtures.NoopWAL{}, 3, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion, complete.WithHistory(store))
+		require.NoError(t, err)
+
+		keys := utils.RandomUniqueKeys(20, 2, 1, 10)
+
+		// queries include keys which are never updated, for non-inclusion proofs
+		queryKeys := append(utils.RandomUniqueKeys(5, 2, 11, 20), keys...)
+
+		type stateData struct {
+			state  ledger.State
+			values []ledger.Value
+			proof  ledger.Proof
+		}
+		states := make([]stateData, 0)
+
+		state := led.InitialState()
+		for i := 0; i < 10; i++ {
+			updatedKeys := keys[i : i+10]
+			update, err := ledger.NewUpdate(state, updatedKeys, utils.RandomValues(len(updatedKeys), 1, 32))
+			require.NoError(t, err)
+
+			state, _, err = led.Set(update)
+			require.NoError(t, err)
+
+			query, err := ledger.NewQuery(state, queryKeys)
+			require.NoError(t, err)
+			values, err := led.Get(query)
+			require.NoError(t, err)
+			proof, err := led.Prove(query)
+			require.NoError(t, err)
+
+			states = append(states, stateData{state: state, values: values, proof: proof})
+		}
+
+		// the tries are stored in the historical store in the background
+		require.Eventually(t, func() bool {
+			for _, data := range states {
+				stored, err := store.HasTrie(ledger.RootHash(data.state))
+				if err != nil || !stored {
+					return false
+				}
+			}
+			return true
+		}, 5*time.Second, 10*time.Millisecond)
+
+		requireHistoricalReads := func(t *testing.T, led *complete.Ledger) {
+			for i, data := range states {
+				availability, err := led.StateAvailability(data.state)
+				require.NoError(t, err)
+				if i < len(states)-3 {
+					assert.Equal(t, complete.StateOnDisk, availability)
+				} else {
+					assert.Equal(t, complete.StateInMemory, availability)
+				}
+				assert.True(t, led.HasState(data.state))
+
+				query, err := ledger.NewQuery(data.state, queryKeys)
+				require.NoError(t, err)
+
+				values, err := led.Get(query)
+				require.NoError(t, err)
+				assert.Equal(t, data.values, values)
+
+				singleQuery, err := ledger.NewQuerySingleValue(data.state, queryKeys[len(queryKeys)-1])
+				require.NoError(t, err)
+				value, err := led.GetSingleValue(singleQuery)
+				require.NoError(t, err)
+				assert.Equal(t, data.values[len(data.values)-1], value)
+
+				sizes, err := led.ValueSizes(query)
+				require.NoError(t, err)
+				for j, size := range sizes {
+					assert.Equal(t, len(data.values[j]), size)
+				}
+
+				retProof, err := led.Prove(query)
+				require.NoError(t, err)
+				assert.Equal(t, data.proof, retProof)
+
+				trieProof, err := encoding.DecodeTrieBatchProof(retProof)
+				require.NoError(t, err)
+				assert.True(t, proof.VerifyTrieBatchProof(trieProof, data.state))
+			}
+		}
+
+		t.Run("evicted states are read from disk", func(t *testing.T) {
+			requireHistoricalReads(t, led)
+		})
+
+		t.Run("unknown states are unavailable", func(t *testing.T) {
+			unknown := ledger.State(unittest.StateCommitmentFixture())
+			availability, err := led.StateAvailability(unknown)
+			require.NoError(t, err)
+			assert.Equal(t, complete.StateUnavailable, availability)
+			assert.False(t, led.HasState(unknown))
+
+			query, err := ledger.NewQuery(unknown, queryKeys)
+			require.NoError(t, err)
+			_, err = led.Get(query)
+			require.ErrorIs(t, err, history.ErrTrieNotFound)
+		})
+
+		t.Run("states are available after restart", func(t *testing.T) {
+			store, err := history.NewStore(db, history.DefaultConfig())
+			require.NoError(t, err)
+
+			// without a WAL, the forest of the restarted ledger is empty
+			restarted, err := complete.NewLedger(&fixtures.NoopWAL{}, 3, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion, complete.WithHistory(store))
+			require.NoError(t, err)
+
+			for _, data := range states {
+				availability, err := restarted.StateAvailability(data.state)
+				require.NoError(t, err)
+				assert.Equal(t, complete.StateOnDisk, availability)
+
+				query, err := ledger.NewQuery(data.state, queryKeys)
+				require.NoError(t, err)
+				values, err := restarted.Get(query)
+				require.NoError(t, err)
+				assert.Equal(t, data.values, values)
+
+				retProof, err := restarted.Prove(query)
+				require.NoError(t, err)
+				assert.Equal(t, data.proof, retProof)
+			}
+			<-restarted.Done()
+		})
+
+		<-led.Done()
+	})
+}
@@ -99,6 +99,14 @@ func NewNodeIterator(mTrie *trie.MTrie) *NodeIterator {
 // as for each node, the children have been previously encountered.
 // WARNING: visitedNodes is not safe for concurrent use.
 func NewUniqueNodeIterator(mTrie *trie.MTrie, visitedNodes map[*node.Node]uint64) *NodeIterator {
+	return NewUniqueSubtrieNodeIterator(mTrie.RootNode(), visitedNodes)
+}
+
+// NewUniqueSubtrieNodeIterator returns a node NodeIterator, which iterates through all
+// unique nodes of the sub-trie with the given root node that weren't visited.
+// It provides the same guarantees as the NodeIterator returned by NewUniqueNodeIterator.
+// WARNING: visitedNodes is not safe for concurrent use.
+func NewUniqueSubtrieNodeIterator(root *node.Node, visitedNodes map[*node.Node]uint64) *NodeIterator {
 	// For a Trie with height H (measured by number of edges), the longest possible path
 	// contains H+1 vertices.
 	stackSize := ledger.NodeMaxHeight + 1
@@ -106,7 +114,7 @@ func NewUniqueNodeIterator(mTrie *trie.MTrie, visitedNodes map[*node.Node]uint64
 		stack:        make([]*node.Node, 0, stackSize),
 		visitedNodes: visitedNodes,
 	}
-	i.unprocessedRoot = mTrie.RootNode()
+	i.unprocessedRoot = root
 	return i
 }
 
@@ -115,7 +123,8 @@ func (i *NodeIterator) Next() bool {
 		// initial call to Next() for a non-empty trie
 		i.dig(i.unprocessedRoot)
 		i.unprocessedRoot = nil
-		return true
+		// the stack is empty if the root node was already visited
+		return len(i.stack) > 0
 	}
 
 	// the current head of the stack, `n`, has been recalled
@@ -264,5 +264,10 @@ func TestUniqueNodeIterator(t *testing.T) {
 			}
 		}
 		require.Equal(t, i, len(expectedNodes))
+
+		// Iterating a trie whose root node was already visited doesn't return any node.
+		itr := flattener.NewUniqueNodeIterator(trie3, visitedNodes)
+		require.False(t, itr.Next())
+		require.Nil(t, itr.Value())
 	})
 }
@@ -0,0 +1,611 @@
+package wal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/model/bootstrap"
+)
+
+const (
+	// DefaultCheckpointPartSize is the default size of the parts in which checkpoint files are
+	// transferred to and from object storage.
+	DefaultCheckpointPartSize = 64 * 1024 * 1024 // 64 MiB
+
+	// DefaultCheckpointTransferConcurrency is the default number of parts which are transferred
+	// concurrently.
+	DefaultCheckpointTransferConcurrency = 8
+
+	manifestObjectName   = "manifest.json"
+	partObjectNamePrefix = "part."
+)
+
+// ErrObjectNotFound is returned by an ObjectStore if the requested object does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is a pluggable object storage, such as a cloud storage bucket or a local
+// directory, to which checkpoint files can be uploaded and from which they can be downloaded.
+type ObjectStore interface {
+	// Put stores the object with the given name, replacing an existing object with the same name.
+	Put(ctx context.Context, name string, reader io.Reader) error
+
+	// Get returns a reader for the object with the given name, which must be closed by the caller.
+	// Returns ErrObjectNotFound if the object does not exist.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// List returns the names of all objects whose name starts with the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// checkpointManifest describes an uploaded checkpoint file. A checkpoint file is uploaded as
+// a sequence of part objects, followed by the manifest object. The manifest is uploaded last,
+// so a checkpoint is only available for download once all its parts were uploaded.
+type checkpointManifest struct {
+	Version        uint16
+	BaseCheckpoint int
+	Size           int64
+	PartSize       int64
+	PartChecksums  []uint32
+	Checksum       uint32
+}
+
+// partRange returns the offset and length of the given part in the checkpoint file.
+func (m *checkpointManifest) partRange(part int) (int64, int64) {
+	offset := int64(part) * m.PartSize
+	length := m.PartSize
+	if offset+length > m.Size {
+		length = m.Size - offset
+	}
+	return offset, length
+}
+
+// validate checks that the parts of the manifest cover the checkpoint file.
+func (m *checkpointManifest) validate() error {
+	if m.PartSize <= 0 {
+		return fmt.Errorf("invalid part size %d", m.PartSize)
+	}
+	if m.Size < 0 {
+		return fmt.Errorf("invalid size %d", m.Size)
+	}
+	parts := (m.Size + m.PartSize - 1) / m.PartSize
+	if int64(len(m.PartChecksums)) != parts {
+		return fmt.Errorf("expected %d part checksums for size %d and part size %d, got %d", parts, m.Size, m.PartSize, len(m.PartChecksums))
+	}
+	return nil
+}
+
+type transferConfig struct {
+	partSize    int64
+	concurrency int
+}
+
+// TransferOption is a functional option for checkpoint uploads and downloads.
+type TransferOption func(*transferConfig)
+
+// WithPartSize sets the size of the parts in which checkpoint files are uploaded.
+// The part size of downloads is determined by the upload.
+func WithPartSize(partSize int64) TransferOption {
+	return func(c *transferConfig) {
+		c.partSize = partSize
+	}
+}
+
+// WithTransferConcurrency sets the number of parts which are transferred concurrently.
+func WithTransferConcurrency(concurrency int) TransferOption {
+	return func(c *transferConfig) {
+		c.concurrency = concurrency
+	}
+}
+
+func newTransferConfig(opts []TransferOption) (*transferConfig, error) {
+	config := &transferConfig{
+		partSize:    DefaultCheckpointPartSize,
+		concurrency: DefaultCheckpointTransferConcurrency,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.partSize <= 0 {
+		return nil, fmt.Errorf("part size must be positive, got %d", config.partSize)
+	}
+	if config.concurrency <= 0 {
+		return nil, fmt.Errorf("transfer concurrency must be positive, got %d", config.concurrency)
+	}
+	return config, nil
+}
+
+func manifestName(filename string) string {
+	return path.Join(filename, manifestObjectName)
+}
+
+func partName(filename string, part int) string {
+	return path.Join(filename, fmt.Sprintf("%s%06d", partObjectNamePrefix, part))
+}
+
+// UploadCheckpoint uploads the given checkpoint to the object store. For delta checkpoints,
+// all checkpoints of the chain of the checkpoint are uploaded, so that the checkpoint can be
+// downloaded and loaded on another node. Checkpoints which were already uploaded with the same
+// content are skipped.
+func (c *Checkpointer) UploadCheckpoint(ctx context.Context, store ObjectStore, checkpoint int, opts ...TransferOption) error {
+	config, err := newTransferConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	chain, err := c.CheckpointChain(checkpoint)
+	if err != nil {
+		return fmt.Errorf("cannot get chain of checkpoint %d: %w", checkpoint, err)
+	}
+
+	for _, number := range chain {
+		err = c.uploadFile(ctx, store, NumberToFilename(number), config)
+		if err != nil {
+			return fmt.Errorf("cannot upload checkpoint %d: %w", number, err)
+		}
+	}
+
+	return nil
+}
+
+// UploadRootCheckpoint uploads the root checkpoint to the object store.
+func (c *Checkpointer) UploadRootCheckpoint(ctx context.Context, store ObjectStore, opts ...TransferOption) error {
+	config, err := newTransferConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	err = c.uploadFile(ctx, store, bootstrap.FilenameWALRootCheckpoint, config)
+	if err != nil {
+		return fmt.Errorf("cannot upload root checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadCheckpoint downloads the given checkpoint from the object store into the checkpoint
+// directory. For delta checkpoints, the base checkpoints are downloaded first, unless they
+// already exist with the same content. Each part is verified against its checksum while it is
+// downloaded, and the whole file is verified against its checksum before it is moved into the
+// checkpoint directory.
+// Returns an error wrapping ErrObjectNotFound if the checkpoint was not uploaded.
+func (c *Checkpointer) DownloadCheckpoint(ctx context.Context, store ObjectStore, checkpoint int, opts ...TransferOption) error {
+	config, err := newTransferConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	return c.downloadCheckpoint(ctx, store, checkpoint, config)
+}
+
+func (c *Checkpointer) downloadCheckpoint(ctx context.Context, store ObjectStore, checkpoint int, config *transferConfig) error {
+	filename := NumberToFilename(checkpoint)
+
+	manifest, err := readManifest(ctx, store, filename)
+	if err != nil {
+		return fmt.Errorf("cannot read manifest of checkpoint %d: %w", checkpoint, err)
+	}
+
+	if manifest.BaseCheckpoint != -1 {
+		if manifest.BaseCheckpoint >= checkpoint {
+			return fmt.Errorf("checkpoint %d has invalid base checkpoint %d", checkpoint, manifest.BaseCheckpoint)
+		}
+
+		err = c.downloadCheckpoint(ctx, store, manifest.BaseCheckpoint, config)
+		if err != nil {
+			return fmt.Errorf("cannot download base of checkpoint %d: %w", checkpoint, err)
+		}
+	}
+
+	err = c.downloadFile(ctx, store, filename, manifest, config)
+	if err != nil {
+		return fmt.Errorf("cannot download checkpoint %d: %w", checkpoint, err)
+	}
+
+	return nil
+}
+
+// DownloadRootCheckpoint downloads the root checkpoint from the object store into the
+// checkpoint directory. See DownloadCheckpoint for details on the verification.
+// Returns an error wrapping ErrObjectNotFound if the root checkpoint was not uploaded.
+func (c *Checkpointer) DownloadRootCheckpoint(ctx context.Context, store ObjectStore, opts ...TransferOption) error {
+	config, err := newTransferConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := readManifest(ctx, store, bootstrap.FilenameWALRootCheckpoint)
+	if err != nil {
+		return fmt.Errorf("cannot read manifest of root checkpoint: %w", err)
+	}
+
+	if manifest.BaseCheckpoint != -1 {
+		return fmt.Errorf("root checkpoint must not be a delta checkpoint, has base checkpoint %d", manifest.BaseCheckpoint)
+	}
+
+	err = c.downloadFile(ctx, store, bootstrap.FilenameWALRootCheckpoint, manifest, config)
+	if err != nil {
+		return fmt.Errorf("cannot download root checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// UploadedCheckpoints returns the numbers of all checkpoints which were completely uploaded to
+// the object store in asc order.
+func UploadedCheckpoints(ctx context.Context, store ObjectStore) ([]int, error) {
+	names, err := store.List(ctx, checkpointFilenamePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list uploaded checkpoints: %w", err)
+	}
+
+	checkpoints := make([]int, 0)
+	for _, name := range names {
+		filename := strings.TrimSuffix(name, "/"+manifestObjectName)
+		if filename == name {
+			continue
+		}
+		number, ok := checkpointNumberFromFilename(filename)
+		if !ok || filename != NumberToFilename(number) {
+			continue
+		}
+		checkpoints = append(checkpoints, number)
+	}
+
+	sort.Ints(checkpoints)
+
+	return checkpoints, nil
+}
+
+// uploadFile uploads the given file of the checkpoint directory in parts, followed by its
+// manifest. The upload is skipped if the file was already uploaded with the same content.
+func (c *Checkpointer) uploadFile(ctx context.Context, store ObjectStore, filename string, config *transferConfig) error {
+	filepath := path.Join(c.dir, filename)
+
+	version, base, err := readCheckpointHeader(filepath)
+	if err != nil {
+		return err
+	}
+
+	size, checksum, err := fileChecksum(filepath)
+	if err != nil {
+		return err
+	}
+
+	uploaded, err := readManifest(ctx, store, filename)
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return fmt.Errorf("cannot read manifest: %w", err)
+	}
+	if err == nil && uploaded.Size == size && uploaded.Checksum == checksum {
+		c.wal.log.Info().Msgf("checkpoint file %s was already uploaded, skipping", filename)
+		return nil
+	}
+
+	manifest := &checkpointManifest{
+		Version:        version,
+		BaseCheckpoint: base,
+		Size:           size,
+		PartSize:       config.partSize,
+		Checksum:       checksum,
+	}
+	manifest.PartChecksums = make([]uint32, (size+config.partSize-1)/config.partSize)
+
+	file, err := os.Open(filepath)
+	if err != nil {
+		return fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	c.wal.log.Info().Msgf("uploading checkpoint file %s in %d parts", filename, len(manifest.PartChecksums))
+
+	err = transferParts(ctx, len(manifest.PartChecksums), config.concurrency, func(ctx context.Context, part int) error {
+		offset, length := manifest.partRange(part)
+
+		crc32Writer := NewCRC32Writer(io.Discard)
+		reader := io.TeeReader(io.NewSectionReader(file, offset, length), crc32Writer)
+
+		err := store.Put(ctx, partName(filename, part), reader)
+		if err != nil {
+			return fmt.Errorf("cannot upload part %d: %w", part, err)
+		}
+
+		manifest.PartChecksums[part] = crc32Writer.Crc32()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("cannot encode manifest: %w", err)
+	}
+
+	err = store.Put(ctx, manifestName(filename), bytes.NewReader(encoded))
+	if err != nil {
+		return fmt.Errorf("cannot upload manifest: %w", err)
+	}
+
+	c.wal.log.Info().Msgf("uploaded checkpoint file %s", filename)
+
+	return nil
+}
+
+// downloadFile downloads the parts of the given file into a temporary file, verifies the
+// checksums of the parts and of the whole file, and moves the file into the checkpoint
+// directory. The download is skipped if the file already exists with the same content.
+func (c *Checkpointer) downloadFile(ctx context.Context, store ObjectStore, filename string, manifest *checkpointManifest, config *transferConfig) error {
+	filepath := path.Join(c.dir, filename)
+
+	_, err := os.Stat(filepath)
+	if err == nil {
+		size, checksum, err := fileChecksum(filepath)
+		if err != nil {
+			return err
+		}
+		if size != manifest.Size || checksum != manifest.Checksum {
+			return fmt.Errorf("checkpoint file %s already exists with different content", filepath)
+		}
+		c.wal.log.Info().Msgf("checkpoint file %s was already downloaded, skipping", filename)
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("cannot check checkpoint file %s: %w", filepath, err)
+	}
+
+	tmpFile, err := os.CreateTemp(c.dir, "downloading-chkpnt-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file for checkpoint %s: %w", filename, err)
+	}
+
+	err = c.downloadParts(ctx, store, filename, tmpFile, manifest, config)
+	if err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("cannot close temporary file %s: %w", tmpFile.Name(), err)
+	}
+
+	err = verifyDownloadedFile(tmpFile.Name(), manifest)
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("downloaded checkpoint file %s is invalid: %w", filename, err)
+	}
+
+	err = os.Rename(tmpFile.Name(), filepath)
+	if err != nil {
+		return fmt.Errorf("error while renaming from %s to %s: %w", tmpFile.Name(), filepath, err)
+	}
+
+	c.wal.log.Info().Msgf("downloaded checkpoint file %s", filename)
+
+	return nil
+}
+
+// downloadParts downloads all parts of the given file into the given file, and verifies the
+// checksum of each part.
+func (c *Checkpointer) downloadParts(ctx context.Context, store ObjectStore, filename string, file *os.File, manifest *checkpointManifest, config *transferConfig) error {
+	c.wal.log.Info().Msgf("downloading checkpoint file %s in %d parts", filename, len(manifest.PartChecksums))
+
+	err := transferParts(ctx, len(manifest.PartChecksums), config.concurrency, func(ctx context.Context, part int) error {
+		offset, length := manifest.partRange(part)
+
+		reader, err := store.Get(ctx, partName(filename, part))
+		if err != nil {
+			return fmt.Errorf("cannot download part %d: %w", part, err)
+		}
+		defer reader.Close()
+
+		crc32Writer := NewCRC32Writer(&offsetWriter{file: file, offset: offset})
+
+		// read one more byte than expected to detect parts which are too large
+		n, err := io.Copy(crc32Writer, io.LimitReader(reader, length+1))
+		if err != nil {
+			return fmt.Errorf("cannot download part %d: %w", part, err)
+		}
+		if n != length {
+			return fmt.Errorf("part %d has size %d, expected %d", part, n, length)
+		}
+
+		if crc32Writer.Crc32() != manifest.PartChecksums[part] {
+			return fmt.Errorf("part %d has checksum %x, expected %x", part, crc32Writer.Crc32(), manifest.PartChecksums[part])
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("cannot sync file %s: %w", file.Name(), err)
+	}
+
+	return nil
+}
+
+// verifyDownloadedFile verifies the checksum and the header of the downloaded file against
+// the manifest, as well as the checksums embedded in the checkpoint file itself, which cover
+// the case of a checkpoint which was already corrupted when it was uploaded.
+func verifyDownloadedFile(filepath string, manifest *checkpointManifest) error {
+	size, checksum, err := fileChecksum(filepath)
+	if err != nil {
+		return err
+	}
+	if size != manifest.Size {
+		return fmt.Errorf("file has size %d, expected %d", size, manifest.Size)
+	}
+	if checksum != manifest.Checksum {
+		return fmt.Errorf("file has checksum %x, expected %x", checksum, manifest.Checksum)
+	}
+
+	version, base, err := readCheckpointHeader(filepath)
+	if err != nil {
+		return err
+	}
+	if version != manifest.Version || base != manifest.BaseCheckpoint {
+		return fmt.Errorf("file has version %d and base checkpoint %d, expected version %d and base checkpoint %d",
+			version, base, manifest.Version, manifest.BaseCheckpoint)
+	}
+
+	err = verifyCheckpointChecksums(filepath, version)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+// verifyCheckpointChecksums verifies the CRC32 sums embedded in the given checkpoint file,
+// without decoding the checkpoint. Version 7 checkpoints have a CRC32 sum for each part and
+// for the footer, while version 4 to 6 checkpoints have a CRC32 sum of the whole file.
+func verifyCheckpointChecksums(filepath string, version uint16) error {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	fstat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat checkpoint file %s: %w", filepath, err)
+	}
+	fileSize := fstat.Size()
+
+	switch version {
+	case VersionV7:
+		footer, err := readFooterV7(file)
+		if err != nil {
+			return err
+		}
+		for part := 0; part <= subtrieCount; part++ {
+			size := footer.partSize(part, fileSize)
+			err = verifySectionCRC32(io.NewSectionReader(file, int64(footer.partOffsets[part]), size), size)
+			if err != nil {
+				return fmt.Errorf("cannot verify part %d: %w", part, err)
+			}
+		}
+		return nil
+	case VersionV4, VersionV5, VersionV6:
+		return verifySectionCRC32(io.NewSectionReader(file, 0, fileSize), fileSize)
+	default:
+		return fmt.Errorf("checkpoint version %d has no checksum", version)
+	}
+}
+
+// verifySectionCRC32 verifies that the last 4 bytes of the section of the given size are the
+// CRC32 sum of the preceding bytes.
+func verifySectionCRC32(reader io.Reader, size int64) error {
+	if size < crc32SumSize {
+		return fmt.Errorf("section of size %d is too small for a checksum", size)
+	}
+
+	bufReader := bufio.NewReaderSize(reader, defaultBufioReadSize)
+	crc32Writer := NewCRC32Writer(io.Discard)
+	_, err := io.CopyN(crc32Writer, bufReader, size-crc32SumSize)
+	if err != nil {
+		return fmt.Errorf("cannot read section: %w", err)
+	}
+
+	return verifyCRC32(bufReader, crc32Writer.Crc32())
+}
+
+// readManifest downloads and decodes the manifest of the given file.
+// Returns an error wrapping ErrObjectNotFound if the file was not completely uploaded.
+func readManifest(ctx context.Context, store ObjectStore, filename string) (*checkpointManifest, error) {
+	reader, err := store.Get(ctx, manifestName(filename))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var manifest checkpointManifest
+	err = json.NewDecoder(reader).Decode(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode manifest: %w", err)
+	}
+
+	err = manifest.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// fileChecksum returns the size and the CRC32 checksum of the given file.
+func fileChecksum(filepath string) (int64, uint32, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot open file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	crc32Writer := NewCRC32Writer(io.Discard)
+	size, err := io.Copy(crc32Writer, bufio.NewReaderSize(file, defaultBufioReadSize))
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot read file %s: %w", filepath, err)
+	}
+
+	return size, crc32Writer.Crc32(), nil
+}
+
+// transferParts calls transfer for all parts, with at most the given number of concurrent calls.
+func transferParts(ctx context.Context, count int, concurrency int, transfer func(ctx context.Context, part int) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	parts := make(chan int)
+	g.Go(func() error {
+		defer close(parts)
+		for part := 0; part < count; part++ {
+			select {
+			case parts <- part:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+
+	for i := 0; i < concurrency; i++ {
+		g.Go(func() error {
+			for part := range parts {
+				err := transfer(ctx, part)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// offsetWriter writes to the file starting at the given offset.
+type offsetWriter struct {
+	file   *os.File
+	offset int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.file.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
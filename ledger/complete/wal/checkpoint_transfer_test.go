@@ -0,0 +1,219 @@
+package wal
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func newTestCheckpointer(t *testing.T, dir string) *Checkpointer {
+	wal, err := NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, 100, 32, 32*1024)
+	require.NoError(t, err)
+
+	checkpointer, err := wal.NewCheckpointer()
+	require.NoError(t, err)
+
+	return checkpointer
+}
+
+func TestCheckpointTransfer(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		srcDir := filepath.Join(dir, "src")
+		dstDir := filepath.Join(dir, "dst")
+		storeDir := filepath.Join(dir, "store")
+		require.NoError(t, os.Mkdir(srcDir, 0700))
+		require.NoError(t, os.Mkdir(dstDir, 0700))
+
+		// a full checkpoint, a delta checkpoint on top of it, and a root checkpoint
+		tries := createTries(t)
+		logger := zerolog.Nop()
+
+		storeTestCheckpoint(t, filepath.Join(srcDir, NumberToFilename(1)), func(file *os.File) error {
+			return StoreCheckpoint(file, tries[:6]...)
+		})
+		baseNodes, _, err := loadCheckpointNodes(filepath.Join(srcDir, NumberToFilename(1)), &logger)
+		require.NoError(t, err)
+		storeTestCheckpoint(t, filepath.Join(srcDir, NumberToFilename(2)), func(file *os.File) error {
+			return StoreDeltaCheckpoint(file, 1, baseNodes, tries[6:]...)
+		})
+		storeTestCheckpoint(t, filepath.Join(srcDir, bootstrap.FilenameWALRootCheckpoint), func(file *os.File) error {
+			return StoreCheckpoint(file, tries[len(tries)-1])
+		})
+
+		src := newTestCheckpointer(t, srcDir)
+		dst := newTestCheckpointer(t, dstDir)
+		store := NewDirectoryStore(storeDir)
+		ctx := context.Background()
+
+		// small parts, so that checkpoints are split into many parts
+		opts := []TransferOption{WithPartSize(1000), WithTransferConcurrency(4)}
+
+		requireEqualFiles := func(t *testing.T, filename string) {
+			expected, err := os.ReadFile(filepath.Join(srcDir, filename))
+			require.NoError(t, err)
+			actual, err := os.ReadFile(filepath.Join(dstDir, filename))
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		}
+
+		t.Run("checkpoint not uploaded", func(t *testing.T) {
+			err := dst.DownloadCheckpoint(ctx, store, 2, opts...)
+			require.ErrorIs(t, err, ErrObjectNotFound)
+
+			err = dst.DownloadRootCheckpoint(ctx, store, opts...)
+			require.ErrorIs(t, err, ErrObjectNotFound)
+		})
+
+		t.Run("delta checkpoint is uploaded with its base", func(t *testing.T) {
+			require.NoError(t, src.UploadCheckpoint(ctx, store, 2, opts...))
+
+			uploaded, err := UploadedCheckpoints(ctx, store)
+			require.NoError(t, err)
+			assert.Equal(t, []int{1, 2}, uploaded)
+
+			// uploading again is a no-op
+			require.NoError(t, src.UploadCheckpoint(ctx, store, 2, opts...))
+		})
+
+		t.Run("delta checkpoint is downloaded with its base", func(t *testing.T) {
+			require.NoError(t, dst.DownloadCheckpoint(ctx, store, 2, opts...))
+
+			requireEqualFiles(t, NumberToFilename(1))
+			requireEqualFiles(t, NumberToFilename(2))
+
+			loaded, err := dst.LoadCheckpoint(2)
+			require.NoError(t, err)
+			requireEqualTries(t, tries[6:], loaded)
+
+			// downloading again is a no-op
+			require.NoError(t, dst.DownloadCheckpoint(ctx, store, 2, opts...))
+		})
+
+		t.Run("root checkpoint", func(t *testing.T) {
+			require.NoError(t, src.UploadRootCheckpoint(ctx, store, opts...))
+			require.NoError(t, dst.DownloadRootCheckpoint(ctx, store, opts...))
+
+			requireEqualFiles(t, bootstrap.FilenameWALRootCheckpoint)
+
+			loaded, err := dst.LoadRootCheckpoint()
+			require.NoError(t, err)
+			requireEqualTries(t, tries[len(tries)-1:], loaded)
+
+			// the root checkpoint is not a numbered checkpoint
+			uploaded, err := UploadedCheckpoints(ctx, store)
+			require.NoError(t, err)
+			assert.Equal(t, []int{1, 2}, uploaded)
+		})
+
+		t.Run("corrupted part is rejected", func(t *testing.T) {
+			corruptedDir := filepath.Join(dir, "corrupted")
+			require.NoError(t, os.Mkdir(corruptedDir, 0700))
+			corrupted := newTestCheckpointer(t, corruptedDir)
+
+			partFile := filepath.Join(storeDir, filepath.FromSlash(partName(NumberToFilename(1), 1)))
+			data, err := os.ReadFile(partFile)
+			require.NoError(t, err)
+			data[0] ^= 0xFF
+			require.NoError(t, os.WriteFile(partFile, data, 0600))
+
+			err = corrupted.DownloadCheckpoint(ctx, store, 2, opts...)
+			require.Error(t, err)
+
+			// neither the corrupted checkpoint nor its delta checkpoint are created
+			checkpoints, err := corrupted.Checkpoints()
+			require.NoError(t, err)
+			assert.Empty(t, checkpoints)
+
+			files, err := os.ReadDir(corruptedDir)
+			require.NoError(t, err)
+			for _, file := range files {
+				assert.NotContains(t, file.Name(), "downloading-chkpnt")
+			}
+
+			// a local checkpoint with different content is not overwritten
+			err = dst.DownloadCheckpoint(ctx, store, 1, opts...)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(dstDir, NumberToFilename(1)), []byte("different"), 0600))
+			err = dst.DownloadCheckpoint(ctx, store, 1, opts...)
+			require.Error(t, err)
+		})
+
+		t.Run("checkpoint corrupted before upload is rejected", func(t *testing.T) {
+			// the manifest is created from the corrupted file, so only the checksums embedded
+			// in the checkpoint itself detect the corruption
+			storeTestCheckpoint(t, filepath.Join(srcDir, NumberToFilename(3)), func(file *os.File) error {
+				return StoreCheckpoint(file, tries[:2]...)
+			})
+			corruptFile(t, filepath.Join(srcDir, NumberToFilename(3)))
+
+			require.NoError(t, src.UploadCheckpoint(ctx, store, 3, opts...))
+			err := dst.DownloadCheckpoint(ctx, store, 3, opts...)
+			require.Error(t, err)
+
+			_, err = os.Stat(filepath.Join(dstDir, NumberToFilename(3)))
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+
+		t.Run("embedded checksums of delta checkpoint", func(t *testing.T) {
+			filename := filepath.Join(dir, "delta")
+			data, err := os.ReadFile(filepath.Join(srcDir, NumberToFilename(2)))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filename, data, 0600))
+			require.NoError(t, verifyCheckpointChecksums(filename, VersionV6))
+
+			corruptFile(t, filename)
+			require.Error(t, verifyCheckpointChecksums(filename, VersionV6))
+		})
+	})
+}
+
+// corruptFile flips a byte in the middle of the given file.
+func corruptFile(t *testing.T, filename string) {
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xFF
+	require.NoError(t, os.WriteFile(filename, data, 0600))
+}
+
+func TestDirectoryStore(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		store := NewDirectoryStore(dir)
+		ctx := context.Background()
+
+		_, err := store.Get(ctx, "a/b")
+		require.ErrorIs(t, err, ErrObjectNotFound)
+
+		require.NoError(t, store.Put(ctx, "a/b", strings.NewReader("b")))
+		require.NoError(t, store.Put(ctx, "a/c", strings.NewReader("c")))
+		require.NoError(t, store.Put(ctx, "d", strings.NewReader("d")))
+
+		// objects are replaced
+		require.NoError(t, store.Put(ctx, "a/b", strings.NewReader("bb")))
+
+		reader, err := store.Get(ctx, "a/b")
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		assert.Equal(t, "bb", string(data))
+
+		names, err := store.List(ctx, "a/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a/b", "a/c"}, names)
+
+		names, err = store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a/b", "a/c", "d"}, names)
+	})
+}
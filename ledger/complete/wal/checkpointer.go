@@ -47,9 +47,21 @@ const VersionV4 uint16 = 0x04
 // See EncodeNode() and EncodeTrie() for more details.
 const VersionV5 uint16 = 0x05
 
+// Version 6 is a delta checkpoint, which references a base checkpoint and only contains
+// the nodes which are not contained in the base checkpoint and its own bases.
+// Nodes and tries are encoded the same way as in version 5.
+// See StoreDeltaCheckpoint() for more details.
+const VersionV6 uint16 = 0x06
+
+// Version 7 splits the nodes into parts for the sub-tries at a fixed level, and a part for
+// the nodes above this level. The parts can be decoded independently and concurrently.
+// Nodes and tries are encoded the same way as in version 5.
+// See StoreCheckpoint() for more details.
+const VersionV7 uint16 = 0x07
+
 // MaxVersion is the latest checkpoint version we support.
 // Need to update MaxVersion when creating a newer version.
-const MaxVersion = VersionV5
+const MaxVersion = VersionV7
 
 const (
 	encMagicSize     = 2
@@ -58,6 +70,8 @@ const (
 	encNodeCountSize = 8
 	encTrieCountSize = 2
 	crc32SumSize     = 4
+
+	encBaseCheckpointSize = 8
 )
 
 // defaultBufioReadSize replaces the default bufio buffer size of 4096 bytes.
@@ -278,8 +292,10 @@ func CreateCheckpointWriterForFile(dir, filename string, logger *zerolog.Logger)
 	}, nil
 }
 
-// StoreCheckpoint writes the given tries to checkpoint file, and also appends
+// StoreCheckpointV5 writes the given tries to checkpoint file (version 5), and also appends
 // a CRC32 file checksum for integrity check.
+// New checkpoints are written by StoreCheckpoint, this function is used to create
+// checkpoints for backwards compatibility.
 // Checkpoint file consists of a flattened forest. Specifically, it consists of:
 //   * a list of encoded nodes, where references to other nodes are by list index.
 //   * a list of encoded tries, each referencing their respective root node by index.
@@ -292,7 +308,7 @@ func CreateCheckpointWriterForFile(dir, filename string, logger *zerolog.Logger)
 // as for each node, the children have been previously encountered.
 // TODO: evaluate alternatives to CRC32 since checkpoint file is many GB in size.
 // TODO: add concurrency if the performance gains are enough to offset complexity.
-func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
+func StoreCheckpointV5(writer io.Writer, tries ...*trie.MTrie) error {
 
 	crc32Writer := NewCRC32Writer(writer)
 
@@ -319,45 +335,92 @@ func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
 	allNodes := make(map[*node.Node]uint64)
 	allNodes[nil] = 0
 
+	err = storeNodesAndTries(crc32Writer, allNodes, tries, scratch)
+	if err != nil {
+		return err
+	}
+
+	// Write footer with nodes count and tries count
+	footer := scratch[:encNodeCountSize+encTrieCountSize]
+	binary.BigEndian.PutUint64(footer, uint64(len(allNodes)-1)) // -1 to account for 0 node meaning nil
+	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	// Write CRC32 sum
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32Writer.Crc32())
+
+	_, err = writer.Write(crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	return nil
+}
+
+// storeNodesAndTries serializes all unique nodes of the given tries which are not in
+// allNodes yet, followed by the given tries. New nodes are indexed after the nodes
+// which are already in allNodes, and are added to allNodes.
+func storeNodesAndTries(writer io.Writer, allNodes map[*node.Node]uint64, tries []*trie.MTrie, scratch []byte) error {
+
 	// Serialize all unique nodes
-	nodeCounter := uint64(1) // start from 1, as 0 marks nil node
+	nodeCounter := uint64(len(allNodes)) // allNodes contains the nil node with index 0
 	for _, t := range tries {
-
+		var err error
 		// Traverse all unique nodes for trie t.
-		for itr := flattener.NewUniqueNodeIterator(t, allNodes); itr.Next(); {
-			n := itr.Value()
+		nodeCounter, err = storeUniqueNodes(writer, flattener.NewUniqueNodeIterator(t, allNodes), allNodes, nodeCounter, scratch)
+		if err != nil {
+			return err
+		}
+	}
 
-			allNodes[n] = nodeCounter
-			nodeCounter++
+	return storeTries(writer, allNodes, tries, scratch)
+}
+
+// storeUniqueNodes serializes all nodes of the given iterator, and adds them to allNodes
+// starting with the given node index. Children of the nodes must be in allNodes.
+// It returns the node index following the last serialized node.
+func storeUniqueNodes(writer io.Writer, itr *flattener.NodeIterator, allNodes map[*node.Node]uint64, nodeCounter uint64, scratch []byte) (uint64, error) {
+	for ; itr.Next(); nodeCounter++ {
+		n := itr.Value()
+
+		allNodes[n] = nodeCounter
 
-			var lchildIndex, rchildIndex uint64
+		var lchildIndex, rchildIndex uint64
 
-			if lchild := n.LeftChild(); lchild != nil {
-				var found bool
-				lchildIndex, found = allNodes[lchild]
-				if !found {
-					hash := lchild.Hash()
-					return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
-				}
+		if lchild := n.LeftChild(); lchild != nil {
+			var found bool
+			lchildIndex, found = allNodes[lchild]
+			if !found {
+				hash := lchild.Hash()
+				return 0, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
 			}
-			if rchild := n.RightChild(); rchild != nil {
-				var found bool
-				rchildIndex, found = allNodes[rchild]
-				if !found {
-					hash := rchild.Hash()
-					return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
-				}
+		}
+		if rchild := n.RightChild(); rchild != nil {
+			var found bool
+			rchildIndex, found = allNodes[rchild]
+			if !found {
+				hash := rchild.Hash()
+				return 0, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
 			}
+		}
 
-			encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
-			_, err = crc32Writer.Write(encNode)
-			if err != nil {
-				return fmt.Errorf("cannot serialize node: %w", err)
-			}
+		encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
+		_, err := writer.Write(encNode)
+		if err != nil {
+			return 0, fmt.Errorf("cannot serialize node: %w", err)
 		}
 	}
 
-	// Serialize trie root nodes
+	return nodeCounter, nil
+}
+
+// storeTries serializes the given tries, referencing their root nodes by their index in allNodes.
+func storeTries(writer io.Writer, allNodes map[*node.Node]uint64, tries []*trie.MTrie, scratch []byte) error {
 	for _, t := range tries {
 		rootNode := t.RootNode()
 
@@ -369,31 +432,12 @@ func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
 		}
 
 		encTrie := flattener.EncodeTrie(t, rootIndex, scratch)
-		_, err = crc32Writer.Write(encTrie)
+		_, err := writer.Write(encTrie)
 		if err != nil {
 			return fmt.Errorf("cannot serialize trie: %w", err)
 		}
 	}
 
-	// Write footer with nodes count and tries count
-	footer := scratch[:encNodeCountSize+encTrieCountSize]
-	binary.BigEndian.PutUint64(footer, uint64(len(allNodes)-1)) // -1 to account for 0 node meaning nil
-	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
-
-	_, err = crc32Writer.Write(footer)
-	if err != nil {
-		return fmt.Errorf("cannot write checkpoint footer: %w", err)
-	}
-
-	// Write CRC32 sum
-	crc32buf := scratch[:crc32SumSize]
-	binary.BigEndian.PutUint32(crc32buf, crc32Writer.Crc32())
-
-	_, err = writer.Write(crc32buf)
-	if err != nil {
-		return fmt.Errorf("cannot write CRC32: %w", err)
-	}
-
 	return nil
 }
 
@@ -422,6 +466,17 @@ func (c *Checkpointer) RemoveCheckpoint(checkpoint int) error {
 }
 
 func LoadCheckpoint(filepath string, logger *zerolog.Logger) ([]*trie.MTrie, error) {
+	version, _, err := readCheckpointHeader(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// delta checkpoints are loaded together with their base checkpoints
+	if version == VersionV6 {
+		_, tries, err := loadCheckpointNodes(filepath, logger)
+		return tries, err
+	}
+
 	file, err := os.Open(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
@@ -439,6 +494,55 @@ func LoadCheckpoint(filepath string, logger *zerolog.Logger) ([]*trie.MTrie, err
 	return readCheckpoint(file)
 }
 
+// CheckpointInfo contains information about the layout of a checkpoint file.
+type CheckpointInfo struct {
+	Version uint16
+
+	// BaseCheckpoint is the number of the base checkpoint of a delta checkpoint, or -1.
+	BaseCheckpoint int
+
+	// PartNodeCounts contains the node count of each sub-trie part, followed by the node
+	// count of the top part. It is only set for version 7 checkpoints.
+	PartNodeCounts []uint64
+
+	// TriesCount is the number of tries. It is only set for version 7 checkpoints.
+	TriesCount uint16
+}
+
+// ReadCheckpointInfo returns information about the layout of the given checkpoint file,
+// without loading the checkpoint.
+func ReadCheckpointInfo(filepath string) (*CheckpointInfo, error) {
+	version, base, err := readCheckpointHeader(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &CheckpointInfo{
+		Version:        version,
+		BaseCheckpoint: base,
+	}
+
+	if version != VersionV7 {
+		return info, nil
+	}
+
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	footer, err := readFooterV7(file)
+	if err != nil {
+		return nil, err
+	}
+
+	info.PartNodeCounts = footer.partNodeCounts[:]
+	info.TriesCount = footer.triesCount
+
+	return info, nil
+}
+
 func readCheckpoint(f *os.File) ([]*trie.MTrie, error) {
 
 	// Read header: magic (2 bytes) + version (2 bytes)
@@ -469,6 +573,11 @@ func readCheckpoint(f *os.File) ([]*trie.MTrie, error) {
 		return readCheckpointV4(f)
 	case VersionV5:
 		return readCheckpointV5(f)
+	case VersionV6:
+		return nil, fmt.Errorf("delta checkpoint must be loaded together with its base checkpoint")
+	case VersionV7:
+		_, tries, err := readCheckpointV7(f, false)
+		return tries, err
 	default:
 		return nil, fmt.Errorf("unsupported file version %x", version)
 	}
@@ -678,6 +787,14 @@ func readCheckpointV4(f *os.File) ([]*trie.MTrie, error) {
 // readCheckpointV5 decodes checkpoint file (version 5) and returns a list of tries.
 // Checkpoint file header (magic and version) are verified by the caller.
 func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
+	_, tries, err := readCheckpointNodesV5(f)
+	return tries, err
+}
+
+// readCheckpointNodesV5 decodes checkpoint file (version 5) and returns all its nodes by
+// node index, as well as the list of tries. The node at index 0 is nil.
+// Checkpoint file header (magic and version) are verified by the caller.
+func readCheckpointNodesV5(f *os.File) ([]*node.Node, []*trie.MTrie, error) {
 
 	// Scratch buffer is used as temporary buffer that reader can read into.
 	// Raw data in scratch buffer should be copied or converted into desired
@@ -695,14 +812,14 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 	// Seek to footer
 	_, err := f.Seek(-footerOffset, io.SeekEnd)
 	if err != nil {
-		return nil, fmt.Errorf("cannot seek to footer: %w", err)
+		return nil, nil, fmt.Errorf("cannot seek to footer: %w", err)
 	}
 
 	footer := scratch[:footerSize]
 
 	_, err = io.ReadFull(f, footer)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read footer: %w", err)
+		return nil, nil, fmt.Errorf("cannot read footer: %w", err)
 	}
 
 	// Decode node count and trie count
@@ -712,7 +829,7 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 	// Seek to the start of file
 	_, err = f.Seek(0, io.SeekStart)
 	if err != nil {
-		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
+		return nil, nil, fmt.Errorf("cannot seek to start of file: %w", err)
 	}
 
 	var bufReader io.Reader = bufio.NewReaderSize(f, defaultBufioReadSize)
@@ -724,7 +841,7 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 
 	_, err = io.ReadFull(reader, scratch[:headerSize])
 	if err != nil {
-		return nil, fmt.Errorf("cannot read header: %w", err)
+		return nil, nil, fmt.Errorf("cannot read header: %w", err)
 	}
 
 	// nodes's element at index 0 is a special, meaning nil .
@@ -739,7 +856,7 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 			return nodes[nodeIndex], nil
 		})
 		if err != nil {
-			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+			return nil, nil, fmt.Errorf("cannot read node %d: %w", i, err)
 		}
 		nodes[i] = n
 	}
@@ -752,7 +869,7 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 			return nodes[nodeIndex], nil
 		})
 		if err != nil {
-			return nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+			return nil, nil, fmt.Errorf("cannot read trie %d: %w", i, err)
 		}
 		tries[i] = trie
 	}
@@ -761,14 +878,14 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 	// No action is needed.
 	_, err = io.ReadFull(reader, footer)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read footer: %w", err)
+		return nil, nil, fmt.Errorf("cannot read footer: %w", err)
 	}
 
 	// Read CRC32
 	crc32buf := scratch[:crc32SumSize]
 	_, err = io.ReadFull(bufReader, crc32buf)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read CRC32: %w", err)
+		return nil, nil, fmt.Errorf("cannot read CRC32: %w", err)
 	}
 
 	readCrc32 := binary.BigEndian.Uint32(crc32buf)
@@ -776,10 +893,10 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 	calculatedCrc32 := crcReader.Crc32()
 
 	if calculatedCrc32 != readCrc32 {
-		return nil, fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+		return nil, nil, fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
 	}
 
-	return tries, nil
+	return nodes, tries, nil
 }
 
 // ReadLastTrieRootHashFromCheckpoint returns last trie's root hash from checkpoint file f.
@@ -810,6 +927,12 @@ func ReadLastTrieRootHashFromCheckpoint(f *os.File) (hash.Hash, error) {
 		if err != nil {
 			return hash.DummyHash, errors.New("invalid checkpoint")
 		}
+	} else if version == VersionV7 {
+		// the last trie is followed by the CRC32 sum of the top part, and the footer
+		_, err = f.Seek(-(hash.HashLen + crc32SumSize + footerSizeV7 + crc32SumSize), 2 /* relative from end */)
+		if err != nil {
+			return hash.DummyHash, errors.New("invalid checkpoint")
+		}
 	} else {
 		_, err = f.Seek(-(hash.HashLen + encNodeCountSize + encTrieCountSize + crc32SumSize), 2 /* relative from end */)
 		if err != nil {
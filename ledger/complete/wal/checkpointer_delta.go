@@ -0,0 +1,422 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// DeltaCheckpoint creates new delta checkpoint stopping at given segment.
+// The delta checkpoint uses the latest checkpoint as its base, and only contains
+// the nodes which were created by the updates in the segments after the base.
+func (c *Checkpointer) DeltaCheckpoint(to int, targetWriter func() (io.WriteCloser, error)) (err error) {
+
+	_, notCheckpointedTo, err := c.NotCheckpointedSegments()
+	if err != nil {
+		return fmt.Errorf("cannot get not checkpointed segments: %w", err)
+	}
+
+	latestCheckpoint, err := c.LatestCheckpoint()
+	if err != nil {
+		return fmt.Errorf("cannot get latest checkpoint: %w", err)
+	}
+
+	if latestCheckpoint == to {
+		return nil //nothing to do
+	}
+
+	if latestCheckpoint == -1 {
+		return fmt.Errorf("no base checkpoint for delta checkpoint %d", to)
+	}
+
+	if notCheckpointedTo < to {
+		return fmt.Errorf("no segments to checkpoint to %d, latests not checkpointed segment: %d", to, notCheckpointedTo)
+	}
+
+	c.wal.log.Info().Msgf("loading base checkpoint %d for delta checkpoint %d", latestCheckpoint, to)
+
+	baseNodes, baseTries, err := loadCheckpointNodes(path.Join(c.dir, NumberToFilename(latestCheckpoint)), &c.wal.log)
+	if err != nil {
+		return fmt.Errorf("cannot load base checkpoint %d: %w", latestCheckpoint, err)
+	}
+
+	forest, err := mtrie.NewForest(c.forestCapacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create Forest: %w", err)
+	}
+
+	err = forest.AddTries(baseTries)
+	if err != nil {
+		return fmt.Errorf("cannot add base checkpoint tries to forest: %w", err)
+	}
+
+	c.wal.log.Info().Msgf("creating delta checkpoint %d on top of checkpoint %d", to, latestCheckpoint)
+
+	err = c.wal.replay(latestCheckpoint+1, to,
+		func(tries []*trie.MTrie) error {
+			return forest.AddTries(tries)
+		},
+		func(update *ledger.TrieUpdate) error {
+			_, err := forest.Update(update)
+			return err
+		}, func(rootHash ledger.RootHash) error {
+			return nil
+		}, false)
+
+	if err != nil {
+		return fmt.Errorf("cannot replay WAL: %w", err)
+	}
+
+	tries, err := forest.GetTries()
+	if err != nil {
+		return fmt.Errorf("cannot get forest tries: %w", err)
+	}
+
+	c.wal.log.Info().Msgf("serializing delta checkpoint %d", to)
+
+	writer, err := targetWriter()
+	if err != nil {
+		return fmt.Errorf("cannot generate writer: %w", err)
+	}
+	defer func() {
+		closeErr := writer.Close()
+		// Return close error if there isn't any prior error to return.
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	err = StoreDeltaCheckpoint(writer, latestCheckpoint, baseNodes, tries...)
+
+	c.wal.log.Info().Msgf("created delta checkpoint %d with %d tries on top of checkpoint %d", to, len(tries), latestCheckpoint)
+
+	return err
+}
+
+// CheckpointChain returns the numbers of all checkpoints which are needed to load the given
+// checkpoint in asc order. The first checkpoint of the chain is a full checkpoint, all
+// following checkpoints are delta checkpoints of the previous one. The chain of a full
+// checkpoint only contains the checkpoint itself.
+func (c *Checkpointer) CheckpointChain(checkpoint int) ([]int, error) {
+	chain := []int{checkpoint}
+
+	for {
+		_, base, err := readCheckpointHeader(path.Join(c.dir, NumberToFilename(checkpoint)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read header of checkpoint %d: %w", checkpoint, err)
+		}
+
+		if base == -1 {
+			break
+		}
+
+		if base >= checkpoint {
+			return nil, fmt.Errorf("checkpoint %d has invalid base checkpoint %d", checkpoint, base)
+		}
+
+		chain = append([]int{base}, chain...)
+		checkpoint = base
+	}
+
+	return chain, nil
+}
+
+// CheckpointVersion returns the file version of the given checkpoint.
+func (c *Checkpointer) CheckpointVersion(checkpoint int) (uint16, error) {
+	version, _, err := readCheckpointHeader(path.Join(c.dir, NumberToFilename(checkpoint)))
+	return version, err
+}
+
+// StoreDeltaCheckpoint writes the given tries to a delta checkpoint file, and also
+// appends a CRC32 file checksum for integrity check.
+// A delta checkpoint extends the flattened forest of its base checkpoint. It consists of:
+//   - the number of the base checkpoint and the number of nodes of the base checkpoint,
+//     including the nodes of its own bases.
+//   - a list of encoded nodes which are not contained in the base checkpoint.
+//   - a list of encoded tries, each referencing their respective root node by index.
+//
+// The nodes of the delta checkpoint are indexed after the nodes of the base checkpoint,
+// so nodes can reference nodes of the base checkpoint by their index in the base.
+// baseNodes contains all nodes of the base checkpoint by index, the node at index 0 is nil.
+func StoreDeltaCheckpoint(writer io.Writer, baseCheckpoint int, baseNodes []*node.Node, tries ...*trie.MTrie) error {
+
+	crc32Writer := NewCRC32Writer(writer)
+
+	// Scratch buffer is used as temporary buffer that node can encode into.
+	// See StoreCheckpointV5() for more details.
+	scratch := make([]byte, 1024*4)
+
+	// Write header: magic (2 bytes) + version (2 bytes) + base checkpoint (8 bytes) + base node count (8 bytes)
+	header := scratch[:headerSize+encBaseCheckpointSize+encNodeCountSize]
+	binary.BigEndian.PutUint16(header, MagicBytes)
+	binary.BigEndian.PutUint16(header[encMagicSize:], VersionV6)
+	binary.BigEndian.PutUint64(header[headerSize:], uint64(baseCheckpoint))
+	binary.BigEndian.PutUint64(header[headerSize+encBaseCheckpointSize:], uint64(len(baseNodes)-1)) // -1 to account for 0 node meaning nil
+
+	_, err := crc32Writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+
+	// allNodes contains all nodes of the base checkpoint and all unique nodes of the given
+	// tries, and their index. Index 0 is a special case with nil node.
+	allNodes := make(map[*node.Node]uint64, len(baseNodes))
+	for i, n := range baseNodes {
+		allNodes[n] = uint64(i)
+	}
+
+	err = storeNodesAndTries(crc32Writer, allNodes, tries, scratch)
+	if err != nil {
+		return err
+	}
+
+	// Write footer with nodes count of the delta and tries count
+	footer := scratch[:encNodeCountSize+encTrieCountSize]
+	binary.BigEndian.PutUint64(footer, uint64(len(allNodes)-len(baseNodes)))
+	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	// Write CRC32 sum
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32Writer.Crc32())
+
+	_, err = writer.Write(crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	return nil
+}
+
+// readCheckpointHeader returns the version of the given checkpoint file, and the number of
+// its base checkpoint, or -1 if the checkpoint is a full checkpoint.
+func readCheckpointHeader(filepath string) (uint16, int, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return 0, -1, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	header := make([]byte, headerSize+encBaseCheckpointSize)
+	_, err = io.ReadFull(file, header[:headerSize])
+	if err != nil {
+		return 0, -1, fmt.Errorf("cannot read header: %w", err)
+	}
+
+	magicBytes := binary.BigEndian.Uint16(header)
+	version := binary.BigEndian.Uint16(header[encMagicSize:])
+
+	if magicBytes != MagicBytes {
+		return 0, -1, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	}
+
+	if version != VersionV6 {
+		return version, -1, nil
+	}
+
+	_, err = io.ReadFull(file, header[headerSize:])
+	if err != nil {
+		return 0, -1, fmt.Errorf("cannot read base checkpoint: %w", err)
+	}
+
+	base := binary.BigEndian.Uint64(header[headerSize:])
+
+	return version, int(base), nil
+}
+
+// loadCheckpointNodes loads the given checkpoint file, and returns all nodes of the checkpoint
+// by node index, as well as the list of tries. The node at index 0 is nil.
+// For delta checkpoints, the base checkpoints are loaded from the same directory, and the
+// returned nodes include the nodes of the base checkpoints.
+// Only version 5 and version 7 checkpoints can be used as base of delta checkpoints.
+func loadCheckpointNodes(filepath string, logger *zerolog.Logger) ([]*node.Node, []*trie.MTrie, error) {
+	version, base, err := readCheckpointHeader(filepath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if version != VersionV5 && version != VersionV6 && version != VersionV7 {
+		return nil, nil, fmt.Errorf("checkpoint file version %x cannot be used with delta checkpoints", version)
+	}
+
+	var baseNodes []*node.Node
+	if version == VersionV6 {
+		// the base of a checkpoint always has a lower number, which guarantees the chain terminates
+		if number, ok := checkpointNumberFromFilename(filepath); ok && base >= number {
+			return nil, nil, fmt.Errorf("checkpoint %d has invalid base checkpoint %d", number, base)
+		}
+
+		basePath := path.Join(path.Dir(filepath), NumberToFilename(base))
+		baseNodes, _, err = loadCheckpointNodes(basePath, logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot load base checkpoint %d: %w", base, err)
+		}
+	}
+
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer func() {
+		evictErr := evictFileFromLinuxPageCache(file, false, logger)
+		if evictErr != nil {
+			logger.Warn().Msgf("failed to evict file %s from Linux page cache: %s", filepath, evictErr)
+			// No need to return this error because it's possible to continue normal operations.
+		}
+
+		_ = file.Close()
+	}()
+
+	switch version {
+	case VersionV5:
+		return readCheckpointNodesV5(file)
+	case VersionV7:
+		return readCheckpointV7(file, true)
+	default:
+		return readCheckpointV6(file, baseNodes)
+	}
+}
+
+// checkpointNumberFromFilename returns the number of the checkpoint with the given file name,
+// and false if the file name is not the name of a numbered checkpoint.
+func checkpointNumberFromFilename(filename string) (int, bool) {
+	base := filepath.Base(filename)
+	if !strings.HasPrefix(base, checkpointFilenamePrefix) {
+		return 0, false
+	}
+	number, err := strconv.Atoi(base[len(checkpointFilenamePrefix):])
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// readCheckpointV6 decodes delta checkpoint file (version 6) on top of the given nodes of its
+// base checkpoint, and returns all nodes of the base and the delta by node index, as well as
+// the list of tries of the delta checkpoint.
+// Checkpoint file header (magic and version) are verified by the caller.
+func readCheckpointV6(f *os.File, baseNodes []*node.Node) ([]*node.Node, []*trie.MTrie, error) {
+
+	// Scratch buffer is used as temporary buffer that reader can read into.
+	// See readCheckpointV5() for more details.
+	scratch := make([]byte, 1024*4) // must not be less than 1024
+
+	// footer offset: nodes count (8 bytes) + tries count (2 bytes) + CRC32 sum (4 bytes)
+	const footerOffset = encNodeCountSize + encTrieCountSize + crc32SumSize
+	const footerSize = encNodeCountSize + encTrieCountSize // footer doesn't include crc32 sum
+
+	// Seek to footer
+	_, err := f.Seek(-footerOffset, io.SeekEnd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot seek to footer: %w", err)
+	}
+
+	footer := scratch[:footerSize]
+
+	_, err = io.ReadFull(f, footer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	// Decode node count and trie count
+	nodesCount := binary.BigEndian.Uint64(footer)
+	triesCount := binary.BigEndian.Uint16(footer[encNodeCountSize:])
+
+	// Seek to the start of file
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot seek to start of file: %w", err)
+	}
+
+	var bufReader io.Reader = bufio.NewReaderSize(f, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+	var reader io.Reader = crcReader
+
+	// Read header: magic (2 bytes) + version (2 bytes) + base checkpoint (8 bytes) + base node count (8 bytes)
+	// Magic, version and base checkpoint are verified by the caller.
+	header := scratch[:headerSize+encBaseCheckpointSize+encNodeCountSize]
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read header: %w", err)
+	}
+
+	baseNodesCount := binary.BigEndian.Uint64(header[headerSize+encBaseCheckpointSize:])
+	if baseNodesCount != uint64(len(baseNodes)-1) {
+		return nil, nil, fmt.Errorf("base checkpoint contains %d nodes, but delta checkpoint expects %d nodes", len(baseNodes)-1, baseNodesCount)
+	}
+
+	// nodes contains the nodes of the base checkpoint followed by the nodes of the delta.
+	// nodes's element at index 0 is a special, meaning nil .
+	nodes := make([]*node.Node, baseNodesCount+nodesCount+1) //+1 for 0 index meaning nil
+	copy(nodes, baseNodes)
+	tries := make([]*trie.MTrie, triesCount)
+
+	for i := baseNodesCount + 1; i <= baseNodesCount+nodesCount; i++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= i {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+		nodes[i] = n
+	}
+
+	for i := uint16(0); i < triesCount; i++ {
+		trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(len(nodes)) {
+				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+		}
+		tries[i] = trie
+	}
+
+	// Read footer again for crc32 computation
+	// No action is needed.
+	_, err = io.ReadFull(reader, footer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	// Read CRC32
+	crc32buf := scratch[:crc32SumSize]
+	_, err = io.ReadFull(bufReader, crc32buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	readCrc32 := binary.BigEndian.Uint32(crc32buf)
+
+	calculatedCrc32 := crcReader.Crc32()
+
+	if calculatedCrc32 != readCrc32 {
+		return nil, nil, fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+
+	return nodes, tries, nil
+}
@@ -0,0 +1,501 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+const (
+	// subtrieLevel is the level of the roots of the sub-tries which are stored in separate
+	// parts of version 7 checkpoint files.
+	subtrieLevel = 4
+
+	// subtrieCount is the number of sub-tries at subtrieLevel, and therefore the number of
+	// sub-trie parts of version 7 checkpoint files.
+	subtrieCount = 1 << subtrieLevel
+
+	// subtrieRootHeight is the height of the root nodes of the sub-tries.
+	subtrieRootHeight = ledger.NodeMaxHeight - subtrieLevel
+
+	// partReadConcurrency is the number of sub-trie parts which are decoded concurrently. It bounds
+	// the number of node slices and read buffers of parts held in memory at the same time.
+	partReadConcurrency = 4
+
+	encPartOffsetSize = 8
+
+	// footerSizeV7 is the size of the footer of version 7 checkpoint files, without its CRC32 sum:
+	// offset and node count of each sub-trie part and the top part, and the trie count.
+	footerSizeV7 = (subtrieCount+1)*(encPartOffsetSize+encNodeCountSize) + encTrieCountSize
+)
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	io.Writer
+	count uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.count += uint64(n)
+	return n, err
+}
+
+// StoreCheckpoint writes the given tries to checkpoint file (version 7).
+// Checkpoint file consists of a flattened forest, which is split into parts that can be
+// decoded independently and concurrently. Specifically, it consists of:
+//   - one part for each sub-trie at subtrieLevel, containing the unique nodes of the sub-tries
+//     of all given tries with the same path prefix. References to other nodes are by index
+//     within the part.
+//   - one top part, containing the unique nodes of all given tries above subtrieLevel, followed
+//     by a list of encoded tries, each referencing their respective root node by index.
+//     References to other nodes are by global index, where the nodes of all parts are indexed
+//     in the order of the parts.
+//   - a footer with the offset and node count of each part, and the trie count.
+//
+// Referencing to other nodes by index 0 is a special case, meaning nil.
+// Each part and the footer are followed by their own CRC32 checksum for integrity check.
+//
+// As an important property, the nodes of each part are listed in an order which satisfies
+// Descendents-First-Relationship, see StoreCheckpointV5() for more details. Nodes of the top
+// part can only reference root nodes of sub-tries, so decoding the top part only requires
+// the root nodes of the sub-tries.
+func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
+
+	countWriter := &countingWriter{Writer: writer}
+
+	// Scratch buffer is used as temporary buffer that node can encode into.
+	// See StoreCheckpointV5() for more details.
+	scratch := make([]byte, 1024*4)
+
+	// Write header: magic (2 bytes) + version (2 bytes)
+	header := scratch[:headerSize]
+	binary.BigEndian.PutUint16(header, MagicBytes)
+	binary.BigEndian.PutUint16(header[encMagicSize:], VersionV7)
+
+	_, err := countWriter.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+
+	subtries := make([][subtrieCount]*node.Node, len(tries))
+	for i, t := range tries {
+		subtries[i] = subtrieRoots(t)
+	}
+
+	var partOffsets, partNodeCounts [subtrieCount + 1]uint64
+
+	// topNodes contains the root nodes of the sub-tries and the top nodes, and their global index.
+	// Index 0 is a special case with nil node.
+	topNodes := make(map[*node.Node]uint64)
+	topNodes[nil] = 0
+
+	// Serialize all unique nodes of the sub-tries part by part
+	subtrieNodeCount := uint64(0)
+	for part := 0; part < subtrieCount; part++ {
+		partOffsets[part] = countWriter.count
+		crc32Writer := NewCRC32Writer(countWriter)
+
+		// partNodes contains all unique nodes of the part and their index within the part.
+		partNodes := make(map[*node.Node]uint64)
+		partNodes[nil] = 0
+
+		nodeCounter := uint64(1) // start from 1, as 0 marks nil node
+		for _, roots := range subtries {
+			itr := flattener.NewUniqueSubtrieNodeIterator(roots[part], partNodes)
+			nodeCounter, err = storeUniqueNodes(crc32Writer, itr, partNodes, nodeCounter, scratch)
+			if err != nil {
+				return fmt.Errorf("cannot serialize part %d: %w", part, err)
+			}
+		}
+
+		err = writeCRC32(countWriter, crc32Writer.Crc32(), scratch)
+		if err != nil {
+			return err
+		}
+
+		// only the sub-trie roots are referenced by the top nodes
+		for _, roots := range subtries {
+			if root := roots[part]; root != nil {
+				topNodes[root] = subtrieNodeCount + partNodes[root]
+			}
+		}
+
+		partNodeCounts[part] = nodeCounter - 1
+		subtrieNodeCount += partNodeCounts[part]
+	}
+
+	// Serialize top nodes and tries
+	partOffsets[subtrieCount] = countWriter.count
+	crc32Writer := NewCRC32Writer(countWriter)
+
+	nodeCounter := subtrieNodeCount + 1
+	for _, t := range tries {
+		nodeCounter, err = storeUniqueNodes(crc32Writer, flattener.NewUniqueNodeIterator(t, topNodes), topNodes, nodeCounter, scratch)
+		if err != nil {
+			return fmt.Errorf("cannot serialize top nodes: %w", err)
+		}
+	}
+	partNodeCounts[subtrieCount] = nodeCounter - subtrieNodeCount - 1
+
+	err = storeTries(crc32Writer, topNodes, tries, scratch)
+	if err != nil {
+		return err
+	}
+
+	err = writeCRC32(countWriter, crc32Writer.Crc32(), scratch)
+	if err != nil {
+		return err
+	}
+
+	// Write footer with offset and node count of each part, and tries count
+	crc32Writer = NewCRC32Writer(countWriter)
+
+	footer := scratch[:footerSizeV7]
+	pos := 0
+	for part := 0; part <= subtrieCount; part++ {
+		binary.BigEndian.PutUint64(footer[pos:], partOffsets[part])
+		pos += encPartOffsetSize
+		binary.BigEndian.PutUint64(footer[pos:], partNodeCounts[part])
+		pos += encNodeCountSize
+	}
+	binary.BigEndian.PutUint16(footer[pos:], uint16(len(tries)))
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	return writeCRC32(countWriter, crc32Writer.Crc32(), scratch)
+}
+
+// writeCRC32 writes the given CRC32 sum.
+func writeCRC32(writer io.Writer, crc32 uint32, scratch []byte) error {
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32)
+
+	_, err := writer.Write(crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+	return nil
+}
+
+// subtrieRoots returns the root nodes of the sub-tries at subtrieLevel of the given trie,
+// indexed by the path prefix of the sub-trie. Compact leaves above subtrieLevel are not part
+// of any sub-trie.
+func subtrieRoots(t *trie.MTrie) [subtrieCount]*node.Node {
+	var roots [subtrieCount]*node.Node
+
+	var traverse func(n *node.Node, level int, index int)
+	traverse = func(n *node.Node, level int, index int) {
+		if n == nil {
+			return
+		}
+		if level == subtrieLevel {
+			roots[index] = n
+			return
+		}
+		if n.IsLeaf() {
+			return
+		}
+		traverse(n.LeftChild(), level+1, index<<1)
+		traverse(n.RightChild(), level+1, index<<1|1)
+	}
+	traverse(t.RootNode(), 0, 0)
+
+	return roots
+}
+
+// footerV7 contains the decoded footer of a version 7 checkpoint file.
+type footerV7 struct {
+	partOffsets    [subtrieCount + 1]uint64
+	partNodeCounts [subtrieCount + 1]uint64
+	triesCount     uint16
+}
+
+// readFooterV7 reads and verifies the footer of the given version 7 checkpoint file.
+func readFooterV7(f *os.File) (*footerV7, error) {
+	fstat, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("cannot stat checkpoint file: %w", err)
+	}
+	fileSize := fstat.Size()
+
+	if fileSize < headerSize+footerSizeV7+crc32SumSize {
+		return nil, fmt.Errorf("checkpoint file is too small")
+	}
+
+	buf := make([]byte, footerSizeV7+crc32SumSize)
+	_, err = f.ReadAt(buf, fileSize-int64(len(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	footer := buf[:footerSizeV7]
+	crc32Writer := NewCRC32Writer(io.Discard)
+	_, _ = crc32Writer.Write(footer)
+
+	readCrc32 := binary.BigEndian.Uint32(buf[footerSizeV7:])
+	calculatedCrc32 := crc32Writer.Crc32()
+	if calculatedCrc32 != readCrc32 {
+		return nil, fmt.Errorf("checkpoint footer checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+
+	var decoded footerV7
+	pos := 0
+	for part := 0; part <= subtrieCount; part++ {
+		decoded.partOffsets[part] = binary.BigEndian.Uint64(footer[pos:])
+		pos += encPartOffsetSize
+		decoded.partNodeCounts[part] = binary.BigEndian.Uint64(footer[pos:])
+		pos += encNodeCountSize
+	}
+	decoded.triesCount = binary.BigEndian.Uint16(footer[pos:])
+
+	// parts are stored in order between the header and the footer
+	footerOffset := uint64(fileSize) - footerSizeV7 - crc32SumSize
+	previous := uint64(headerSize)
+	for part := 0; part <= subtrieCount; part++ {
+		if decoded.partOffsets[part] < previous || decoded.partOffsets[part] > footerOffset {
+			return nil, fmt.Errorf("invalid offset %d of part %d", decoded.partOffsets[part], part)
+		}
+		previous = decoded.partOffsets[part]
+	}
+
+	return &decoded, nil
+}
+
+// partSize returns the size of the given part including its CRC32 sum.
+func (footer *footerV7) partSize(part int, fileSize int64) int64 {
+	if part == subtrieCount {
+		return fileSize - footerSizeV7 - crc32SumSize - int64(footer.partOffsets[part])
+	}
+	return int64(footer.partOffsets[part+1] - footer.partOffsets[part])
+}
+
+// readCheckpointV7 decodes checkpoint file (version 7) and returns a list of tries.
+// The sub-trie parts are decoded concurrently. Unless keepAllNodes is true, only the root
+// nodes of the sub-tries are kept while decoding, and no nodes are returned. Otherwise, all
+// nodes are returned by their global node index, where the node at index 0 is nil.
+// Checkpoint file header (magic and version) are verified by the caller.
+func readCheckpointV7(f *os.File, keepAllNodes bool) ([]*node.Node, []*trie.MTrie, error) {
+
+	footer, err := readFooterV7(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fstat, err := f.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot stat checkpoint file: %w", err)
+	}
+	fileSize := fstat.Size()
+
+	// the global index of the first node of each part is the number of nodes of previous parts plus 1
+	var partStartIndex [subtrieCount + 1]uint64
+	subtrieNodeCount := uint64(0)
+	for part := 0; part < subtrieCount; part++ {
+		partStartIndex[part] = subtrieNodeCount + 1
+		subtrieNodeCount += footer.partNodeCounts[part]
+	}
+	partStartIndex[subtrieCount] = subtrieNodeCount + 1
+
+	// Decode the sub-trie parts concurrently, at most partReadConcurrency at a time. The file is
+	// read with ReadAt, which is safe for concurrent use.
+	var partNodes [subtrieCount][]*node.Node
+	var partRoots [subtrieCount]map[uint64]*node.Node
+
+	var group errgroup.Group
+	sem := make(chan struct{}, partReadConcurrency)
+	for part := 0; part < subtrieCount; part++ {
+		part := part
+		group.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			reader := io.NewSectionReader(f, int64(footer.partOffsets[part]), footer.partSize(part, fileSize))
+			nodes, err := readPartNodes(reader, footer.partNodeCounts[part], 1, func(uint64) (*node.Node, bool) {
+				// nodes of sub-trie parts only reference nodes of the same part
+				return nil, false
+			})
+			if err != nil {
+				return fmt.Errorf("cannot read part %d: %w", part, err)
+			}
+
+			if keepAllNodes {
+				partNodes[part] = nodes
+				return nil
+			}
+
+			// only keep the sub-trie roots, which are referenced by the top part, and release the
+			// node slice of the part
+			roots := make(map[uint64]*node.Node)
+			for i, n := range nodes[1:] {
+				if n.Height() == subtrieRootHeight {
+					roots[partStartIndex[part]+uint64(i)] = n
+				}
+			}
+			partRoots[part] = roots
+			return nil
+		})
+	}
+
+	err = group.Wait()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var allNodes []*node.Node
+	if keepAllNodes {
+		allNodes = make([]*node.Node, 1, subtrieNodeCount+footer.partNodeCounts[subtrieCount]+1)
+		for _, nodes := range partNodes {
+			allNodes = append(allNodes, nodes[1:]...)
+		}
+	}
+
+	getSubtrieNode := func(nodeIndex uint64) (*node.Node, bool) {
+		if keepAllNodes {
+			return allNodes[nodeIndex], true
+		}
+		for part := subtrieCount - 1; part >= 0; part-- {
+			if nodeIndex >= partStartIndex[part] {
+				n, ok := partRoots[part][nodeIndex]
+				return n, ok
+			}
+		}
+		return nil, false
+	}
+
+	// Decode the top nodes and the tries
+	reader := io.NewSectionReader(f, int64(footer.partOffsets[subtrieCount]), footer.partSize(subtrieCount, fileSize))
+	bufReader := bufio.NewReaderSize(reader, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+
+	topNodes, err := readNodes(crcReader, footer.partNodeCounts[subtrieCount], partStartIndex[subtrieCount], getSubtrieNode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read top nodes: %w", err)
+	}
+
+	getNode := func(nodeIndex uint64) (*node.Node, error) {
+		if nodeIndex >= partStartIndex[subtrieCount] {
+			i := nodeIndex - partStartIndex[subtrieCount] + 1
+			if i >= uint64(len(topNodes)) {
+				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
+			}
+			return topNodes[i], nil
+		}
+		if nodeIndex == 0 {
+			return nil, nil
+		}
+		n, ok := getSubtrieNode(nodeIndex)
+		if !ok {
+			return nil, fmt.Errorf("node %d is not the root of a sub-trie", nodeIndex)
+		}
+		return n, nil
+	}
+
+	scratch := make([]byte, 1024*4)
+	tries := make([]*trie.MTrie, footer.triesCount)
+	for i := uint16(0); i < footer.triesCount; i++ {
+		trie, err := flattener.ReadTrie(crcReader, scratch, getNode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+		}
+		tries[i] = trie
+	}
+
+	err = verifyCRC32(bufReader, crcReader.Crc32())
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot verify top part: %w", err)
+	}
+
+	if keepAllNodes {
+		allNodes = append(allNodes, topNodes[1:]...)
+	}
+
+	return allNodes, tries, nil
+}
+
+// readPartNodes decodes the given number of nodes of a sub-trie part from the given reader,
+// and verifies the CRC32 sum of the part.
+func readPartNodes(reader io.Reader, nodesCount uint64, startIndex uint64, getExternalNode func(uint64) (*node.Node, bool)) ([]*node.Node, error) {
+	bufReader := bufio.NewReaderSize(reader, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+
+	nodes, err := readNodes(crcReader, nodesCount, startIndex, getExternalNode)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyCRC32(bufReader, crcReader.Crc32())
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// readNodes decodes the given number of nodes from the given reader. The nodes are indexed
+// starting with startIndex, and can reference nodes with a lower index through
+// getExternalNode. The returned nodes are indexed by their index minus startIndex plus 1,
+// the node at index 0 is nil.
+func readNodes(reader io.Reader, nodesCount uint64, startIndex uint64, getExternalNode func(uint64) (*node.Node, bool)) ([]*node.Node, error) {
+
+	// Scratch buffer is used as temporary buffer that reader can read into.
+	// See readCheckpointV5() for more details.
+	scratch := make([]byte, 1024*4) // must not be less than 1024
+
+	nodes := make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
+
+	for i := uint64(1); i <= nodesCount; i++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex == 0 {
+				return nil, nil
+			}
+			if nodeIndex >= startIndex {
+				localIndex := nodeIndex - startIndex + 1
+				if localIndex >= i {
+					return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+				}
+				return nodes[localIndex], nil
+			}
+			n, ok := getExternalNode(nodeIndex)
+			if !ok {
+				return nil, fmt.Errorf("node %d is not available", nodeIndex)
+			}
+			return n, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+		nodes[i] = n
+	}
+
+	return nodes, nil
+}
+
+// verifyCRC32 reads the CRC32 sum from the given reader, and compares it to the given
+// calculated CRC32 sum.
+func verifyCRC32(reader io.Reader, calculatedCrc32 uint32) error {
+	crc32buf := make([]byte, crc32SumSize)
+	_, err := io.ReadFull(reader, crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	readCrc32 := binary.BigEndian.Uint32(crc32buf)
+	if calculatedCrc32 != readCrc32 {
+		return fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+
+	return nil
+}
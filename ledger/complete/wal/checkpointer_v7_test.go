@@ -0,0 +1,187 @@
+package wal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// createTries returns a sequence of tries sharing nodes, starting with an empty trie and a
+// trie with compact leaves above the sub-trie level.
+func createTries(t *testing.T) []*trie.MTrie {
+	emptyTrie := trie.NewEmptyMTrie()
+
+	// two registers, whose leaves are compacted right below the root node
+	paths := []ledger.Path{utils.PathByUint8(1), utils.PathByUint8(128)}
+	payloads := []ledger.Payload{*utils.LightPayload8('A', 'a'), *utils.LightPayload8('B', 'b')}
+
+	smallTrie, _, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads, true)
+	require.NoError(t, err)
+
+	tries := []*trie.MTrie{emptyTrie, smallTrie}
+
+	parent := smallTrie
+	for i := 0; i < 10; i++ {
+		paths := utils.RandomPaths(100)
+		payloads := utils.RandomPayloads(100, 10, 100)
+
+		values := make([]ledger.Payload, len(payloads))
+		for j, payload := range payloads {
+			values[j] = *payload
+		}
+
+		updated, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths, values, true)
+		require.NoError(t, err)
+
+		tries = append(tries, updated)
+		parent = updated
+	}
+
+	return tries
+}
+
+func storeTestCheckpoint(t *testing.T, filename string, store func(*os.File) error) {
+	file, err := os.Create(filename)
+	require.NoError(t, err)
+
+	require.NoError(t, store(file))
+	require.NoError(t, file.Close())
+}
+
+func requireEqualTries(t *testing.T, expected []*trie.MTrie, actual []*trie.MTrie) {
+	require.Equal(t, len(expected), len(actual))
+	for i, expectedTrie := range expected {
+		require.Equal(t, expectedTrie.RootHash(), actual[i].RootHash())
+		require.Equal(t, expectedTrie.AllocatedRegCount(), actual[i].AllocatedRegCount())
+		require.Equal(t, expectedTrie.AllocatedRegSize(), actual[i].AllocatedRegSize())
+		if !actual[i].IsEmpty() {
+			require.True(t, actual[i].RootNode().VerifyCachedHash())
+		}
+	}
+}
+
+func TestLoadCheckpointV5(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createTries(t)
+		filename := filepath.Join(dir, "checkpoint.v5")
+
+		storeTestCheckpoint(t, filename, func(file *os.File) error {
+			return StoreCheckpointV5(file, tries...)
+		})
+
+		info, err := ReadCheckpointInfo(filename)
+		require.NoError(t, err)
+		assert.Equal(t, VersionV5, info.Version)
+
+		logger := zerolog.Nop()
+		loaded, err := LoadCheckpoint(filename, &logger)
+		require.NoError(t, err)
+		requireEqualTries(t, tries, loaded)
+	})
+}
+
+func TestCheckpointV7(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createTries(t)
+		filename := filepath.Join(dir, "checkpoint.v7")
+
+		storeTestCheckpoint(t, filename, func(file *os.File) error {
+			return StoreCheckpoint(file, tries...)
+		})
+
+		logger := zerolog.Nop()
+
+		t.Run("layout", func(t *testing.T) {
+			info, err := ReadCheckpointInfo(filename)
+			require.NoError(t, err)
+			assert.Equal(t, VersionV7, info.Version)
+			assert.Equal(t, -1, info.BaseCheckpoint)
+			assert.Equal(t, uint16(len(tries)), info.TriesCount)
+			require.Len(t, info.PartNodeCounts, subtrieCount+1)
+
+			// the nodes are stored once, no matter in how many tries they are contained
+			v5Filename := filepath.Join(dir, "checkpoint.v5")
+			storeTestCheckpoint(t, v5Filename, func(file *os.File) error {
+				return StoreCheckpointV5(file, tries...)
+			})
+			v5File, err := os.Open(v5Filename)
+			require.NoError(t, err)
+			defer v5File.Close()
+			v5Nodes, _, err := readCheckpointNodesV5(v5File)
+			require.NoError(t, err)
+
+			var total uint64
+			for _, count := range info.PartNodeCounts {
+				total += count
+			}
+			assert.Equal(t, uint64(len(v5Nodes)-1), total)
+
+			// nodes of all sub-tries and the top nodes are split into parts
+			for part, count := range info.PartNodeCounts {
+				assert.Greater(t, count, uint64(0), "part %d is empty", part)
+			}
+		})
+
+		t.Run("load", func(t *testing.T) {
+			loaded, err := LoadCheckpoint(filename, &logger)
+			require.NoError(t, err)
+			requireEqualTries(t, tries, loaded)
+		})
+
+		t.Run("load all nodes", func(t *testing.T) {
+			file, err := os.Open(filename)
+			require.NoError(t, err)
+			defer file.Close()
+
+			nodes, loaded, err := readCheckpointV7(file, true)
+			require.NoError(t, err)
+			requireEqualTries(t, tries, loaded)
+
+			info, err := ReadCheckpointInfo(filename)
+			require.NoError(t, err)
+
+			var total uint64
+			for _, count := range info.PartNodeCounts {
+				total += count
+			}
+			require.Equal(t, total+1, uint64(len(nodes)))
+			assert.Nil(t, nodes[0])
+			for _, n := range nodes[1:] {
+				assert.NotNil(t, n)
+			}
+		})
+
+		t.Run("last trie root hash", func(t *testing.T) {
+			file, err := os.Open(filename)
+			require.NoError(t, err)
+			defer file.Close()
+
+			rootHash, err := ReadLastTrieRootHashFromCheckpoint(file)
+			require.NoError(t, err)
+			assert.Equal(t, ledger.RootHash(tries[len(tries)-1].RootHash()), ledger.RootHash(rootHash))
+		})
+
+		t.Run("corrupted part", func(t *testing.T) {
+			data, err := os.ReadFile(filename)
+			require.NoError(t, err)
+
+			// flip a byte right after the header, in the first node of the first part
+			data[headerSize+1] ^= 0xFF
+
+			corrupted := filepath.Join(dir, "checkpoint.corrupted")
+			require.NoError(t, os.WriteFile(corrupted, data, 0600))
+
+			_, err = LoadCheckpoint(corrupted, &logger)
+			require.Error(t, err)
+		})
+	})
+}
@@ -3,6 +3,8 @@ package wal
 import (
 	"fmt"
 	"io"
+	"os"
+	"path"
 	"sync"
 	"time"
 
@@ -12,6 +14,16 @@ import (
 	"github.com/onflow/flow-go/module/observable"
 )
 
+const (
+	// DefaultMaxDeltaCheckpointsSize is the default total size of the delta checkpoints on top of
+	// a full checkpoint, after which the next checkpoint is a full checkpoint again.
+	DefaultMaxDeltaCheckpointsSize = 32 * 1024 * 1024 * 1024 // 32 GiB
+
+	// DefaultFullCheckpointInterval is the default maximum age of the latest full checkpoint,
+	// after which the next checkpoint is a full checkpoint again.
+	DefaultFullCheckpointInterval = 24 * time.Hour
+)
+
 type Compactor struct {
 	checkpointer *Checkpointer
 	logger       zerolog.Logger
@@ -22,13 +34,33 @@ type Compactor struct {
 	interval           time.Duration
 	checkpointDistance uint
 	checkpointsToKeep  uint
+
+	deltaCheckpoints        bool
+	maxDeltaCheckpointsSize uint64
+	fullCheckpointInterval  time.Duration
+}
+
+// CompactorOption is a functional option for the Compactor
+type CompactorOption func(*Compactor)
+
+// WithDeltaCheckpoints enables delta checkpoints, which only contain the trie nodes created since
+// the previous checkpoint. A full checkpoint is created instead of a delta checkpoint once the
+// delta checkpoints on top of the latest full checkpoint are larger than maxDeltaCheckpointsSize
+// bytes in total, or once the latest full checkpoint is older than fullCheckpointInterval.
+// A threshold of 0 disables the respective check.
+func WithDeltaCheckpoints(maxDeltaCheckpointsSize uint64, fullCheckpointInterval time.Duration) CompactorOption {
+	return func(c *Compactor) {
+		c.deltaCheckpoints = true
+		c.maxDeltaCheckpointsSize = maxDeltaCheckpointsSize
+		c.fullCheckpointInterval = fullCheckpointInterval
+	}
 }
 
-func NewCompactor(checkpointer *Checkpointer, interval time.Duration, checkpointDistance uint, checkpointsToKeep uint, logger zerolog.Logger) *Compactor {
+func NewCompactor(checkpointer *Checkpointer, interval time.Duration, checkpointDistance uint, checkpointsToKeep uint, logger zerolog.Logger, opts ...CompactorOption) *Compactor {
 	if checkpointDistance < 1 {
 		checkpointDistance = 1
 	}
-	return &Compactor{
+	c := &Compactor{
 		checkpointer:       checkpointer,
 		logger:             logger,
 		stopc:              make(chan struct{}),
@@ -38,6 +70,10 @@ func NewCompactor(checkpointer *Checkpointer, interval time.Duration, checkpoint
 		checkpointDistance: checkpointDistance,
 		checkpointsToKeep:  checkpointsToKeep,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *Compactor) Subscribe(observer observable.Observer) {
@@ -119,12 +155,33 @@ func (c *Compactor) createCheckpoints() (int, error) {
 		startTime := time.Now()
 
 		checkpointNumber := to - 1
-		c.logger.Info().Msgf("creating checkpoint %d from segment %d to segment %d", checkpointNumber, from, checkpointNumber)
-		err = c.checkpointer.Checkpoint(checkpointNumber, func() (io.WriteCloser, error) {
+		writer := func() (io.WriteCloser, error) {
 			return c.checkpointer.CheckpointWriter(checkpointNumber)
-		})
-		if err != nil {
-			return -1, fmt.Errorf("error creating checkpoint (%d): %w", checkpointNumber, err)
+		}
+
+		created := false
+		if c.deltaCheckpoints {
+			delta, reason := c.useDeltaCheckpoint()
+			if delta {
+				c.logger.Info().Msgf("creating delta checkpoint %d from segment %d to segment %d", checkpointNumber, from, checkpointNumber)
+				err = c.checkpointer.DeltaCheckpoint(checkpointNumber, writer)
+				if err == nil {
+					created = true
+				} else {
+					// a full checkpoint doesn't depend on previous checkpoints, so it can still be created
+					c.logger.Warn().Err(err).Msgf("cannot create delta checkpoint %d, creating full checkpoint instead", checkpointNumber)
+				}
+			} else {
+				c.logger.Info().Str("reason", reason).Msgf("creating full checkpoint %d instead of delta checkpoint", checkpointNumber)
+			}
+		}
+
+		if !created {
+			c.logger.Info().Msgf("creating checkpoint %d from segment %d to segment %d", checkpointNumber, from, checkpointNumber)
+			err = c.checkpointer.Checkpoint(checkpointNumber, writer)
+			if err != nil {
+				return -1, fmt.Errorf("error creating checkpoint (%d): %w", checkpointNumber, err)
+			}
 		}
 		newLatestCheckpoint = checkpointNumber
 
@@ -145,8 +202,26 @@ func (c *Compactor) cleanupCheckpoints() error {
 	}
 	if len(checkpoints) > int(c.checkpointsToKeep) {
 		checkpointsToRemove := checkpoints[:len(checkpoints)-int(c.checkpointsToKeep)] // if condition guarantees this never fails
+		checkpointsToKeep := checkpoints[len(checkpoints)-int(c.checkpointsToKeep):]
+
+		// base checkpoints of the kept delta checkpoints are needed to load them, so they are kept as well
+		basesToKeep := make(map[int]struct{})
+		for _, checkpoint := range checkpointsToKeep {
+			chain, err := c.checkpointer.CheckpointChain(checkpoint)
+			if err != nil {
+				// the checkpoint can't be loaded anyway if its chain is broken
+				c.logger.Warn().Err(err).Msgf("cannot get base checkpoints of checkpoint %d", checkpoint)
+				continue
+			}
+			for _, base := range chain {
+				basesToKeep[base] = struct{}{}
+			}
+		}
 
 		for _, checkpoint := range checkpointsToRemove {
+			if _, ok := basesToKeep[checkpoint]; ok {
+				continue
+			}
 			err := c.checkpointer.RemoveCheckpoint(checkpoint)
 			if err != nil {
 				return fmt.Errorf("cannot remove checkpoint %d: %w", checkpoint, err)
@@ -155,3 +230,51 @@ func (c *Compactor) cleanupCheckpoints() error {
 	}
 	return nil
 }
+
+// useDeltaCheckpoint returns true if the next checkpoint can be a delta checkpoint on top of the
+// latest checkpoint, and otherwise returns the reason why a full checkpoint should be created.
+func (c *Compactor) useDeltaCheckpoint() (bool, string) {
+	latestCheckpoint, err := c.checkpointer.LatestCheckpoint()
+	if err != nil {
+		return false, fmt.Sprintf("cannot get latest checkpoint: %v", err)
+	}
+	if latestCheckpoint == -1 {
+		return false, "no base checkpoint"
+	}
+
+	chain, err := c.checkpointer.CheckpointChain(latestCheckpoint)
+	if err != nil {
+		return false, fmt.Sprintf("cannot get base checkpoints: %v", err)
+	}
+
+	fullCheckpoint := chain[0]
+	version, err := c.checkpointer.CheckpointVersion(fullCheckpoint)
+	if err != nil {
+		return false, fmt.Sprintf("cannot get version of checkpoint %d: %v", fullCheckpoint, err)
+	}
+	if version != VersionV5 && version != VersionV7 {
+		return false, fmt.Sprintf("checkpoint %d has version %d without delta support", fullCheckpoint, version)
+	}
+
+	info, err := os.Stat(path.Join(c.checkpointer.dir, NumberToFilename(fullCheckpoint)))
+	if err != nil {
+		return false, fmt.Sprintf("cannot stat checkpoint %d: %v", fullCheckpoint, err)
+	}
+	if c.fullCheckpointInterval > 0 && time.Since(info.ModTime()) >= c.fullCheckpointInterval {
+		return false, "full checkpoint interval reached"
+	}
+
+	var deltaSize uint64
+	for _, delta := range chain[1:] {
+		info, err := os.Stat(path.Join(c.checkpointer.dir, NumberToFilename(delta)))
+		if err != nil {
+			return false, fmt.Sprintf("cannot stat checkpoint %d: %v", delta, err)
+		}
+		deltaSize += uint64(info.Size())
+	}
+	if c.maxDeltaCheckpointsSize > 0 && deltaSize >= c.maxDeltaCheckpointsSize {
+		return false, "max delta checkpoints size reached"
+	}
+
+	return true, ""
+}
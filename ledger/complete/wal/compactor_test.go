@@ -328,3 +328,162 @@ func Test_Compactor_checkpointInterval(t *testing.T) {
 		})
 	})
 }
+
+func Test_Compactor_deltaCheckpoints(t *testing.T) {
+	numInsPerStep := 2
+	pathByteSize := 32
+	minPayloadByteSize := 2 << 15
+	maxPayloadByteSize := 2 << 16
+	size := 10
+	metricsCollector := &metrics.NoopCollector{}
+	checkpointDistance := uint(1)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewForest(size*10, metricsCollector, nil)
+		require.NoError(t, err)
+
+		var rootHash = f.GetEmptyRootHash()
+
+		//saved data after updates
+		savedData := make(map[ledger.RootHash]map[ledger.Path]*ledger.Payload)
+
+		wal, err := NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, size*10, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+
+		update := func(compactor *Compactor) {
+			paths := utils.RandomPaths(numInsPerStep)
+			payloads := utils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+			update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+
+			err = wal.RecordUpdate(update)
+			require.NoError(t, err)
+
+			rootHash, err = f.Update(update)
+			require.NoError(t, err)
+
+			data := make(map[ledger.Path]*ledger.Payload, len(paths))
+			for j, path := range paths {
+				data[path] = payloads[j]
+			}
+			savedData[rootHash] = data
+
+			_, err = compactor.createCheckpoints()
+			require.NoError(t, err)
+		}
+
+		t.Run("Compactor creates delta checkpoints on top of full checkpoint", func(t *testing.T) {
+			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 0, zerolog.Nop(),
+				WithDeltaCheckpoints(0, 0))
+
+			for i := 0; i < size; i++ {
+				update(compactor)
+			}
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Greater(t, len(checkpoints), 2)
+
+			// only the first checkpoint is a full checkpoint
+			for i, checkpoint := range checkpoints {
+				version, err := checkpointer.CheckpointVersion(checkpoint)
+				require.NoError(t, err)
+				if i == 0 {
+					assert.Equal(t, VersionV7, version)
+				} else {
+					assert.Equal(t, VersionV6, version)
+				}
+			}
+
+			chain, err := checkpointer.CheckpointChain(checkpoints[len(checkpoints)-1])
+			require.NoError(t, err)
+			assert.Equal(t, checkpoints, chain)
+		})
+
+		t.Run("delta checkpoints are loaded with their base checkpoints", func(t *testing.T) {
+			latest, err := checkpointer.LatestCheckpoint()
+			require.NoError(t, err)
+
+			tries, err := checkpointer.LoadCheckpoint(latest)
+			require.NoError(t, err)
+			require.NotEmpty(t, tries)
+
+			for _, trie := range tries {
+				expected, err := f.GetTrie(trie.RootHash())
+				require.NoError(t, err)
+				assert.Equal(t, expected.AllocatedRegCount(), trie.AllocatedRegCount())
+				assert.Equal(t, expected.AllocatedRegSize(), trie.AllocatedRegSize())
+			}
+
+			// replaying the WAL loads the latest delta checkpoint
+			f2, err := mtrie.NewForest(size*10, metricsCollector, nil)
+			require.NoError(t, err)
+
+			err = wal.ReplayOnForest(f2)
+			require.NoError(t, err)
+
+			for rootHash, data := range savedData {
+				paths := make([]ledger.Path, 0, len(data))
+				for path := range data {
+					paths = append(paths, path)
+				}
+
+				values, err := f2.Read(&ledger.TrieRead{RootHash: rootHash, Paths: paths})
+				require.NoError(t, err)
+
+				for i, path := range paths {
+					require.Equal(t, data[path].Value, values[i])
+				}
+			}
+		})
+
+		t.Run("base checkpoints of kept checkpoints are not removed", func(t *testing.T) {
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+
+			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 1, zerolog.Nop(),
+				WithDeltaCheckpoints(0, 0))
+			err = compactor.cleanupCheckpoints()
+			require.NoError(t, err)
+
+			remaining, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			assert.Equal(t, checkpoints, remaining)
+		})
+
+		t.Run("Compactor creates full checkpoint once deltas exceed size threshold", func(t *testing.T) {
+			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 1, zerolog.Nop(),
+				WithDeltaCheckpoints(1, 0))
+
+			previous, err := checkpointer.LatestCheckpoint()
+			require.NoError(t, err)
+
+			// update until the next checkpoint is created
+			latest := previous
+			for latest == previous {
+				update(compactor)
+
+				latest, err = checkpointer.LatestCheckpoint()
+				require.NoError(t, err)
+			}
+
+			version, err := checkpointer.CheckpointVersion(latest)
+			require.NoError(t, err)
+			assert.Equal(t, VersionV7, version)
+
+			// the previous chain is not needed anymore
+			err = compactor.cleanupCheckpoints()
+			require.NoError(t, err)
+
+			remaining, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			assert.Equal(t, []int{latest}, remaining)
+		})
+
+		<-wal.Done()
+	})
+}
@@ -0,0 +1,107 @@
+package wal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var _ ObjectStore = (*DirectoryStore)(nil)
+
+// DirectoryStore is an ObjectStore which stores objects as files in a local directory, such as
+// a mounted network file system. Object names are paths relative to the directory.
+type DirectoryStore struct {
+	dir string
+}
+
+// NewDirectoryStore returns a new object store for the given directory.
+func NewDirectoryStore(dir string) *DirectoryStore {
+	return &DirectoryStore{
+		dir: dir,
+	}
+}
+
+// Put writes the object to a temporary file, which is renamed to the object file once it is
+// completely written.
+func (s *DirectoryStore) Put(_ context.Context, name string, reader io.Reader) error {
+	filename := filepath.Join(s.dir, filepath.FromSlash(name))
+
+	err := os.MkdirAll(filepath.Dir(filename), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("cannot create directory for object %s: %w", name, err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), "writing-object-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file for object %s: %w", name, err)
+	}
+
+	_, err = io.Copy(tmpFile, reader)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("cannot write object %s: %w", name, err)
+	}
+
+	err = os.Rename(tmpFile.Name(), filename)
+	if err != nil {
+		return fmt.Errorf("error while renaming from %s to %s: %w", tmpFile.Name(), filename, err)
+	}
+
+	return nil
+}
+
+// Get opens the object file.
+func (s *DirectoryStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("object %s does not exist: %w", name, ErrObjectNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open object %s: %w", name, err)
+	}
+	return file, nil
+}
+
+// List walks the directory and returns the names of all object files with the given prefix.
+// Temporary files of objects which are being written are not listed.
+func (s *DirectoryStore) List(_ context.Context, prefix string) ([]string, error) {
+	names := make([]string, 0)
+
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), "writing-object-") {
+			return nil
+		}
+
+		name, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list directory %s: %w", s.dir, err)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
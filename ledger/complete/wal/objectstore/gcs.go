@@ -0,0 +1,90 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var _ wal.ObjectStore = (*GCSStore)(nil)
+
+// GCSStore is an object store backed by a Google Cloud Storage bucket. All object names are
+// prefixed with the configured prefix, so a bucket can be shared with other data.
+type GCSStore struct {
+	client *storage.Client
+	bucket string
+	prefix string
+}
+
+// NewGCSStore returns a new object store for the given bucket and object name prefix.
+func NewGCSStore(client *storage.Client, bucket string, prefix string) *GCSStore {
+	return &GCSStore{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+// Put uploads the object to the bucket. The object is only visible once it is completely uploaded.
+func (s *GCSStore) Put(ctx context.Context, name string, reader io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := s.client.Bucket(s.bucket).Object(s.prefix + name).NewWriter(ctx)
+
+	_, err := io.Copy(writer, reader)
+	if err != nil {
+		// closing the writer would commit the partially uploaded object, cancelling its
+		// context aborts the upload instead
+		cancel()
+		return fmt.Errorf("cannot upload object %s: %w", name, err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("cannot finish upload of object %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Get returns a reader for the object in the bucket.
+func (s *GCSStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	reader, err := s.client.Bucket(s.bucket).Object(s.prefix + name).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, fmt.Errorf("object %s does not exist: %w", name, wal.ErrObjectNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create GCS object reader for object %s: %w", name, err)
+	}
+	return reader, nil
+}
+
+// List returns the names of all objects in the bucket with the given prefix.
+func (s *GCSStore) List(ctx context.Context, prefix string) ([]string, error) {
+	it := s.client.Bucket(s.bucket).Objects(ctx, &storage.Query{
+		Prefix: s.prefix + prefix,
+	})
+
+	var names []string
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot list objects: %w", err)
+		}
+
+		names = append(names, strings.TrimPrefix(attrs.Name, s.prefix))
+	}
+
+	return names, nil
+}
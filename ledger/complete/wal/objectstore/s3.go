@@ -0,0 +1,93 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var _ wal.ObjectStore = (*S3Store)(nil)
+
+// S3Store is an object store backed by a S3 bucket. All object names are prefixed with the
+// configured prefix, so a bucket can be shared with other data.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store returns a new object store for the given bucket and object name prefix.
+func NewS3Store(client *s3.Client, bucket string, prefix string) *S3Store {
+	return &S3Store{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+// Put uploads the object to the bucket. Large objects are uploaded using a multipart upload.
+func (s *S3Store) Put(ctx context.Context, name string, reader io.Reader) error {
+	key := s.prefix + name
+
+	_, err := manager.NewUploader(s.client).Upload(ctx, &s3.PutObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+		Body:   reader,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot upload object %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Get returns a reader for the object in the bucket.
+func (s *S3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	key := s.prefix + name
+
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, fmt.Errorf("object %s does not exist: %w", name, wal.ErrObjectNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get object %s: %w", name, err)
+	}
+
+	return output.Body, nil
+}
+
+// List returns the names of all objects in the bucket with the given prefix.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	keyPrefix := s.prefix + prefix
+
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: &s.bucket,
+		Prefix: &keyPrefix,
+	})
+
+	var names []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list objects: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			names = append(names, strings.TrimPrefix(*object.Key, s.prefix))
+		}
+	}
+
+	return names, nil
+}
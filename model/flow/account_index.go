@@ -0,0 +1,132 @@
+package flow
+
+import (
+	"strings"
+)
+
+// TransactionRole is a bit set of the roles in which an account signed a transaction.
+type TransactionRole uint8
+
+const (
+	TransactionRoleAuthorizer TransactionRole = 1 << iota
+	TransactionRolePayer
+	TransactionRoleProposer
+)
+
+// Has returns true if the role set contains the given role.
+func (r TransactionRole) Has(role TransactionRole) bool {
+	return r&role == role
+}
+
+// String returns the names of the roles in the set, separated by commas.
+func (r TransactionRole) String() string {
+	var roles []string
+	if r.Has(TransactionRoleAuthorizer) {
+		roles = append(roles, "authorizer")
+	}
+	if r.Has(TransactionRolePayer) {
+		roles = append(roles, "payer")
+	}
+	if r.Has(TransactionRoleProposer) {
+		roles = append(roles, "proposer")
+	}
+	return strings.Join(roles, ",")
+}
+
+// AccountTransaction is an entry of the account transaction index. It references a transaction
+// which was signed by the account in one or more roles.
+type AccountTransaction struct {
+	Address          Address
+	BlockID          Identifier
+	BlockHeight      uint64
+	TransactionID    Identifier
+	TransactionIndex uint32
+	Roles            TransactionRole
+}
+
+// AccountEvent is an entry of the account event index. It references an event which was emitted
+// by a contract deployed to the account.
+type AccountEvent struct {
+	Address     Address
+	BlockID     Identifier
+	BlockHeight uint64
+	Event       Event
+}
+
+// AccountIndexCursor identifies the position in the account index at which a paginated query
+// continues. Entries are ordered by height, then by transaction index and event index.
+type AccountIndexCursor struct {
+	BlockHeight      uint64
+	TransactionIndex uint32
+	EventIndex       uint32
+}
+
+// AccountTransactions returns the account transaction index entries for all transactions of the
+// given block, in the order of the transactions. An entry is created for every account which signed
+// a transaction, with all the roles it signed the transaction in.
+func AccountTransactions(blockID Identifier, height uint64, transactions []*TransactionBody) []AccountTransaction {
+	var entries []AccountTransaction
+	for i, tx := range transactions {
+		txID := tx.ID()
+
+		// accounts are listed in the order of their first role in the transaction
+		var addresses []Address
+		roles := make(map[Address]TransactionRole)
+		addRole := func(address Address, role TransactionRole) {
+			if _, ok := roles[address]; !ok {
+				addresses = append(addresses, address)
+			}
+			roles[address] |= role
+		}
+
+		for _, authorizer := range tx.Authorizers {
+			addRole(authorizer, TransactionRoleAuthorizer)
+		}
+		addRole(tx.Payer, TransactionRolePayer)
+		addRole(tx.ProposalKey.Address, TransactionRoleProposer)
+
+		for _, address := range addresses {
+			entries = append(entries, AccountTransaction{
+				Address:          address,
+				BlockID:          blockID,
+				BlockHeight:      height,
+				TransactionID:    txID,
+				TransactionIndex: uint32(i),
+				Roles:            roles[address],
+			})
+		}
+	}
+	return entries
+}
+
+// AccountEvents returns the account event index entries for the given events of a block. Events
+// emitted by contracts deployed to an account are indexed by the account's address, while protocol
+// events are not indexed.
+func AccountEvents(blockID Identifier, height uint64, events []Event) []AccountEvent {
+	var entries []AccountEvent
+	for _, event := range events {
+		address, _, ok := ParseAccountEventType(event.Type)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, AccountEvent{
+			Address:     address,
+			BlockID:     blockID,
+			BlockHeight: height,
+			Event:       event,
+		})
+	}
+	return entries
+}
+
+// ParseAccountEventType returns the address of the account the contract emitting an event of the
+// given type is deployed to, and the name of the contract. Account event types have the form
+// A.[Address].[Contract].[EventName]. It returns false for all other event types, e.g. protocol events.
+func ParseAccountEventType(eventType EventType) (Address, string, bool) {
+	parts := strings.Split(string(eventType), ".")
+	if len(parts) != 4 || parts[0] != "A" || parts[1] == "" || parts[2] == "" {
+		return EmptyAddress, "", false
+	}
+	return HexToAddress(parts[1]), parts[2], true
+}
@@ -0,0 +1,59 @@
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAccountTransactions(t *testing.T) {
+	alice := unittest.RandomAddressFixture()
+	bob := unittest.RandomAddressFixture()
+	blockID := unittest.IdentifierFixture()
+
+	tx1 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Authorizers = []flow.Address{alice}
+		tx.Payer = alice
+		tx.ProposalKey.Address = alice
+	})
+	tx2 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Authorizers = []flow.Address{bob, alice}
+		tx.Payer = alice
+		tx.ProposalKey.Address = bob
+	})
+
+	entries := flow.AccountTransactions(blockID, 10, []*flow.TransactionBody{&tx1, &tx2})
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, flow.AccountTransaction{
+		Address:          alice,
+		BlockID:          blockID,
+		BlockHeight:      10,
+		TransactionID:    tx1.ID(),
+		TransactionIndex: 0,
+		Roles:            flow.TransactionRoleAuthorizer | flow.TransactionRolePayer | flow.TransactionRoleProposer,
+	}, entries[0])
+	assert.Equal(t, bob, entries[1].Address)
+	assert.Equal(t, flow.TransactionRoleAuthorizer|flow.TransactionRoleProposer, entries[1].Roles)
+	assert.Equal(t, uint32(1), entries[1].TransactionIndex)
+	assert.Equal(t, alice, entries[2].Address)
+	assert.Equal(t, flow.TransactionRoleAuthorizer|flow.TransactionRolePayer, entries[2].Roles)
+	assert.Equal(t, "authorizer,payer", entries[2].Roles.String())
+}
+
+func TestParseAccountEventType(t *testing.T) {
+	address, contract, ok := flow.ParseAccountEventType("A.0000000000000001.Contract1.EventA")
+	require.True(t, ok)
+	assert.Equal(t, flow.HexToAddress("0000000000000001"), address)
+	assert.Equal(t, "Contract1", contract)
+
+	_, _, ok = flow.ParseAccountEventType(flow.EventAccountCreated)
+	assert.False(t, ok)
+
+	_, _, ok = flow.ParseAccountEventType("A.0000000000000001.Contract1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,145 @@
+package flow
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/encoding/rlp"
+)
+
+// SlashingViolation is the type of a slashable protocol violation committed by a consensus participant.
+type SlashingViolation string
+
+const (
+	// SlashingViolationDoubleVote is a participant voting for two different blocks in the same view.
+	SlashingViolationDoubleVote SlashingViolation = "double_vote"
+	// SlashingViolationInvalidVote is a participant sending a vote with an invalid signature.
+	SlashingViolationInvalidVote SlashingViolation = "invalid_vote"
+	// SlashingViolationVoteForInvalidBlock is a participant voting for a block which failed validation.
+	SlashingViolationVoteForInvalidBlock SlashingViolation = "vote_for_invalid_block"
+	// SlashingViolationDoubleProposal is a leader proposing two different blocks in the same view.
+	SlashingViolationDoubleProposal SlashingViolation = "double_proposal"
+)
+
+// SlashingVote is a consensus vote, as included in slashing evidence. The signature data
+// is the packed signature data of the vote, signed over the view and block ID.
+type SlashingVote struct {
+	View     uint64
+	BlockID  Identifier
+	SignerID Identifier
+	SigData  []byte
+}
+
+// SlashingCommittee is the consensus committee of the epoch in which a violation was
+// committed, which is needed to verify the signatures of the evidence.
+type SlashingCommittee struct {
+	EpochCounter uint64
+	// Participants are the consensus participants of the epoch, with their staking keys.
+	Participants IdentityList
+	// DKGGroupKey is the random beacon group key of the epoch.
+	DKGGroupKey encodable.RandomBeaconPubKey
+	// DKGParticipantKeys are the random beacon key shares of the participants, where
+	// DKGParticipantKeys[i] is the key share of Participants[i].
+	DKGParticipantKeys []encodable.RandomBeaconPubKey
+}
+
+// SlashingEvidence is a self-contained record of a slashable violation committed by a
+// consensus participant. It contains the conflicting (or invalid) votes and proposals of
+// the offender with their signatures, and the committee of the epoch of the violation, so
+// that third parties can verify the evidence without access to the protocol state.
+//
+// The evidence is signed by the node which detected the violation.
+type SlashingEvidence struct {
+	Violation  SlashingViolation
+	View       uint64
+	OffenderID Identifier
+
+	// Votes are the votes of the offender: both votes of a double vote, or the single
+	// vote of an invalid vote or a vote for an invalid block.
+	Votes []SlashingVote
+
+	// Proposals are the proposals of the violation: both proposals of a double proposal,
+	// or the invalid proposal voted for. The proposer signature data is included in the
+	// headers. Headers of invalid proposals are reconstructed from the proposal, hence
+	// only the fields covered by the proposal are set.
+	Proposals []*Header
+
+	Committee SlashingCommittee
+
+	ReporterID  Identifier
+	DetectedAt  time.Time
+	ReporterSig crypto.Signature
+}
+
+// Fingerprint returns the canonical encoding of the evidence, excluding the signature of
+// the reporter, which is computed over the ID of the evidence.
+func (e *SlashingEvidence) Fingerprint() []byte {
+	proposals := make([]encodableSlashingProposal, 0, len(e.Proposals))
+	for _, proposal := range e.Proposals {
+		proposals = append(proposals, encodableSlashingProposal{
+			HeaderID:        proposal.ID(),
+			ProposerSigData: proposal.ProposerSigData,
+		})
+	}
+	keys := make([][]byte, 0, len(e.Committee.DKGParticipantKeys))
+	for _, key := range e.Committee.DKGParticipantKeys {
+		keys = append(keys, encodeBeaconKey(key))
+	}
+
+	return rlp.NewMarshaler().MustMarshal(struct {
+		Violation          string
+		View               uint64
+		OffenderID         Identifier
+		Votes              []SlashingVote
+		Proposals          []encodableSlashingProposal
+		EpochCounter       uint64
+		Participants       IdentityList
+		DKGGroupKey        []byte
+		DKGParticipantKeys [][]byte
+		ReporterID         Identifier
+		DetectedAt         uint64
+	}{
+		Violation:          string(e.Violation),
+		View:               e.View,
+		OffenderID:         e.OffenderID,
+		Votes:              e.Votes,
+		Proposals:          proposals,
+		EpochCounter:       e.Committee.EpochCounter,
+		Participants:       e.Committee.Participants,
+		DKGGroupKey:        encodeBeaconKey(e.Committee.DKGGroupKey),
+		DKGParticipantKeys: keys,
+		ReporterID:         e.ReporterID,
+		DetectedAt:         uint64(e.DetectedAt.UnixNano()),
+	})
+}
+
+// ID returns the hash of the evidence, which is signed by the reporter.
+func (e *SlashingEvidence) ID() Identifier {
+	return MakeID(e)
+}
+
+// Checksum returns a checksum of the evidence, including the signature of the reporter.
+func (e *SlashingEvidence) Checksum() Identifier {
+	return MakeID(struct {
+		ID          Identifier
+		ReporterSig crypto.Signature
+	}{
+		ID:          e.ID(),
+		ReporterSig: e.ReporterSig,
+	})
+}
+
+// encodableSlashingProposal is the RLP encoding of a proposal of slashing evidence. The
+// header is covered by its ID, which excludes the signature of the proposer.
+type encodableSlashingProposal struct {
+	HeaderID        Identifier
+	ProposerSigData []byte
+}
+
+func encodeBeaconKey(key encodable.RandomBeaconPubKey) []byte {
+	if key.PublicKey == nil {
+		return nil
+	}
+	return key.Encode()
+}
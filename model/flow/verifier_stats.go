@@ -0,0 +1,28 @@
+package flow
+
+import (
+	"time"
+)
+
+// VerifierStats records how responsive a verification node has been to the approval requests
+// sent by this consensus node. The statistics are node-local and are not part of the protocol state.
+type VerifierStats struct {
+	VerifierID        Identifier
+	Requests          uint64        // number of approvals requested from the verifier
+	Responses         uint64        // number of requested approvals provided by the verifier
+	Misses            uint64        // number of requests the verifier did not respond to
+	ConsecutiveMisses uint64        // number of requests the verifier did not respond to since its last response
+	TotalResponseTime time.Duration // sum of the response times of all responses
+	LastRequest       time.Time     // time of the latest request sent to the verifier
+	LastResponse      time.Time     // time of the latest response received from the verifier
+	LastMiss          time.Time     // time the latest request the verifier did not respond to was missed
+}
+
+// AverageResponseTime returns the average time the verifier took to respond to a request, or
+// zero if the verifier has not responded to any request.
+func (s VerifierStats) AverageResponseTime() time.Duration {
+	if s.Responses == 0 {
+		return 0
+	}
+	return s.TotalResponseTime / time.Duration(s.Responses)
+}
@@ -191,7 +191,9 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 	var transactions []*flow.TransactionBody
 	var totalByteSize uint64
 	var totalGas uint64
-	for _, tx := range b.transactions.All() {
+	// transactions are considered in descending order of priority, so that higher priority
+	// transactions are included first when there are more transactions than fit in a collection
+	for _, tx := range b.transactions.ByPriority() {
 
 		// if we have reached maximum number of transactions, stop
 		if uint(len(transactions)) >= b.config.MaxCollectionSize {
@@ -206,10 +208,10 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 			continue
 		}
 
-		// because the max byte size per tx is way smaller than the max collection byte size, we can stop here and not continue.
-		// to make it more effective in the future we can continue adding smaller ones
+		// skip transactions which do not fit in the collection anymore, smaller transactions
+		// with lower priority may still fit
 		if totalByteSize+txByteSize > b.config.MaxCollectionByteSize {
-			break
+			continue
 		}
 
 		// ignore transactions with max gas bigger that the max total gas per collection
@@ -218,10 +220,10 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 			continue
 		}
 
-		// cause the max gas limit per tx is way smaller than the total max gas per collection, we can stop here and not continue.
-		// to make it more effective in the future we can continue adding smaller ones
+		// skip transactions which exceed the remaining gas of the collection, transactions with
+		// lower priority and gas limit may still fit
 		if totalGas+tx.GasLimit > b.config.MaxCollectionTotalGas {
-			break
+			continue
 		}
 
 		// retrieve the main chain header that was used as reference
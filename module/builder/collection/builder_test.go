@@ -599,6 +599,45 @@ func (suite *BuilderSuite) TestBuildOn_MaxCollectionTotalGas() {
 	suite.Assert().Equal(builtCollection.Len(), 2)
 }
 
+// With a priority mempool, the builder should include the transactions with the highest priority first,
+// and fill the remaining gas of the collection with lower priority transactions.
+func (suite *BuilderSuite) TestBuildOn_TransactionPriority() {
+
+	// start with an empty priority mempool
+	suite.pool = herocache.NewPriorityTransactions(1000, mempool.TransactionPriorityGasLimit, unittest.Logger(), metrics.NewNoopCollector())
+	suite.builder, _ = builder.NewBuilder(suite.db, trace.NewNoopTracer(), suite.headers, suite.headers, suite.payloads, suite.pool,
+		builder.WithMaxCollectionTotalGas(20000),
+	)
+
+	final, err := suite.protoState.Final().Head()
+	suite.Require().NoError(err)
+	create := func(gasLimit uint64) *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.ReferenceBlockID = final.ID()
+			tx.GasLimit = gasLimit
+		})
+		suite.Require().True(suite.pool.Add(&tx))
+		return &tx
+	}
+	low := create(1000)
+	high := create(15000)
+	medium := create(9000)
+	lowest := create(500)
+
+	header, err := suite.builder.BuildOn(suite.genesis.ID(), noopSetter)
+	suite.Require().NoError(err)
+
+	var built model.Block
+	err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+	suite.Require().NoError(err)
+	builtCollection := built.Payload.Collection
+
+	// the medium priority transaction exceeds the remaining gas after the high priority one, so it is skipped
+	// in favour of the low priority transactions
+	suite.Assert().Equal([]*flow.TransactionBody{high, low, lowest}, builtCollection.Transactions)
+	suite.Assert().True(suite.pool.Has(medium.ID()))
+}
+
 func (suite *BuilderSuite) TestBuildOn_ExpiredTransaction() {
 
 	// create enough main-chain blocks that an expired transaction is possible
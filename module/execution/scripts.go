@@ -0,0 +1,212 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultScriptExecutionTimeLimit is the default maximum duration of a locally executed script.
+const DefaultScriptExecutionTimeLimit = 10 * time.Second
+
+// maxScriptErrorMessageSize is the maximum length of a script error message, longer messages are
+// truncated in the middle.
+const maxScriptErrorMessageSize = 1000
+
+// ErrDataNotAvailable is returned when the registers at the requested height are not available in
+// the local register index.
+var ErrDataNotAvailable = errors.New("data for block is not available")
+
+// ScriptExecutionError is returned when a script fails because of an error of the script itself,
+// e.g. a Cadence runtime error. Such failures are deterministic, hence they would also occur when
+// executing the script on an execution node.
+type ScriptExecutionError struct {
+	err error
+}
+
+func NewScriptExecutionErrorf(msg string, args ...interface{}) error {
+	return ScriptExecutionError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e ScriptExecutionError) Unwrap() error {
+	return e.err
+}
+
+func (e ScriptExecutionError) Error() string {
+	return e.err.Error()
+}
+
+// IsScriptExecutionError returns whether the given error is a ScriptExecutionError
+func IsScriptExecutionError(err error) bool {
+	var scriptErr ScriptExecutionError
+	return errors.As(err, &scriptErr)
+}
+
+// ScriptExecutor executes scripts and account queries against a local copy of the execution state.
+type ScriptExecutor interface {
+	// ExecuteAtBlockHeight executes the script with the given arguments against the execution state
+	// at the given height, and returns the JSON-CDC encoded result.
+	// Expected errors:
+	// - ErrDataNotAvailable: if the execution state at the height is not available locally
+	// - ScriptExecutionError: if the script failed because of an error of the script itself
+	// Any other error is an internal failure to execute the script.
+	ExecuteAtBlockHeight(ctx context.Context, script []byte, arguments [][]byte, height uint64) ([]byte, error)
+
+	// GetAccountAtBlockHeight returns the account with the given address at the given height.
+	// Expected errors:
+	// - ErrDataNotAvailable: if the execution state at the height is not available locally
+	// - fvm/errors.AccountNotFoundError: if the account does not exist at the height
+	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error)
+}
+
+// Scripts executes scripts and account queries with the FVM, reading registers from the register
+// index.
+type Scripts struct {
+	log                      zerolog.Logger
+	vm                       *fvm.VirtualMachine
+	vmCtx                    fvm.Context
+	headers                  storage.Headers
+	registers                storage.RegisterIndex
+	scriptExecutionTimeLimit time.Duration
+}
+
+var _ ScriptExecutor = (*Scripts)(nil)
+
+func NewScripts(
+	log zerolog.Logger,
+	vm *fvm.VirtualMachine,
+	vmCtx fvm.Context,
+	headers storage.Headers,
+	registers storage.RegisterIndex,
+	scriptExecutionTimeLimit time.Duration,
+) *Scripts {
+	return &Scripts{
+		log:                      log.With().Str("component", "script_executor").Logger(),
+		vm:                       vm,
+		vmCtx:                    vmCtx,
+		headers:                  headers,
+		registers:                registers,
+		scriptExecutionTimeLimit: scriptExecutionTimeLimit,
+	}
+}
+
+// ExecuteAtBlockHeight executes the script with the given arguments at the given height, and returns
+// the JSON-CDC encoded result.
+func (s *Scripts) ExecuteAtBlockHeight(ctx context.Context, code []byte, arguments [][]byte, height uint64) (encoded []byte, err error) {
+	header, view, err := s.viewAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx, s.scriptExecutionTimeLimit)
+	defer cancel()
+
+	script := fvm.NewScriptWithContextAndArgs(code, requestCtx, arguments...)
+	blockCtx := fvm.NewContextFromParent(s.vmCtx, fvm.WithBlockHeader(header))
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error().
+				Interface("recovered", r).
+				Hex("script_hex", code).
+				Uint64("height", height).
+				Msg("script execution caused runtime panic")
+			err = fmt.Errorf("cadence runtime error: %s", r)
+		}
+	}()
+
+	err = s.vm.Run(blockCtx, script, view, programs.NewEmptyPrograms())
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute script (internal error): %w", err)
+	}
+
+	if script.Err != nil {
+		return nil, NewScriptExecutionErrorf("failed to execute script at height %d: %s", height, truncateErrorMessage(script.Err.Error()))
+	}
+
+	encoded, err = jsoncdc.Encode(script.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode runtime value: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// GetAccountAtBlockHeight returns the account with the given address at the given height.
+func (s *Scripts) GetAccountAtBlockHeight(_ context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	header, view, err := s.viewAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCtx := fvm.NewContextFromParent(s.vmCtx, fvm.WithBlockHeader(header))
+
+	account, err := s.vm.GetAccount(blockCtx, address, view, programs.NewEmptyPrograms())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account (%s) at height %d: %w", address.String(), height, err)
+	}
+
+	return account, nil
+}
+
+// viewAtHeight returns the header of the block at the given height, and a read-only view of the
+// execution state at this height.
+// Expected errors:
+// - ErrDataNotAvailable: if the registers at the height are not indexed
+func (s *Scripts) viewAtHeight(height uint64) (*flow.Header, *delta.View, error) {
+	first, err := s.registers.FirstHeight()
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, fmt.Errorf("register index was not bootstrapped: %w", ErrDataNotAvailable)
+		}
+		return nil, nil, fmt.Errorf("could not get first indexed height: %w", err)
+	}
+
+	latest, err := s.registers.LatestHeight()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get latest indexed height: %w", err)
+	}
+
+	if height < first || height > latest {
+		return nil, nil, fmt.Errorf("height %d is outside of indexed range [%d, %d]: %w", height, first, latest, ErrDataNotAvailable)
+	}
+
+	header, err := s.headers.ByHeight(height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+
+	view := delta.NewView(func(owner, key string) (flow.RegisterValue, error) {
+		return s.registers.Get(flow.NewRegisterID(owner, key), height)
+	})
+
+	return header, view, nil
+}
+
+// truncateErrorMessage shortens error messages longer than maxScriptErrorMessageSize by removing
+// the middle of the message.
+func truncateErrorMessage(msg string) string {
+	if len(msg) <= maxScriptErrorMessageSize {
+		return msg
+	}
+
+	split := maxScriptErrorMessageSize/2 - 1
+	var sb strings.Builder
+	sb.WriteString(msg[:split])
+	sb.WriteString(" ... ")
+	sb.WriteString(msg[len(msg)-split:])
+	return sb.String()
+}
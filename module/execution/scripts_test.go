@@ -0,0 +1,87 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestScripts(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		chain := flow.Localnet.Chain()
+		vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+		vmCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+
+		// bootstrap the execution state, and index it at height 10
+		view := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+		bootstrap := fvm.Bootstrap(unittest.ServiceAccountPublicKey, fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply))
+		err := vm.Run(vmCtx, bootstrap, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+
+		ids, values := view.Delta().RegisterUpdates()
+		entries := make(flow.RegisterEntries, len(ids))
+		for i := range ids {
+			entries[i] = flow.RegisterEntry{Key: ids[i], Value: values[i]}
+		}
+
+		registers := bstorage.NewRegisterIndex(db)
+		require.NoError(t, registers.Bootstrap(10, entries))
+
+		header := unittest.BlockHeaderFixture()
+		header.Height = 10
+		headers := storagemock.NewHeaders(t)
+		headers.On("ByHeight", uint64(10)).Return(header, nil).Maybe()
+
+		scripts := NewScripts(unittest.Logger(), vm, vmCtx, headers, registers, DefaultScriptExecutionTimeLimit)
+
+		t.Run("execute script", func(t *testing.T) {
+			result, err := scripts.ExecuteAtBlockHeight(context.Background(), []byte("pub fun main(): Int { return 42 }"), nil, 10)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"type":"Int","value":"42"}`, string(result))
+		})
+
+		t.Run("script error", func(t *testing.T) {
+			_, err := scripts.ExecuteAtBlockHeight(context.Background(), []byte("pub fun main(): Int { panic(\"failed\") }"), nil, 10)
+			require.Error(t, err)
+			assert.True(t, IsScriptExecutionError(err))
+			assert.False(t, errors.Is(err, ErrDataNotAvailable))
+		})
+
+		t.Run("get account", func(t *testing.T) {
+			account, err := scripts.GetAccountAtBlockHeight(context.Background(), chain.ServiceAddress(), 10)
+			require.NoError(t, err)
+			assert.Equal(t, chain.ServiceAddress(), account.Address)
+			assert.NotEmpty(t, account.Keys)
+		})
+
+		t.Run("account not found", func(t *testing.T) {
+			address, err := chain.AddressAtIndex(1000)
+			require.NoError(t, err)
+			_, err = scripts.GetAccountAtBlockHeight(context.Background(), address, 10)
+			require.Error(t, err)
+			assert.True(t, fvmerrors.IsAccountNotFoundError(err))
+		})
+
+		t.Run("height not indexed", func(t *testing.T) {
+			_, err := scripts.ExecuteAtBlockHeight(context.Background(), []byte("pub fun main(): Int { return 42 }"), nil, 11)
+			assert.True(t, errors.Is(err, ErrDataNotAvailable))
+
+			_, err = scripts.GetAccountAtBlockHeight(context.Background(), chain.ServiceAddress(), 9)
+			assert.True(t, errors.Is(err, ErrDataNotAvailable))
+		})
+	})
+}
@@ -10,6 +10,8 @@ const (
 
 	ConsumeProgressExecutionDataRequesterBlockHeight  = "ConsumeProgressExecutionDataRequesterBlockHeight"
 	ConsumeProgressExecutionDataRequesterNotification = "ConsumeProgressExecutionDataRequesterNotification"
+
+	ConsumeProgressExecutionPruner = "ConsumeProgressExecutionPruner"
 )
 
 // JobID is a unique ID of the job.
@@ -7,17 +7,32 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool"
 	herocache "github.com/onflow/flow-go/module/mempool/herocache/backdata"
 	"github.com/onflow/flow-go/module/mempool/herocache/backdata/heropool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 )
 
 type Transactions struct {
-	c *stdmap.Backend
+	c          *stdmap.Backend
+	priorities *stdmap.TransactionPriorities
 }
 
 // NewTransactions implements a transactions mempool based on hero cache.
 func NewTransactions(limit uint32, logger zerolog.Logger, collector module.HeroCacheMetrics) *Transactions {
+	return NewPriorityTransactions(limit, nil, logger, collector)
+}
+
+// NewPriorityTransactions implements a transactions mempool based on hero cache, which orders its
+// transactions by the given priority function. Once the mempool is full, the least recently used
+// transactions are evicted, regardless of their priority. If the priority function is nil,
+// transactions are not ordered.
+func NewPriorityTransactions(
+	limit uint32,
+	priority mempool.TransactionPriorityFunc,
+	logger zerolog.Logger,
+	collector module.HeroCacheMetrics,
+) *Transactions {
 	t := &Transactions{
 		c: stdmap.NewBackend(
 			stdmap.WithBackData(
@@ -27,6 +42,9 @@ func NewTransactions(limit uint32, logger zerolog.Logger, collector module.HeroC
 					logger.With().Str("mempool", "transactions").Logger(),
 					collector))),
 	}
+	if priority != nil {
+		t.priorities = stdmap.NewTransactionPriorities(priority, uint(limit))
+	}
 
 	return t
 }
@@ -41,7 +59,16 @@ func (t Transactions) Has(id flow.Identifier) bool {
 func (t *Transactions) Add(tx *flow.TransactionBody) bool {
 	// Warning! reference pointer must be dereferenced before adding to HeroCache.
 	// This is crucial for its heap object optimizations.
-	return t.c.Add(*tx)
+	if t.priorities == nil {
+		return t.c.Add(*tx)
+	}
+
+	added := false
+	_ = t.c.Run(func(backdata mempool.BackData) error {
+		added = t.priorities.Add(backdata, tx.ID(), tx, *tx)
+		return nil
+	})
+	return added
 }
 
 // ByID returns the transaction with the given ID from the mempool.
@@ -72,9 +99,41 @@ func (t Transactions) All() []*flow.TransactionBody {
 	return txs
 }
 
+// ByPriority returns all transactions from the mempool, ordered by descending priority. Without
+// a priority function, it returns the transactions in the same order as All.
+func (t Transactions) ByPriority() []*flow.TransactionBody {
+	if t.priorities == nil {
+		return t.All()
+	}
+
+	var entities stdmap.PrioritizedEntities
+	_ = t.c.Run(func(backdata mempool.BackData) error {
+		entities = t.priorities.Entities(backdata)
+		return nil
+	})
+	// order the transactions after releasing the lock on the mempool
+	txs := make([]*flow.TransactionBody, 0, len(entities))
+	for _, entity := range entities.Sorted() {
+		tx, ok := entity.(flow.TransactionBody)
+		if !ok {
+			panic(fmt.Sprintf("invalid entity in transaction pool (%T)", entity))
+		}
+		txs = append(txs, &tx)
+	}
+	return txs
+}
+
 // Clear removes all transactions stored in this mempool.
 func (t *Transactions) Clear() {
-	t.c.Clear()
+	if t.priorities == nil {
+		t.c.Clear()
+		return
+	}
+
+	_ = t.c.Run(func(backdata mempool.BackData) error {
+		t.priorities.Clear(backdata)
+		return nil
+	})
 }
 
 // Size returns total number of stored transactions.
@@ -84,7 +143,16 @@ func (t Transactions) Size() uint {
 
 // Remove removes transaction from mempool.
 func (t *Transactions) Remove(id flow.Identifier) bool {
-	return t.c.Remove(id)
+	if t.priorities == nil {
+		return t.c.Remove(id)
+	}
+
+	removed := false
+	_ = t.c.Run(func(backdata mempool.BackData) error {
+		removed = t.priorities.Remove(backdata, id)
+		return nil
+	})
+	return removed
 }
 
 // Hash will return a fingerprint hash representing the contents of the
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/herocache"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -59,6 +60,55 @@ func TestTransactionPool(t *testing.T) {
 	})
 }
 
+// TestPriorityTransactionPool tests that the pool returns transactions in priority order, and that it evicts the least
+// recently added transactions once it is full, regardless of their priority.
+func TestPriorityTransactionPool(t *testing.T) {
+	txs := make([]flow.TransactionBody, 0, 4)
+	for _, gasLimit := range []uint64{10, 30, 20, 20} {
+		txs = append(txs, unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = gasLimit
+		}))
+	}
+
+	transactions := herocache.NewPriorityTransactions(4, mempool.TransactionPriorityGasLimit, unittest.Logger(), metrics.NewNoopCollector())
+	for i := range txs {
+		require.True(t, transactions.Add(&txs[i]))
+	}
+
+	t.Run("should order by priority, then arrival", func(t *testing.T) {
+		assert.Equal(t, []*flow.TransactionBody{&txs[1], &txs[2], &txs[3], &txs[0]}, transactions.ByPriority())
+		// All keeps returning transactions in the order they were added
+		assert.Equal(t, []*flow.TransactionBody{&txs[0], &txs[1], &txs[2], &txs[3]}, transactions.All())
+	})
+
+	t.Run("should evict least recently added transaction when full", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = 5
+		})
+		require.True(t, transactions.Add(&tx))
+		assert.EqualValues(t, 4, transactions.Size())
+		assert.False(t, transactions.Has(txs[0].ID()))
+		assert.Equal(t, []*flow.TransactionBody{&txs[1], &txs[2], &txs[3], &tx}, transactions.ByPriority())
+	})
+
+	t.Run("should evict transaction with highest priority if least recently added", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = 40
+		})
+		require.True(t, transactions.Add(&tx))
+		assert.EqualValues(t, 4, transactions.Size())
+		assert.False(t, transactions.Has(txs[1].ID()))
+		assert.Equal(t, &tx, transactions.ByPriority()[0])
+		assert.Len(t, transactions.ByPriority(), 4)
+	})
+
+	t.Run("should be able to clear", func(t *testing.T) {
+		transactions.Clear()
+		assert.Equal(t, uint(0), transactions.Size())
+		assert.Empty(t, transactions.ByPriority())
+	})
+}
+
 // TestConcurrentWriteAndRead checks correctness of transactions mempool under concurrent read and write.
 func TestConcurrentWriteAndRead(t *testing.T) {
 	total := 100
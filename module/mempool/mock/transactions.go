@@ -66,6 +66,22 @@ func (_m *Transactions) ByID(txID flow.Identifier) (*flow.TransactionBody, bool)
 	return r0, r1
 }
 
+// ByPriority provides a mock function with given fields:
+func (_m *Transactions) ByPriority() []*flow.TransactionBody {
+	ret := _m.Called()
+
+	var r0 []*flow.TransactionBody
+	if rf, ok := ret.Get(0).(func() []*flow.TransactionBody); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.TransactionBody)
+		}
+	}
+
+	return r0
+}
+
 // Clear provides a mock function with given fields:
 func (_m *Transactions) Clear() {
 	_m.Called()
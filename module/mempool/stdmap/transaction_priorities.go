@@ -0,0 +1,132 @@
+package stdmap
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+)
+
+// TransactionPriorities indexes the transactions of a memory pool by their priority, so
+// that the memory pool can return its transactions in priority order. Transactions with
+// equal priority are ordered by arrival.
+//
+// The priority only determines the order in which transactions are included in collections.
+// Once the memory pool is full, transactions are ejected by the backdata as usual, regardless
+// of their priority, so that claiming a high priority does not allow to flush the memory pool.
+// Transactions ejected by the backdata are dropped from the index lazily.
+//
+// TransactionPriorities is NOT concurrency safe: the memory pool must only call it from
+// within Backend.Run, so that the index is modified together with the backdata.
+type TransactionPriorities struct {
+	priority mempool.TransactionPriorityFunc
+	limit    uint
+	seqNum   uint64
+	entries  map[flow.Identifier]transactionPriority
+}
+
+type transactionPriority struct {
+	priority uint64
+	seqNum   uint64
+}
+
+// higher returns whether p is ordered before other, i.e. has a higher priority or has equal
+// priority and arrived earlier.
+func (p *transactionPriority) higher(other *transactionPriority) bool {
+	if p.priority != other.priority {
+		return p.priority > other.priority
+	}
+	return p.seqNum < other.seqNum
+}
+
+// NewTransactionPriorities creates a new index for a memory pool holding at most limit
+// transactions.
+func NewTransactionPriorities(priority mempool.TransactionPriorityFunc, limit uint) *TransactionPriorities {
+	return &TransactionPriorities{
+		priority: priority,
+		limit:    limit,
+		entries:  make(map[flow.Identifier]transactionPriority),
+	}
+}
+
+// Add adds the transaction, stored as the given entity, to the backdata and the index. It
+// returns false if the transaction was already stored.
+func (t *TransactionPriorities) Add(backdata mempool.BackData, txID flow.Identifier, tx *flow.TransactionBody, entity flow.Entity) bool {
+	if !backdata.Add(txID, entity) {
+		return false
+	}
+
+	t.entries[txID] = transactionPriority{
+		priority: t.priority(tx),
+		seqNum:   t.seqNum,
+	}
+	t.seqNum++
+
+	// drop the transactions ejected by the backdata, before they outnumber the stored ones
+	if uint(len(t.entries)) > 2*t.limit {
+		t.prune(backdata)
+	}
+	return true
+}
+
+// Remove removes the transaction from the backdata and the index.
+func (t *TransactionPriorities) Remove(backdata mempool.BackData, txID flow.Identifier) bool {
+	delete(t.entries, txID)
+	_, removed := backdata.Remove(txID)
+	return removed
+}
+
+// Clear removes all transactions from the backdata and the index.
+func (t *TransactionPriorities) Clear(backdata mempool.BackData) {
+	t.entries = make(map[flow.Identifier]transactionPriority)
+	backdata.Clear()
+}
+
+// Entities returns the entities of all transactions in the backdata together with their
+// priority, in no particular order. Transactions which were ejected by the backdata are
+// dropped from the index. The result is ordered by calling Sorted on it, which does not
+// need to happen within Backend.Run.
+func (t *TransactionPriorities) Entities(backdata mempool.BackData) PrioritizedEntities {
+	entities := make(PrioritizedEntities, 0, len(t.entries))
+	for txID, entry := range t.entries {
+		entity, ok := backdata.ByID(txID)
+		if !ok {
+			delete(t.entries, txID)
+			continue
+		}
+		entities = append(entities, prioritizedEntity{
+			entity:   entity,
+			priority: entry,
+		})
+	}
+	return entities
+}
+
+// prune drops the transactions which were ejected by the backdata from the index.
+func (t *TransactionPriorities) prune(backdata mempool.BackData) {
+	for txID := range t.entries {
+		if !backdata.Has(txID) {
+			delete(t.entries, txID)
+		}
+	}
+}
+
+// PrioritizedEntities are the entities of a memory pool together with their priority.
+type PrioritizedEntities []prioritizedEntity
+
+type prioritizedEntity struct {
+	entity   flow.Entity
+	priority transactionPriority
+}
+
+// Sorted returns the entities ordered by descending priority.
+func (p PrioritizedEntities) Sorted() []flow.Entity {
+	sort.Slice(p, func(i, j int) bool {
+		return p[i].priority.higher(&p[j].priority)
+	})
+	entities := make([]flow.Entity, 0, len(p))
+	for _, e := range p {
+		entities = append(entities, e.entity)
+	}
+	return entities
+}
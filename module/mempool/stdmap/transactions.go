@@ -6,27 +6,77 @@ import (
 	"fmt"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
 )
 
 // Transactions implements the transactions memory pool of the consensus nodes,
 // used to store transactions and to generate block payloads.
 type Transactions struct {
 	*Backend
+	priorities *TransactionPriorities
 }
 
 // NewTransactions creates a new memory pool for transactions.
 // Deprecated: use herocache.Transactions instead.
 func NewTransactions(limit uint) *Transactions {
+	return NewPriorityTransactions(limit, nil)
+}
+
+// NewPriorityTransactions creates a new memory pool for transactions, which orders its
+// transactions by the given priority function. Once full, transactions are ejected as in
+// the memory pool without priorities. If the priority function is nil, transactions are
+// not ordered.
+// Deprecated: use herocache.Transactions instead.
+func NewPriorityTransactions(limit uint, priority mempool.TransactionPriorityFunc) *Transactions {
 	t := &Transactions{
 		Backend: NewBackend(WithLimit(limit)),
 	}
+	if priority != nil {
+		t.priorities = NewTransactionPriorities(priority, limit)
+	}
 
 	return t
 }
 
 // Add adds a transaction to the mempool.
 func (t *Transactions) Add(tx *flow.TransactionBody) bool {
-	return t.Backend.Add(tx)
+	if t.priorities == nil {
+		return t.Backend.Add(tx)
+	}
+
+	added := false
+	_ = t.Backend.Run(func(backdata mempool.BackData) error {
+		added = t.priorities.Add(backdata, tx.ID(), tx, tx)
+		return nil
+	})
+	return added
+}
+
+// Remove removes the transaction with the given ID from the mempool.
+func (t *Transactions) Remove(txID flow.Identifier) bool {
+	if t.priorities == nil {
+		return t.Backend.Remove(txID)
+	}
+
+	removed := false
+	_ = t.Backend.Run(func(backdata mempool.BackData) error {
+		removed = t.priorities.Remove(backdata, txID)
+		return nil
+	})
+	return removed
+}
+
+// Clear removes all transactions from the mempool.
+func (t *Transactions) Clear() {
+	if t.priorities == nil {
+		t.Backend.Clear()
+		return
+	}
+
+	_ = t.Backend.Run(func(backdata mempool.BackData) error {
+		t.priorities.Clear(backdata)
+		return nil
+	})
 }
 
 // ByID returns the transaction with the given ID from the mempool.
@@ -51,3 +101,22 @@ func (t *Transactions) All() []*flow.TransactionBody {
 	}
 	return txs
 }
+
+// ByPriority returns all transactions from the mempool, ordered by descending priority.
+func (t *Transactions) ByPriority() []*flow.TransactionBody {
+	if t.priorities == nil {
+		return t.All()
+	}
+
+	var entities PrioritizedEntities
+	_ = t.Backend.Run(func(backdata mempool.BackData) error {
+		entities = t.priorities.Entities(backdata)
+		return nil
+	})
+	// order the transactions after releasing the lock on the mempool
+	txs := make([]*flow.TransactionBody, 0, len(entities))
+	for _, entity := range entities.Sorted() {
+		txs = append(txs, entity.(*flow.TransactionBody))
+	}
+	return txs
+}
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -58,3 +61,62 @@ func TestTransactionPool(t *testing.T) {
 		assert.Equal(t, uint(0), pool.Size())
 	})
 }
+
+// TestPriorityTransactionPool tests that the pool returns transactions in priority order, and that the priority does not
+// affect which transactions are ejected once the pool is full.
+func TestPriorityTransactionPool(t *testing.T) {
+	txs := make([]*flow.TransactionBody, 0, 4)
+	for _, gasLimit := range []uint64{20, 10, 30, 20} {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = gasLimit
+		})
+		txs = append(txs, &tx)
+	}
+
+	pool := stdmap.NewPriorityTransactions(4, mempool.TransactionPriorityGasLimit)
+	for _, tx := range txs {
+		require.True(t, pool.Add(tx))
+	}
+
+	t.Run("should order by priority, then arrival", func(t *testing.T) {
+		assert.Equal(t, []*flow.TransactionBody{txs[2], txs[0], txs[3], txs[1]}, pool.ByPriority())
+	})
+
+	t.Run("should not reject transaction with lowest priority when full", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = 10
+		})
+		require.True(t, pool.Add(&tx))
+		assert.True(t, pool.Has(txs[1].ID()))
+		assert.Equal(t, []*flow.TransactionBody{txs[2], txs[0], txs[3], txs[1], &tx}, pool.ByPriority())
+	})
+
+	t.Run("should remove from priority order", func(t *testing.T) {
+		require.True(t, pool.Remove(txs[2].ID()))
+		assert.Equal(t, []*flow.TransactionBody{txs[0], txs[3], txs[1]}, pool.ByPriority()[:3])
+	})
+
+	t.Run("should be able to clear", func(t *testing.T) {
+		pool.Clear()
+		assert.Equal(t, uint(0), pool.Size())
+		assert.Empty(t, pool.ByPriority())
+	})
+}
+
+// TestPriorityTransactionPool_Ejection tests that transactions ejected by the pool are dropped from the priority order.
+func TestPriorityTransactionPool_Ejection(t *testing.T) {
+	pool := stdmap.NewPriorityTransactions(10, mempool.TransactionPriorityGasLimit)
+	for i := 0; i < 1000; i++ {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = uint64(i % 7)
+		})
+		require.True(t, pool.Add(&tx))
+	}
+	require.Less(t, pool.Size(), uint(1000))
+
+	txs := pool.ByPriority()
+	assert.Len(t, txs, int(pool.Size()))
+	for i := 1; i < len(txs); i++ {
+		assert.GreaterOrEqual(t, txs[i-1].GasLimit, txs[i].GasLimit)
+	}
+}
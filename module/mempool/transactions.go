@@ -32,6 +32,12 @@ type Transactions interface {
 	// as a slice.
 	All() []*flow.TransactionBody
 
+	// ByPriority will retrieve all transactions that are currently in the memory
+	// pool, ordered by descending priority. Transactions with equal priority are
+	// ordered by arrival. If the memory pool has no priority function, it returns
+	// the transactions in the same order as All.
+	ByPriority() []*flow.TransactionBody
+
 	// Clear removes all transactions from the mempool.
 	Clear()
 
@@ -39,3 +45,15 @@ type Transactions interface {
 	// entire memory pool.
 	Hash() flow.Identifier
 }
+
+// TransactionPriorityFunc computes the priority of a transaction in the memory pool.
+// Transactions with higher priority are included in collections first. The priority does
+// not affect which transactions are ejected once the memory pool is full.
+type TransactionPriorityFunc func(tx *flow.TransactionBody) uint64
+
+// TransactionPriorityGasLimit prioritizes transactions by their gas limit. As the gas limit is
+// set by the sender and fees are not charged for unused computation, any transaction can claim
+// the highest priority at no cost.
+func TransactionPriorityGasLimit(tx *flow.TransactionBody) uint64 {
+	return tx.GasLimit
+}
@@ -32,6 +32,9 @@ type ResolverMetrics interface {
 type NetworkMetrics interface {
 	ResolverMetrics
 	DHTMetrics
+	ReputationMetrics
+	RateLimitMetrics
+	OutboundQueueMetrics
 
 	// NetworkMessageSent size in bytes and count of the network message sent
 	NetworkMessageSent(sizeBytes int, topic string, messageType string)
@@ -193,6 +196,15 @@ type ConsensusMetrics interface {
 
 	// CheckSealingDuration records absolute time for the full sealing check by the consensus match engine
 	CheckSealingDuration(duration time.Duration)
+
+	// OnApprovalRequested increments the number of approvals requested from the verifier
+	OnApprovalRequested(verifierID flow.Identifier)
+
+	// OnApprovalResponse records the time the verifier took to provide a requested approval
+	OnApprovalResponse(verifierID flow.Identifier, duration time.Duration)
+
+	// OnApprovalRequestMissed increments the number of approval requests the verifier did not respond to
+	OnApprovalRequestMissed(verifierID flow.Identifier)
 }
 
 type VerificationMetrics interface {
@@ -519,3 +531,42 @@ type DHTMetrics interface {
 	RoutingTablePeerAdded()
 	RoutingTablePeerRemoved()
 }
+
+// ReputationMetrics tracks the reputation of remote peers, which is penalized by reported misbehavior.
+type ReputationMetrics interface {
+	// OnMisbehaviorReported tracks an offense reported for a remote peer.
+	OnMisbehaviorReported(offense string)
+
+	// TrackedPeers tracks the number of remote peers which have a penalty score.
+	TrackedPeers(count int)
+
+	// MaxPeerPenaltyScore tracks the highest current penalty score of all remote peers.
+	MaxPeerPenaltyScore(score float64)
+
+	// PenalizedPeers tracks the number of remote peers which are currently penalized with the given response.
+	PenalizedPeers(response string, count int)
+}
+
+// RateLimitMetrics tracks the inbound messages dropped by the rate limiter of the networking layer.
+type RateLimitMetrics interface {
+	// InboundMessageRateLimited tracks a message on the given topic which was dropped because its sender
+	// exceeded the given limit.
+	InboundMessageRateLimited(topic string, limit string)
+
+	// OnRateLimitDisconnect tracks a peer which was disconnected for repeatedly exceeding the rate limits.
+	OnRateLimitDisconnect()
+}
+
+// OutboundQueueMetrics tracks the outbound queues of the conduits, which prioritize outbound messages by the class
+// of their channel.
+type OutboundQueueMetrics interface {
+	// OutboundQueueDepth tracks the number of queued outbound messages of the given channel class.
+	OutboundQueueDepth(class string, depth int)
+
+	// OutboundQueueDuration tracks the time spent by an outbound message of the given channel class in the queue.
+	OutboundQueueDuration(class string, duration time.Duration)
+
+	// OutboundMessageDropped tracks an outbound message of the given channel class which was dropped because
+	// the queue of the class was full.
+	OutboundMessageDropped(class string)
+}
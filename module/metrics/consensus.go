@@ -24,6 +24,15 @@ type ConsensusCollector struct {
 
 	// The number of emergency seals
 	emergencySealedBlocks prometheus.Counter
+
+	// The number of approvals requested from each verifier
+	approvalRequests *prometheus.CounterVec
+
+	// The time each verifier took to provide requested approvals
+	approvalResponseDuration *prometheus.HistogramVec
+
+	// The number of approval requests each verifier did not respond to
+	approvalRequestsMissed *prometheus.CounterVec
 }
 
 // NewConsensusCollector created a new consensus collector
@@ -52,18 +61,43 @@ func NewConsensusCollector(tracer module.Tracer, registerer prometheus.Registere
 		Subsystem: subsystemCompliance,
 		Help:      "the number of blocks sealed in emergency mode",
 	})
+	approvalRequests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "approval_requests_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemSealing,
+		Help:      "the number of approvals requested from each verification node",
+	}, []string{LabelVerifier})
+	approvalResponseDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:      "approval_response_duration_seconds",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemSealing,
+		Help:      "the time each verification node took to provide requested approvals",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{LabelVerifier})
+	approvalRequestsMissed := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "approval_requests_missed_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemSealing,
+		Help:      "the number of approval requests each verification node did not respond to",
+	}, []string{LabelVerifier})
 	registerer.MustRegister(
 		onReceiptDuration,
 		onApprovalDuration,
 		checkSealingDuration,
 		emergencySealedBlocks,
+		approvalRequests,
+		approvalResponseDuration,
+		approvalRequestsMissed,
 	)
 	cc := &ConsensusCollector{
-		tracer:                tracer,
-		onReceiptDuration:     onReceiptDuration,
-		onApprovalDuration:    onApprovalDuration,
-		checkSealingDuration:  checkSealingDuration,
-		emergencySealedBlocks: emergencySealedBlocks,
+		tracer:                   tracer,
+		onReceiptDuration:        onReceiptDuration,
+		onApprovalDuration:       onApprovalDuration,
+		checkSealingDuration:     checkSealingDuration,
+		emergencySealedBlocks:    emergencySealedBlocks,
+		approvalRequests:         approvalRequests,
+		approvalResponseDuration: approvalResponseDuration,
+		approvalRequestsMissed:   approvalRequestsMissed,
 	}
 	return cc
 }
@@ -103,3 +137,18 @@ func (cc *ConsensusCollector) OnApprovalProcessingDuration(duration time.Duratio
 func (cc *ConsensusCollector) CheckSealingDuration(duration time.Duration) {
 	cc.checkSealingDuration.Add(duration.Seconds())
 }
+
+// OnApprovalRequested increments the number of approvals requested from the verifier
+func (cc *ConsensusCollector) OnApprovalRequested(verifierID flow.Identifier) {
+	cc.approvalRequests.WithLabelValues(verifierID.String()).Inc()
+}
+
+// OnApprovalResponse records the time the verifier took to provide a requested approval
+func (cc *ConsensusCollector) OnApprovalResponse(verifierID flow.Identifier, duration time.Duration) {
+	cc.approvalResponseDuration.WithLabelValues(verifierID.String()).Observe(duration.Seconds())
+}
+
+// OnApprovalRequestMissed increments the number of approval requests the verifier did not respond to
+func (cc *ConsensusCollector) OnApprovalRequestMissed(verifierID flow.Identifier) {
+	cc.approvalRequestsMissed.WithLabelValues(verifierID.String()).Inc()
+}
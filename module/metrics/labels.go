@@ -13,6 +13,11 @@ const (
 	LabelNodeInfo    = "nodeinfo"
 	LabelNodeVersion = "nodeversion"
 	LabelPriority    = "priority"
+	LabelOffense     = "offense"
+	LabelResponse    = "response"
+	LabelLimit       = "limit"
+	LabelClass       = "class"
+	LabelVerifier    = "verifier"
 )
 
 const (
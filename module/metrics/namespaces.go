@@ -17,10 +17,12 @@ const (
 // Network subsystems represent the various layers of networking.
 const (
 	// subsystemLibp2p = "libp2p"
-	subsystemGossip = "gossip"
-	subsystemEngine = "engine"
-	subsystemQueue  = "queue"
-	subsystemDHT    = "dht"
+	subsystemGossip     = "gossip"
+	subsystemEngine     = "engine"
+	subsystemQueue      = "queue"
+	subsystemDHT        = "dht"
+	subsystemReputation = "reputation"
+	subsystemRateLimit  = "rate_limit"
 )
 
 // Storage subsystems represent the various components of the storage layer.
@@ -47,6 +49,7 @@ const (
 	subsystemCompliance  = "compliance"
 	subsystemHotstuff    = "hotstuff"
 	subsystemMatchEngine = "match"
+	subsystemSealing     = "sealing"
 )
 
 // Execution Subsystems
@@ -32,6 +32,15 @@ type NetworkCollector struct {
 	dnsCacheInvalidationCount    prometheus.Counter
 	dnsLookupRequestDroppedCount prometheus.Counter
 	routingTableSize             prometheus.Gauge
+	misbehaviorReported          *prometheus.CounterVec
+	trackedPeers                 prometheus.Gauge
+	maxPeerPenaltyScore          prometheus.Gauge
+	penalizedPeers               *prometheus.GaugeVec
+	inboundMessagesRateLimited   *prometheus.CounterVec
+	rateLimitDisconnects         prometheus.Counter
+	outboundQueueDepth           *prometheus.GaugeVec
+	outboundQueueDuration        *prometheus.HistogramVec
+	outboundMessagesDropped      *prometheus.CounterVec
 
 	prefix string
 }
@@ -201,6 +210,88 @@ func NewNetworkCollector(opts ...NetworkCollectorOpt) *NetworkCollector {
 		},
 	)
 
+	nc.misbehaviorReported = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemReputation,
+			Name:      nc.prefix + "misbehavior_reported_total",
+			Help:      "the number of offenses reported for remote peers",
+		}, []string{LabelOffense},
+	)
+
+	nc.trackedPeers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemReputation,
+			Name:      nc.prefix + "tracked_peers",
+			Help:      "the number of remote peers which have a penalty score",
+		},
+	)
+
+	nc.maxPeerPenaltyScore = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemReputation,
+			Name:      nc.prefix + "max_peer_penalty_score",
+			Help:      "the highest current penalty score of all remote peers, decaying over time",
+		},
+	)
+
+	nc.penalizedPeers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemReputation,
+			Name:      nc.prefix + "penalized_peers",
+			Help:      "the number of remote peers which are currently penalized with the given response",
+		}, []string{LabelResponse},
+	)
+
+	nc.inboundMessagesRateLimited = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemRateLimit,
+			Name:      nc.prefix + "inbound_messages_dropped_total",
+			Help:      "the number of inbound messages dropped because their sender exceeded the given limit",
+		}, []string{LabelChannel, LabelLimit},
+	)
+
+	nc.rateLimitDisconnects = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemRateLimit,
+			Name:      nc.prefix + "peer_disconnects_total",
+			Help:      "the number of peers disconnected for repeatedly exceeding the rate limits",
+		},
+	)
+
+	nc.outboundQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemQueue,
+			Name:      nc.prefix + "outbound_queue_size",
+			Help:      "the number of outbound messages queued for the channel class",
+		}, []string{LabelClass},
+	)
+
+	nc.outboundQueueDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemQueue,
+			Name:      nc.prefix + "outbound_queue_duration_seconds",
+			Help:      "duration [seconds; measured with float64 precision] of how long an outbound message spent in the queue before being sent.",
+			Buckets:   []float64{0.01, 0.1, 0.5, 1, 2, 5}, // 10ms, 100ms, 500ms, 1s, 2s, 5s
+		}, []string{LabelClass},
+	)
+
+	nc.outboundMessagesDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemQueue,
+			Name:      nc.prefix + "outbound_messages_dropped_total",
+			Help:      "the number of outbound messages dropped because the queue of the channel class was full",
+		}, []string{LabelClass},
+	)
+
 	return nc
 }
 
@@ -294,3 +385,50 @@ func (nc *NetworkCollector) OnDNSCacheHit() {
 func (nc *NetworkCollector) OnDNSLookupRequestDropped() {
 	nc.dnsLookupRequestDroppedCount.Inc()
 }
+
+// OnMisbehaviorReported tracks an offense reported for a remote peer.
+func (nc *NetworkCollector) OnMisbehaviorReported(offense string) {
+	nc.misbehaviorReported.WithLabelValues(offense).Inc()
+}
+
+// TrackedPeers tracks the number of remote peers which have a penalty score.
+func (nc *NetworkCollector) TrackedPeers(count int) {
+	nc.trackedPeers.Set(float64(count))
+}
+
+// MaxPeerPenaltyScore tracks the highest current penalty score of all remote peers.
+func (nc *NetworkCollector) MaxPeerPenaltyScore(score float64) {
+	nc.maxPeerPenaltyScore.Set(score)
+}
+
+// PenalizedPeers tracks the number of remote peers which are currently penalized with the given response.
+func (nc *NetworkCollector) PenalizedPeers(response string, count int) {
+	nc.penalizedPeers.WithLabelValues(response).Set(float64(count))
+}
+
+// InboundMessageRateLimited tracks a message on the given topic which was dropped because its sender
+// exceeded the given limit.
+func (nc *NetworkCollector) InboundMessageRateLimited(topic string, limit string) {
+	nc.inboundMessagesRateLimited.WithLabelValues(topic, limit).Inc()
+}
+
+// OnRateLimitDisconnect tracks a peer which was disconnected for repeatedly exceeding the rate limits.
+func (nc *NetworkCollector) OnRateLimitDisconnect() {
+	nc.rateLimitDisconnects.Inc()
+}
+
+// OutboundQueueDepth tracks the number of queued outbound messages of the given channel class.
+func (nc *NetworkCollector) OutboundQueueDepth(class string, depth int) {
+	nc.outboundQueueDepth.WithLabelValues(class).Set(float64(depth))
+}
+
+// OutboundQueueDuration tracks the time spent by an outbound message of the given channel class in the queue.
+func (nc *NetworkCollector) OutboundQueueDuration(class string, duration time.Duration) {
+	nc.outboundQueueDuration.WithLabelValues(class).Observe(duration.Seconds())
+}
+
+// OutboundMessageDropped tracks an outbound message of the given channel class which was dropped because
+// the queue of the class was full.
+func (nc *NetworkCollector) OutboundMessageDropped(class string) {
+	nc.outboundMessagesDropped.WithLabelValues(class).Inc()
+}
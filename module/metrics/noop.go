@@ -92,6 +92,9 @@ func (nc *NoopCollector) EmergencySeal()
 func (nc *NoopCollector) OnReceiptProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) OnApprovalProcessingDuration(duration time.Duration)                    {}
 func (nc *NoopCollector) CheckSealingDuration(duration time.Duration)                            {}
+func (nc *NoopCollector) OnApprovalRequested(verifierID flow.Identifier)                         {}
+func (nc *NoopCollector) OnApprovalResponse(verifierID flow.Identifier, duration time.Duration)  {}
+func (nc *NoopCollector) OnApprovalRequestMissed(verifierID flow.Identifier)                     {}
 func (nc *NoopCollector) OnExecutionResultReceivedAtAssignerEngine()                             {}
 func (nc *NoopCollector) OnVerifiableChunkReceivedAtVerifierEngine()                             {}
 func (nc *NoopCollector) OnResultApprovalDispatchedInNetworkByVerifier()                         {}
@@ -176,6 +179,15 @@ func (nc *NoopCollector) NotificationSent(height uint64)
 func (nc *NoopCollector) FetchRetried()                                                         {}
 func (nc *NoopCollector) RoutingTablePeerAdded()                                                {}
 func (nc *NoopCollector) RoutingTablePeerRemoved()                                              {}
+func (nc *NoopCollector) OnMisbehaviorReported(offense string)                                  {}
+func (nc *NoopCollector) TrackedPeers(count int)                                                {}
+func (nc *NoopCollector) MaxPeerPenaltyScore(score float64)                                     {}
+func (nc *NoopCollector) PenalizedPeers(response string, count int)                             {}
+func (nc *NoopCollector) InboundMessageRateLimited(topic string, limit string)                  {}
+func (nc *NoopCollector) OnRateLimitDisconnect()                                                {}
+func (nc *NoopCollector) OutboundQueueDepth(class string, depth int)                            {}
+func (nc *NoopCollector) OutboundQueueDuration(class string, duration time.Duration)            {}
+func (nc *NoopCollector) OutboundMessageDropped(class string)                                   {}
 func (nc *NoopCollector) PrunedBlockById(status *chainsync.Status)                              {}
 func (nc *NoopCollector) PrunedBlockByHeight(status *chainsync.Status)                          {}
 func (nc *NoopCollector) PrunedBlocks(totalByHeight, totalById, storedByHeight, storedById int) {}
@@ -39,6 +39,21 @@ func (_m *ConsensusMetrics) OnApprovalProcessingDuration(duration time.Duration)
 	_m.Called(duration)
 }
 
+// OnApprovalRequestMissed provides a mock function with given fields: verifierID
+func (_m *ConsensusMetrics) OnApprovalRequestMissed(verifierID flow.Identifier) {
+	_m.Called(verifierID)
+}
+
+// OnApprovalRequested provides a mock function with given fields: verifierID
+func (_m *ConsensusMetrics) OnApprovalRequested(verifierID flow.Identifier) {
+	_m.Called(verifierID)
+}
+
+// OnApprovalResponse provides a mock function with given fields: verifierID, duration
+func (_m *ConsensusMetrics) OnApprovalResponse(verifierID flow.Identifier, duration time.Duration) {
+	_m.Called(verifierID, duration)
+}
+
 // OnReceiptProcessingDuration provides a mock function with given fields: duration
 func (_m *ConsensusMetrics) OnReceiptProcessingDuration(duration time.Duration) {
 	_m.Called(duration)
@@ -33,6 +33,16 @@ func (_m *NetworkMetrics) InboundConnections(connectionCount uint) {
 	_m.Called(connectionCount)
 }
 
+// InboundMessageRateLimited provides a mock function with given fields: topic, limit
+func (_m *NetworkMetrics) InboundMessageRateLimited(topic string, limit string) {
+	_m.Called(topic, limit)
+}
+
+// MaxPeerPenaltyScore provides a mock function with given fields: score
+func (_m *NetworkMetrics) MaxPeerPenaltyScore(score float64) {
+	_m.Called(score)
+}
+
 // MessageAdded provides a mock function with given fields: priority
 func (_m *NetworkMetrics) MessageAdded(priority int) {
 	_m.Called(priority)
@@ -88,11 +98,41 @@ func (_m *NetworkMetrics) OnDNSLookupRequestDropped() {
 	_m.Called()
 }
 
+// OnMisbehaviorReported provides a mock function with given fields: offense
+func (_m *NetworkMetrics) OnMisbehaviorReported(offense string) {
+	_m.Called(offense)
+}
+
+// OnRateLimitDisconnect provides a mock function with given fields:
+func (_m *NetworkMetrics) OnRateLimitDisconnect() {
+	_m.Called()
+}
+
 // OutboundConnections provides a mock function with given fields: connectionCount
 func (_m *NetworkMetrics) OutboundConnections(connectionCount uint) {
 	_m.Called(connectionCount)
 }
 
+// OutboundMessageDropped provides a mock function with given fields: class
+func (_m *NetworkMetrics) OutboundMessageDropped(class string) {
+	_m.Called(class)
+}
+
+// OutboundQueueDepth provides a mock function with given fields: class, depth
+func (_m *NetworkMetrics) OutboundQueueDepth(class string, depth int) {
+	_m.Called(class, depth)
+}
+
+// OutboundQueueDuration provides a mock function with given fields: class, duration
+func (_m *NetworkMetrics) OutboundQueueDuration(class string, duration time.Duration) {
+	_m.Called(class, duration)
+}
+
+// PenalizedPeers provides a mock function with given fields: response, count
+func (_m *NetworkMetrics) PenalizedPeers(response string, count int) {
+	_m.Called(response, count)
+}
+
 // QueueDuration provides a mock function with given fields: duration, priority
 func (_m *NetworkMetrics) QueueDuration(duration time.Duration, priority int) {
 	_m.Called(duration, priority)
@@ -108,6 +148,11 @@ func (_m *NetworkMetrics) RoutingTablePeerRemoved() {
 	_m.Called()
 }
 
+// TrackedPeers provides a mock function with given fields: count
+func (_m *NetworkMetrics) TrackedPeers(count int) {
+	_m.Called(count)
+}
+
 type NewNetworkMetricsT interface {
 	mock.TestingT
 	Cleanup(func())
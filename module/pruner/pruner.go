@@ -0,0 +1,229 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// DefaultRetentionHeights is the default number of heights below the latest sealed height for
+	// which block payloads are kept.
+	DefaultRetentionHeights = 100_000
+
+	// MinRetentionHeights is the minimum retention. It keeps the payloads of all blocks in the
+	// sealing segment of the latest finalized block, and of the reference blocks of all transactions
+	// which did not expire yet.
+	MinRetentionHeights = flow.DefaultTransactionExpiry
+
+	// DefaultMaxHeightsPerSecond is the default maximum number of heights pruned per second.
+	DefaultMaxHeightsPerSecond = 100
+)
+
+// Config contains configuration options for the Pruner
+type Config struct {
+	// RetentionHeights is the number of heights below the latest sealed height for which block
+	// payloads, collections and transactions are kept.
+	RetentionHeights uint64
+
+	// MaxHeightsPerSecond is the maximum number of heights pruned per second. 0 means no limit.
+	MaxHeightsPerSecond float64
+}
+
+// DefaultConfig returns the default configuration of the pruner.
+func DefaultConfig() Config {
+	return Config{
+		RetentionHeights:    DefaultRetentionHeights,
+		MaxHeightsPerSecond: DefaultMaxHeightsPerSecond,
+	}
+}
+
+// HeightLimit returns the highest height which may be pruned according to a component which still
+// reads the payloads of finalized blocks, such as a block consumer which is behind.
+type HeightLimit func() (uint64, error)
+
+// Option is a functional option for the Pruner
+type Option func(*Pruner)
+
+// WithHeightLimit limits pruning to the heights at or below the height returned by the given limit.
+func WithHeightLimit(limit HeightLimit) Option {
+	return func(p *Pruner) {
+		p.limits = append(p.limits, limit)
+	}
+}
+
+// Pruner removes the payloads of finalized blocks below the latest sealed height minus the
+// configured retention, together with the collections and transactions of the payloads. Block
+// headers are kept, so that blocks can still be looked up by height.
+//
+// Heights are pruned one at a time in consecutive order, and the pruned height is persisted in the
+// same database transaction as the removal of the payload. Pruning therefore resumes at the next
+// height after a restart.
+type Pruner struct {
+	component.Component
+
+	log           zerolog.Logger
+	state         protocol.State
+	payloadPruner storage.PayloadPruner
+	rootHeight    uint64
+
+	retentionHeights uint64
+	limits           []HeightLimit
+	limiter          *rate.Limiter
+	notifier         engine.Notifier
+}
+
+// New creates a new pruner. The root height is the height of the root block of the protocol state,
+// pruning starts at the next height.
+func New(
+	log zerolog.Logger,
+	config Config,
+	state protocol.State,
+	payloadPruner storage.PayloadPruner,
+	rootHeight uint64,
+	opts ...Option,
+) *Pruner {
+	limit := rate.Inf
+	if config.MaxHeightsPerSecond > 0 {
+		limit = rate.Limit(config.MaxHeightsPerSecond)
+	}
+
+	p := &Pruner{
+		log:              log.With().Str("component", "protocol_state_pruner").Logger(),
+		state:            state,
+		payloadPruner:    payloadPruner,
+		rootHeight:       rootHeight,
+		retentionHeights: config.RetentionHeights,
+		limiter:          rate.NewLimiter(limit, 1),
+		notifier:         engine.NewNotifier(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// check for prunable heights on startup, instead of waiting for the next finalized block
+	p.notifier.Notify()
+
+	p.Component = component.NewComponentManagerBuilder().
+		AddWorker(p.processLoop).
+		Build()
+
+	return p
+}
+
+// OnBlockFinalized accepts block finalization notifications from the FinalizationDistributor, and
+// checks for newly prunable heights.
+func (p *Pruner) OnBlockFinalized(*model.Block) {
+	p.notifier.Notify()
+}
+
+// processLoop prunes all prunable heights each time the pruner is notified.
+func (p *Pruner) processLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	notifier := p.notifier.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notifier:
+		}
+
+		err := p.pruneHeights(ctx)
+		if err != nil {
+			ctx.Throw(err)
+			return
+		}
+	}
+}
+
+// pruneHeights prunes all heights after the pruned height, up to the prunable height.
+// No errors are expected during normal operation.
+func (p *Pruner) pruneHeights(ctx context.Context) error {
+	prunable, err := p.PrunableHeight()
+	if err != nil {
+		return fmt.Errorf("could not get prunable height: %w", err)
+	}
+
+	pruned, err := p.PrunedHeight()
+	if err != nil {
+		return fmt.Errorf("could not get pruned height: %w", err)
+	}
+
+	for height := pruned + 1; height <= prunable; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err = p.limiter.Wait(ctx)
+		if err != nil {
+			// the context was cancelled, the height is pruned after a restart
+			return nil
+		}
+
+		err = p.payloadPruner.PruneHeight(height)
+		if err != nil {
+			return fmt.Errorf("could not prune height %d: %w", height, err)
+		}
+
+		p.log.Debug().Uint64("height", height).Msg("pruned block payload")
+	}
+
+	return nil
+}
+
+// PrunedHeight returns the highest height up to which the block payloads were pruned, or the root
+// height if no payload was pruned yet.
+// No errors are expected during normal operation.
+func (p *Pruner) PrunedHeight() (uint64, error) {
+	height, err := p.payloadPruner.PrunedHeight()
+	if errors.Is(err, storage.ErrNotFound) {
+		return p.rootHeight, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// PrunableHeight returns the highest height which can be pruned, based on the latest sealed
+// height, the retention, and the height limits of the pruner.
+// No errors are expected during normal operation.
+func (p *Pruner) PrunableHeight() (uint64, error) {
+	sealed, err := p.state.Sealed().Head()
+	if err != nil {
+		return 0, fmt.Errorf("could not get last sealed block: %w", err)
+	}
+
+	if sealed.Height <= p.rootHeight+p.retentionHeights {
+		return p.rootHeight, nil
+	}
+	height := sealed.Height - p.retentionHeights
+
+	for _, limit := range p.limits {
+		limitHeight, err := limit()
+		if err != nil {
+			return 0, fmt.Errorf("could not get height limit: %w", err)
+		}
+		if limitHeight < height {
+			height = limitHeight
+		}
+	}
+
+	if height < p.rootHeight {
+		return p.rootHeight, nil
+	}
+	return height, nil
+}
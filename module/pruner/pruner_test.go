@@ -0,0 +1,153 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// newTestState returns a protocol state with the sealed height provided by the given function.
+func newTestState(t *testing.T, sealedHeight func() uint64) *protocolmock.State {
+	snapshot := protocolmock.NewSnapshot(t)
+	snapshot.On("Head").Return(
+		func() *flow.Header {
+			header := unittest.BlockHeaderFixture()
+			header.Height = sealedHeight()
+			return header
+		},
+		nil,
+	).Maybe()
+
+	state := protocolmock.NewState(t)
+	state.On("Sealed").Return(snapshot).Maybe()
+	return state
+}
+
+func TestPrunableHeight(t *testing.T) {
+	rootHeight := uint64(10)
+	sealed := uint64(100)
+	limit := uint64(200)
+
+	config := DefaultConfig()
+	config.RetentionHeights = 20
+	pruner := New(
+		unittest.Logger(),
+		config,
+		newTestState(t, func() uint64 { return sealed }),
+		storagemock.NewPayloadPruner(t),
+		rootHeight,
+		WithHeightLimit(func() (uint64, error) { return limit, nil }),
+	)
+
+	// pruning is bounded by the sealed height
+	height, err := pruner.PrunableHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(80), height)
+
+	// pruning is bounded by the height limit
+	limit = 50
+	height, err = pruner.PrunableHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), height)
+
+	// pruning never goes below the root height
+	limit = 5
+	height, err = pruner.PrunableHeight()
+	require.NoError(t, err)
+	assert.Equal(t, rootHeight, height)
+
+	// nothing is prunable within the retention
+	limit = 200
+	sealed = 25
+	height, err = pruner.PrunableHeight()
+	require.NoError(t, err)
+	assert.Equal(t, rootHeight, height)
+}
+
+func TestPrunedHeight(t *testing.T) {
+	payloadPruner := storagemock.NewPayloadPruner(t)
+	pruner := New(unittest.Logger(), DefaultConfig(), newTestState(t, func() uint64 { return 0 }), payloadPruner, 10)
+
+	// the root height is returned if nothing was pruned yet
+	payloadPruner.On("PrunedHeight").Return(uint64(0), storage.ErrNotFound).Once()
+	height, err := pruner.PrunedHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), height)
+
+	payloadPruner.On("PrunedHeight").Return(uint64(15), nil).Once()
+	height, err = pruner.PrunedHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), height)
+}
+
+func TestPruner(t *testing.T) {
+	rootHeight := uint64(10)
+	sealed := atomic.NewUint64(20)
+
+	// heights must be pruned in consecutive order
+	pruned := atomic.NewUint64(rootHeight)
+	payloadPruner := storagemock.NewPayloadPruner(t)
+	payloadPruner.On("PrunedHeight").Return(
+		func() uint64 { return pruned.Load() },
+		func() error { return nil },
+	)
+	payloadPruner.On("PruneHeight", mock.Anything).Return(
+		func(height uint64) error {
+			assert.Equal(t, pruned.Load()+1, height)
+			pruned.Store(height)
+			return nil
+		},
+	)
+
+	config := Config{
+		RetentionHeights:    5,
+		MaxHeightsPerSecond: 0,
+	}
+	pruner := New(
+		unittest.Logger(),
+		config,
+		newTestState(t, sealed.Load),
+		payloadPruner,
+		rootHeight,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+	go func() {
+		select {
+		case err := <-errChan:
+			t.Errorf("unexpected error: %v", err)
+		case <-ctx.Done():
+		}
+	}()
+
+	pruner.Start(signalerCtx)
+	unittest.RequireComponentsReadyBefore(t, time.Second, pruner)
+
+	// prunable heights are pruned on startup
+	require.Eventually(t, func() bool {
+		return pruned.Load() == 15
+	}, time.Second, 10*time.Millisecond)
+
+	// newly prunable heights are pruned when a block is finalized
+	sealed.Store(30)
+	pruner.OnBlockFinalized(nil)
+	require.Eventually(t, func() bool {
+		return pruned.Load() == 25
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	unittest.RequireComponentsDoneBefore(t, time.Second, pruner)
+}
@@ -52,6 +52,8 @@ var (
 	SPOCKTag = tag("SPoCK")
 	// DKGMessageTag is used for DKG messages
 	DKGMessageTag = tag("DKG_Message")
+	// SlashingEvidenceTag is used for slashing evidence signed by the reporting node
+	SlashingEvidenceTag = tag("Slashing_Evidence")
 )
 
 // NewBLSHasher returns a hasher to be used for BLS signing and verifying
@@ -0,0 +1,70 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+)
+
+// AccountIndexer builds the account index of transactions and events from the execution data of
+// sealed blocks.
+type AccountIndexer struct {
+	log   zerolog.Logger
+	index storage.AccountIndex
+}
+
+var _ BlockIndexer = (*AccountIndexer)(nil)
+
+func NewAccountIndexer(log zerolog.Logger, index storage.AccountIndex) *AccountIndexer {
+	return &AccountIndexer{
+		log:   log.With().Str("component", "account_indexer").Logger(),
+		index: index,
+	}
+}
+
+// LatestHeight returns the height of the latest block in the account index.
+// Expected errors:
+// - storage.ErrNotFound: if no block was indexed yet
+func (a *AccountIndexer) LatestHeight() (uint64, error) {
+	return a.index.LatestHeight()
+}
+
+// IndexBlock stores the account index entries for the transactions and events of the given block.
+// Transactions are indexed in the order of the block's chunks, which matches their transaction
+// index in the block.
+// No errors are expected during normal operation.
+func (a *AccountIndexer) IndexBlock(header *flow.Header, executionData *state_synchronization.ExecutionData) error {
+	blockID := header.ID()
+
+	var transactions []*flow.TransactionBody
+	for _, collection := range executionData.Collections {
+		transactions = append(transactions, collection.Transactions...)
+	}
+
+	var events []flow.Event
+	for _, chunkEvents := range executionData.Events {
+		events = append(events, chunkEvents...)
+	}
+
+	err := a.index.Store(
+		header.Height,
+		flow.AccountTransactions(blockID, header.Height, transactions),
+		flow.AccountEvents(blockID, header.Height, events),
+	)
+	if err != nil {
+		return fmt.Errorf("could not store account index: %w", err)
+	}
+
+	a.log.Debug().
+		Uint64("height", header.Height).
+		Hex("block_id", blockID[:]).
+		Int("transactions", len(transactions)).
+		Int("events", len(events)).
+		Msg("indexed block")
+
+	return nil
+}
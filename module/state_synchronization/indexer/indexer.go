@@ -0,0 +1,201 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+)
+
+// BlockIndexer indexes the execution data of a single block at a time, in consecutive height order.
+type BlockIndexer interface {
+	// LatestHeight returns the height of the latest indexed block.
+	// Expected errors:
+	// - storage.ErrNotFound: if no block was indexed yet
+	LatestHeight() (uint64, error)
+
+	// IndexBlock indexes the execution data of the given block, which is the block following the
+	// latest indexed block.
+	// No errors are expected during normal operation.
+	IndexBlock(header *flow.Header, executionData *state_synchronization.ExecutionData) error
+}
+
+// Indexer feeds the execution data of sealed blocks to a BlockIndexer.
+//
+// Blocks are indexed in consecutive height order, starting at the first height for which execution
+// data is available. The indexer is notified of new execution data through OnExecutionData, and
+// reads the execution data of each height it indexes from the execution data service. This allows
+// it to catch up with the execution data requester after a restart, independently of which
+// notifications were delivered before.
+type Indexer struct {
+	component.Component
+
+	log             zerolog.Logger
+	blockIndexer    BlockIndexer
+	headers         storage.Headers
+	seals           storage.Seals
+	results         storage.ExecutionResults
+	execDataService state_synchronization.ExecutionDataService
+
+	// startHeight is the first height to index if the index is empty
+	startHeight uint64
+
+	// highestExecDataHeight is the highest height for which execution data is available
+	highestExecDataHeight *atomic.Uint64
+
+	notifier engine.Notifier
+}
+
+// NewIndexer creates a new indexer. startHeight is the first height for which execution data is
+// available, and highestExecDataHeight is the highest height for which execution data was already
+// downloaded and notified by the execution data requester.
+func NewIndexer(
+	log zerolog.Logger,
+	blockIndexer BlockIndexer,
+	headers storage.Headers,
+	seals storage.Seals,
+	results storage.ExecutionResults,
+	execDataService state_synchronization.ExecutionDataService,
+	startHeight uint64,
+	highestExecDataHeight uint64,
+) *Indexer {
+	i := &Indexer{
+		log:                   log,
+		blockIndexer:          blockIndexer,
+		headers:               headers,
+		seals:                 seals,
+		results:               results,
+		execDataService:       execDataService,
+		startHeight:           startHeight,
+		highestExecDataHeight: atomic.NewUint64(highestExecDataHeight),
+		notifier:              engine.NewNotifier(),
+	}
+
+	// catch up with the execution data which is already available on startup
+	i.notifier.Notify()
+
+	i.Component = component.NewComponentManagerBuilder().
+		AddWorker(i.processLoop).
+		Build()
+
+	return i
+}
+
+// OnExecutionData is called when the execution data for a new sealed block is available. It
+// notifies the indexer to index all blocks up to the block's height.
+func (i *Indexer) OnExecutionData(executionData *state_synchronization.ExecutionData) {
+	header, err := i.headers.ByBlockID(executionData.BlockID)
+	if err != nil {
+		// if the execution data is available, the block must be locally finalized
+		i.log.Error().Err(err).Hex("block_id", executionData.BlockID[:]).Msg("could not get header for execution data")
+		return
+	}
+
+	// execution data is delivered in consecutive height order, but may be delivered more than once
+	// after a restart. Never move the highest height backwards.
+	for {
+		highest := i.highestExecDataHeight.Load()
+		if header.Height <= highest || i.highestExecDataHeight.CAS(highest, header.Height) {
+			break
+		}
+	}
+
+	i.notifier.Notify()
+}
+
+// processLoop indexes all available blocks each time the indexer is notified.
+func (i *Indexer) processLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	notifier := i.notifier.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notifier:
+		}
+
+		err := i.indexAvailableBlocks(ctx)
+		if err != nil {
+			ctx.Throw(err)
+			return
+		}
+	}
+}
+
+// indexAvailableBlocks indexes all blocks after the latest indexed block, up to the highest height
+// for which execution data is available.
+// No errors are expected during normal operation. If the execution data for a block can't be
+// read, indexing stops and is retried on the next notification.
+func (i *Indexer) indexAvailableBlocks(ctx context.Context) error {
+	height, err := i.nextHeight()
+	if err != nil {
+		return err
+	}
+
+	for ; height <= i.highestExecDataHeight.Load(); height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		header, err := i.headers.ByHeight(height)
+		if err != nil {
+			return fmt.Errorf("could not get header for height %d: %w", height, err)
+		}
+
+		executionData, err := i.getExecutionData(ctx, header.ID())
+		if err != nil {
+			i.log.Warn().Err(err).Uint64("height", height).Msg("could not get execution data, retrying on next notification")
+			return nil
+		}
+
+		err = i.blockIndexer.IndexBlock(header, executionData)
+		if err != nil {
+			return fmt.Errorf("could not index block at height %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+// nextHeight returns the height of the next block to index.
+// No errors are expected during normal operation.
+func (i *Indexer) nextHeight() (uint64, error) {
+	latest, err := i.blockIndexer.LatestHeight()
+	if errors.Is(err, storage.ErrNotFound) {
+		return i.startHeight, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not get latest indexed height: %w", err)
+	}
+	return latest + 1, nil
+}
+
+// getExecutionData returns the execution data of the sealed block with the given ID.
+func (i *Indexer) getExecutionData(ctx context.Context, blockID flow.Identifier) (*state_synchronization.ExecutionData, error) {
+	seal, err := i.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get finalized seal for block: %w", err)
+	}
+
+	result, err := i.results.ByID(seal.ResultID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution result (id: %s): %w", seal.ResultID, err)
+	}
+
+	executionData, err := i.execDataService.Get(ctx, result.ExecutionDataID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution data (id: %s): %w", result.ExecutionDataID, err)
+	}
+
+	return executionData, nil
+}
@@ -0,0 +1,104 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestIndexer(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		headers := storagemock.NewHeaders(t)
+		seals := storagemock.NewSeals(t)
+		results := storagemock.NewExecutionResults(t)
+		execDataService := syncmock.NewExecutionDataService(t)
+		index := bstorage.NewAccountIndex(db)
+
+		payer := unittest.RandomAddressFixture()
+
+		// blocks 10 to 12, each with a single transaction paid by the same account, and an event
+		// emitted by a contract of this account
+		execData := make(map[uint64]*state_synchronization.ExecutionData)
+		for height := uint64(10); height <= 12; height++ {
+			header := unittest.BlockHeaderFixture()
+			header.Height = height
+			blockID := header.ID()
+
+			tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+				tx.Payer = payer
+			})
+			event := unittest.EventFixture(flow.EventType("A."+payer.Hex()+".Contract.Event"), 0, 0, tx.ID(), 0)
+			execData[height] = &state_synchronization.ExecutionData{
+				BlockID:     blockID,
+				Collections: []*flow.Collection{{Transactions: []*flow.TransactionBody{&tx}}},
+				Events:      []flow.EventsList{{event}},
+			}
+
+			seal := unittest.Seal.Fixture()
+			result := unittest.ExecutionResultFixture()
+			seal.ResultID = result.ID()
+
+			headers.On("ByHeight", height).Return(header, nil).Maybe()
+			headers.On("ByBlockID", blockID).Return(header, nil).Maybe()
+			seals.On("FinalizedSealForBlock", blockID).Return(seal, nil).Maybe()
+			results.On("ByID", seal.ResultID).Return(result, nil).Maybe()
+			execDataService.On("Get", mock.Anything, result.ExecutionDataID).Return(execData[height], nil).Maybe()
+		}
+
+		indexer := NewIndexer(unittest.Logger(), NewAccountIndexer(unittest.Logger(), index), headers, seals, results, execDataService, 10, 11)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+		indexer.Start(signalerCtx)
+		unittest.RequireComponentsReadyBefore(t, time.Second, indexer)
+
+		// the available execution data is indexed on startup
+		require.Eventually(t, func() bool {
+			latest, err := index.LatestHeight()
+			return err == nil && latest == 11
+		}, time.Second, 10*time.Millisecond)
+
+		indexer.OnExecutionData(execData[12])
+		require.Eventually(t, func() bool {
+			latest, err := index.LatestHeight()
+			return err == nil && latest == 12
+		}, time.Second, 10*time.Millisecond)
+
+		first, err := index.FirstHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), first)
+
+		transactions, _, err := index.TransactionsByAddress(payer, 10, 12, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, transactions, 3)
+		for _, entry := range transactions {
+			assert.Equal(t, execData[entry.BlockHeight].Collections[0].Transactions[0].ID(), entry.TransactionID)
+			assert.True(t, entry.Roles.Has(flow.TransactionRolePayer))
+		}
+
+		events, _, err := index.EventsByAddress(payer, "Contract", 10, 12, nil, 10)
+		require.NoError(t, err)
+		assert.Len(t, events, 3)
+
+		cancel()
+		unittest.RequireComponentsDoneBefore(t, time.Second, indexer)
+		select {
+		case err := <-errChan:
+			require.NoError(t, err)
+		default:
+		}
+	})
+}
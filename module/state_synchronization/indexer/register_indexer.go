@@ -0,0 +1,121 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+)
+
+// RegisterIndexer builds the height-indexed register store from the trie updates contained in the
+// execution data of sealed blocks.
+type RegisterIndexer struct {
+	log   zerolog.Logger
+	index storage.RegisterIndex
+}
+
+var _ BlockIndexer = (*RegisterIndexer)(nil)
+
+func NewRegisterIndexer(log zerolog.Logger, index storage.RegisterIndex) *RegisterIndexer {
+	return &RegisterIndexer{
+		log:   log.With().Str("component", "register_indexer").Logger(),
+		index: index,
+	}
+}
+
+// Bootstrap loads the execution state at the given height from a checkpoint file, and stores it as
+// the first height of the register index. The checkpoint must contain the trie with the given state
+// commitment.
+// No errors are expected during normal operation.
+func (r *RegisterIndexer) Bootstrap(checkpointFile string, height uint64, commit flow.StateCommitment) error {
+	r.log.Info().
+		Str("checkpoint", checkpointFile).
+		Uint64("height", height).
+		Hex("state_commitment", commit[:]).
+		Msg("bootstrapping register index from checkpoint")
+
+	tries, err := wal.LoadCheckpoint(checkpointFile, &r.log)
+	if err != nil {
+		return fmt.Errorf("could not load checkpoint: %w", err)
+	}
+
+	var payloads []ledger.Payload
+	found := false
+	for _, trie := range tries {
+		if trie.RootHash().Equals(ledger.RootHash(commit)) {
+			payloads = trie.AllPayloads()
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("checkpoint does not contain the state commitment %x of height %d", commit, height)
+	}
+
+	entries := make(flow.RegisterEntries, 0, len(payloads))
+	for _, payload := range payloads {
+		id, err := state.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return fmt.Errorf("could not convert payload key: %w", err)
+		}
+		entries = append(entries, flow.RegisterEntry{Key: id, Value: flow.RegisterValue(payload.Value)})
+	}
+
+	err = r.index.Bootstrap(height, entries)
+	if err != nil {
+		return fmt.Errorf("could not bootstrap register index: %w", err)
+	}
+
+	r.log.Info().Int("registers", len(entries)).Msg("register index bootstrapped")
+
+	return nil
+}
+
+// LatestHeight returns the height of the latest block in the register index.
+// Expected errors:
+// - storage.ErrNotFound: if the index was not bootstrapped yet
+func (r *RegisterIndexer) LatestHeight() (uint64, error) {
+	return r.index.LatestHeight()
+}
+
+// IndexBlock stores the registers updated by the given block. If a register was updated by more than
+// one chunk of the block, the update of the last chunk is stored.
+// No errors are expected during normal operation.
+func (r *RegisterIndexer) IndexBlock(header *flow.Header, executionData *state_synchronization.ExecutionData) error {
+	blockID := header.ID()
+
+	updates := make(map[flow.RegisterID]flow.RegisterValue)
+	for _, trieUpdate := range executionData.TrieUpdates {
+		for _, payload := range trieUpdate.Payloads {
+			id, err := state.KeyToRegisterID(payload.Key)
+			if err != nil {
+				return fmt.Errorf("could not convert payload key: %w", err)
+			}
+			updates[id] = flow.RegisterValue(payload.Value)
+		}
+	}
+
+	entries := make(flow.RegisterEntries, 0, len(updates))
+	for id, value := range updates {
+		entries = append(entries, flow.RegisterEntry{Key: id, Value: value})
+	}
+
+	err := r.index.Store(header.Height, entries)
+	if err != nil {
+		return fmt.Errorf("could not store registers: %w", err)
+	}
+
+	r.log.Debug().
+		Uint64("height", header.Height).
+		Hex("block_id", blockID[:]).
+		Int("registers", len(entries)).
+		Msg("indexed block")
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package indexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestRegisterIndexerBootstrap(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewRegisterIndex(db)
+		indexer := NewRegisterIndexer(unittest.Logger(), index)
+
+		idA := flow.NewRegisterID(string(unittest.RandomAddressFixture().Bytes()), "a")
+		idB := flow.NewRegisterID("", "b")
+
+		// write a checkpoint of a trie containing two registers
+		keys := []ledger.Key{state.RegisterIDToKey(idA), state.RegisterIDToKey(idB)}
+		paths, err := pathfinder.KeysToPaths(keys, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+		payloads := []ledger.Payload{
+			*ledger.NewPayload(keys[0], []byte("a")),
+			*ledger.NewPayload(keys[1], []byte("b")),
+		}
+		mtrie, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+		require.NoError(t, err)
+
+		checkpointFile := filepath.Join(t.TempDir(), "root.checkpoint")
+		file, err := os.Create(checkpointFile)
+		require.NoError(t, err)
+		require.NoError(t, wal.StoreCheckpoint(file, mtrie))
+		require.NoError(t, file.Close())
+
+		// the checkpoint must contain the expected state commitment
+		err = indexer.Bootstrap(checkpointFile, 10, unittest.StateCommitmentFixture())
+		require.Error(t, err)
+
+		err = indexer.Bootstrap(checkpointFile, 10, flow.StateCommitment(mtrie.RootHash()))
+		require.NoError(t, err)
+
+		latest, err := indexer.LatestHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), latest)
+
+		value, err := index.Get(idA, 10)
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("a"), value)
+
+		value, err = index.Get(idB, 10)
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("b"), value)
+	})
+}
+
+func TestRegisterIndexerIndexBlock(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewRegisterIndex(db)
+		indexer := NewRegisterIndexer(unittest.Logger(), index)
+		require.NoError(t, index.Bootstrap(10, nil))
+
+		idA := flow.NewRegisterID("", "a")
+		idB := flow.NewRegisterID("", "b")
+		keyA := state.RegisterIDToKey(idA)
+		keyB := state.RegisterIDToKey(idB)
+
+		header := unittest.BlockHeaderFixture()
+		header.Height = 11
+
+		// register a is updated by both chunks of the block
+		executionData := &state_synchronization.ExecutionData{
+			BlockID: header.ID(),
+			TrieUpdates: []*ledger.TrieUpdate{
+				{Payloads: []*ledger.Payload{ledger.NewPayload(keyA, []byte("a1")), ledger.NewPayload(keyB, []byte("b1"))}},
+				{Payloads: []*ledger.Payload{ledger.NewPayload(keyA, []byte("a2"))}},
+			},
+		}
+		require.NoError(t, indexer.IndexBlock(header, executionData))
+
+		value, err := index.Get(idA, 11)
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("a2"), value)
+
+		value, err = index.Get(idB, 11)
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("b1"), value)
+	})
+}
@@ -0,0 +1,28 @@
+package compressor
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/network"
+)
+
+// Names of the compressors, e.g. for selecting a compressor with a flag.
+const (
+	GzipName = "gzip"
+	Lz4Name  = "lz4"
+	ZstdName = "zstd"
+)
+
+// NewCompressor returns a new compressor with the default options, selected by its name.
+func NewCompressor(name string) (network.Compressor, error) {
+	switch name {
+	case GzipName:
+		return GzipStreamCompressor{}, nil
+	case Lz4Name:
+		return NewLz4Compressor(), nil
+	case ZstdName:
+		return NewZstdCompressor()
+	default:
+		return nil, fmt.Errorf("unknown compressor %q, must be one of %s, %s or %s", name, GzipName, Lz4Name, ZstdName)
+	}
+}
@@ -0,0 +1,108 @@
+package compressor
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/onflow/flow-go/network"
+)
+
+var _ network.Compressor = (*ZstdCompressor)(nil)
+
+// ZstdCompressor is a zstandard compressor. It optionally compresses with a dictionary, which improves the
+// compression ratio of small payloads with a common structure, such as Flow messages.
+// Dictionaries can be trained with the reference implementation (zstd --train) on samples of encoded
+// messages, e.g. the payloads of a traffic recording. Data compressed with a dictionary can only be
+// decompressed by a compressor with the same dictionary.
+type ZstdCompressor struct {
+	level      zstd.EncoderLevel
+	dictionary []byte
+}
+
+// ZstdOption configures a ZstdCompressor.
+type ZstdOption func(*ZstdCompressor)
+
+// WithZstdLevel sets the compression level, which trades compression speed for ratio.
+func WithZstdLevel(level zstd.EncoderLevel) ZstdOption {
+	return func(z *ZstdCompressor) {
+		z.level = level
+	}
+}
+
+// WithZstdDictionary sets the dictionary used to compress and decompress data, which must be a zstd
+// dictionary in the format produced by zstd --train.
+func WithZstdDictionary(dictionary []byte) ZstdOption {
+	return func(z *ZstdCompressor) {
+		z.dictionary = dictionary
+	}
+}
+
+// NewZstdCompressor creates a new zstandard compressor. By default, it compresses with the default level and
+// without a dictionary.
+func NewZstdCompressor(opts ...ZstdOption) (*ZstdCompressor, error) {
+	z := &ZstdCompressor{
+		level: zstd.SpeedDefault,
+	}
+	for _, opt := range opts {
+		opt(z)
+	}
+
+	// validates the options once, rather than on every new reader and writer
+	enc, err := zstd.NewWriter(nil, z.encoderOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zstd encoder options: %w", err)
+	}
+	_ = enc.Close()
+
+	dec, err := zstd.NewReader(nil, z.decoderOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zstd decoder options: %w", err)
+	}
+	dec.Close()
+
+	return z, nil
+}
+
+// encoderOptions returns the options of the encoders. Each stream is encoded synchronously, as streams are
+// small and numerous.
+func (z *ZstdCompressor) encoderOptions() []zstd.EOption {
+	opts := []zstd.EOption{
+		zstd.WithEncoderLevel(z.level),
+		zstd.WithEncoderConcurrency(1),
+		zstd.WithLowerEncoderMem(true),
+	}
+	if z.dictionary != nil {
+		opts = append(opts, zstd.WithEncoderDict(z.dictionary))
+	}
+	return opts
+}
+
+// decoderOptions returns the options of the decoders. Each stream is decoded synchronously.
+func (z *ZstdCompressor) decoderOptions() []zstd.DOption {
+	opts := []zstd.DOption{
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderLowmem(true),
+	}
+	if z.dictionary != nil {
+		opts = append(opts, zstd.WithDecoderDicts(z.dictionary))
+	}
+	return opts
+}
+
+func (z *ZstdCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	dec, err := zstd.NewReader(r, z.decoderOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create zstd reader: %w", err)
+	}
+	return dec.IOReadCloser(), nil
+}
+
+func (z *ZstdCompressor) NewWriter(w io.Writer) (network.WriteCloseFlusher, error) {
+	enc, err := zstd.NewWriter(w, z.encoderOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create zstd writer: %w", err)
+	}
+	return enc, nil
+}
@@ -0,0 +1,105 @@
+package compressor_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/compressor"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestZstdRoundTrip evaluates that reading what has been written by the zstd compressor yields the same data,
+// and that the data is compressed when written.
+func TestZstdRoundTrip(t *testing.T) {
+	zstdComp, err := compressor.NewZstdCompressor()
+	require.NoError(t, err)
+
+	data := bytes.Repeat([]byte("hello world, hello world!"), 100)
+	compressed := compress(t, zstdComp, data)
+	require.Less(t, len(compressed), len(data))
+
+	require.Equal(t, data, decompress(t, zstdComp, compressed))
+}
+
+// TestZstdInvalidDictionary evaluates that a zstd compressor cannot be created with a dictionary that is not in the
+// zstd dictionary format.
+func TestZstdInvalidDictionary(t *testing.T) {
+	_, err := compressor.NewZstdCompressor(compressor.WithZstdDictionary([]byte("not a dictionary")))
+	require.Error(t, err)
+}
+
+// TestNewCompressor evaluates that compressors are selected by their name, and that all of them can decompress
+// what they have compressed.
+func TestNewCompressor(t *testing.T) {
+	data := bytes.Repeat([]byte("hello world, hello world!"), 100)
+
+	for _, name := range []string{compressor.GzipName, compressor.Lz4Name, compressor.ZstdName} {
+		comp, err := compressor.NewCompressor(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, data, decompress(t, comp, compress(t, comp, data)), name)
+	}
+
+	_, err := compressor.NewCompressor("snappy")
+	require.Error(t, err)
+}
+
+// BenchmarkCompressors compares the speed and the compression ratio of the compressors on encoded block proposals,
+// which are representative of the larger messages exchanged by nodes.
+func BenchmarkCompressors(b *testing.B) {
+	codec := cbor.NewCodec()
+	block := unittest.FullBlockFixture()
+	data, err := codec.Encode(&messages.BlockProposal{Header: block.Header, Payload: block.Payload})
+	require.NoError(b, err)
+
+	for _, name := range []string{compressor.GzipName, compressor.Lz4Name, compressor.ZstdName} {
+		comp, err := compressor.NewCompressor(name)
+		require.NoError(b, err)
+
+		b.Run(fmt.Sprintf("%s/compress", name), func(b *testing.B) {
+			var compressed []byte
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				compressed = compress(b, comp, data)
+			}
+			b.ReportMetric(float64(len(data))/float64(len(compressed)), "ratio")
+		})
+
+		b.Run(fmt.Sprintf("%s/decompress", name), func(b *testing.B) {
+			compressed := compress(b, comp, data)
+			b.SetBytes(int64(len(data)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				decompress(b, comp, compressed)
+			}
+		})
+	}
+}
+
+// compress compresses the data with the compressor.
+func compress(t testing.TB, comp network.Compressor, data []byte) []byte {
+	buf := new(bytes.Buffer)
+	w, err := comp.NewWriter(buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// decompress decompresses the data with the compressor.
+func decompress(t testing.TB, comp network.Compressor, data []byte) []byte {
+	r, err := comp.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	return decompressed
+}
@@ -35,18 +35,37 @@ type Conduit interface {
 	// recipients received the event.
 	// The event is published on the channels of this Conduit and will be received
 	// by the nodes specified as part of the targetIDs
+	// If the conduit queues outbound messages, Publish returns once the event is queued,
+	// before it is sent. The returned error then only indicates whether the event could be
+	// queued, and errors of the send are logged instead of returned.
 	Publish(event interface{}, targetIDs ...flow.Identifier) error
 
 	// Unicast sends the event in a reliable way to the given recipient.
 	// It uses 1-1 direct messaging over the underlying network to deliver the event.
 	// It returns an error if the unicast fails.
+	// If the conduit queues outbound messages, Unicast returns once the event is queued,
+	// before it is sent. The returned error then only indicates whether the event could be
+	// queued, and errors of the send are logged instead of returned.
 	Unicast(event interface{}, targetID flow.Identifier) error
 
+	// UnicastLatencyCritical sends the event like Unicast, for events whose delivery is
+	// latency-critical, e.g. consensus votes. The event is never queued, and is sent before
+	// this method returns, so the returned error is the error of the send.
+	UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error
+
 	// Multicast unreliably sends the specified event over the channel
 	// to the specified number of recipients selected from the specified subset.
 	// The recipients are selected randomly from the targetIDs.
+	// If the conduit queues outbound messages, Multicast returns once the event is queued,
+	// before it is sent. The returned error then only indicates whether the event could be
+	// queued, and errors of the send are logged instead of returned.
 	Multicast(event interface{}, num uint, targetIDs ...flow.Identifier) error
 
+	// ReportMisbehavior reports that the node with the given ID committed the given offense on the
+	// channel of this Conduit. Reported offenses penalize the reputation of the node, which limits
+	// the resources the node can consume on this node.
+	ReportMisbehavior(originID flow.Identifier, offense Offense)
+
 	// Close unsubscribes from the channels of this conduit. After calling close,
 	// the conduit can no longer be used to send a message.
 	Close() error
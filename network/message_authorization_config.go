@@ -1,13 +1,26 @@
 package network
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"reflect"
+	"sync"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/libp2p/message"
 	"github.com/onflow/flow-go/model/messages"
 )
 
+// DefaultMessageAuthTableVersion is the version of the message authorization table compiled into the node.
+const DefaultMessageAuthTableVersion = 1
+
+// init is called first time this package is imported.
+// It registers the authorization configs of all flow messages.
+func init() {
+	initializeMessageAuthConfigs()
+}
+
 // MsgAuthConfig contains authorization information for a specific flow message. The authorization
 // is represented as a map from network channel -> list of all roles allowed to send the message on
 // the channel.
@@ -16,6 +29,15 @@ type MsgAuthConfig struct {
 	config map[Channel]flow.RoleList
 }
 
+// NewMsgAuthConfig returns the authorization config of the message with the given name, which can be sent
+// on the given channels by the given roles.
+func NewMsgAuthConfig(name string, config map[Channel]flow.RoleList) MsgAuthConfig {
+	return MsgAuthConfig{
+		String: name,
+		config: config,
+	}
+}
+
 // IsAuthorized checks if the specified role is authorized to send the message on channel and
 // asserts that the message is authorized to be sent on channel.
 func (m MsgAuthConfig) IsAuthorized(role flow.Role, channel Channel) error {
@@ -220,81 +242,225 @@ var (
 	}
 )
 
-func GetMessageAuthConfig(v interface{}) (MsgAuthConfig, error) {
-	switch v.(type) {
+// MessageAuthTable is a versioned table of the authorization configs of all flow messages. It maps the
+// name of each message type to the channels the message can be sent on, and the roles allowed to send
+// the message on each channel. A message type without channels is not authorized to be sent at all.
+type MessageAuthTable struct {
+	Version  uint64                               `json:"version"`
+	Messages map[string]map[Channel]flow.RoleList `json:"messages"`
+}
+
+// Copy returns a deep copy of the table.
+func (t MessageAuthTable) Copy() MessageAuthTable {
+	messages := make(map[string]map[Channel]flow.RoleList, len(t.Messages))
+	for name, config := range t.Messages {
+		messages[name] = copyAuthConfig(config)
+	}
+
+	return MessageAuthTable{
+		Version:  t.Version,
+		Messages: messages,
+	}
+}
+
+func copyAuthConfig(config map[Channel]flow.RoleList) map[Channel]flow.RoleList {
+	c := make(map[Channel]flow.RoleList, len(config))
+	for channel, roles := range config {
+		c[channel] = append(flow.RoleList{}, roles...)
+	}
+	return c
+}
+
+// messageAuthRegistry keeps the names of the registered message types, their default authorization configs,
+// and the active authorization table.
+type messageAuthRegistry struct {
+	mu       sync.RWMutex
+	names    map[reflect.Type]string
+	defaults map[string]map[Channel]flow.RoleList
+	active   MessageAuthTable
+}
+
+var authRegistry = &messageAuthRegistry{
+	names:    make(map[reflect.Type]string),
+	defaults: make(map[string]map[Channel]flow.RoleList),
+	active: MessageAuthTable{
+		Version:  DefaultMessageAuthTableVersion,
+		Messages: make(map[string]map[Channel]flow.RoleList),
+	},
+}
+
+// initializeMessageAuthConfigs registers the authorization configs of all flow messages.
+// Note: Please register the authorization config of a new message type here, or with RegisterMessageAuthConfig
+// in the package defining the message type. The middleware does not need to be changed.
+func initializeMessageAuthConfigs() {
 	// consensus
-	case *messages.BlockProposal:
-		return blockProposal, nil
-	case *messages.BlockVote:
-		return blockVote, nil
+	mustRegisterMessageAuthConfig(&messages.BlockProposal{}, blockProposal)
+	mustRegisterMessageAuthConfig(&messages.BlockVote{}, blockVote)
 
 	// protocol state sync
-	case *messages.SyncRequest:
-		return syncRequest, nil
-	case *messages.SyncResponse:
-		return syncResponse, nil
-	case *messages.RangeRequest:
-		return rangeRequest, nil
-	case *messages.BatchRequest:
-		return batchRequest, nil
-	case *messages.BlockResponse:
-		return blockResponse, nil
+	mustRegisterMessageAuthConfig(&messages.SyncRequest{}, syncRequest)
+	mustRegisterMessageAuthConfig(&messages.SyncResponse{}, syncResponse)
+	mustRegisterMessageAuthConfig(&messages.RangeRequest{}, rangeRequest)
+	mustRegisterMessageAuthConfig(&messages.BatchRequest{}, batchRequest)
+	mustRegisterMessageAuthConfig(&messages.BlockResponse{}, blockResponse)
 
 	// cluster consensus
-	case *messages.ClusterBlockProposal:
-		return clusterBlockProposal, nil
-	case *messages.ClusterBlockVote:
-		return clusterBlockVote, nil
-	case *messages.ClusterBlockResponse:
-		return clusterBlockResponse, nil
+	mustRegisterMessageAuthConfig(&messages.ClusterBlockProposal{}, clusterBlockProposal)
+	mustRegisterMessageAuthConfig(&messages.ClusterBlockVote{}, clusterBlockVote)
+	mustRegisterMessageAuthConfig(&messages.ClusterBlockResponse{}, clusterBlockResponse)
 
 	// collections, guarantees & transactions
-	case *flow.CollectionGuarantee:
-		return collectionGuarantee, nil
-	case *flow.TransactionBody:
-		return transactionBody, nil
-	case *flow.Transaction:
-		return transaction, nil
+	mustRegisterMessageAuthConfig(&flow.CollectionGuarantee{}, collectionGuarantee)
+	mustRegisterMessageAuthConfig(&flow.TransactionBody{}, transactionBody)
+	mustRegisterMessageAuthConfig(&flow.Transaction{}, transaction)
 
 	// core messages for execution & verification
-	case *flow.ExecutionReceipt:
-		return executionReceipt, nil
-	case *flow.ResultApproval:
-		return resultApproval, nil
+	mustRegisterMessageAuthConfig(&flow.ExecutionReceipt{}, executionReceipt)
+	mustRegisterMessageAuthConfig(&flow.ResultApproval{}, resultApproval)
 
 	// execution state synchronization
-	case *messages.ExecutionStateSyncRequest:
-		return executionStateSyncRequest, nil
-	case *messages.ExecutionStateDelta:
-		return executionStateDelta, nil
+	mustRegisterMessageAuthConfig(&messages.ExecutionStateSyncRequest{}, executionStateSyncRequest)
+	mustRegisterMessageAuthConfig(&messages.ExecutionStateDelta{}, executionStateDelta)
 
 	// data exchange for execution of blocks
-	case *messages.ChunkDataRequest:
-		return chunkDataRequest, nil
-	case *messages.ChunkDataResponse:
-		return chunkDataResponse, nil
+	mustRegisterMessageAuthConfig(&messages.ChunkDataRequest{}, chunkDataRequest)
+	mustRegisterMessageAuthConfig(&messages.ChunkDataResponse{}, chunkDataResponse)
 
 	// result approvals
-	case *messages.ApprovalRequest:
-		return approvalRequest, nil
-	case *messages.ApprovalResponse:
-		return approvalResponse, nil
+	mustRegisterMessageAuthConfig(&messages.ApprovalRequest{}, approvalRequest)
+	mustRegisterMessageAuthConfig(&messages.ApprovalResponse{}, approvalResponse)
 
 	// generic entity exchange engines
-	case *messages.EntityRequest:
-		return entityRequest, nil
-	case *messages.EntityResponse:
-		return entityResponse, nil
+	mustRegisterMessageAuthConfig(&messages.EntityRequest{}, entityRequest)
+	mustRegisterMessageAuthConfig(&messages.EntityResponse{}, entityResponse)
 
 	// testing
-	case *message.TestMessage:
-		return echo, nil
+	mustRegisterMessageAuthConfig(&message.TestMessage{}, echo)
 
 	// dkg
-	case *messages.DKGMessage:
-		return dkgMessage, nil
+	mustRegisterMessageAuthConfig(&messages.DKGMessage{}, dkgMessage)
+}
+
+func mustRegisterMessageAuthConfig(v interface{}, config MsgAuthConfig) {
+	err := RegisterMessageAuthConfig(v, config)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RegisterMessageAuthConfig registers a new message type, with the type of v, and its default authorization
+// config. The default config is added to the active authorization table, unless the table already contains
+// a config for the message.
+// An error is returned if the message type or its name is already registered.
+func RegisterMessageAuthConfig(v interface{}, config MsgAuthConfig) error {
+	authRegistry.mu.Lock()
+	defer authRegistry.mu.Unlock()
+
+	typ := reflect.TypeOf(v)
+	if name, ok := authRegistry.names[typ]; ok {
+		return fmt.Errorf("message type (%T) is already registered as (%s)", v, name)
+	}
+	if _, ok := authRegistry.defaults[config.String]; ok {
+		return fmt.Errorf("message name (%s) is already registered", config.String)
+	}
+
+	authRegistry.names[typ] = config.String
+	authRegistry.defaults[config.String] = copyAuthConfig(config.config)
+	if _, ok := authRegistry.active.Messages[config.String]; !ok {
+		authRegistry.active.Messages[config.String] = copyAuthConfig(config.config)
+	}
+
+	return nil
+}
+
+// DefaultMessageAuthTable returns the authorization table made up of the default configs of all registered
+// message types.
+func DefaultMessageAuthTable() MessageAuthTable {
+	authRegistry.mu.RLock()
+	defer authRegistry.mu.RUnlock()
+
+	return MessageAuthTable{
+		Version:  DefaultMessageAuthTableVersion,
+		Messages: authRegistry.defaults,
+	}.Copy()
+}
+
+// ActiveMessageAuthTable returns the authorization table which is currently used to authorize messages.
+func ActiveMessageAuthTable() MessageAuthTable {
+	authRegistry.mu.RLock()
+	defer authRegistry.mu.RUnlock()
+
+	return authRegistry.active.Copy()
+}
+
+// SetMessageAuthTable replaces the active authorization table. The table must contain a config for each
+// registered message type, and only for registered message types, and the configs may only refer to existing
+// channels and valid roles.
+// An error is returned if the table is invalid, in which case the active table is not changed.
+func SetMessageAuthTable(table MessageAuthTable) error {
+	authRegistry.mu.Lock()
+	defer authRegistry.mu.Unlock()
+
+	for name, config := range table.Messages {
+		if _, ok := authRegistry.defaults[name]; !ok {
+			return fmt.Errorf("authorization table (version %d) contains unknown message (%s)", table.Version, name)
+		}
+
+		for channel, roles := range config {
+			if !ChannelExists(channel) {
+				return fmt.Errorf("authorization config of message (%s) contains unknown channel (%s)", name, channel)
+			}
+			for _, role := range roles {
+				if !role.Valid() {
+					return fmt.Errorf("authorization config of message (%s) contains invalid role (%d) on channel (%s)", name, role, channel)
+				}
+			}
+		}
+	}
+
+	for name := range authRegistry.defaults {
+		if _, ok := table.Messages[name]; !ok {
+			return fmt.Errorf("authorization table (version %d) is missing message (%s)", table.Version, name)
+		}
+	}
+
+	authRegistry.active = table.Copy()
+
+	return nil
+}
+
+// LoadMessageAuthTable reads an authorization table from the JSON file at the given path.
+func LoadMessageAuthTable(path string) (MessageAuthTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return MessageAuthTable{}, fmt.Errorf("could not open authorization table file: %w", err)
+	}
+	defer file.Close()
 
-	default:
+	var table MessageAuthTable
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&table)
+	if err != nil {
+		return MessageAuthTable{}, fmt.Errorf("could not decode authorization table: %w", err)
+	}
+
+	return table, nil
+}
+
+// GetMessageAuthConfig returns the authorization config of the message v from the active authorization table.
+// An error is returned if the type of v is not registered.
+func GetMessageAuthConfig(v interface{}) (MsgAuthConfig, error) {
+	authRegistry.mu.RLock()
+	defer authRegistry.mu.RUnlock()
+
+	name, ok := authRegistry.names[reflect.TypeOf(v)]
+	if !ok {
 		return MsgAuthConfig{}, fmt.Errorf("could not get authorization config for message with type (%T)", v)
 	}
+
+	return MsgAuthConfig{
+		String: name,
+		config: authRegistry.active.Messages[name],
+	}, nil
 }
@@ -0,0 +1,183 @@
+package network
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// nonNetworkMessages are the types in the messages package which are never sent over the network, and thus
+// need no authorization config.
+var nonNetworkMessages = map[string]struct{}{
+	"SubmitCollectionGuarantee": {}, // node-local message of the collection node
+	"CollectionRequest":         {}, // collections are requested with EntityRequest
+	"CollectionResponse":        {}, // collections are provided with EntityResponse
+	"PrivDKGMessageIn":          {}, // node-local message of the DKG messaging engine
+	"PrivDKGMessageOut":         {}, // node-local message of the DKG messaging engine
+	"BroadcastDKGMessage":       {}, // broadcast through the DKG smart contract
+}
+
+// TestMessageAuthConfig_MessagesCovered tests that an authorization config is registered for every message
+// type in the messages package which is sent over the network.
+func TestMessageAuthConfig_MessagesCovered(t *testing.T) {
+	pkgPath := reflect.TypeOf(messages.BlockProposal{}).PkgPath()
+
+	registered := make(map[string]struct{})
+	for typ := range authRegistry.names {
+		if typ.Kind() == reflect.Ptr && typ.Elem().PkgPath() == pkgPath {
+			registered[typ.Elem().Name()] = struct{}{}
+		}
+	}
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), filepath.Join("..", "model", "messages"), nil, 0)
+	require.NoError(t, err)
+	require.Contains(t, pkgs, "messages")
+
+	count := 0
+	for _, file := range pkgs["messages"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, ok := typeSpec.Type.(*ast.StructType); !ok || !typeSpec.Name.IsExported() {
+					continue
+				}
+				count++
+
+				name := typeSpec.Name.Name
+				if _, ok := nonNetworkMessages[name]; ok {
+					assert.NotContains(t, registered, name, "node-local message %s has an authorization config", name)
+					continue
+				}
+				assert.Contains(t, registered, name, "message %s has no authorization config", name)
+			}
+		}
+	}
+	require.Greater(t, count, len(nonNetworkMessages))
+}
+
+// TestMessageAuthConfig_DefaultTable tests that the default table is active, and that it can be set and
+// serialized.
+func TestMessageAuthConfig_DefaultTable(t *testing.T) {
+	table := DefaultMessageAuthTable()
+	assert.Equal(t, uint64(DefaultMessageAuthTableVersion), table.Version)
+	assert.Equal(t, table, ActiveMessageAuthTable())
+	assert.Len(t, table.Messages, len(authRegistry.names))
+	assert.NoError(t, SetMessageAuthTable(table))
+
+	encoded, err := json.Marshal(table)
+	require.NoError(t, err)
+
+	var decoded MessageAuthTable
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, table, decoded)
+}
+
+// TestMessageAuthConfig_LoadTable tests that a table loaded from a file replaces the active authorization
+// configs.
+func TestMessageAuthConfig_LoadTable(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, SetMessageAuthTable(DefaultMessageAuthTable()))
+	})
+
+	// authorize verification nodes to send result approvals on the request channel as well
+	table := DefaultMessageAuthTable()
+	table.Version = 2
+	table.Messages["ResultApproval"][RequestApprovalsByChunk] = flow.RoleList{flow.RoleVerification}
+
+	encoded, err := json.Marshal(table)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "message-auth.json")
+	require.NoError(t, os.WriteFile(path, encoded, 0600))
+
+	conf, err := GetMessageAuthConfig(&flow.ResultApproval{})
+	require.NoError(t, err)
+	assert.Error(t, conf.IsAuthorized(flow.RoleVerification, RequestApprovalsByChunk))
+
+	loaded, err := LoadMessageAuthTable(path)
+	require.NoError(t, err)
+	require.NoError(t, SetMessageAuthTable(loaded))
+	assert.Equal(t, uint64(2), ActiveMessageAuthTable().Version)
+
+	conf, err = GetMessageAuthConfig(&flow.ResultApproval{})
+	require.NoError(t, err)
+	assert.Equal(t, "ResultApproval", conf.String)
+	assert.NoError(t, conf.IsAuthorized(flow.RoleVerification, RequestApprovalsByChunk))
+	assert.NoError(t, conf.IsAuthorized(flow.RoleVerification, PushApprovals))
+	assert.Error(t, conf.IsAuthorized(flow.RoleExecution, RequestApprovalsByChunk))
+}
+
+// TestMessageAuthConfig_InvalidTable tests that invalid tables are rejected and do not replace the active table.
+func TestMessageAuthConfig_InvalidTable(t *testing.T) {
+	t.Run("unknown message", func(t *testing.T) {
+		table := DefaultMessageAuthTable()
+		table.Messages["UnknownMessage"] = map[Channel]flow.RoleList{PushBlocks: {flow.RoleConsensus}}
+		assert.Error(t, SetMessageAuthTable(table))
+	})
+
+	t.Run("missing message", func(t *testing.T) {
+		table := DefaultMessageAuthTable()
+		delete(table.Messages, "BlockProposal")
+		assert.Error(t, SetMessageAuthTable(table))
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		table := DefaultMessageAuthTable()
+		table.Messages["BlockProposal"][Channel("unknown-channel")] = flow.RoleList{flow.RoleConsensus}
+		assert.Error(t, SetMessageAuthTable(table))
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		table := DefaultMessageAuthTable()
+		table.Messages["BlockProposal"][PushBlocks] = flow.RoleList{flow.Role(42)}
+		assert.Error(t, SetMessageAuthTable(table))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "message-auth.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "roles": {}}`), 0600))
+		_, err := LoadMessageAuthTable(path)
+		assert.Error(t, err)
+	})
+
+	assert.Equal(t, DefaultMessageAuthTable(), ActiveMessageAuthTable())
+}
+
+// TestMessageAuthConfig_Register tests that new message types can be registered, and that message types
+// and names cannot be registered twice.
+func TestMessageAuthConfig_Register(t *testing.T) {
+	type testMessage struct{}
+
+	err := RegisterMessageAuthConfig(&testMessage{}, NewMsgAuthConfig("TestRegisterMessage", map[Channel]flow.RoleList{
+		TestNetworkChannel: {flow.RoleAccess},
+	}))
+	require.NoError(t, err)
+
+	conf, err := GetMessageAuthConfig(&testMessage{})
+	require.NoError(t, err)
+	assert.NoError(t, conf.IsAuthorized(flow.RoleAccess, TestNetworkChannel))
+	assert.Error(t, conf.IsAuthorized(flow.RoleConsensus, TestNetworkChannel))
+	assert.Contains(t, DefaultMessageAuthTable().Messages, "TestRegisterMessage")
+
+	// unregistered types have no config
+	_, err = GetMessageAuthConfig(testMessage{})
+	assert.Error(t, err)
+
+	// the type and the name can only be registered once
+	assert.Error(t, RegisterMessageAuthConfig(&testMessage{}, NewMsgAuthConfig("OtherMessage", nil)))
+	assert.Error(t, RegisterMessageAuthConfig(&struct{ Other bool }{}, NewMsgAuthConfig("BlockProposal", nil)))
+}
@@ -0,0 +1,60 @@
+package network
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Offense is a type of misbehavior of a remote node, which is reported to the network layer
+// to penalize the reputation of the node.
+type Offense int
+
+const (
+	// OffenseMalformedMessage is committed by sending a message which cannot be decoded.
+	OffenseMalformedMessage Offense = iota + 1
+
+	// OffenseOversizedMessage is committed by sending a message which exceeds the permissible size.
+	OffenseOversizedMessage
+
+	// OffenseUnauthorizedSender is committed by sending a message which the role of the sender is
+	// not authorized to send on the channel.
+	OffenseUnauthorizedSender
+
+	// OffenseInvalidMessage is committed by sending a well-formed message with invalid content,
+	// such as an invalid signature or a reference to a non-existent block.
+	OffenseInvalidMessage
+
+	// OffenseUnsolicitedMessage is committed by sending a message which was not requested,
+	// such as a response to a request which was never sent.
+	OffenseUnsolicitedMessage
+
+	// OffenseExcessiveRequests is committed by sending requests at a rate exceeding what an
+	// honest node would need.
+	OffenseExcessiveRequests
+)
+
+func (o Offense) String() string {
+	switch o {
+	case OffenseMalformedMessage:
+		return "malformed_message"
+	case OffenseOversizedMessage:
+		return "oversized_message"
+	case OffenseUnauthorizedSender:
+		return "unauthorized_sender"
+	case OffenseInvalidMessage:
+		return "invalid_message"
+	case OffenseUnsolicitedMessage:
+		return "unsolicited_message"
+	case OffenseExcessiveRequests:
+		return "excessive_requests"
+	default:
+		return "unknown"
+	}
+}
+
+// MisbehaviorReporter is the interface of the component that receives the reported misbehavior of
+// remote nodes.
+type MisbehaviorReporter interface {
+	// ReportMisbehavior reports that the node with the given ID committed the given offense on the
+	// given channel.
+	ReportMisbehavior(originID flow.Identifier, channel Channel, offense Offense)
+}
@@ -56,6 +56,11 @@ func (_m *Adapter) PublishOnChannel(_a0 network.Channel, _a1 interface{}, _a2 ..
 	return r0
 }
 
+// ReportMisbehaviorOnChannel provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Adapter) ReportMisbehaviorOnChannel(_a0 network.Channel, _a1 flow.Identifier, _a2 network.Offense) {
+	_m.Called(_a0, _a1, _a2)
+}
+
 // UnRegisterChannel provides a mock function with given fields: channel
 func (_m *Adapter) UnRegisterChannel(channel network.Channel) error {
 	ret := _m.Called(channel)
@@ -5,6 +5,8 @@ package mocknetwork
 import (
 	flow "github.com/onflow/flow-go/model/flow"
 	mock "github.com/stretchr/testify/mock"
+
+	network "github.com/onflow/flow-go/network"
 )
 
 // Conduit is an autogenerated mock type for the Conduit type
@@ -68,6 +70,11 @@ func (_m *Conduit) Publish(event interface{}, targetIDs ...flow.Identifier) erro
 	return r0
 }
 
+// ReportMisbehavior provides a mock function with given fields: originID, offense
+func (_m *Conduit) ReportMisbehavior(originID flow.Identifier, offense network.Offense) {
+	_m.Called(originID, offense)
+}
+
 // Unicast provides a mock function with given fields: event, targetID
 func (_m *Conduit) Unicast(event interface{}, targetID flow.Identifier) error {
 	ret := _m.Called(event, targetID)
@@ -82,6 +89,20 @@ func (_m *Conduit) Unicast(event interface{}, targetID flow.Identifier) error {
 	return r0
 }
 
+// UnicastLatencyCritical provides a mock function with given fields: event, targetID
+func (_m *Conduit) UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error {
+	ret := _m.Called(event, targetID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}, flow.Identifier) error); ok {
+		r0 = rf(event, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewConduitT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -43,6 +43,9 @@ type Adapter interface {
 	// selected from the specified targetIDs.
 	MulticastOnChannel(Channel, interface{}, uint, ...flow.Identifier) error
 
+	// ReportMisbehaviorOnChannel reports that the node with the given ID committed the given offense on the channel.
+	ReportMisbehaviorOnChannel(Channel, flow.Identifier, Offense)
+
 	// UnRegisterChannel unregisters the engine for the specified channel. The engine will no longer be able to send or
 	// receive messages from that channel.
 	UnRegisterChannel(channel Channel) error
@@ -4,35 +4,98 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/rs/zerolog"
+
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/component"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/queue"
 )
 
 // DefaultConduitFactory is a wrapper around the network Adapter.
 // It directly passes the incoming messages to the corresponding methods of the
-// network Adapter.
+// network Adapter, unless an outbound queue is configured. In that case, messages are
+// queued by the class of their channel, and sent by a pool of workers in priority order.
 type DefaultConduitFactory struct {
 	*component.ComponentManager
 	adapter network.Adapter
+	log     zerolog.Logger
+	queue   *queue.OutboundQueue
+	workers int
+}
+
+// ConduitFactoryOption configures the DefaultConduitFactory.
+type ConduitFactoryOption func(*DefaultConduitFactory)
+
+// WithOutboundQueue makes the conduits queue outbound messages in the given queue, which are sent by the given
+// number of workers. Errors of queued sends are logged, as they are no longer returned to the engines.
+func WithOutboundQueue(log zerolog.Logger, q *queue.OutboundQueue, workers int) ConduitFactoryOption {
+	return func(d *DefaultConduitFactory) {
+		d.log = log.With().Str("component", "conduit_factory").Logger()
+		d.queue = q
+		d.workers = workers
+	}
 }
 
-func NewDefaultConduitFactory() *DefaultConduitFactory {
-	d := &DefaultConduitFactory{}
+func NewDefaultConduitFactory(opts ...ConduitFactoryOption) *DefaultConduitFactory {
+	d := &DefaultConduitFactory{
+		log: zerolog.Nop(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
 	// worker added so conduit factory doesn't immediately shut down when it's started
-	cm := component.NewComponentManagerBuilder().
+	builder := component.NewComponentManagerBuilder().
 		AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
 			ready()
 
 			<-ctx.Done()
-		}).Build()
 
-	d.ComponentManager = cm
+			if d.queue != nil {
+				// unblocks the outbound workers
+				d.queue.Close()
+			}
+		})
+
+	if d.queue != nil {
+		for i := 0; i < d.workers; i++ {
+			builder.AddWorker(d.outboundWorker)
+		}
+	}
+
+	d.ComponentManager = builder.Build()
 
 	return d
 }
 
+// outboundMessage is a send of a conduit which is queued in the outbound queue.
+type outboundMessage struct {
+	channel network.Channel
+	send    func() error
+}
+
+// outboundWorker sends the queued outbound messages in priority order until the outbound queue is closed.
+func (d *DefaultConduitFactory) outboundWorker(_ irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		message, ok := d.queue.Remove()
+		if !ok {
+			return
+		}
+
+		msg := message.(*outboundMessage)
+		if err := msg.send(); err != nil {
+			d.log.Warn().
+				Err(err).
+				Str("channel", msg.channel.String()).
+				Msg("could not send queued outbound message")
+		}
+	}
+}
+
 // RegisterAdapter sets the Adapter component of the factory.
 // The Adapter is a wrapper around the Network layer that only exposes the set of methods
 // that are needed by a conduit.
@@ -59,7 +122,10 @@ func (d *DefaultConduitFactory) NewConduit(ctx context.Context, channel network.
 		ctx:     child,
 		cancel:  cancel,
 		channel: channel,
+		class:   queue.ChannelClassOf(channel),
 		adapter: d.adapter,
+		queue:   d.queue,
+		log:     d.log,
 	}, nil
 }
 
@@ -70,37 +136,88 @@ type Conduit struct {
 	ctx     context.Context
 	cancel  context.CancelFunc
 	channel network.Channel
+	class   queue.ChannelClass
 	adapter network.Adapter
+	queue   *queue.OutboundQueue // nil if outbound messages are not queued
+	log     zerolog.Logger
 }
 
 // Publish sends an event to the network layer for unreliable delivery
 // to subscribers of the given event on the network layer. It uses a
 // publish-subscribe layer and can thus not guarantee that the specified
 // recipients received the event.
+// If outbound messages are queued, it returns once the event is queued.
 func (c *Conduit) Publish(event interface{}, targetIDs ...flow.Identifier) error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit for channel %s closed", c.channel)
 	}
-	return c.adapter.PublishOnChannel(c.channel, event, targetIDs...)
+	targetIDs = append([]flow.Identifier(nil), targetIDs...)
+	return c.send(false, func() error {
+		return c.adapter.PublishOnChannel(c.channel, event, targetIDs...)
+	})
 }
 
 // Unicast sends an event in a reliable way to the given recipient.
 // It uses 1-1 direct messaging over the underlying network to deliver the event.
-// It returns an error if the unicast fails.
+// It returns an error if the unicast fails, or if the event could not be queued when
+// outbound messages are queued.
 func (c *Conduit) Unicast(event interface{}, targetID flow.Identifier) error {
+	return c.unicast(false, event, targetID)
+}
+
+// UnicastLatencyCritical sends an event like Unicast, but bypasses the outbound queue, so the
+// event is sent before it returns.
+func (c *Conduit) UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error {
+	return c.unicast(true, event, targetID)
+}
+
+func (c *Conduit) unicast(critical bool, event interface{}, targetID flow.Identifier) error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit for channel %s closed", c.channel)
 	}
-	return c.adapter.UnicastOnChannel(c.channel, event, targetID)
+	return c.send(critical, func() error {
+		return c.adapter.UnicastOnChannel(c.channel, event, targetID)
+	})
 }
 
 // Multicast unreliably sends the specified event to the specified number of recipients selected from the specified subset.
 // The recipients are selected randomly from targetIDs
+// If outbound messages are queued, it returns once the event is queued.
 func (c *Conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Identifier) error {
 	if c.ctx.Err() != nil {
 		return fmt.Errorf("conduit for channel %s closed", c.channel)
 	}
-	return c.adapter.MulticastOnChannel(c.channel, event, num, targetIDs...)
+	targetIDs = append([]flow.Identifier(nil), targetIDs...)
+	return c.send(false, func() error {
+		return c.adapter.MulticastOnChannel(c.channel, event, num, targetIDs...)
+	})
+}
+
+// send sends the message immediately if it is latency-critical or outbound messages are not queued.
+// Otherwise, it queues the message, and the returned error only indicates whether the message was queued.
+func (c *Conduit) send(critical bool, send func() error) error {
+	if critical || c.queue == nil {
+		return send()
+	}
+
+	evicted, err := c.queue.Insert(c.class, &outboundMessage{channel: c.channel, send: send})
+	if err != nil {
+		return fmt.Errorf("could not queue outbound message on channel %s: %w", c.channel, err)
+	}
+	if evicted != nil {
+		c.log.Warn().
+			Str("channel", evicted.(*outboundMessage).channel.String()).
+			Str("class", c.class.String()).
+			Msg("outbound queue is full, dropped oldest queued message")
+	}
+
+	return nil
+}
+
+// ReportMisbehavior reports that the node with the given ID committed the given offense on the channel
+// of this conduit.
+func (c *Conduit) ReportMisbehavior(originID flow.Identifier, offense network.Offense) {
+	c.adapter.ReportMisbehaviorOnChannel(c.channel, originID, offense)
 }
 
 func (c *Conduit) Close() error {
@@ -1,13 +1,22 @@
 package conduit_test
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/p2p/conduit"
+	"github.com/onflow/flow-go/network/queue"
+	"github.com/onflow/flow-go/utils/unittest"
 )
 
 func TestWrappedByMultiError(t *testing.T) {
@@ -32,3 +41,78 @@ func TestNestedWrappedMultiError(t *testing.T) {
 	outerError = multierror.Append(outerError, fmt.Errorf("inner: %w", err))
 	require.True(t, network.AllPeerUnreachableError(outerError.WrappedErrors()...))
 }
+
+// fakeAdapter records the unicasts on channels, and blocks the unicast of the blocking event until it is released.
+type fakeAdapter struct {
+	network.Adapter
+	mu       sync.Mutex
+	sent     []interface{}
+	blocking interface{}
+	release  chan struct{}
+}
+
+func (a *fakeAdapter) UnicastOnChannel(_ network.Channel, event interface{}, _ flow.Identifier) error {
+	a.mu.Lock()
+	a.sent = append(a.sent, event)
+	a.mu.Unlock()
+
+	if event == a.blocking {
+		<-a.release
+	}
+	return nil
+}
+
+func (a *fakeAdapter) sentEvents() []interface{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]interface{}(nil), a.sent...)
+}
+
+// TestOutboundQueue_Prioritization tests that queued outbound messages are sent by the class of their channel, and
+// that latency-critical messages bypass the outbound queue.
+func TestOutboundQueue_Prioritization(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q, err := queue.NewOutboundQueue(queue.DefaultOutboundQueueConfig(), metrics.NewNoopCollector())
+	require.NoError(t, err)
+
+	adapter := &fakeAdapter{blocking: "data-1", release: make(chan struct{})}
+	factory := conduit.NewDefaultConduitFactory(conduit.WithOutboundQueue(unittest.Logger(), q, 1))
+	require.NoError(t, factory.RegisterAdapter(adapter))
+	signalerCtx, _ := irrecoverable.WithSignaler(ctx)
+	factory.Start(signalerCtx)
+	unittest.RequireCloseBefore(t, factory.Ready(), time.Second, "could not start conduit factory")
+
+	consensusCon, err := factory.NewConduit(ctx, network.ConsensusCommittee)
+	require.NoError(t, err)
+	syncCon, err := factory.NewConduit(ctx, network.SyncCommittee)
+	require.NoError(t, err)
+	dataCon, err := factory.NewConduit(ctx, network.ProvideChunks)
+	require.NoError(t, err)
+
+	targetID := unittest.IdentifierFixture()
+
+	// the only worker blocks on sending the first data message, so that the following messages are queued
+	require.NoError(t, dataCon.Unicast("data-1", targetID))
+	require.Eventually(t, func() bool {
+		return len(adapter.sentEvents()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, dataCon.Unicast("data-2", targetID))
+	require.NoError(t, syncCon.Unicast("sync-1", targetID))
+	require.NoError(t, consensusCon.Unicast("consensus-1", targetID))
+
+	// latency-critical messages are sent immediately, regardless of their class
+	require.NoError(t, dataCon.UnicastLatencyCritical("critical-1", targetID))
+	require.Equal(t, []interface{}{"data-1", "critical-1"}, adapter.sentEvents())
+
+	close(adapter.release)
+	require.Eventually(t, func() bool {
+		return len(adapter.sentEvents()) == 5
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []interface{}{"data-1", "critical-1", "consensus-1", "sync-1", "data-2"}, adapter.sentEvents())
+
+	cancel()
+	unittest.RequireCloseBefore(t, factory.Done(), time.Second, "could not stop conduit factory")
+}
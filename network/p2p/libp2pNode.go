@@ -16,6 +16,7 @@ import (
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	kbucket "github.com/libp2p/go-libp2p-kbucket"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/rs/zerolog"
 
 	flownet "github.com/onflow/flow-go/network"
@@ -43,6 +44,20 @@ type Node struct {
 	subs           map[flownet.Topic]*pubsub.Subscription // map of a topic string to an actual subscription
 	routing        routing.Routing
 	pCache         *protocolPeerCache
+	rateLimiter    TopicRateLimiterFunc // optional rate limiter applied by topic validators
+}
+
+// TopicRateLimiterFunc decides whether a message of the given size, originated by the given peer on the given
+// topic, is processed. The origin is the peer which signed the message, not the peer which forwarded it.
+type TopicRateLimiterFunc func(origin peer.ID, topic flownet.Topic, size int) bool
+
+// SetInboundRateLimiter sets the rate limiter which the topic validators of subsequently subscribed topics
+// apply to inbound messages before their payload is decoded.
+func (n *Node) SetInboundRateLimiter(rateLimiter TopicRateLimiterFunc) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.rateLimiter = rateLimiter
 }
 
 // Stop terminates the libp2p node.
@@ -161,6 +176,20 @@ func (n *Node) GetIPPort() (string, string, error) {
 	return IPPortFromMultiAddress(n.host.Network().ListenAddresses()...)
 }
 
+// GetQUICPort returns the port the node listens on for QUIC connections, and false if QUIC is disabled.
+func (n *Node) GetQUICPort() (string, bool) {
+	for _, addr := range n.host.Network().ListenAddresses() {
+		if !isQUICAddr(addr) {
+			continue
+		}
+		port, err := addr.ValueForProtocol(multiaddr.P_UDP)
+		if err == nil {
+			return port, true
+		}
+	}
+	return "", false
+}
+
 func (n *Node) RoutingTable() *kbucket.RoutingTable {
 	return n.routing.(*dht.IpfsDHT).RoutingTable()
 }
@@ -181,7 +210,15 @@ func (n *Node) Subscribe(topic flownet.Topic, codec flownet.Codec, peerFilter pe
 	tp, found := n.topics[topic]
 	var err error
 	if !found {
-		topicValidator := validator.TopicValidator(n.logger, codec, peerFilter, validators...)
+		var rateLimiter validator.RateLimiter
+		if n.rateLimiter != nil {
+			limiter := n.rateLimiter
+			rateLimiter = func(origin peer.ID, size int) bool {
+				return limiter(origin, topic, size)
+			}
+		}
+
+		topicValidator := validator.TopicValidator(n.logger, codec, peerFilter, rateLimiter, validators...)
 		if err := n.pubSub.RegisterTopicValidator(
 			topic.String(), topicValidator, pubsub.WithValidatorInline(true),
 		); err != nil {
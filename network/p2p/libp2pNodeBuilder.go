@@ -15,6 +15,7 @@ import (
 	discovery "github.com/libp2p/go-libp2p-discovery"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	libp2pquic "github.com/libp2p/go-libp2p-quic-transport"
 	"github.com/libp2p/go-libp2p/config"
 	"github.com/libp2p/go-tcp-transport"
 	"github.com/multiformats/go-multiaddr"
@@ -36,6 +37,8 @@ type LibP2PFactoryFunc func(context.Context) (*Node, error)
 
 // DefaultLibP2PNodeFactory returns a LibP2PFactoryFunc which generates the libp2p host initialized with the
 // default options for the host, the pubsub and the ping service.
+// If a reputation manager is given, the connection gater refuses connections with penalized peers.
+// The transport config determines whether the node listens on QUIC in addition to TCP.
 func DefaultLibP2PNodeFactory(
 	log zerolog.Logger,
 	address string,
@@ -45,14 +48,20 @@ func DefaultLibP2PNodeFactory(
 	metrics module.NetworkMetrics,
 	resolver madns.BasicResolver,
 	role string,
+	reputation *ReputationManager,
+	transportConfig TransportConfig,
 ) LibP2PFactoryFunc {
 
 	return func(ctx context.Context) (*Node, error) {
 		connManager := NewConnManager(log, metrics)
 		connGater := NewConnGater(log, func(pid peer.ID) bool {
 			_, found := idProvider.ByPeerID(pid)
+			if !found {
+				return false
+			}
 
-			return found
+			// refuse connections with peers which are disconnected or blocked due to their reputation
+			return reputation == nil || reputation.AllowConnection(pid)
 		})
 
 		builder := NewNodeBuilder(log, address, flowKey, sporkId).
@@ -69,7 +78,8 @@ func DefaultLibP2PNodeFactory(
 					AsServer(),
 				)
 			}).
-			SetPubSub(pubsub.NewGossipSub)
+			SetPubSub(pubsub.NewGossipSub).
+			SetTransportConfig(transportConfig)
 
 		if role != "ghost" {
 			r, _ := flow.ParseRole(role)
@@ -94,6 +104,7 @@ type NodeBuilder interface {
 	SetConnectionGater(connmgr.ConnectionGater) NodeBuilder
 	SetRoutingSystem(func(context.Context, host.Host) (routing.Routing, error)) NodeBuilder
 	SetPubSub(func(context.Context, host.Host, ...pubsub.Option) (*pubsub.PubSub, error)) NodeBuilder
+	SetTransportConfig(TransportConfig) NodeBuilder
 	Build(context.Context) (*Node, error)
 }
 
@@ -108,6 +119,7 @@ type LibP2PNodeBuilder struct {
 	connGater          connmgr.ConnectionGater
 	routingFactory     func(context.Context, host.Host) (routing.Routing, error)
 	pubsubFactory      func(context.Context, host.Host, ...pubsub.Option) (*pubsub.PubSub, error)
+	transportConfig    TransportConfig
 }
 
 func NewNodeBuilder(
@@ -117,10 +129,11 @@ func NewNodeBuilder(
 	sporkID flow.Identifier,
 ) *LibP2PNodeBuilder {
 	return &LibP2PNodeBuilder{
-		logger:     logger,
-		sporkID:    sporkID,
-		addr:       addr,
-		networkKey: networkKey,
+		logger:          logger,
+		sporkID:         sporkID,
+		addr:            addr,
+		networkKey:      networkKey,
+		transportConfig: DefaultTransportConfig(),
 	}
 }
 
@@ -154,6 +167,12 @@ func (builder *LibP2PNodeBuilder) SetPubSub(f func(context.Context, host.Host, .
 	return builder
 }
 
+// SetTransportConfig sets the transports of the node, by default the node only listens on TCP.
+func (builder *LibP2PNodeBuilder) SetTransportConfig(config TransportConfig) NodeBuilder {
+	builder.transportConfig = config
+	return builder
+}
+
 func (builder *LibP2PNodeBuilder) Build(ctx context.Context) (*Node, error) {
 	if builder.routingFactory == nil {
 		return nil, errors.New("routing factory is not set")
@@ -179,8 +198,23 @@ func (builder *LibP2PNodeBuilder) Build(ctx context.Context) (*Node, error) {
 		opts = append(opts, libp2p.ConnectionManager(builder.connManager))
 	}
 
-	if builder.connGater != nil {
-		opts = append(opts, libp2p.ConnectionGater(builder.connGater))
+	connGater := builder.connGater
+	if builder.transportConfig.QUICEnabled {
+		quicOpts, err := quicLibP2POptions(builder.transportConfig.QUICAddress)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, quicOpts...)
+
+		// the QUIC addresses of peers are learned through libp2p identify and stored in the peerstore
+		// together with their TCP address, hence dialing over TCP only requires refusing to dial them
+		if builder.transportConfig.DialPreference == PreferTCP {
+			connGater = newTCPDialGater(connGater)
+		}
+	}
+
+	if connGater != nil {
+		opts = append(opts, libp2p.ConnectionGater(connGater))
 	}
 
 	host, err := DefaultLibP2PHost(ctx, builder.addr, builder.networkKey, opts...)
@@ -292,6 +326,25 @@ func defaultLibP2POptions(address string, key fcrypto.PrivateKey) ([]config.Opti
 	return options, nil
 }
 
+// quicLibP2POptions creates the libp2p host options which enable the QUIC transport, in addition to the TCP
+// transport of the default options, listening on the given address.
+func quicLibP2POptions(address string) ([]config.Option, error) {
+	ip, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not split QUIC address %s:%w", address, err)
+	}
+
+	quicMultiAddr, err := multiaddr.NewMultiaddr(QUICMultiAddressStr(ip, port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate QUIC address to Libp2p multiaddress: %w", err)
+	}
+
+	return []config.Option{
+		libp2p.ListenAddrs(quicMultiAddr),
+		libp2p.Transport(libp2pquic.NewTransport),
+	}, nil
+}
+
 func DefaultPubsubOptions(maxPubSubMsgSize int) []pubsub.Option {
 	return []pubsub.Option{
 		// skip message signing
@@ -132,6 +132,52 @@ func TestCreateStream_WithPreferredGzipUnicast(t *testing.T) {
 		unicast.FlowGzipProtocolId(sporkId))
 }
 
+// TestCreateStream_WithPreferredZstdUnicast evaluates correctness of creating zstd-compressed tcp unicast streams between two libp2p nodes,
+// when zstd is the most preferred unicast of both nodes.
+func TestCreateStream_WithPreferredZstdUnicast(t *testing.T) {
+	sporkId := unittest.IdentifierFixture()
+	testCreateStream(t,
+		sporkId,
+		[]unicast.ProtocolName{unicast.GzipCompressionUnicast, unicast.ZstdCompressionUnicast},
+		unicast.FlowZstdProtocolId(sporkId))
+}
+
+// TestCreateStream_NegotiatesBestCommonUnicast checks that a node which prefers zstd over gzip creates gzip-compressed
+// streams to a node which only supports gzip, i.e., the nodes negotiate their most preferred common unicast protocol.
+func TestCreateStream_NegotiatesBestCommonUnicast(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sporkId := unittest.IdentifierFixture()
+	thisNode, _ := nodeFixture(t,
+		ctx,
+		sporkId,
+		"test_create_stream_negotiation",
+		withPreferredUnicasts([]unicast.ProtocolName{unicast.GzipCompressionUnicast, unicast.ZstdCompressionUnicast}))
+	otherNode, otherId := nodeFixture(t,
+		ctx,
+		sporkId,
+		"test_create_stream_negotiation",
+		withPreferredUnicasts([]unicast.ProtocolName{unicast.GzipCompressionUnicast}))
+
+	defer stopNodes(t, []*p2p.Node{thisNode, otherNode})
+
+	pInfo, err := p2p.PeerAddressInfo(otherId)
+	require.NoError(t, err)
+	thisNode.Host().Peerstore().AddAddrs(pInfo.ID, pInfo.Addrs, peerstore.AddressTTL)
+
+	s, err := thisNode.CreateStream(ctx, pInfo.ID)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	// the stream must be gzip-compressed, as it is the most preferred unicast supported by both nodes.
+	require.Equal(t, unicast.FlowGzipProtocolId(sporkId), s.Protocol())
+	require.Equal(t, 1, p2p.CountStream(thisNode.Host(), otherNode.Host().ID(), unicast.FlowGzipProtocolId(sporkId), network.DirOutbound))
+	require.Equal(t, 0, p2p.CountStream(thisNode.Host(), otherNode.Host().ID(), unicast.FlowZstdProtocolId(sporkId), network.DirOutbound))
+
+	require.NoError(t, s.Close())
+}
+
 // testCreateStreams checks if a new streams of "preferred" type is created each time when CreateStream is called and an existing stream is not
 // reused. The "preferred" stream type is the one with the largest index in `unicasts` list.
 // To check that the streams are of "preferred" type, it evaluates the protocol id of established stream against the input `protocolID`.
@@ -362,6 +408,12 @@ func TestUnicastOverStream_WithGzipStreamCompression(t *testing.T) {
 	testUnicastOverStream(t, withPreferredUnicasts([]unicast.ProtocolName{unicast.GzipCompressionUnicast}))
 }
 
+// TestUnicastOverStream_WithZstdStreamCompression checks two nodes can send and receive unicast messages on zstd compressed streams
+// when both nodes have zstd stream compression enabled.
+func TestUnicastOverStream_WithZstdStreamCompression(t *testing.T) {
+	testUnicastOverStream(t, withPreferredUnicasts([]unicast.ProtocolName{unicast.ZstdCompressionUnicast}))
+}
+
 // testUnicastOverStream sends a message from node 1 to node 2 and then from node 2 to node 1 over a unicast stream.
 func testUnicastOverStream(t *testing.T, opts ...nodeFixtureParameterOption) {
 	ctx, cancel := context.WithCancel(context.Background())
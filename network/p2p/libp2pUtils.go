@@ -131,7 +131,7 @@ func MultiAddressStr(ip, port string) string {
 	return fmt.Sprintf("/dns4/%s/tcp/%s", ip, port)
 }
 
-// IPPortFromMultiAddress returns the IP/hostname and the port for the given multi-addresses
+// IPPortFromMultiAddress returns the IP/hostname and the TCP port for the given multi-addresses
 // associated with a libp2p host
 func IPPortFromMultiAddress(addrs ...multiaddr.Multiaddr) (string, string, error) {
 
@@ -152,6 +152,9 @@ func IPPortFromMultiAddress(addrs ...multiaddr.Multiaddr) (string, string, error
 		// if either IP address or hostname is found, look for the port number
 		port, err = a.ValueForProtocol(multiaddr.P_TCP)
 		if err != nil {
+			if _, udpErr := a.ValueForProtocol(multiaddr.P_UDP); udpErr == nil {
+				continue // this is a QUIC multiaddress
+			}
 			// an IPv4 or DNS4 based multiaddress should have a port number
 			return "", "", err
 		}
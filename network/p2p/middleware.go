@@ -16,6 +16,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
 	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
@@ -91,6 +92,8 @@ type Middleware struct {
 	validators                 []network.MessageValidator
 	peerManagerFactory         PeerManagerFactoryFunc
 	peerManager                *PeerManager
+	reputation                 *ReputationManager
+	rateLimiter                *RateLimiter
 	unicastMessageTimeout      time.Duration
 	idTranslator               IDTranslator
 	previousProtocolStatePeers []peer.AddrInfo
@@ -118,6 +121,22 @@ func WithPeerManager(peerManagerFunc PeerManagerFactoryFunc) MiddlewareOption {
 	}
 }
 
+// WithRateLimiter sets the rate limiter which is applied to inbound unicast messages before they are decoded,
+// and by the topic validators of subscribed channels.
+func WithRateLimiter(rateLimiter *RateLimiter) MiddlewareOption {
+	return func(mw *Middleware) {
+		mw.rateLimiter = rateLimiter
+	}
+}
+
+// WithReputationManager sets the reputation manager which is used to report the misbehavior of remote peers
+// detected by the middleware, and to throttle and disconnect penalized peers.
+func WithReputationManager(reputation *ReputationManager) MiddlewareOption {
+	return func(mw *Middleware) {
+		mw.reputation = reputation
+	}
+}
+
 // NewMiddleware creates a new middleware instance
 // libP2PNodeFactory is the factory used to create a LibP2PNode
 // flowID is this node's Flow ID
@@ -219,7 +238,19 @@ func (m *Middleware) topologyPeers() (peer.IDSlice, error) {
 		return nil, err
 	}
 
-	return m.peerIDs(identities.NodeIDs()), nil
+	peerIDs := m.peerIDs(identities.NodeIDs())
+	if m.reputation == nil {
+		return peerIDs, nil
+	}
+
+	// do not connect to peers which are disconnected or blocked due to their reputation
+	allowed := make(peer.IDSlice, 0, len(peerIDs))
+	for _, pid := range peerIDs {
+		if m.reputation.AllowConnection(pid) {
+			allowed = append(allowed, pid)
+		}
+	}
+	return allowed, nil
 }
 
 func (m *Middleware) peerIDs(flowIDs flow.IdentifierList) peer.IDSlice {
@@ -298,6 +329,10 @@ func (m *Middleware) start(ctx context.Context) error {
 	}
 
 	m.libP2PNode = libP2PNode
+	if m.rateLimiter != nil {
+		m.libP2PNode.SetInboundRateLimiter(m.allowPubSubMessage)
+	}
+
 	err = m.libP2PNode.WithDefaultUnicastProtocol(m.handleIncomingStream, m.preferredUnicasts)
 	if err != nil {
 		return fmt.Errorf("could not register preferred unicast protocols on libp2p node: %w", err)
@@ -320,6 +355,10 @@ func (m *Middleware) start(ctx context.Context) error {
 		}
 	}
 
+	if m.reputation != nil {
+		m.reputation.SetDisconnectHandler(m.disconnectPeer)
+	}
+
 	return nil
 }
 
@@ -517,9 +556,30 @@ func (m *Middleware) handleIncomingStream(s libp2pnetwork.Stream) {
 				Str("channel", msg.ChannelID).
 				Int("maxSize", maxSize).
 				Msg("received message exceeded permissible message maxSize")
+			m.reportMisbehavior(s.Conn().RemotePeer(), network.Channel(msg.ChannelID), network.OffenseOversizedMessage)
 			return
 		}
 
+		// the channel of unicast messages is chosen by the sender, drop messages on unknown channels
+		// before they are rate limited, so that the rate limits are only tracked for known channels
+		channel := network.Channel(msg.ChannelID)
+		if !network.ChannelExists(channel) {
+			m.log.Warn().
+				Hex("sender", msg.OriginID).
+				Hex("event_id", msg.EventID).
+				Str("event_type", msg.Type).
+				Str("channel", msg.ChannelID).
+				Msg("dropping message on unknown channel")
+			m.reportMisbehavior(s.Conn().RemotePeer(), channel, network.OffenseInvalidMessage)
+			continue
+		}
+
+		// apply the rate limits before the message is decoded, if the peer is disconnected for exceeding
+		// them, reading the next message fails
+		if !m.allowMessage(s.Conn().RemotePeer(), channel, msg.Size()) {
+			continue
+		}
+
 		m.wg.Add(1)
 		go func(msg *message.Message) {
 			defer m.wg.Done()
@@ -530,7 +590,9 @@ func (m *Middleware) handleIncomingStream(s libp2pnetwork.Stream) {
 					Hex("sender", msg.OriginID).
 					Hex("event_id", msg.EventID).
 					Str("event_type", msg.Type).
-					Str("channel", msg.ChannelID)
+					Str("channel", msg.ChannelID).
+					Msg("dropping message")
+				m.reportMisbehavior(s.Conn().RemotePeer(), network.Channel(msg.ChannelID), network.OffenseMalformedMessage)
 			} else {
 				// log metrics with the channel name as OneToOne
 				m.metrics.NetworkMessageReceived(msg.Size(), metrics.ChannelOneToOne, msg.Type)
@@ -557,7 +619,7 @@ func (m *Middleware) Subscribe(channel network.Channel) error {
 	} else {
 		// for channels used by the staked nodes, add the topic validator to filter out messages from non-staked nodes
 		validators = append(validators,
-			psValidator.AuthorizedSenderValidator(m.log, channel, m.ov.Identity),
+			m.reportRejected(channel, psValidator.AuthorizedSenderValidator(m.log, channel, m.ov.Identity), network.OffenseUnauthorizedSender),
 		)
 
 		// NOTE: For non-public channels the libP2P node topic validator will reject
@@ -606,6 +668,15 @@ func (m *Middleware) Unsubscribe(channel network.Channel) error {
 // The assumption is that the message has been authenticated at the network level (libp2p) to originate from the peer with ID `peerID`
 // this requirement is fulfilled by e.g. the output of readConnection and readSubscription
 func (m *Middleware) processAuthenticatedMessage(msg *message.Message, decodedMsgPayload interface{}, peerID peer.ID) {
+	if m.reputation != nil && !m.reputation.AllowMessage(peerID) {
+		m.log.Debug().
+			Str("peer_id", peerID.String()).
+			Str("channel", msg.ChannelID).
+			Str("type", msg.Type).
+			Msg("dropping message from penalized peer")
+		return
+	}
+
 	flowID, err := m.idTranslator.GetFlowID(peerID)
 	if err != nil {
 		m.log.Warn().Err(err).Msgf("received message from unknown peer %v, and was dropped", peerID.String())
@@ -730,3 +801,68 @@ func (m *Middleware) peerMgr() (*PeerManager, bool) {
 	}
 	return nil, false
 }
+
+// reportMisbehavior reports the given offense of the given peer to the reputation manager, if one is configured.
+func (m *Middleware) reportMisbehavior(pid peer.ID, channel network.Channel, offense network.Offense) {
+	if m.reputation != nil {
+		m.reputation.ReportPeer(pid, channel, offense)
+	}
+}
+
+// reportRejected wraps the given topic validator to report the given offense for the senders of rejected messages.
+func (m *Middleware) reportRejected(channel network.Channel, v psValidator.MessageValidator, offense network.Offense) psValidator.MessageValidator {
+	return func(ctx context.Context, from peer.ID, msg interface{}) pubsub.ValidationResult {
+		result := v(ctx, from, msg)
+		if result == pubsub.ValidationReject {
+			m.reportMisbehavior(from, channel, offense)
+		}
+		return result
+	}
+}
+
+// disconnectPeer closes the connections with a peer which was penalized by the reputation manager, and
+// requests a peer update, so the peer manager no longer connects to the peer.
+func (m *Middleware) disconnectPeer(pid peer.ID) {
+	err := m.libP2PNode.RemovePeer(pid)
+	if err != nil {
+		m.log.Err(err).Str("peer_id", pid.String()).Msg("failed to disconnect penalized peer")
+	}
+
+	m.peerManagerUpdate()
+}
+
+// allowPubSubMessage applies the rate limits to a message originated by the given peer on the given topic.
+func (m *Middleware) allowPubSubMessage(origin peer.ID, topic network.Topic, size int) bool {
+	channel, ok := network.ChannelFromTopic(topic)
+	if !ok {
+		channel = network.Channel(topic)
+	}
+	return m.allowMessage(origin, channel, size)
+}
+
+// allowMessage applies the rate limits to a message of the given size, sent by the given peer on the given
+// channel. Peers which repeatedly exceed the rate limits are disconnected.
+func (m *Middleware) allowMessage(pid peer.ID, channel network.Channel, size int) bool {
+	if m.rateLimiter == nil {
+		return true
+	}
+
+	var role flow.Role
+	if identity, ok := m.ov.Identity(pid); ok {
+		role = identity.Role
+	}
+
+	switch m.rateLimiter.Allow(pid, role, channel, size) {
+	case RateLimitAccept:
+		return true
+	case RateLimitDisconnect:
+		m.reportMisbehavior(pid, channel, network.OffenseExcessiveRequests)
+		err := m.libP2PNode.RemovePeer(pid)
+		if err != nil {
+			m.log.Err(err).Str("peer_id", pid.String()).Msg("failed to disconnect peer exceeding the rate limits")
+		}
+		return false
+	default:
+		return false
+	}
+}
@@ -52,6 +52,13 @@ func WithConduitFactory(f network.ConduitFactory) NetworkOptFunction {
 	}
 }
 
+// WithMisbehaviorReporter sets the reporter which receives the misbehavior reported by engines.
+func WithMisbehaviorReporter(r network.MisbehaviorReporter) NetworkOptFunction {
+	return func(n *Network) {
+		n.misbehaviorReporter = r
+	}
+}
+
 // Network represents the overlay network of our peer-to-peer network, including
 // the protocols for handshakes, authentication, gossiping and heartbeats.
 type Network struct {
@@ -68,6 +75,7 @@ type Network struct {
 	queue                       network.MessageQueue
 	subscriptionManager         network.SubscriptionManager // used to keep track of subscribed channels
 	conduitFactory              network.ConduitFactory
+	misbehaviorReporter         network.MisbehaviorReporter // nil if the reputation of nodes is not tracked
 	registerEngineRequests      chan *registerEngineRequest
 	registerBlobServiceRequests chan *registerBlobServiceRequest
 }
@@ -485,6 +493,20 @@ func (n *Network) MulticastOnChannel(channel network.Channel, message interface{
 	return nil
 }
 
+// ReportMisbehaviorOnChannel reports that the node with the given ID committed the given offense on the channel.
+func (n *Network) ReportMisbehaviorOnChannel(channel network.Channel, originID flow.Identifier, offense network.Offense) {
+	if n.misbehaviorReporter == nil {
+		n.logger.Debug().
+			Str("channel", channel.String()).
+			Hex("origin_id", originID[:]).
+			Str("offense", offense.String()).
+			Msg("ignoring reported misbehavior, no misbehavior reporter is configured")
+		return
+	}
+
+	n.misbehaviorReporter.ReportMisbehavior(originID, channel, offense)
+}
+
 // removeSelfFilter removes the flow.Identifier of this node if present, from the list of nodes
 func (n *Network) removeSelfFilter() flow.IdentifierFilter {
 	return func(id flow.Identifier) bool {
@@ -0,0 +1,422 @@
+package p2p
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+)
+
+const (
+	// DefaultInboundMessageRateLimit is the default number of messages per second accepted from a peer on a channel.
+	// The inbound messages of the staked network are not limited by default.
+	DefaultInboundMessageRateLimit = 0
+
+	// DefaultInboundByteRateLimit is the default number of bytes per second accepted from a peer on a channel.
+	// The inbound bytes of the staked network are not limited by default.
+	DefaultInboundByteRateLimit = 0
+
+	// DefaultRateLimitMaxViolations is the default number of dropped messages within the violation window
+	// after which a peer is disconnected. Peers of the staked network are never disconnected by default.
+	DefaultRateLimitMaxViolations = 0
+
+	// DefaultPublicInboundMessageRateLimit is the default number of messages per second accepted from a peer
+	// on a channel of the public network.
+	DefaultPublicInboundMessageRateLimit = 100
+
+	// DefaultPublicInboundByteRateLimit is the default number of bytes per second accepted from a peer on a
+	// channel of the public network.
+	DefaultPublicInboundByteRateLimit = mb
+
+	// DefaultPublicRateLimitMaxViolations is the default number of dropped messages within the violation
+	// window after which a peer of the public network is disconnected.
+	DefaultPublicRateLimitMaxViolations = 100
+
+	// DefaultRateLimitViolationWindow is the default window in which the violations of a peer are counted.
+	DefaultRateLimitViolationWindow = time.Minute
+
+	// rateLimiterCacheSize is the maximum number of tracked token buckets and violation counters. The least
+	// recently used entries are evicted, which resets the limits of peers which have been quiet the longest.
+	rateLimiterCacheSize = 10_000
+)
+
+const (
+	rateLimitMessages = "messages"
+	rateLimitBytes    = "bytes"
+)
+
+// unknownChannel is the channel which messages on channels that do not exist are limited on.
+const unknownChannel = network.Channel("unknown")
+
+// RateLimit is a token-bucket limit of the inbound messages of a peer on a channel, covering both the
+// number of messages and the number of bytes.
+type RateLimit struct {
+	// MessagesPerSecond is the number of messages per second accepted from the peer on the channel.
+	// The number of messages is not limited if it is zero.
+	MessagesPerSecond float64
+
+	// MessageBurst is the number of messages which can be accepted at once. If it is zero, the burst
+	// is one second of messages at the configured rate.
+	MessageBurst int
+
+	// BytesPerSecond is the number of bytes per second accepted from the peer on the channel.
+	// The number of bytes is not limited if it is zero.
+	BytesPerSecond float64
+
+	// ByteBurst is the number of bytes which can be accepted at once. A message larger than the
+	// burst is accepted if the bucket is full, and empties the bucket. If it is zero, the burst is
+	// one second of bytes at the configured rate.
+	ByteBurst int
+}
+
+// NewRateLimit returns a rate limit with the given rates, and a burst of one second of traffic.
+func NewRateLimit(messagesPerSecond float64, bytesPerSecond float64) RateLimit {
+	return RateLimit{
+		MessagesPerSecond: messagesPerSecond,
+		MessageBurst:      burst(messagesPerSecond),
+		BytesPerSecond:    bytesPerSecond,
+		ByteBurst:         burst(bytesPerSecond),
+	}
+}
+
+// withBursts returns the rate limit with the bursts which are not configured derived from the rates.
+func (l RateLimit) withBursts() RateLimit {
+	if l.MessageBurst <= 0 {
+		l.MessageBurst = burst(l.MessagesPerSecond)
+	}
+	if l.ByteBurst <= 0 {
+		l.ByteBurst = burst(l.BytesPerSecond)
+	}
+	return l
+}
+
+// limited returns true if the rate limit limits the number of messages or bytes.
+func (l RateLimit) limited() bool {
+	return l.MessagesPerSecond > 0 || l.BytesPerSecond > 0
+}
+
+func burst(perSecond float64) int {
+	if perSecond < 1 {
+		return 1
+	}
+	return int(perSecond)
+}
+
+// newLimiter returns a token bucket with the given rate and burst, which accepts everything if the rate is zero.
+func newLimiter(perSecond float64, burst int) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, burst)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), burst)
+}
+
+// RateLimitKey selects the messages a rate limit applies to. The zero role matches senders of any role,
+// and the empty channel matches any channel.
+type RateLimitKey struct {
+	Role    flow.Role
+	Channel network.Channel
+}
+
+// RateLimiterConfig contains the configuration of the RateLimiter.
+type RateLimiterConfig struct {
+	// Default is the limit of messages for which no more specific limit is configured.
+	Default RateLimit
+
+	// Limits overrides the default limit for messages sent by a role, on a channel, or by a role on a
+	// channel. The most specific limit is applied, in the order role and channel, channel, role.
+	Limits map[RateLimitKey]RateLimit
+
+	// MaxViolations is the number of dropped messages within the violation window after which a peer
+	// is disconnected.
+	MaxViolations int
+
+	// ViolationWindow is the window in which the violations of a peer are counted.
+	ViolationWindow time.Duration
+}
+
+// DefaultRateLimiterConfig returns the default configuration of the RateLimiter for the staked network,
+// which does not limit any messages.
+func DefaultRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		// the bursts are derived from the rates when the rate limiter is created
+		Default: RateLimit{
+			MessagesPerSecond: DefaultInboundMessageRateLimit,
+			BytesPerSecond:    DefaultInboundByteRateLimit,
+		},
+		Limits:          make(map[RateLimitKey]RateLimit),
+		MaxViolations:   DefaultRateLimitMaxViolations,
+		ViolationWindow: DefaultRateLimitViolationWindow,
+	}
+}
+
+// DefaultPublicRateLimiterConfig returns the default configuration of the RateLimiter for the public network,
+// which is stricter than the configuration for the staked network.
+func DefaultPublicRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		Default:         NewRateLimit(DefaultPublicInboundMessageRateLimit, DefaultPublicInboundByteRateLimit),
+		Limits:          make(map[RateLimitKey]RateLimit),
+		MaxViolations:   DefaultPublicRateLimitMaxViolations,
+		ViolationWindow: DefaultRateLimitViolationWindow,
+	}
+}
+
+// Enabled returns true if the configuration limits any messages.
+func (c RateLimiterConfig) Enabled() bool {
+	if c.Default.limited() {
+		return true
+	}
+	for _, limit := range c.Limits {
+		if limit.limited() {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRateLimits parses rate limits of the form "<role>:<channel>=<messages per second>:<bytes per second>",
+// where the role and the channel can be "*" to match any role or channel.
+func ParseRateLimits(limits []string) (map[RateLimitKey]RateLimit, error) {
+	parsed := make(map[RateLimitKey]RateLimit, len(limits))
+
+	for _, limit := range limits {
+		selector, rates, ok := strings.Cut(limit, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, expected <role>:<channel>=<messages>:<bytes>", limit)
+		}
+
+		role, channel, ok := strings.Cut(selector, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit selector %q, expected <role>:<channel>", selector)
+		}
+
+		var key RateLimitKey
+		if role != "*" {
+			r, err := flow.ParseRole(role)
+			if err != nil {
+				return nil, fmt.Errorf("invalid role in rate limit %q: %w", limit, err)
+			}
+			key.Role = r
+		}
+		if channel != "*" {
+			key.Channel = network.Channel(channel)
+		}
+
+		messages, bytes, ok := strings.Cut(rates, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid rates %q, expected <messages>:<bytes>", rates)
+		}
+
+		messagesPerSecond, err := strconv.ParseFloat(messages, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message rate in rate limit %q: %w", limit, err)
+		}
+		bytesPerSecond, err := strconv.ParseFloat(bytes, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid byte rate in rate limit %q: %w", limit, err)
+		}
+
+		parsed[key] = NewRateLimit(messagesPerSecond, bytesPerSecond)
+	}
+
+	return parsed, nil
+}
+
+// RateLimitDecision is the decision of the RateLimiter on an inbound message.
+type RateLimitDecision int
+
+const (
+	// RateLimitAccept means that the message is within the limits and is processed.
+	RateLimitAccept RateLimitDecision = iota
+
+	// RateLimitDrop means that the message exceeds the limits and is dropped.
+	RateLimitDrop
+
+	// RateLimitDisconnect means that the message exceeds the limits and is dropped, and that the sender
+	// exceeded the limits so often that it should be disconnected.
+	RateLimitDisconnect
+)
+
+// bucketKey identifies the token buckets of a peer on a channel.
+type bucketKey struct {
+	peerID  peer.ID
+	channel network.Channel
+}
+
+// buckets are the token buckets of a peer on a channel.
+type buckets struct {
+	messages *rate.Limiter
+	bytes    *rate.Limiter
+}
+
+// violations counts the dropped messages of a peer in the current violation window.
+type violations struct {
+	windowStart time.Time
+	count       int
+}
+
+// RateLimiter limits the inbound messages of peers with token buckets. Each peer has separate buckets on
+// each channel, whose limits are selected by the role of the peer and the channel. Peers which exceed the
+// limits too often are disconnected.
+type RateLimiter struct {
+	mu         sync.Mutex
+	log        zerolog.Logger
+	config     RateLimiterConfig
+	metrics    module.RateLimitMetrics
+	buckets    *lru.Cache // bucketKey -> *buckets
+	violations *lru.Cache // peer.ID -> *violations
+	now        func() time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter with the given configuration. Bursts which are not configured
+// are derived from the configured rates.
+func NewRateLimiter(log zerolog.Logger, config RateLimiterConfig, metrics module.RateLimitMetrics) (*RateLimiter, error) {
+	limits := make(map[RateLimitKey]RateLimit, len(config.Limits))
+	for key, limit := range config.Limits {
+		limits[key] = limit.withBursts()
+	}
+	config.Default = config.Default.withBursts()
+	config.Limits = limits
+
+	bucketCache, err := lru.New(rateLimiterCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create bucket cache: %w", err)
+	}
+
+	violationCache, err := lru.New(rateLimiterCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create violation cache: %w", err)
+	}
+
+	return &RateLimiter{
+		log:        log.With().Str("component", "rate_limiter").Logger(),
+		config:     config,
+		metrics:    metrics,
+		buckets:    bucketCache,
+		violations: violationCache,
+		now:        time.Now,
+	}, nil
+}
+
+// Allow decides whether a message of the given size, sent by the given peer on the given channel, is
+// processed. The peer is the origin of the message, which for pubsub messages is the peer which signed
+// the message rather than the peer which forwarded it. The role of the peer is the zero role if the peer
+// is not a staked node.
+func (r *RateLimiter) Allow(pid peer.ID, role flow.Role, channel network.Channel, size int) RateLimitDecision {
+	// the number of token buckets and metric labels is bounded by the known channels
+	if !network.ChannelExists(channel) {
+		channel = unknownChannel
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	b := r.bucketsFor(pid, role, channel)
+
+	// the byte bucket can never hold more than its burst, so larger messages consume the full burst
+	n := size
+	if n > b.bytes.Burst() {
+		n = b.bytes.Burst()
+	}
+
+	// a message must fit into both buckets, tokens are only consumed if it does
+	messages := b.messages.ReserveN(now, 1)
+	if messages.OK() && messages.DelayFrom(now) == 0 {
+		bytes := b.bytes.ReserveN(now, n)
+		if bytes.OK() && bytes.DelayFrom(now) == 0 {
+			return RateLimitAccept
+		}
+		bytes.CancelAt(now)
+		messages.CancelAt(now)
+		return r.violation(pid, channel, rateLimitBytes, now)
+	}
+	messages.CancelAt(now)
+	return r.violation(pid, channel, rateLimitMessages, now)
+}
+
+// bucketsFor returns the token buckets of the given peer on the given channel.
+// The caller must hold the lock.
+func (r *RateLimiter) bucketsFor(pid peer.ID, role flow.Role, channel network.Channel) *buckets {
+	key := bucketKey{peerID: pid, channel: channel}
+	if cached, ok := r.buckets.Get(key); ok {
+		return cached.(*buckets)
+	}
+
+	limit := r.limit(role, channel)
+	b := &buckets{
+		messages: newLimiter(limit.MessagesPerSecond, limit.MessageBurst),
+		bytes:    newLimiter(limit.BytesPerSecond, limit.ByteBurst),
+	}
+	r.buckets.Add(key, b)
+
+	return b
+}
+
+// limit returns the most specific configured limit for messages of the given role on the given channel.
+func (r *RateLimiter) limit(role flow.Role, channel network.Channel) RateLimit {
+	// cluster channels are configured by their prefix
+	if prefix, ok := network.ClusterChannelPrefix(channel); ok {
+		channel = network.Channel(prefix)
+	}
+
+	for _, key := range []RateLimitKey{
+		{Role: role, Channel: channel},
+		{Channel: channel},
+		{Role: role},
+	} {
+		if limit, ok := r.config.Limits[key]; ok {
+			return limit
+		}
+	}
+	return r.config.Default
+}
+
+// violation records a dropped message of the given peer, and decides whether the peer is disconnected.
+// The caller must hold the lock.
+func (r *RateLimiter) violation(pid peer.ID, channel network.Channel, limit string, now time.Time) RateLimitDecision {
+	r.metrics.InboundMessageRateLimited(channel.String(), limit)
+
+	var v *violations
+	if cached, ok := r.violations.Get(pid); ok {
+		v = cached.(*violations)
+	} else {
+		v = &violations{windowStart: now}
+		r.violations.Add(pid, v)
+	}
+
+	if now.Sub(v.windowStart) >= r.config.ViolationWindow {
+		v.windowStart = now
+		v.count = 0
+	}
+	v.count++
+
+	if r.config.MaxViolations <= 0 || v.count < r.config.MaxViolations {
+		return RateLimitDrop
+	}
+
+	r.log.Warn().
+		Str("peer_id", pid.String()).
+		Str("channel", channel.String()).
+		Str("limit", limit).
+		Int("violations", v.count).
+		Dur("window", r.config.ViolationWindow).
+		Msg("peer repeatedly exceeded the rate limits, disconnecting")
+
+	r.metrics.OnRateLimitDisconnect()
+
+	// start a new window, the peer is only disconnected again if it keeps exceeding the limits
+	v.windowStart = now
+	v.count = 0
+
+	return RateLimitDisconnect
+}
@@ -0,0 +1,198 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+)
+
+// newTestRateLimiter returns a rate limiter with the given configuration, whose clock is controlled by the
+// returned function.
+func newTestRateLimiter(t *testing.T, config RateLimiterConfig) (*RateLimiter, func(time.Duration)) {
+	r, err := NewRateLimiter(zerolog.Nop(), config, metrics.NewNoopCollector())
+	require.NoError(t, err)
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	return r, func(d time.Duration) { now = now.Add(d) }
+}
+
+// TestRateLimiter_MessageLimit tests that messages exceeding the message rate of a peer on a channel are
+// dropped, and that the bucket refills over time.
+func TestRateLimiter_MessageLimit(t *testing.T) {
+	config := DefaultRateLimiterConfig()
+	config.Default = NewRateLimit(10, 1000)
+	r, advance := newTestRateLimiter(t, config)
+
+	pid := peer.ID("peer")
+	channel := network.PushBlocks
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	}
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+
+	// other peers and other channels have separate buckets
+	assert.Equal(t, RateLimitAccept, r.Allow(peer.ID("other"), flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, network.SyncCommittee, 1))
+
+	// a dropped message does not consume tokens, so one message is accepted after 100ms
+	advance(100 * time.Millisecond)
+	assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+}
+
+// TestRateLimiter_UnknownChannels tests that messages on channels which do not exist share a single bucket,
+// so that a peer cannot evade its limits by sending messages on made-up channels.
+func TestRateLimiter_UnknownChannels(t *testing.T) {
+	config := DefaultRateLimiterConfig()
+	config.Default = NewRateLimit(10, 1000)
+	r, _ := newTestRateLimiter(t, config)
+
+	pid := peer.ID("peer")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, network.Channel(fmt.Sprintf("unknown-%d", i)), 1))
+	}
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, network.Channel("unknown-10"), 1))
+	assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, network.PushBlocks, 1))
+	assert.Equal(t, 2, r.buckets.Len())
+}
+
+// TestRateLimiter_ByteLimit tests that messages exceeding the byte rate of a peer on a channel are dropped,
+// and that messages larger than the burst are accepted when the bucket is full.
+func TestRateLimiter_ByteLimit(t *testing.T) {
+	config := DefaultRateLimiterConfig()
+	config.Default = NewRateLimit(100, 1000)
+	r, advance := newTestRateLimiter(t, config)
+
+	pid := peer.ID("peer")
+	channel := network.PushBlocks
+
+	assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 600))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 600))
+	assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 400))
+
+	// a message larger than the burst consumes the full bucket
+	advance(time.Second)
+	assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 5000))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+}
+
+// TestRateLimiter_Unlimited tests that the default configuration does not limit any messages, and that
+// unconfigured bursts are derived from the configured rates.
+func TestRateLimiter_Unlimited(t *testing.T) {
+	config := DefaultRateLimiterConfig()
+	assert.False(t, config.Enabled())
+	r, _ := newTestRateLimiter(t, config)
+
+	pid := peer.ID("peer")
+	channel := network.PushBlocks
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 10*mb))
+	}
+
+	// only the message rate is configured, so the byte rate is not limited
+	config.Default.MessagesPerSecond = 5
+	assert.True(t, config.Enabled())
+	r, _ = newTestRateLimiter(t, config)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 10*mb))
+	}
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+}
+
+// TestRateLimiter_Limits tests that the most specific configured limit is applied to a message.
+func TestRateLimiter_Limits(t *testing.T) {
+	config := DefaultRateLimiterConfig()
+	config.Default = NewRateLimit(1, 1000)
+	config.Limits = map[RateLimitKey]RateLimit{
+		{Role: flow.RoleCollection, Channel: network.ConsensusClusterPrefix}: NewRateLimit(4, 1000),
+		{Channel: network.ConsensusClusterPrefix}:                            NewRateLimit(3, 1000),
+		{Role: flow.RoleCollection}:                                          NewRateLimit(2, 1000),
+	}
+	r, _ := newTestRateLimiter(t, config)
+
+	clusterChannel := network.ChannelConsensusCluster(flow.ChainID("cluster"))
+	channel := network.PushBlocks
+
+	accepted := func(pid peer.ID, role flow.Role, channel network.Channel) int {
+		count := 0
+		for i := 0; i < 10; i++ {
+			if r.Allow(pid, role, channel, 1) == RateLimitAccept {
+				count++
+			}
+		}
+		return count
+	}
+
+	assert.Equal(t, 4, accepted(peer.ID("collection"), flow.RoleCollection, clusterChannel))
+	assert.Equal(t, 3, accepted(peer.ID("consensus"), flow.RoleConsensus, clusterChannel))
+	assert.Equal(t, 2, accepted(peer.ID("collection"), flow.RoleCollection, channel))
+	assert.Equal(t, 1, accepted(peer.ID("consensus"), flow.RoleConsensus, channel))
+
+	// unstaked peers have no role and get the default limit
+	assert.Equal(t, 1, accepted(peer.ID("unstaked"), flow.Role(0), channel))
+}
+
+// TestRateLimiter_Disconnect tests that peers are disconnected after exceeding the limits too often within
+// the violation window.
+func TestRateLimiter_Disconnect(t *testing.T) {
+	config := DefaultRateLimiterConfig()
+	config.Default = NewRateLimit(1, 1000)
+	config.MaxViolations = 3
+	config.ViolationWindow = time.Minute
+	r, advance := newTestRateLimiter(t, config)
+
+	pid := peer.ID("peer")
+	channel := network.PushBlocks
+
+	require.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+
+	// violations older than the window are not counted
+	advance(time.Minute)
+	require.Equal(t, RateLimitAccept, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+	assert.Equal(t, RateLimitDisconnect, r.Allow(pid, flow.RoleConsensus, channel, 1))
+
+	// the count starts over after a disconnect
+	assert.Equal(t, RateLimitDrop, r.Allow(pid, flow.RoleConsensus, channel, 1))
+}
+
+// TestParseRateLimits tests parsing rate limits from their string representation.
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits([]string{
+		"collection:push-transactions=100:1000",
+		"*:request-collections=10.5:2000",
+		"execution:*=50:5000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[RateLimitKey]RateLimit{
+		{Role: flow.RoleCollection, Channel: network.PushTransactions}: NewRateLimit(100, 1000),
+		{Channel: network.RequestCollections}:                          NewRateLimit(10.5, 2000),
+		{Role: flow.RoleExecution}:                                     NewRateLimit(50, 5000),
+	}, limits)
+
+	for _, invalid := range []string{
+		"collection:push-transactions",
+		"collection=100:1000",
+		"unknown:push-transactions=100:1000",
+		"collection:push-transactions=100",
+		"collection:push-transactions=fast:1000",
+	} {
+		_, err := ParseRateLimits([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,451 @@
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+const (
+	// DefaultReputationHalfLife is the default duration after which the penalty score of a peer is halved.
+	DefaultReputationHalfLife = 10 * time.Minute
+
+	// DefaultThrottleThreshold is the default penalty score above which the inbound messages of a peer are throttled.
+	DefaultThrottleThreshold = 20
+
+	// DefaultDisconnectThreshold is the default penalty score at which a peer is temporarily disconnected.
+	DefaultDisconnectThreshold = 50
+
+	// DefaultBlockThreshold is the default penalty score at which a peer is blocked until the next epoch.
+	DefaultBlockThreshold = 100
+
+	// DefaultDisconnectDuration is the default duration for which a disconnected peer is not allowed to reconnect.
+	DefaultDisconnectDuration = 10 * time.Minute
+
+	// DefaultThrottleRate is the default number of inbound messages per second accepted from a throttled peer.
+	DefaultThrottleRate = 10
+
+	// reputationUpdateInterval is the interval in which the metrics of the peer reputations are updated,
+	// and the reputations of peers which have no penalty left are forgotten.
+	reputationUpdateInterval = time.Minute
+
+	// minTrackedScore is the penalty score below which the reputation of an unpenalized peer is forgotten.
+	minTrackedScore = 0.01
+)
+
+// offensePenalties is the penalty score added to the reputation of a peer for each type of offense.
+var offensePenalties = map[network.Offense]float64{
+	network.OffenseMalformedMessage:   10,
+	network.OffenseOversizedMessage:   20,
+	network.OffenseUnauthorizedSender: 20,
+	network.OffenseInvalidMessage:     10,
+	network.OffenseUnsolicitedMessage: 2,
+	network.OffenseExcessiveRequests:  5,
+}
+
+// ReputationResponse is the response of this node to the reputation of a remote peer.
+// Responses are graduated, a higher response implies the restrictions of all lower responses.
+type ReputationResponse int
+
+const (
+	// ResponseNone means that the peer is not penalized.
+	ResponseNone ReputationResponse = iota
+
+	// ResponseThrottle means that the inbound messages of the peer are rate limited.
+	ResponseThrottle
+
+	// ResponseDisconnect means that the peer is disconnected, and not allowed to reconnect until the
+	// disconnect duration elapsed.
+	ResponseDisconnect
+
+	// ResponseBlock means that the peer is disconnected, and not allowed to reconnect until the next epoch.
+	ResponseBlock
+)
+
+func (r ReputationResponse) String() string {
+	switch r {
+	case ResponseNone:
+		return "none"
+	case ResponseThrottle:
+		return "throttled"
+	case ResponseDisconnect:
+		return "disconnected"
+	case ResponseBlock:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
+
+// ReputationConfig contains the configuration of the ReputationManager.
+type ReputationConfig struct {
+	// HalfLife is the duration after which the penalty score of a peer is halved.
+	HalfLife time.Duration
+
+	// ThrottleThreshold is the penalty score above which the inbound messages of a peer are throttled.
+	ThrottleThreshold float64
+
+	// DisconnectThreshold is the penalty score at which a peer is temporarily disconnected.
+	DisconnectThreshold float64
+
+	// BlockThreshold is the penalty score at which a peer is blocked until the next epoch.
+	BlockThreshold float64
+
+	// DisconnectDuration is the duration for which a disconnected peer is not allowed to reconnect.
+	DisconnectDuration time.Duration
+
+	// ThrottleRate is the number of inbound messages per second accepted from a throttled peer.
+	ThrottleRate float64
+}
+
+// DefaultReputationConfig returns the default configuration of the ReputationManager.
+func DefaultReputationConfig() ReputationConfig {
+	return ReputationConfig{
+		HalfLife:            DefaultReputationHalfLife,
+		ThrottleThreshold:   DefaultThrottleThreshold,
+		DisconnectThreshold: DefaultDisconnectThreshold,
+		BlockThreshold:      DefaultBlockThreshold,
+		DisconnectDuration:  DefaultDisconnectDuration,
+		ThrottleRate:        DefaultThrottleRate,
+	}
+}
+
+// PeerReputation is a snapshot of the reputation of a remote peer.
+type PeerReputation struct {
+	PeerID            peer.ID
+	NodeID            flow.Identifier // zero if the peer is not a known Flow node
+	Score             float64
+	Response          ReputationResponse
+	Offenses          map[network.Offense]uint64
+	DisconnectedUntil time.Time // zero if the peer is not temporarily disconnected
+}
+
+// peerReputation is the tracked reputation of a remote peer.
+type peerReputation struct {
+	score             float64   // penalty score at the time of the last update
+	lastUpdate        time.Time // time of the last update of the score
+	offenses          map[network.Offense]uint64
+	limiter           *rate.Limiter // non-nil while the peer is throttled
+	disconnectedUntil time.Time
+	blocked           bool
+}
+
+// ReputationManager tracks the reputation of remote peers, which is penalized by reported misbehavior.
+// Each offense adds a penalty to the score of the peer, which decays exponentially over time. When
+// the score of a peer crosses the configured thresholds, the peer is penalized with graduated
+// responses:
+//   - throttle: the inbound messages of the peer are rate limited by the middleware.
+//   - disconnect: the peer is disconnected, and the ConnGater and PeerManager refuse connections
+//     with the peer until the disconnect duration elapsed.
+//   - block: the peer is disconnected, and connections with the peer are refused until the next epoch.
+type ReputationManager struct {
+	events.Noop // only EpochTransition is used
+	component.Component
+
+	mu           sync.Mutex
+	log          zerolog.Logger
+	config       ReputationConfig
+	idTranslator IDTranslator
+	metrics      module.ReputationMetrics
+	peers        map[peer.ID]*peerReputation
+	onDisconnect func(peer.ID) // called when a peer must be disconnected
+	now          func() time.Time
+}
+
+var _ network.MisbehaviorReporter = (*ReputationManager)(nil)
+
+// NewReputationManager creates a new ReputationManager, which translates the Flow IDs of reported
+// nodes to peer IDs with the given translator.
+func NewReputationManager(
+	log zerolog.Logger,
+	config ReputationConfig,
+	idTranslator IDTranslator,
+	metrics module.ReputationMetrics,
+) *ReputationManager {
+	r := &ReputationManager{
+		log:          log.With().Str("component", "reputation_manager").Logger(),
+		config:       config,
+		idTranslator: idTranslator,
+		metrics:      metrics,
+		peers:        make(map[peer.ID]*peerReputation),
+		now:          time.Now,
+	}
+
+	r.Component = component.NewComponentManagerBuilder().
+		AddWorker(r.updateLoop).
+		Build()
+
+	return r
+}
+
+// SetDisconnectHandler sets the function which is called when a peer must be disconnected.
+func (r *ReputationManager) SetDisconnectHandler(onDisconnect func(peer.ID)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onDisconnect = onDisconnect
+}
+
+// ReportMisbehavior reports that the node with the given ID committed the given offense on the given channel.
+func (r *ReputationManager) ReportMisbehavior(originID flow.Identifier, channel network.Channel, offense network.Offense) {
+	pid, err := r.idTranslator.GetPeerID(originID)
+	if err != nil {
+		r.log.Warn().
+			Err(err).
+			Hex("origin_id", originID[:]).
+			Str("channel", channel.String()).
+			Str("offense", offense.String()).
+			Msg("could not translate reported node to peer ID, misbehavior is ignored")
+		return
+	}
+
+	r.ReportPeer(pid, channel, offense)
+}
+
+// ReportPeer reports that the given peer committed the given offense on the given channel.
+func (r *ReputationManager) ReportPeer(pid peer.ID, channel network.Channel, offense network.Offense) {
+	r.metrics.OnMisbehaviorReported(offense.String())
+
+	r.mu.Lock()
+
+	now := r.now()
+	rep, ok := r.peers[pid]
+	if !ok {
+		rep = &peerReputation{
+			lastUpdate: now,
+			offenses:   make(map[network.Offense]uint64),
+		}
+		r.peers[pid] = rep
+	}
+
+	previous := r.response(rep, now)
+
+	rep.score = r.decayedScore(rep, now) + offensePenalties[offense]
+	rep.lastUpdate = now
+	rep.offenses[offense]++
+
+	switch {
+	case rep.score >= r.config.BlockThreshold:
+		rep.blocked = true
+	case rep.score >= r.config.DisconnectThreshold && previous < ResponseDisconnect:
+		rep.disconnectedUntil = now.Add(r.config.DisconnectDuration)
+	}
+
+	response := r.response(rep, now)
+	score := rep.score
+	onDisconnect := r.onDisconnect
+
+	r.mu.Unlock()
+
+	log := r.log.With().
+		Str("peer_id", pid.String()).
+		Str("channel", channel.String()).
+		Str("offense", offense.String()).
+		Float64("score", score).
+		Str("response", response.String()).
+		Logger()
+
+	if response == previous {
+		log.Debug().Msg("misbehavior reported")
+		return
+	}
+
+	log.Warn().Str("previous_response", previous.String()).Msg("misbehavior reported, peer is penalized")
+
+	if response >= ResponseDisconnect && previous < ResponseDisconnect && onDisconnect != nil {
+		onDisconnect(pid)
+	}
+}
+
+// Response returns the current response of this node to the reputation of the given peer.
+func (r *ReputationManager) Response(pid peer.ID) ReputationResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep, ok := r.peers[pid]
+	if !ok {
+		return ResponseNone
+	}
+	return r.response(rep, r.now())
+}
+
+// AllowConnection returns true if connections with the given peer are allowed, i.e. if the peer is
+// neither disconnected nor blocked.
+func (r *ReputationManager) AllowConnection(pid peer.ID) bool {
+	return r.Response(pid) < ResponseDisconnect
+}
+
+// AllowMessage returns true if an inbound message of the given peer should be processed. Messages of
+// disconnected and blocked peers are never processed, and messages of throttled peers are processed
+// at the configured throttle rate.
+func (r *ReputationManager) AllowMessage(pid peer.ID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep, ok := r.peers[pid]
+	if !ok {
+		return true
+	}
+
+	switch r.response(rep, r.now()) {
+	case ResponseNone:
+		rep.limiter = nil
+		return true
+	case ResponseThrottle:
+		if rep.limiter == nil {
+			rep.limiter = rate.NewLimiter(rate.Limit(r.config.ThrottleRate), int(math.Max(1, r.config.ThrottleRate)))
+		}
+		return rep.limiter.Allow()
+	default:
+		return false
+	}
+}
+
+// Reputations returns a snapshot of the reputations of all tracked peers, sorted by descending score.
+func (r *ReputationManager) Reputations() []PeerReputation {
+	r.mu.Lock()
+
+	now := r.now()
+	reputations := make([]PeerReputation, 0, len(r.peers))
+	for pid, rep := range r.peers {
+		offenses := make(map[network.Offense]uint64, len(rep.offenses))
+		for offense, count := range rep.offenses {
+			offenses[offense] = count
+		}
+
+		reputation := PeerReputation{
+			PeerID:   pid,
+			Score:    r.decayedScore(rep, now),
+			Response: r.response(rep, now),
+			Offenses: offenses,
+		}
+		if now.Before(rep.disconnectedUntil) {
+			reputation.DisconnectedUntil = rep.disconnectedUntil
+		}
+		reputations = append(reputations, reputation)
+	}
+
+	r.mu.Unlock()
+
+	for i := range reputations {
+		nodeID, err := r.idTranslator.GetFlowID(reputations[i].PeerID)
+		if err == nil {
+			reputations[i].NodeID = nodeID
+		}
+	}
+
+	sort.Slice(reputations, func(i, j int) bool {
+		return reputations[i].Score > reputations[j].Score
+	})
+
+	return reputations
+}
+
+// EpochTransition unblocks all peers which were blocked during the previous epoch.
+func (r *ReputationManager) EpochTransition(newEpochCounter uint64, _ *flow.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for pid, rep := range r.peers {
+		if !rep.blocked {
+			continue
+		}
+
+		rep.blocked = false
+		rep.score = 0
+		rep.lastUpdate = r.now()
+		rep.disconnectedUntil = time.Time{}
+		rep.limiter = nil
+
+		r.log.Info().
+			Str("peer_id", pid.String()).
+			Uint64("epoch_counter", newEpochCounter).
+			Msg("unblocked peer at epoch transition")
+	}
+}
+
+// updateLoop periodically updates the reputation metrics and forgets peers which have no penalty left.
+func (r *ReputationManager) updateLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(reputationUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.update()
+		}
+	}
+}
+
+// update reports the number of tracked peers, the highest score and the response counts, and forgets
+// peers which have no penalty left. The metrics are aggregated over all peers, so that their
+// cardinality does not grow with the number of peers which were ever penalized.
+func (r *ReputationManager) update() {
+	r.mu.Lock()
+
+	now := r.now()
+	maxScore := 0.0
+	counts := make(map[ReputationResponse]int)
+	for pid, rep := range r.peers {
+		score := r.decayedScore(rep, now)
+		response := r.response(rep, now)
+
+		if score < minTrackedScore && response == ResponseNone {
+			delete(r.peers, pid)
+			continue
+		}
+
+		maxScore = math.Max(maxScore, score)
+		counts[response]++
+	}
+	tracked := len(r.peers)
+
+	r.mu.Unlock()
+
+	r.metrics.TrackedPeers(tracked)
+	r.metrics.MaxPeerPenaltyScore(maxScore)
+	for _, response := range []ReputationResponse{ResponseThrottle, ResponseDisconnect, ResponseBlock} {
+		r.metrics.PenalizedPeers(response.String(), counts[response])
+	}
+}
+
+// decayedScore returns the penalty score of the peer at the given time.
+// The caller must hold the lock.
+func (r *ReputationManager) decayedScore(rep *peerReputation, now time.Time) float64 {
+	elapsed := now.Sub(rep.lastUpdate)
+	if elapsed <= 0 || r.config.HalfLife <= 0 {
+		return rep.score
+	}
+	return rep.score * math.Pow(0.5, elapsed.Seconds()/r.config.HalfLife.Seconds())
+}
+
+// response returns the response to the reputation of the peer at the given time.
+// The caller must hold the lock.
+func (r *ReputationManager) response(rep *peerReputation, now time.Time) ReputationResponse {
+	switch {
+	case rep.blocked:
+		return ResponseBlock
+	case now.Before(rep.disconnectedUntil):
+		return ResponseDisconnect
+	case r.decayedScore(rep, now) >= r.config.ThrottleThreshold:
+		return ResponseThrottle
+	default:
+		return ResponseNone
+	}
+}
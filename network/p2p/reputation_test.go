@@ -0,0 +1,182 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// mapTranslator is an IDTranslator backed by a map of known nodes.
+type mapTranslator map[flow.Identifier]peer.ID
+
+func (m mapTranslator) GetPeerID(flowID flow.Identifier) (peer.ID, error) {
+	pid, ok := m[flowID]
+	if !ok {
+		return "", fmt.Errorf("unknown node %v", flowID)
+	}
+	return pid, nil
+}
+
+func (m mapTranslator) GetFlowID(pid peer.ID) (flow.Identifier, error) {
+	for flowID, p := range m {
+		if p == pid {
+			return flowID, nil
+		}
+	}
+	return flow.ZeroID, fmt.Errorf("unknown peer %v", pid)
+}
+
+// newTestReputationManager returns a reputation manager with the default configuration, whose clock
+// is controlled by the returned function.
+func newTestReputationManager(translator IDTranslator) (*ReputationManager, func(time.Duration)) {
+	r := NewReputationManager(zerolog.Nop(), DefaultReputationConfig(), translator, metrics.NewNoopCollector())
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	return r, func(d time.Duration) { now = now.Add(d) }
+}
+
+// TestReputation_GraduatedResponses tests that peers are throttled, disconnected and blocked as their
+// penalty score crosses the thresholds.
+func TestReputation_GraduatedResponses(t *testing.T) {
+	r, _ := newTestReputationManager(mapTranslator{})
+	pid := peer.ID("misbehaving")
+	channel := network.Channel("test-channel")
+
+	var disconnected []peer.ID
+	r.SetDisconnectHandler(func(p peer.ID) {
+		disconnected = append(disconnected, p)
+	})
+
+	// 10 points
+	r.ReportPeer(pid, channel, network.OffenseMalformedMessage)
+	assert.Equal(t, ResponseNone, r.Response(pid))
+	assert.True(t, r.AllowConnection(pid))
+	assert.True(t, r.AllowMessage(pid))
+
+	// 30 points
+	r.ReportPeer(pid, channel, network.OffenseUnauthorizedSender)
+	assert.Equal(t, ResponseThrottle, r.Response(pid))
+	assert.True(t, r.AllowConnection(pid))
+
+	// throttled peers can send a burst of messages at the throttle rate, further messages are dropped
+	for i := 0; i < DefaultThrottleRate; i++ {
+		assert.True(t, r.AllowMessage(pid))
+	}
+	assert.False(t, r.AllowMessage(pid))
+
+	// 50 points
+	r.ReportPeer(pid, channel, network.OffenseOversizedMessage)
+	assert.Equal(t, ResponseDisconnect, r.Response(pid))
+	assert.False(t, r.AllowConnection(pid))
+	assert.False(t, r.AllowMessage(pid))
+	assert.Equal(t, []peer.ID{pid}, disconnected)
+
+	// 100 points, the peer is already disconnected, so the handler is not called again
+	r.ReportPeer(pid, channel, network.OffenseOversizedMessage)
+	r.ReportPeer(pid, channel, network.OffenseOversizedMessage)
+	r.ReportPeer(pid, channel, network.OffenseMalformedMessage)
+	assert.Equal(t, ResponseBlock, r.Response(pid))
+	assert.False(t, r.AllowConnection(pid))
+	assert.Len(t, disconnected, 1)
+
+	// other peers are not affected
+	assert.Equal(t, ResponseNone, r.Response(peer.ID("honest")))
+	assert.True(t, r.AllowConnection(peer.ID("honest")))
+}
+
+// TestReputation_Decay tests that penalty scores decay over time, which lifts throttling and
+// temporary disconnects.
+func TestReputation_Decay(t *testing.T) {
+	r, advance := newTestReputationManager(mapTranslator{})
+	pid := peer.ID("misbehaving")
+	channel := network.Channel("test-channel")
+
+	// 60 points
+	r.ReportPeer(pid, channel, network.OffenseOversizedMessage)
+	r.ReportPeer(pid, channel, network.OffenseOversizedMessage)
+	r.ReportPeer(pid, channel, network.OffenseOversizedMessage)
+	require.Equal(t, ResponseDisconnect, r.Response(pid))
+
+	// after the disconnect duration, the score decayed to 30 points, so the peer is still throttled
+	advance(DefaultDisconnectDuration)
+	reputations := r.Reputations()
+	require.Len(t, reputations, 1)
+	assert.InDelta(t, 30, reputations[0].Score, 0.001)
+	assert.Equal(t, ResponseThrottle, r.Response(pid))
+	assert.True(t, r.AllowConnection(pid))
+
+	// after another half-life, the score decayed to 15 points, so the peer is no longer penalized
+	advance(DefaultReputationHalfLife)
+	assert.Equal(t, ResponseNone, r.Response(pid))
+	assert.True(t, r.AllowMessage(pid))
+
+	// the peer is forgotten once its score decayed completely
+	advance(20 * DefaultReputationHalfLife)
+	r.update()
+	assert.Empty(t, r.Reputations())
+}
+
+// TestReputation_EpochTransition tests that blocked peers are unblocked at the next epoch transition.
+func TestReputation_EpochTransition(t *testing.T) {
+	r, advance := newTestReputationManager(mapTranslator{})
+	blocked := peer.ID("blocked")
+	disconnected := peer.ID("disconnected")
+	channel := network.Channel("test-channel")
+
+	for i := 0; i < 5; i++ {
+		r.ReportPeer(blocked, channel, network.OffenseOversizedMessage)
+	}
+	for i := 0; i < 3; i++ {
+		r.ReportPeer(disconnected, channel, network.OffenseOversizedMessage)
+	}
+	require.Equal(t, ResponseBlock, r.Response(blocked))
+	require.Equal(t, ResponseDisconnect, r.Response(disconnected))
+
+	// blocked peers remain blocked although their score decays
+	advance(20 * DefaultReputationHalfLife)
+	r.update()
+	assert.Equal(t, ResponseBlock, r.Response(blocked))
+
+	r.EpochTransition(1, unittest.BlockHeaderFixture())
+	assert.Equal(t, ResponseNone, r.Response(blocked))
+	assert.True(t, r.AllowConnection(blocked))
+}
+
+// TestReputation_ReportMisbehavior tests that misbehavior reported for Flow nodes is attributed to their peer,
+// and that the offenses are included in the snapshot of the reputations.
+func TestReputation_ReportMisbehavior(t *testing.T) {
+	nodeID := unittest.IdentifierFixture()
+	pid := peer.ID("node")
+	r, _ := newTestReputationManager(mapTranslator{nodeID: pid})
+	channel := network.Channel("test-channel")
+
+	r.ReportMisbehavior(nodeID, channel, network.OffenseInvalidMessage)
+	r.ReportMisbehavior(nodeID, channel, network.OffenseInvalidMessage)
+	r.ReportMisbehavior(nodeID, channel, network.OffenseUnsolicitedMessage)
+
+	// misbehavior of unknown nodes is ignored
+	r.ReportMisbehavior(unittest.IdentifierFixture(), channel, network.OffenseInvalidMessage)
+
+	reputations := r.Reputations()
+	require.Len(t, reputations, 1)
+	assert.Equal(t, pid, reputations[0].PeerID)
+	assert.Equal(t, nodeID, reputations[0].NodeID)
+	assert.InDelta(t, 22, reputations[0].Score, 0.001)
+	assert.Equal(t, ResponseThrottle, reputations[0].Response)
+	assert.Equal(t, map[network.Offense]uint64{
+		network.OffenseInvalidMessage:     2,
+		network.OffenseUnsolicitedMessage: 1,
+	}, reputations[0].Offenses)
+}
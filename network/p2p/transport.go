@@ -0,0 +1,116 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// DialPreference determines which transport is used to dial peers which support both TCP and QUIC.
+type DialPreference string
+
+const (
+	// PreferTCP dials peers over TCP only, and uses QUIC for inbound connections only.
+	PreferTCP DialPreference = "tcp"
+
+	// PreferQUIC dials the known QUIC addresses of peers first, and falls back to TCP.
+	PreferQUIC DialPreference = "quic"
+)
+
+// ParseDialPreference parses the string representation of a dial preference.
+func ParseDialPreference(preference string) (DialPreference, error) {
+	switch DialPreference(preference) {
+	case PreferTCP, PreferQUIC:
+		return DialPreference(preference), nil
+	default:
+		return "", fmt.Errorf("unknown dial preference %q, must be either %q or %q", preference, PreferTCP, PreferQUIC)
+	}
+}
+
+// TransportConfig configures the transports of a libp2p node. A node always listens on TCP, and additionally on
+// QUIC if QUIC is enabled.
+//
+// The networking address in the identity of a node is always its TCP address, so that the identity table can be
+// parsed by all nodes regardless of their version. A node which listens on QUIC advertises its QUIC address to its
+// peers through libp2p identify once they are connected, and the peers store it in their peerstore. Hence, the
+// first connection between two nodes is always established over TCP, and later connections may use QUIC.
+type TransportConfig struct {
+	// QUICEnabled enables the QUIC transport.
+	QUICEnabled bool
+
+	// QUICAddress is the address (ip:port) on which the node listens for QUIC connections.
+	QUICAddress string
+
+	// DialPreference determines which transport is used to dial peers which support both transports.
+	// It has no effect if QUIC is disabled.
+	DialPreference DialPreference
+}
+
+// DefaultTransportConfig returns the default transport config, which only enables TCP.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		QUICEnabled:    false,
+		DialPreference: PreferQUIC,
+	}
+}
+
+// QUICMultiAddressStr receives a node ip and port and returns its corresponding QUIC multi-address in
+// string format, in the same manner as MultiAddressStr for TCP.
+func QUICMultiAddressStr(ip, port string) string {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP != nil {
+		return fmt.Sprintf("/ip4/%s/udp/%s/quic", ip, port)
+	}
+	return fmt.Sprintf("/dns4/%s/udp/%s/quic", ip, port)
+}
+
+// isQUICAddr returns true if the multi-address is a QUIC address.
+func isQUICAddr(addr multiaddr.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(multiaddr.P_QUIC)
+	return err == nil
+}
+
+// tcpDialGater is a connection gater which refuses to dial QUIC addresses, so that a node which listens on
+// QUIC dials its peers over TCP only. All other decisions are taken by the wrapped gater, if any.
+type tcpDialGater struct {
+	gater connmgr.ConnectionGater
+}
+
+var _ connmgr.ConnectionGater = (*tcpDialGater)(nil)
+
+// newTCPDialGater wraps the given connection gater, which may be nil, into a gater refusing to dial QUIC addresses.
+func newTCPDialGater(gater connmgr.ConnectionGater) *tcpDialGater {
+	return &tcpDialGater{gater: gater}
+}
+
+func (g *tcpDialGater) InterceptPeerDial(p peer.ID) bool {
+	return g.gater == nil || g.gater.InterceptPeerDial(p)
+}
+
+// InterceptAddrDial refuses to dial QUIC addresses, which the peer advertised through libp2p identify.
+func (g *tcpDialGater) InterceptAddrDial(p peer.ID, addr multiaddr.Multiaddr) bool {
+	if isQUICAddr(addr) {
+		return false
+	}
+	return g.gater == nil || g.gater.InterceptAddrDial(p, addr)
+}
+
+func (g *tcpDialGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	return g.gater == nil || g.gater.InterceptAccept(addrs)
+}
+
+func (g *tcpDialGater) InterceptSecured(dir network.Direction, p peer.ID, addrs network.ConnMultiaddrs) bool {
+	return g.gater == nil || g.gater.InterceptSecured(dir, p, addrs)
+}
+
+func (g *tcpDialGater) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	if g.gater == nil {
+		return true, 0
+	}
+	return g.gater.InterceptUpgraded(conn)
+}
@@ -0,0 +1,50 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTCPDialGater tests that the gater refuses to dial QUIC addresses, and otherwise defers to the wrapped gater.
+func TestTCPDialGater(t *testing.T) {
+	tcpAddr := multiaddr.StringCast("/ip4/1.1.1.1/tcp/3569")
+	quicAddr := multiaddr.StringCast("/ip4/1.1.1.1/udp/3569/quic")
+	allowed := peer.ID("allowed")
+	refused := peer.ID("refused")
+
+	t.Run("without wrapped gater", func(t *testing.T) {
+		gater := newTCPDialGater(nil)
+		assert.True(t, gater.InterceptPeerDial(allowed))
+		assert.True(t, gater.InterceptAddrDial(allowed, tcpAddr))
+		assert.False(t, gater.InterceptAddrDial(allowed, quicAddr))
+	})
+
+	t.Run("with wrapped gater", func(t *testing.T) {
+		gater := newTCPDialGater(NewConnGater(zerolog.Nop(), func(pid peer.ID) bool {
+			return pid == allowed
+		}))
+		assert.True(t, gater.InterceptPeerDial(allowed))
+		assert.False(t, gater.InterceptPeerDial(refused))
+		assert.True(t, gater.InterceptAddrDial(allowed, tcpAddr))
+		assert.False(t, gater.InterceptAddrDial(allowed, quicAddr))
+	})
+}
+
+// TestParseDialPreference tests parsing dial preferences.
+func TestParseDialPreference(t *testing.T) {
+	preference, err := ParseDialPreference("tcp")
+	require.NoError(t, err)
+	assert.Equal(t, PreferTCP, preference)
+
+	preference, err = ParseDialPreference("quic")
+	require.NoError(t, err)
+	assert.Equal(t, PreferQUIC, preference)
+
+	_, err = ParseDialPreference("udp")
+	assert.Error(t, err)
+}
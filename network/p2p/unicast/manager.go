@@ -75,35 +75,49 @@ func (m *Manager) Register(unicast ProtocolName) error {
 	return nil
 }
 
-// CreateStream tries establishing a libp2p stream to the remote peer id. It tries creating streams in the descending order of preference until
-// it either creates a successful stream or runs out of options. Creating stream on each protocol is tried at most `maxAttempt` one, and then falls
-// back to the less preferred one.
+// CreateStream tries establishing a libp2p stream to the remote peer id. The protocol of the stream is negotiated with the
+// remote peer, which selects the most preferred protocol that both nodes support, so that each peer is reached with the
+// best common protocol, e.g., compression, in a single negotiation. Creating the stream is tried at most `maxAttempt` times.
 func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID, maxAttempts int) (libp2pnet.Stream, []multiaddr.Multiaddr, error) {
-	var errs error
-
+	// protocol ids in descending order of preference
+	protocolIDs := make([]protocol.ID, 0, len(m.unicasts))
 	for i := len(m.unicasts) - 1; i >= 0; i-- {
-		s, addrs, err := m.rawStreamWithProtocol(ctx, m.unicasts[i].ProtocolId(), peerID, maxAttempts)
-		if err != nil {
-			errs = multierror.Append(errs, err)
-			continue
-		}
+		protocolIDs = append(protocolIDs, m.unicasts[i].ProtocolId())
+	}
 
-		s, err = m.unicasts[i].UpgradeRawStream(s)
-		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("could not upgrade stream: %w", err))
-			continue
-		}
+	s, addrs, err := m.rawStreamWithProtocols(ctx, protocolIDs, peerID, maxAttempts)
+	if err != nil {
+		return nil, addrs, fmt.Errorf("could not create stream on any available unicast protocol: %w", err)
+	}
 
-		// return first successful stream
-		return s, addrs, nil
+	u, ok := m.unicastByProtocolId(s.Protocol())
+	if !ok {
+		_ = s.Reset()
+		return nil, addrs, fmt.Errorf("stream negotiated unknown protocol: %s", s.Protocol())
+	}
+
+	upgraded, err := u.UpgradeRawStream(s)
+	if err != nil {
+		_ = s.Reset()
+		return nil, addrs, fmt.Errorf("could not upgrade stream on protocol %s: %w", s.Protocol(), err)
 	}
 
-	return nil, nil, fmt.Errorf("could not create stream on any available unicast protocol: %w", errs)
+	return upgraded, addrs, nil
+}
+
+// unicastByProtocolId returns the registered unicast protocol with the given protocol id.
+func (m *Manager) unicastByProtocolId(protocolID protocol.ID) (Protocol, bool) {
+	for _, u := range m.unicasts {
+		if u.ProtocolId() == protocolID {
+			return u, true
+		}
+	}
+	return nil, false
 }
 
-// rawStreamWithProtocol creates a stream raw libp2p stream on specified protocol.
+// rawStreamWithProtocols creates a raw libp2p stream on the first of the specified protocols supported by the remote peer.
 //
-// Note: a raw stream must be upgraded by the given unicast protocol id.
+// Note: a raw stream must be upgraded by the unicast protocol of its negotiated protocol id.
 //
 // It makes at most `maxAttempts` to create a stream with the peer.
 // This was put in as a fix for #2416. PubSub and 1-1 communication compete with each other when trying to connect to
@@ -111,8 +125,8 @@ func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID, maxAttempts
 //
 // Note that in case an existing TCP connection underneath to `peerID` exists, that connection is utilized for creating a new stream.
 // The multiaddr.Multiaddr return value represents the addresses of `peerID` we dial while trying to create a stream to it.
-func (m *Manager) rawStreamWithProtocol(ctx context.Context,
-	protocolID protocol.ID,
+func (m *Manager) rawStreamWithProtocols(ctx context.Context,
+	protocolIDs []protocol.ID,
 	peerID peer.ID,
 	maxAttempts int) (libp2pnet.Stream, []multiaddr.Multiaddr, error) {
 
@@ -163,11 +177,11 @@ func (m *Manager) rawStreamWithProtocol(ctx context.Context,
 		}
 
 		// creates stream using stream factory
-		s, err = m.streamFactory.NewStream(ctx, peerID, protocolID)
+		s, err = m.streamFactory.NewStream(ctx, peerID, protocolIDs...)
 		if err != nil {
 			// if the stream creation failed due to invalid protocol id, skip the re-attempt
 			if strings.Contains(err.Error(), "protocol not supported") {
-				return nil, dialAddr, fmt.Errorf("remote node is running on a different spork: %w, protocol attempted: %s", err, protocolID)
+				return nil, dialAddr, fmt.Errorf("remote node is running on a different spork: %w, protocols attempted: %v", err, protocolIDs)
 			}
 			errs = multierror.Append(errs, err)
 			continue
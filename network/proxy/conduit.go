@@ -20,6 +20,10 @@ func (c *ProxyConduit) Unicast(event interface{}, targetID flow.Identifier) erro
 	return c.Conduit.Unicast(event, c.targetNodeID)
 }
 
+func (c *ProxyConduit) UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error {
+	return c.Conduit.UnicastLatencyCritical(event, c.targetNodeID)
+}
+
 func (c *ProxyConduit) Multicast(event interface{}, num uint, targetIDs ...flow.Identifier) error {
 	return c.Conduit.Multicast(event, 1, c.targetNodeID)
 }
@@ -0,0 +1,306 @@
+package queue
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+)
+
+// ErrOutboundQueueFull is returned when a message is rejected because the outbound queue of its channel class is full.
+var ErrOutboundQueueFull = errors.New("outbound queue is full")
+
+// ChannelClass groups channels by the latency requirements of their messages. Outbound messages of a class are only
+// sent once no messages of a more urgent class are queued.
+type ChannelClass int
+
+const (
+	// ConsensusClass contains the channels of the consensus protocols, and the channels on which core protocol
+	// entities (blocks, guarantees, receipts and approvals) are pushed. It is the most urgent class.
+	ConsensusClass ChannelClass = iota
+
+	// SyncClass contains the channels of the protocols synchronizing state across nodes.
+	SyncClass
+
+	// DataClass contains all other channels, e.g. the channels on which entities are requested and provided.
+	// It is the least urgent class.
+	DataClass
+
+	numChannelClasses = int(DataClass) + 1
+)
+
+// ChannelClasses returns all channel classes from the most to the least urgent one.
+func ChannelClasses() []ChannelClass {
+	return []ChannelClass{ConsensusClass, SyncClass, DataClass}
+}
+
+func (c ChannelClass) String() string {
+	switch c {
+	case ConsensusClass:
+		return "consensus"
+	case SyncClass:
+		return "sync"
+	case DataClass:
+		return "data"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(c))
+	}
+}
+
+// ChannelClassOf returns the class of the channel.
+func ChannelClassOf(channel network.Channel) ChannelClass {
+	if prefix, ok := network.ClusterChannelPrefix(channel); ok {
+		switch prefix {
+		case network.ConsensusClusterPrefix:
+			return ConsensusClass
+		case network.SyncClusterPrefix:
+			return SyncClass
+		}
+	}
+
+	switch channel {
+	case network.ConsensusCommittee,
+		network.DKGCommittee,
+		network.PushBlocks,
+		network.PushGuarantees,
+		network.PushReceipts,
+		network.PushApprovals:
+		return ConsensusClass
+	case network.SyncCommittee,
+		network.SyncExecution,
+		network.PublicSyncCommittee:
+		return SyncClass
+	default:
+		return DataClass
+	}
+}
+
+// DropPolicy determines which message is dropped when a message is inserted into a full outbound queue.
+type DropPolicy string
+
+const (
+	// DropNewest rejects the inserted message, and keeps the queued ones.
+	DropNewest DropPolicy = "drop-newest"
+
+	// DropOldest evicts the oldest queued message to make room for the inserted one.
+	DropOldest DropPolicy = "drop-oldest"
+)
+
+// ParseDropPolicy parses the string representation of a drop policy.
+func ParseDropPolicy(policy string) (DropPolicy, error) {
+	switch DropPolicy(policy) {
+	case DropNewest, DropOldest:
+		return DropPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unknown drop policy %q, must be either %q or %q", policy, DropNewest, DropOldest)
+	}
+}
+
+// ClassConfig configures the outbound queue of a channel class.
+type ClassConfig struct {
+	// Capacity is the maximum number of queued messages of the class.
+	Capacity int
+
+	// DropPolicy determines which message is dropped when the queue of the class is full.
+	DropPolicy DropPolicy
+}
+
+// OutboundQueueConfig configures the outbound queues of the conduits.
+type OutboundQueueConfig struct {
+	// Enabled enables the outbound queues. If disabled, conduits send messages in the goroutine of the caller.
+	Enabled bool
+
+	// Workers is the number of workers sending the queued messages.
+	Workers int
+
+	// Classes configures the queue of each channel class.
+	Classes map[ChannelClass]ClassConfig
+}
+
+// DefaultOutboundQueueConfig returns the default outbound queue config. The queues are disabled by default.
+// Stale consensus and sync messages are dropped in favor of newer ones, while new data messages are rejected,
+// as requesters retry their requests.
+func DefaultOutboundQueueConfig() OutboundQueueConfig {
+	return OutboundQueueConfig{
+		Enabled: false,
+		Workers: 10,
+		Classes: map[ChannelClass]ClassConfig{
+			ConsensusClass: {Capacity: 1000, DropPolicy: DropOldest},
+			SyncClass:      {Capacity: 500, DropPolicy: DropOldest},
+			DataClass:      {Capacity: 500, DropPolicy: DropNewest},
+		},
+	}
+}
+
+// outboundItem is a message in an outbound queue.
+type outboundItem struct {
+	message   interface{}
+	timestamp time.Time
+}
+
+// OutboundQueue is a set of bounded FIFO queues, one per channel class. Messages are removed from the queue of the
+// most urgent class which is not empty, so that e.g. consensus messages are not delayed by a burst of large data
+// messages.
+type OutboundQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queues  [numChannelClasses]*list.List
+	classes [numChannelClasses]ClassConfig
+	metrics module.OutboundQueueMetrics
+	closed  bool
+}
+
+// NewOutboundQueue creates a new outbound queue with the given config.
+func NewOutboundQueue(config OutboundQueueConfig, metrics module.OutboundQueueMetrics) (*OutboundQueue, error) {
+	q := &OutboundQueue{
+		metrics: metrics,
+	}
+	q.cond = sync.NewCond(&q.mu)
+
+	for _, class := range ChannelClasses() {
+		classConfig, ok := config.Classes[class]
+		if !ok {
+			return nil, fmt.Errorf("missing outbound queue config for channel class %s", class)
+		}
+		if classConfig.Capacity <= 0 {
+			return nil, fmt.Errorf("invalid outbound queue capacity %d for channel class %s", classConfig.Capacity, class)
+		}
+		if _, err := ParseDropPolicy(string(classConfig.DropPolicy)); err != nil {
+			return nil, fmt.Errorf("invalid outbound queue config for channel class %s: %w", class, err)
+		}
+
+		q.classes[class] = classConfig
+		q.queues[class] = list.New()
+	}
+
+	return q, nil
+}
+
+// Insert inserts the message into the queue of the given class. If the queue is full, the message is dropped
+// according to the drop policy of the class: either the message is rejected with ErrOutboundQueueFull, or the
+// oldest queued message is evicted and returned.
+func (q *OutboundQueue) Insert(class ChannelClass, message interface{}) (interface{}, error) {
+	if int(class) < 0 || int(class) >= numChannelClasses {
+		return nil, fmt.Errorf("unknown channel class %d", int(class))
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, fmt.Errorf("outbound queue closed")
+	}
+
+	queue := q.queues[class]
+	var evicted interface{}
+	if queue.Len() >= q.classes[class].Capacity {
+		q.metrics.OutboundMessageDropped(class.String())
+
+		if q.classes[class].DropPolicy == DropNewest {
+			return nil, ErrOutboundQueueFull
+		}
+		evicted = queue.Remove(queue.Front()).(*outboundItem).message
+	}
+
+	queue.PushBack(&outboundItem{
+		message:   message,
+		timestamp: time.Now(),
+	})
+	q.metrics.OutboundQueueDepth(class.String(), queue.Len())
+
+	q.cond.Signal()
+
+	return evicted, nil
+}
+
+// Remove removes the oldest message of the most urgent class which has queued messages. If no message is queued,
+// this call blocks. It returns false once the queue is closed.
+func (q *OutboundQueue) Remove() (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+
+		for _, class := range ChannelClasses() {
+			queue := q.queues[class]
+			if queue.Len() == 0 {
+				continue
+			}
+
+			item := queue.Remove(queue.Front()).(*outboundItem)
+			q.metrics.OutboundQueueDepth(class.String(), queue.Len())
+			q.metrics.OutboundQueueDuration(class.String(), time.Since(item.timestamp))
+
+			return item.message, true
+		}
+
+		q.cond.Wait()
+	}
+}
+
+// Len returns the number of queued messages of the class.
+func (q *OutboundQueue) Len(class ChannelClass) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queues[class].Len()
+}
+
+// Close closes the queue, and unblocks all pending calls to Remove. Queued messages are discarded.
+func (q *OutboundQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// ParseChannelClass parses the string representation of a channel class.
+func ParseChannelClass(class string) (ChannelClass, error) {
+	for _, c := range ChannelClasses() {
+		if c.String() == class {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown channel class %q, must be one of %s, %s or %s", class, ConsensusClass, SyncClass, DataClass)
+}
+
+// ParseClassConfigs parses outbound queue configs of channel classes in the form <class>=<capacity>:<drop policy>,
+// e.g. "data=200:drop-newest".
+func ParseClassConfigs(configs []string) (map[ChannelClass]ClassConfig, error) {
+	classes := make(map[ChannelClass]ClassConfig, len(configs))
+	for _, config := range configs {
+		className, classConfig, ok := strings.Cut(config, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid outbound queue config %q, must be in the form <class>=<capacity>:<drop policy>", config)
+		}
+		class, err := ParseChannelClass(className)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outbound queue config %q: %w", config, err)
+		}
+
+		capacity, policy, ok := strings.Cut(classConfig, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid outbound queue config %q, must be in the form <class>=<capacity>:<drop policy>", config)
+		}
+		parsedCapacity, err := strconv.Atoi(capacity)
+		if err != nil || parsedCapacity <= 0 {
+			return nil, fmt.Errorf("invalid capacity in outbound queue config %q, must be a positive integer", config)
+		}
+		parsedPolicy, err := ParseDropPolicy(policy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outbound queue config %q: %w", config, err)
+		}
+
+		classes[class] = ClassConfig{Capacity: parsedCapacity, DropPolicy: parsedPolicy}
+	}
+	return classes, nil
+}
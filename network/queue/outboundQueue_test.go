@@ -0,0 +1,165 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/queue"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestOutboundQueue_RetrievalByClass tests that messages are retrieved from the most urgent class first, and in
+// insertion order within a class.
+func TestOutboundQueue_RetrievalByClass(t *testing.T) {
+	q := outboundQueueFixture(t, queue.DefaultOutboundQueueConfig())
+
+	inserts := []struct {
+		class   queue.ChannelClass
+		message string
+	}{
+		{queue.DataClass, "data-1"},
+		{queue.SyncClass, "sync-1"},
+		{queue.DataClass, "data-2"},
+		{queue.ConsensusClass, "consensus-1"},
+		{queue.SyncClass, "sync-2"},
+		{queue.ConsensusClass, "consensus-2"},
+	}
+	for _, insert := range inserts {
+		evicted, err := q.Insert(insert.class, insert.message)
+		require.NoError(t, err)
+		require.Nil(t, evicted)
+	}
+	assert.Equal(t, 2, q.Len(queue.ConsensusClass))
+	assert.Equal(t, 2, q.Len(queue.SyncClass))
+	assert.Equal(t, 2, q.Len(queue.DataClass))
+
+	for _, expected := range []string{"consensus-1", "consensus-2", "sync-1", "sync-2", "data-1", "data-2"} {
+		message, ok := q.Remove()
+		require.True(t, ok)
+		assert.Equal(t, expected, message)
+	}
+}
+
+// TestOutboundQueue_DropPolicies tests that messages inserted into a full queue are dropped according to the drop
+// policy of their class.
+func TestOutboundQueue_DropPolicies(t *testing.T) {
+	config := queue.DefaultOutboundQueueConfig()
+	config.Classes[queue.ConsensusClass] = queue.ClassConfig{Capacity: 2, DropPolicy: queue.DropOldest}
+	config.Classes[queue.DataClass] = queue.ClassConfig{Capacity: 2, DropPolicy: queue.DropNewest}
+	q := outboundQueueFixture(t, config)
+
+	t.Run("drop oldest", func(t *testing.T) {
+		for _, message := range []string{"vote-1", "vote-2"} {
+			_, err := q.Insert(queue.ConsensusClass, message)
+			require.NoError(t, err)
+		}
+
+		evicted, err := q.Insert(queue.ConsensusClass, "vote-3")
+		require.NoError(t, err)
+		assert.Equal(t, "vote-1", evicted)
+		assert.Equal(t, 2, q.Len(queue.ConsensusClass))
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		for _, message := range []string{"chunk-1", "chunk-2"} {
+			_, err := q.Insert(queue.DataClass, message)
+			require.NoError(t, err)
+		}
+
+		_, err := q.Insert(queue.DataClass, "chunk-3")
+		require.ErrorIs(t, err, queue.ErrOutboundQueueFull)
+		assert.Equal(t, 2, q.Len(queue.DataClass))
+	})
+
+	for _, expected := range []string{"vote-2", "vote-3", "chunk-1", "chunk-2"} {
+		message, ok := q.Remove()
+		require.True(t, ok)
+		assert.Equal(t, expected, message)
+	}
+}
+
+// TestOutboundQueue_Close tests that closing the queue unblocks pending removals, and rejects further insertions.
+func TestOutboundQueue_Close(t *testing.T) {
+	q := outboundQueueFixture(t, queue.DefaultOutboundQueueConfig())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, ok := q.Remove()
+		assert.False(t, ok)
+	}()
+
+	// the removal blocks as long as the queue is empty
+	unittest.RequireNeverClosedWithin(t, done, 100*time.Millisecond, "removal from empty queue returned")
+
+	q.Close()
+	unittest.RequireCloseBefore(t, done, time.Second, "removal did not return after closing the queue")
+
+	_, err := q.Insert(queue.ConsensusClass, "vote")
+	require.Error(t, err)
+}
+
+// TestNewOutboundQueue_InvalidConfig tests that an outbound queue cannot be created with an invalid config.
+func TestNewOutboundQueue_InvalidConfig(t *testing.T) {
+	config := queue.DefaultOutboundQueueConfig()
+	delete(config.Classes, queue.SyncClass)
+	_, err := queue.NewOutboundQueue(config, metrics.NewNoopCollector())
+	require.Error(t, err)
+
+	config = queue.DefaultOutboundQueueConfig()
+	config.Classes[queue.SyncClass] = queue.ClassConfig{Capacity: 0, DropPolicy: queue.DropOldest}
+	_, err = queue.NewOutboundQueue(config, metrics.NewNoopCollector())
+	require.Error(t, err)
+
+	config = queue.DefaultOutboundQueueConfig()
+	config.Classes[queue.SyncClass] = queue.ClassConfig{Capacity: 10, DropPolicy: "drop-random"}
+	_, err = queue.NewOutboundQueue(config, metrics.NewNoopCollector())
+	require.Error(t, err)
+}
+
+// TestChannelClassOf tests the classification of channels.
+func TestChannelClassOf(t *testing.T) {
+	clusterID := flow.ChainID("cluster")
+
+	assert.Equal(t, queue.ConsensusClass, queue.ChannelClassOf(network.ConsensusCommittee))
+	assert.Equal(t, queue.ConsensusClass, queue.ChannelClassOf(network.ChannelConsensusCluster(clusterID)))
+	assert.Equal(t, queue.ConsensusClass, queue.ChannelClassOf(network.PushBlocks))
+	assert.Equal(t, queue.SyncClass, queue.ChannelClassOf(network.SyncCommittee))
+	assert.Equal(t, queue.SyncClass, queue.ChannelClassOf(network.ChannelSyncCluster(clusterID)))
+	assert.Equal(t, queue.SyncClass, queue.ChannelClassOf(network.PublicSyncCommittee))
+	assert.Equal(t, queue.DataClass, queue.ChannelClassOf(network.ProvideChunks))
+	assert.Equal(t, queue.DataClass, queue.ChannelClassOf(network.RequestCollections))
+}
+
+// TestParseClassConfigs tests parsing the outbound queue configs of channel classes.
+func TestParseClassConfigs(t *testing.T) {
+	classes, err := queue.ParseClassConfigs([]string{"consensus=2000:drop-oldest", "data=100:drop-newest"})
+	require.NoError(t, err)
+	assert.Equal(t, map[queue.ChannelClass]queue.ClassConfig{
+		queue.ConsensusClass: {Capacity: 2000, DropPolicy: queue.DropOldest},
+		queue.DataClass:      {Capacity: 100, DropPolicy: queue.DropNewest},
+	}, classes)
+
+	for _, config := range []string{
+		"consensus",
+		"blocks=100:drop-oldest",
+		"data=100",
+		"data=-1:drop-oldest",
+		"data=100:drop-random",
+	} {
+		_, err := queue.ParseClassConfigs([]string{config})
+		assert.Error(t, err, config)
+	}
+}
+
+func outboundQueueFixture(t *testing.T, config queue.OutboundQueueConfig) *queue.OutboundQueue {
+	q, err := queue.NewOutboundQueue(config, metrics.NewNoopCollector())
+	require.NoError(t, err)
+	return q
+}
@@ -0,0 +1,86 @@
+package recorder
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+)
+
+// Network wraps a network, and records all messages passed to and sent by the engines registered with it.
+type Network struct {
+	network.Network
+	recorder *Recorder
+}
+
+var _ network.Network = (*Network)(nil)
+
+// NewNetwork returns a new Network which records the traffic of the engines registered with the given
+// network with the given recorder.
+func NewNetwork(net network.Network, recorder *Recorder) *Network {
+	return &Network{
+		Network:  net,
+		recorder: recorder,
+	}
+}
+
+// Register registers the message processor with the underlying network. The messages passed to the
+// processor, and the messages sent with the returned conduit, are recorded.
+func (n *Network) Register(channel network.Channel, processor network.MessageProcessor) (network.Conduit, error) {
+	con, err := n.Network.Register(channel, &messageProcessor{
+		processor: processor,
+		recorder:  n.recorder,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not register engine on channel %s: %w", channel, err)
+	}
+
+	return &conduit{
+		Conduit:  con,
+		channel:  channel,
+		recorder: n.recorder,
+	}, nil
+}
+
+// messageProcessor records the messages passed to the wrapped message processor.
+type messageProcessor struct {
+	processor network.MessageProcessor
+	recorder  *Recorder
+}
+
+var _ network.MessageProcessor = (*messageProcessor)(nil)
+
+func (m *messageProcessor) Process(channel network.Channel, originID flow.Identifier, message interface{}) error {
+	m.recorder.RecordInbound(channel, originID, message)
+	return m.processor.Process(channel, originID, message)
+}
+
+// conduit records the messages sent with the wrapped conduit.
+type conduit struct {
+	network.Conduit
+	channel  network.Channel
+	recorder *Recorder
+}
+
+var _ network.Conduit = (*conduit)(nil)
+
+func (c *conduit) Publish(event interface{}, targetIDs ...flow.Identifier) error {
+	c.recorder.RecordOutbound(c.channel, targetIDs, event)
+	return c.Conduit.Publish(event, targetIDs...)
+}
+
+func (c *conduit) Unicast(event interface{}, targetID flow.Identifier) error {
+	c.recorder.RecordOutbound(c.channel, []flow.Identifier{targetID}, event)
+	return c.Conduit.Unicast(event, targetID)
+}
+
+func (c *conduit) UnicastLatencyCritical(event interface{}, targetID flow.Identifier) error {
+	c.recorder.RecordOutbound(c.channel, []flow.Identifier{targetID}, event)
+	return c.Conduit.UnicastLatencyCritical(event, targetID)
+}
+
+// Multicast records the set of nodes the targets are sampled from, as the sampled targets are not known.
+func (c *conduit) Multicast(event interface{}, num uint, targetIDs ...flow.Identifier) error {
+	c.recorder.RecordOutbound(c.channel, targetIDs, event)
+	return c.Conduit.Multicast(event, num, targetIDs...)
+}
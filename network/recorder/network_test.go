@@ -0,0 +1,123 @@
+package recorder
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fakeNetwork registers the processors and returns conduits which do nothing.
+type fakeNetwork struct {
+	network.Network
+	processors map[network.Channel]network.MessageProcessor
+}
+
+func (f *fakeNetwork) Register(channel network.Channel, processor network.MessageProcessor) (network.Conduit, error) {
+	f.processors[channel] = processor
+	return &fakeConduit{}, nil
+}
+
+type fakeConduit struct {
+	network.Conduit
+}
+
+func (f *fakeConduit) Publish(interface{}, ...flow.Identifier) error             { return nil }
+func (f *fakeConduit) Unicast(interface{}, flow.Identifier) error                { return nil }
+func (f *fakeConduit) UnicastLatencyCritical(interface{}, flow.Identifier) error { return nil }
+func (f *fakeConduit) Multicast(interface{}, uint, ...flow.Identifier) error     { return nil }
+
+// processorFunc is a message processor which calls the function.
+type processorFunc func(channel network.Channel, originID flow.Identifier, message interface{}) error
+
+func (p processorFunc) Process(channel network.Channel, originID flow.Identifier, message interface{}) error {
+	return p(channel, originID, message)
+}
+
+// TestNetwork_RecordsTraffic tests that the messages received and sent by a registered engine are recorded,
+// and that the recorded messages can be read and decoded.
+func TestNetwork_RecordsTraffic(t *testing.T) {
+	dir := t.TempDir()
+	codec := cbor.NewCodec()
+	me := unittest.IdentifierFixture()
+
+	writer, err := NewRotatingFileWriter(dir, 1024, 0)
+	require.NoError(t, err)
+	rec := NewRecorder(zerolog.Nop(), codec, me, writer)
+
+	fake := &fakeNetwork{processors: make(map[network.Channel]network.MessageProcessor)}
+	net := NewNetwork(fake, rec)
+
+	var processed []interface{}
+	con, err := net.Register(network.PushBlocks, processorFunc(func(_ network.Channel, _ flow.Identifier, message interface{}) error {
+		processed = append(processed, message)
+		return nil
+	}))
+	require.NoError(t, err)
+
+	origin := unittest.IdentifierFixture()
+	targets := unittest.IdentifierListFixture(3)
+	inbound := &messages.SyncRequest{Nonce: 1, Height: 10}
+	outbound := &messages.SyncResponse{Nonce: 1, Height: 20}
+
+	require.NoError(t, fake.processors[network.PushBlocks].Process(network.PushBlocks, origin, inbound))
+	require.NoError(t, con.Publish(outbound, targets...))
+	require.NoError(t, con.Unicast(outbound, targets[0]))
+	require.NoError(t, con.UnicastLatencyCritical(outbound, targets[1]))
+	require.NoError(t, con.Multicast(outbound, 2, targets...))
+	require.NoError(t, rec.Close())
+
+	// messages are forwarded unchanged
+	assert.Equal(t, []interface{}{inbound}, processed)
+
+	records, err := ReadRecords(dir)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	assert.Equal(t, Inbound, records[0].Direction)
+	assert.Equal(t, network.PushBlocks, records[0].Channel)
+	assert.Equal(t, origin, records[0].OriginID)
+	assert.Equal(t, flow.IdentifierList{me}, records[0].TargetIDs)
+	decoded, err := codec.Decode(records[0].Payload)
+	require.NoError(t, err)
+	assert.Equal(t, inbound, decoded)
+
+	expectedTargets := []flow.IdentifierList{targets, {targets[0]}, {targets[1]}, targets}
+	for i, record := range records[1:] {
+		assert.Equal(t, Outbound, record.Direction)
+		assert.Equal(t, me, record.OriginID)
+		assert.Equal(t, expectedTargets[i], record.TargetIDs)
+		decoded, err := codec.Decode(record.Payload)
+		require.NoError(t, err)
+		assert.Equal(t, outbound, decoded)
+	}
+}
+
+// TestRecorder_SkipsUnencodableMessages tests that messages which cannot be encoded are still forwarded.
+func TestRecorder_SkipsUnencodableMessages(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, err := NewRotatingFileWriter(dir, 1024, 0)
+	require.NoError(t, err)
+	rec := NewRecorder(zerolog.Nop(), cbor.NewCodec(), unittest.IdentifierFixture(), writer)
+
+	fake := &fakeNetwork{processors: make(map[network.Channel]network.MessageProcessor)}
+	con, err := NewNetwork(fake, rec).Register(network.PushBlocks, processorFunc(func(network.Channel, flow.Identifier, interface{}) error {
+		return nil
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, con.Unicast(struct{}{}, unittest.IdentifierFixture()))
+	require.NoError(t, rec.Close())
+
+	records, err := ReadRecords(dir)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
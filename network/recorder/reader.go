@@ -0,0 +1,58 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// maxRecordSize is the maximum size of a single encoded record.
+const maxRecordSize = 64 << 20 // 64 mb
+
+// ReadRecords reads all records from the recording files in the given directory, in the order in which
+// they were recorded.
+func ReadRecords(dir string) ([]Record, error) {
+	files, err := recordingFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for _, file := range files {
+		fileRecords, err := ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, fileRecords...)
+	}
+
+	return records, nil
+}
+
+// ReadFile reads all records from the given recording file.
+func ReadFile(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open recording file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxRecordSize)
+
+	var records []Record
+	for line := 1; scanner.Scan(); line++ {
+		var record Record
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode record on line %d of %s: %w", line, path, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read recording file %s: %w", path, err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,42 @@
+package recorder
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+)
+
+// Direction is the direction of a recorded message, as seen by the recording node.
+type Direction string
+
+const (
+	// Inbound messages are received by the recording node, and passed to one of its engines.
+	Inbound Direction = "inbound"
+
+	// Outbound messages are sent by one of the engines of the recording node.
+	Outbound Direction = "outbound"
+)
+
+// Record is a single message recorded by the Recorder.
+type Record struct {
+	// Timestamp is the time at which the message was passed to an engine or sent by an engine.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Direction is the direction of the message.
+	Direction Direction `json:"direction"`
+
+	// Channel is the channel the message was sent on.
+	Channel network.Channel `json:"channel"`
+
+	// OriginID is the ID of the node which sent the message. For outbound messages, it is the ID of
+	// the recording node.
+	OriginID flow.Identifier `json:"origin_id"`
+
+	// TargetIDs are the IDs of the nodes the message was sent to. For inbound messages, it is the ID
+	// of the recording node. For multicast messages, it is the set of nodes the targets were sampled from.
+	TargetIDs flow.IdentifierList `json:"target_ids"`
+
+	// Payload is the message encoded with the network codec.
+	Payload []byte `json:"payload"`
+}
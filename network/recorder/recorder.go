@@ -0,0 +1,89 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+)
+
+// Recorder writes every message sent and received by the engines of a node to a writer, one JSON encoded
+// Record per line. Failing to record a message is logged, and never affects the delivery of the message.
+type Recorder struct {
+	mu      sync.Mutex
+	log     zerolog.Logger
+	codec   network.Codec
+	me      flow.Identifier
+	writer  io.WriteCloser
+	encoder *json.Encoder
+	now     func() time.Time
+}
+
+// NewRecorder creates a new Recorder for the node with the given ID, which encodes the message payloads
+// with the given codec and writes the records to the given writer.
+func NewRecorder(log zerolog.Logger, codec network.Codec, me flow.Identifier, writer io.WriteCloser) *Recorder {
+	return &Recorder{
+		log:     log.With().Str("component", "traffic_recorder").Logger(),
+		codec:   codec,
+		me:      me,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+		now:     time.Now,
+	}
+}
+
+// RecordInbound records a message received from the given origin on the given channel.
+func (r *Recorder) RecordInbound(channel network.Channel, originID flow.Identifier, message interface{}) {
+	r.record(Inbound, channel, originID, flow.IdentifierList{r.me}, message)
+}
+
+// RecordOutbound records a message sent to the given targets on the given channel.
+func (r *Recorder) RecordOutbound(channel network.Channel, targetIDs []flow.Identifier, message interface{}) {
+	r.record(Outbound, channel, r.me, targetIDs, message)
+}
+
+func (r *Recorder) record(direction Direction, channel network.Channel, originID flow.Identifier, targetIDs flow.IdentifierList, message interface{}) {
+	payload, err := r.codec.Encode(message)
+	if err != nil {
+		r.log.Warn().
+			Err(err).
+			Str("direction", string(direction)).
+			Str("channel", channel.String()).
+			Str("message_type", fmt.Sprintf("%T", message)).
+			Msg("could not encode message, skipping record")
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err = r.encoder.Encode(Record{
+		Timestamp: r.now(),
+		Direction: direction,
+		Channel:   channel,
+		OriginID:  originID,
+		TargetIDs: targetIDs,
+		Payload:   payload,
+	})
+	if err != nil {
+		r.log.Warn().
+			Err(err).
+			Str("direction", string(direction)).
+			Str("channel", channel.String()).
+			Msg("could not write record")
+	}
+}
+
+// Close closes the underlying writer. Messages must not be recorded after calling Close.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.writer.Close()
+}
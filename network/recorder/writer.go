@@ -0,0 +1,143 @@
+package recorder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// recordingFilePattern is the pattern of the names of the recording files. The files are numbered
+	// sequentially, so the lexicographic order of the names is the order in which the files were written.
+	recordingFilePattern = "traffic-%08d.jsonl"
+
+	// recordingFileGlob matches the names of the recording files.
+	recordingFileGlob = "traffic-*.jsonl"
+)
+
+// RotatingFileWriter writes to a sequence of files in a directory. Once the current file exceeds the
+// maximum file size, it continues writing to a new file, and removes the oldest files in excess of the
+// maximum number of files.
+//
+// RotatingFileWriter is not concurrency safe.
+type RotatingFileWriter struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	file        *os.File
+	size        int64
+	index       uint64
+}
+
+// NewRotatingFileWriter creates a new RotatingFileWriter, which starts writing to a new file after the
+// files which already exist in the directory. A maxFiles of zero keeps all files.
+func NewRotatingFileWriter(dir string, maxFileSize int64, maxFiles int) (*RotatingFileWriter, error) {
+	if maxFileSize <= 0 {
+		return nil, fmt.Errorf("maximum file size must be positive, got %d", maxFileSize)
+	}
+	if maxFiles < 0 {
+		return nil, fmt.Errorf("maximum number of files must not be negative, got %d", maxFiles)
+	}
+
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		return nil, fmt.Errorf("could not create recording directory: %w", err)
+	}
+
+	files, err := recordingFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &RotatingFileWriter{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}
+
+	// continues the numbering of the existing files
+	for _, file := range files {
+		var index uint64
+		_, err := fmt.Sscanf(filepath.Base(file), recordingFilePattern, &index)
+		if err == nil && index >= w.index {
+			w.index = index + 1
+		}
+	}
+
+	err = w.rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write writes the given bytes to the current file. If the current file would exceed the maximum size,
+// the bytes are written to a new file instead. Bytes passed to a single call are never split across files.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	if w.size > 0 && w.size+int64(len(p)) > w.maxFileSize {
+		err := w.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (w *RotatingFileWriter) Close() error {
+	return w.file.Close()
+}
+
+// rotate closes the current file, opens the next file, and removes the oldest files in excess of the
+// maximum number of files.
+func (w *RotatingFileWriter) rotate() error {
+	if w.file != nil {
+		err := w.file.Close()
+		if err != nil {
+			return fmt.Errorf("could not close recording file: %w", err)
+		}
+	}
+
+	path := filepath.Join(w.dir, fmt.Sprintf(recordingFilePattern, w.index))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return fmt.Errorf("could not create recording file: %w", err)
+	}
+
+	w.file = file
+	w.size = 0
+	w.index++
+
+	if w.maxFiles == 0 {
+		return nil
+	}
+
+	files, err := recordingFiles(w.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > w.maxFiles {
+		err := os.Remove(files[0])
+		if err != nil {
+			return fmt.Errorf("could not remove recording file: %w", err)
+		}
+		files = files[1:]
+	}
+
+	return nil
+}
+
+// recordingFiles returns the paths of the recording files in the directory, from the oldest to the newest.
+func recordingFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, recordingFileGlob))
+	if err != nil {
+		return nil, fmt.Errorf("could not list recording files: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
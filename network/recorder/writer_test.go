@@ -0,0 +1,68 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRotatingFileWriter_Rotate tests that the writer starts a new file once the current file would exceed
+// the maximum size, and that it removes the oldest files.
+func TestRotatingFileWriter_Rotate(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := NewRotatingFileWriter(dir, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeeeeeeeeeee\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	files, err := recordingFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, filepath.Join(dir, "traffic-00000001.jsonl"), files[0])
+	assert.Equal(t, filepath.Join(dir, "traffic-00000002.jsonl"), files[1])
+
+	// writes are never split across files, even if they exceed the maximum size
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "cccc\ndddd\n", string(content))
+	content, err = os.ReadFile(files[1])
+	require.NoError(t, err)
+	assert.Equal(t, "eeeeeeeeeeee\n", string(content))
+}
+
+// TestRotatingFileWriter_Reopen tests that a new writer continues after the files of a previous writer.
+func TestRotatingFileWriter_Reopen(t *testing.T) {
+	dir := t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		w, err := NewRotatingFileWriter(dir, 1024, 0)
+		require.NoError(t, err)
+		_, err = w.Write([]byte("record\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	}
+
+	files, err := recordingFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "traffic-00000000.jsonl"),
+		filepath.Join(dir, "traffic-00000001.jsonl"),
+	}, files)
+}
+
+// TestRotatingFileWriter_InvalidArguments tests that the writer rejects invalid limits.
+func TestRotatingFileWriter_InvalidArguments(t *testing.T) {
+	_, err := NewRotatingFileWriter(t.TempDir(), 0, 1)
+	assert.Error(t, err)
+
+	_, err = NewRotatingFileWriter(t.TempDir(), 1024, -1)
+	assert.Error(t, err)
+}
@@ -95,6 +95,10 @@ func (n *Network) UnRegisterChannel(channel network.Channel) error {
 	return nil
 }
 
+// ReportMisbehaviorOnChannel is a no-op, as the stub network does not track the reputation of nodes.
+func (n *Network) ReportMisbehaviorOnChannel(_ network.Channel, _ flow.Identifier, _ network.Offense) {
+}
+
 // submit is called when the attached Engine to the channel is sending an event to an
 // Engine attached to the same channel on another node or nodes.
 func (n *Network) submit(channel network.Channel, event interface{}, targetIDs ...flow.Identifier) error {
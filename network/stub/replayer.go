@@ -0,0 +1,85 @@
+package stub
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/recorder"
+)
+
+// Replayer feeds the inbound messages of a recorded session into the engines of a single node, in the
+// order in which they were recorded, in order to reproduce the behavior of the node deterministically.
+// The engines of the node register with the Network of the replayer. The messages the engines send
+// while processing the replayed messages remain in the buffer of the Hub, where they can be inspected.
+type Replayer struct {
+	t       testing.TB
+	hub     *Hub
+	net     *Network
+	codec   network.Codec
+	origins map[flow.Identifier]*Network
+}
+
+// NewReplayer creates a new Replayer for the node with the given ID, which decodes the recorded payloads
+// with the given codec. The codec must be the one the session was recorded with.
+func NewReplayer(t testing.TB, nodeID flow.Identifier, codec network.Codec) *Replayer {
+	hub := NewNetworkHub()
+	return &Replayer{
+		t:       t,
+		hub:     hub,
+		net:     NewNetwork(t, nodeID, hub),
+		codec:   codec,
+		origins: make(map[flow.Identifier]*Network),
+	}
+}
+
+// Network returns the network of the replayed node, which its engines register with.
+func (r *Replayer) Network() *Network {
+	return r.net
+}
+
+// Hub returns the hub of the replayer.
+func (r *Replayer) Hub() *Hub {
+	return r.hub
+}
+
+// Replay delivers the inbound messages of the given records to the engines of the node one at a time,
+// and returns once each message has been processed. Outbound records are skipped, as they are the
+// result of processing the inbound messages.
+// As on the real network, a message which the node has already received from the same origin on the
+// same channel is not delivered again.
+func (r *Replayer) Replay(records []recorder.Record) error {
+	for i, record := range records {
+		if record.Direction != recorder.Inbound {
+			continue
+		}
+
+		event, err := r.codec.Decode(record.Payload)
+		if err != nil {
+			return fmt.Errorf("could not decode payload of record %d: %w", i, err)
+		}
+
+		err = r.origin(record.OriginID).sendToAllTargets(&PendingMessage{
+			From:      record.OriginID,
+			Channel:   record.Channel,
+			Event:     event,
+			TargetIDs: []flow.Identifier{r.net.GetID()},
+		}, true)
+		if err != nil {
+			return fmt.Errorf("could not replay record %d on channel %s: %w", i, record.Channel, err)
+		}
+	}
+
+	return nil
+}
+
+// origin returns the network of the given origin node, and creates it on first use.
+func (r *Replayer) origin(originID flow.Identifier) *Network {
+	net, ok := r.origins[originID]
+	if !ok {
+		net = NewNetwork(r.t, originID, r.hub)
+		r.origins[originID] = net
+	}
+	return net
+}
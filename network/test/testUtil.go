@@ -130,6 +130,14 @@ func GenerateIDs(
 
 		opts = append(opts, withDHT(o.dhtPrefix, o.dhtOpts...))
 
+		if _, ok := o.quicNodes[i]; ok {
+			opts = append(opts, withTransportConfig(p2p.TransportConfig{
+				QUICEnabled:    true,
+				QUICAddress:    "0.0.0.0:0",
+				DialPreference: p2p.PreferQUIC,
+			}))
+		}
+
 		libP2PNodes[i], tagObservables[i] = generateLibP2PNode(t, logger, *id, key, o.connectionGating, idProvider, opts...)
 
 		_, port, err := libP2PNodes[i].GetIPPort()
@@ -278,6 +286,7 @@ type optsConfig struct {
 	dhtOpts          []dht.Option
 	peerManagerOpts  []p2p.Option
 	connectionGating bool
+	quicNodes        map[int]struct{}
 }
 
 func WithIdentityOpts(idOpts ...func(*flow.Identity)) func(*optsConfig) {
@@ -299,6 +308,16 @@ func WithPeerManagerOpts(peerManagerOpts ...p2p.Option) func(*optsConfig) {
 	}
 }
 
+// WithQUIC enables the QUIC transport, in addition to TCP, on the nodes with the given indices.
+func WithQUIC(indices ...int) func(*optsConfig) {
+	return func(o *optsConfig) {
+		o.quicNodes = make(map[int]struct{}, len(indices))
+		for _, i := range indices {
+			o.quicNodes[i] = struct{}{}
+		}
+	}
+}
+
 func GenerateIDsMiddlewaresNetworks(
 	ctx context.Context,
 	t *testing.T,
@@ -339,6 +358,12 @@ func withDHT(prefix string, dhtOpts ...dht.Option) nodeBuilderOption {
 	}
 }
 
+func withTransportConfig(config p2p.TransportConfig) nodeBuilderOption {
+	return func(nb p2p.NodeBuilder) {
+		nb.SetTransportConfig(config)
+	}
+}
+
 // generateLibP2PNode generates a `LibP2PNode` on localhost using a port assigned by the OS
 func generateLibP2PNode(
 	t *testing.T,
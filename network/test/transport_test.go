@@ -0,0 +1,201 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// MixedTransportTestSuite evaluates the message delivery between nodes which listen on both TCP and QUIC,
+// and nodes which only listen on TCP.
+type MixedTransportTestSuite struct {
+	suite.Suite
+	ConduitWrapper
+	nets   []network.Network // used to keep track of the networks
+	ids    flow.IdentityList // used to keep track of the identifiers associated with networks
+	nodes  []*p2p.Node       // used to inspect the connections of the nodes
+	quic   map[int]bool      // used to keep track of the nodes which listen on QUIC
+	cancel context.CancelFunc
+}
+
+// TestMixedTransportTestSuite runs all tests in this test suite
+func TestMixedTransportTestSuite(t *testing.T) {
+	suite.Run(t, new(MixedTransportTestSuite))
+}
+
+// SetupTest creates a network of nodes, half of which listen on QUIC in addition to TCP.
+func (suite *MixedTransportTestSuite) SetupTest() {
+	const count = 6
+	logger := zerolog.New(os.Stderr).Level(zerolog.ErrorLevel)
+	log.SetAllLoggers(log.LevelError)
+
+	quicNodes := []int{0, 1, 2}
+	suite.quic = make(map[int]bool)
+	for _, i := range quicNodes {
+		suite.quic[i] = true
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.cancel = cancel
+
+	opts := []func(*optsConfig){WithIdentityOpts(unittest.WithAllRoles()), WithQUIC(quicNodes...)}
+	ids, nodes, _ := GenerateIDs(suite.T(), logger, count, opts...)
+	mws, _ := GenerateMiddlewares(suite.T(), logger, ids, nodes, unittest.NetworkCodec(), opts...)
+	sms := GenerateSubscriptionManagers(suite.T(), mws)
+
+	suite.ids = ids
+	suite.nodes = nodes
+	suite.nets = GenerateNetworks(ctx, suite.T(), logger, ids, mws, nil, sms)
+}
+
+// TearDownTest closes the networks within a specified timeout
+func (suite *MixedTransportTestSuite) TearDownTest() {
+	suite.cancel()
+	stopNetworks(suite.T(), suite.nets, 3*time.Second)
+}
+
+// TestIdentityAddresses checks that the identity of every node only carries its TCP address, regardless of its
+// transports, so that the identity table can be parsed by nodes which are not aware of QUIC.
+func (suite *MixedTransportTestSuite) TestIdentityAddresses() {
+	for _, id := range suite.ids {
+		info, err := p2p.PeerAddressInfo(*id)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), info.Addrs, 1)
+
+		_, err = info.Addrs[0].ValueForProtocol(multiaddr.P_TCP)
+		assert.NoError(suite.T(), err)
+	}
+}
+
+// TestAllToAll_Unicast checks that all nodes can exchange messages regardless of their transports, that the nodes
+// which listen on QUIC advertise their QUIC address to their peers, and that nodes which both listen on QUIC can
+// connect over QUIC.
+func (suite *MixedTransportTestSuite) TestAllToAll_Unicast() {
+	count := len(suite.nets)
+	engs := make([]*MeshEngine, 0, count)
+	for i := range suite.nets {
+		engs = append(engs, NewMeshEngine(suite.T(), suite.nets[i], count-1, network.TestNetworkChannel))
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range suite.nets {
+		for j, id := range suite.ids {
+			if i == j {
+				continue
+			}
+			event := &message.TestMessage{
+				Text: fmt.Sprintf("hello from node %v", i),
+			}
+			require.NoError(suite.T(), suite.Unicast(event, engs[i].con, id.NodeID))
+		}
+		wg.Add(count - 1)
+	}
+
+	for i := range suite.nets {
+		go func(e *MeshEngine) {
+			for x := 0; x < count-1; x++ {
+				<-e.received
+				wg.Done()
+			}
+		}(engs[i])
+	}
+
+	unittest.AssertReturnsBefore(suite.T(), wg.Wait, 30*time.Second)
+
+	// the QUIC addresses of the nodes are advertised to their peers through libp2p identify
+	for i := range suite.nodes {
+		for j, id := range suite.ids {
+			if i == j {
+				continue
+			}
+			info, err := p2p.PeerAddressInfo(*id)
+			require.NoError(suite.T(), err)
+
+			if suite.quic[j] {
+				quicPort, ok := suite.nodes[j].GetQUICPort()
+				require.True(suite.T(), ok)
+				assert.Eventually(suite.T(), func() bool {
+					return suite.hasQUICAddr(suite.nodes[i], info.ID, quicPort)
+				}, 5*time.Second, 100*time.Millisecond, "node %d did not learn the QUIC address of node %d", i, j)
+			} else {
+				assert.False(suite.T(), suite.hasQUICAddr(suite.nodes[i], info.ID, ""), "node %d learned a QUIC address of TCP only node %d", i, j)
+			}
+		}
+	}
+
+	// once the QUIC address of a peer is known, nodes which both listen on QUIC can connect over QUIC, which is
+	// checked by reconnecting without the TCP addresses of the peer
+	for i := range suite.nodes {
+		for j, id := range suite.ids {
+			if i == j || !suite.quic[j] {
+				continue
+			}
+			info, err := p2p.PeerAddressInfo(*id)
+			require.NoError(suite.T(), err)
+
+			host := suite.nodes[i].Host()
+			require.NoError(suite.T(), suite.nodes[i].RemovePeer(info.ID))
+			for _, addr := range host.Peerstore().Addrs(info.ID) {
+				if _, err := addr.ValueForProtocol(multiaddr.P_TCP); err == nil {
+					host.Peerstore().SetAddr(info.ID, addr, 0)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err = host.Connect(ctx, peer.AddrInfo{ID: info.ID})
+			cancel()
+
+			if suite.quic[i] {
+				require.NoError(suite.T(), err, "node %d could not connect to node %d over QUIC", i, j)
+				assert.Contains(suite.T(), suite.connTransports(suite.nodes[i], info.ID), "quic")
+			} else {
+				assert.Error(suite.T(), err, "TCP only node %d connected to node %d over QUIC", i, j)
+			}
+		}
+	}
+}
+
+// hasQUICAddr returns true if the peerstore of the node holds a QUIC address of the peer, with the given port
+// unless it is empty.
+func (suite *MixedTransportTestSuite) hasQUICAddr(node *p2p.Node, pid peer.ID, port string) bool {
+	for _, addr := range node.Host().Peerstore().Addrs(pid) {
+		addrPort, err := addr.ValueForProtocol(multiaddr.P_UDP)
+		if err != nil {
+			continue
+		}
+		if _, err := addr.ValueForProtocol(multiaddr.P_QUIC); err == nil && (port == "" || port == addrPort) {
+			return true
+		}
+	}
+	return false
+}
+
+// connTransports returns the transports of the connections of the node to the peer.
+func (suite *MixedTransportTestSuite) connTransports(node *p2p.Node, pid peer.ID) []string {
+	var transports []string
+	for _, conn := range node.Host().Network().ConnsToPeer(pid) {
+		if _, err := conn.RemoteMultiaddr().ValueForProtocol(multiaddr.P_QUIC); err == nil {
+			transports = append(transports, "quic")
+		} else {
+			transports = append(transports, "tcp")
+		}
+	}
+	return transports
+}
@@ -28,6 +28,9 @@ func Factory(name Name) (FactoryFunction, error) {
 		return RandomizedTopologyFactory(), nil
 	case TopicBased:
 		return TopicBasedTopologyFactory(), nil
+	case LatencyAware:
+		// without latency measurements, the topology is built from the ring and the randomly sampled peers
+		return LatencyAwareTopologyFactory(nil), nil
 	default:
 		return nil, fmt.Errorf("unknown topology name: %s", name)
 	}
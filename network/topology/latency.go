@@ -0,0 +1,189 @@
+package topology
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/id"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network"
+)
+
+const (
+	// DefaultLatencyProbeInterval is the default interval in which the round-trip time to all peers is measured.
+	DefaultLatencyProbeInterval = time.Minute
+
+	// latencyProbeTimeout is the maximum time to wait for a ping reply from a peer.
+	latencyProbeTimeout = 4 * time.Second
+
+	// latencyProbeConcurrency is the maximum number of concurrent pings.
+	latencyProbeConcurrency = 16
+
+	// latencySmoothing is the weight of a new round-trip time measurement in the moving average.
+	latencySmoothing = 0.2
+)
+
+// LatencyTracker keeps an exponential moving average of the round-trip times to other nodes.
+// It is concurrency safe.
+type LatencyTracker struct {
+	mu        sync.RWMutex
+	latencies map[flow.Identifier]time.Duration
+}
+
+var _ LatencyProvider = (*LatencyTracker)(nil)
+
+// NewLatencyTracker returns a new LatencyTracker without any measurements.
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{
+		latencies: make(map[flow.Identifier]time.Duration),
+	}
+}
+
+// Record adds a round-trip time measurement of the given node to its moving average.
+func (t *LatencyTracker) Record(nodeID flow.Identifier, rtt time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, ok := t.latencies[nodeID]
+	if !ok {
+		t.latencies[nodeID] = rtt
+		return
+	}
+	t.latencies[nodeID] = previous + time.Duration(latencySmoothing*float64(rtt-previous))
+}
+
+// Forget removes the measurements of the given node, e.g., because it is unreachable.
+func (t *LatencyTracker) Forget(nodeID flow.Identifier) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.latencies, nodeID)
+}
+
+// Latency returns the average round-trip time to the given node, and false if the round-trip
+// time to the node has not been measured.
+func (t *LatencyTracker) Latency(nodeID flow.Identifier) (time.Duration, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	latency, ok := t.latencies[nodeID]
+	return latency, ok
+}
+
+// PeerIDTranslator translates Flow identifiers to libp2p peer IDs.
+type PeerIDTranslator interface {
+	GetPeerID(flow.Identifier) (peer.ID, error)
+}
+
+// LatencyProber periodically pings all other nodes, and records the round-trip times in a LatencyTracker.
+type LatencyProber struct {
+	component.Component
+	log          zerolog.Logger
+	me           flow.Identifier
+	tracker      *LatencyTracker
+	idProvider   id.IdentityProvider
+	idTranslator PeerIDTranslator
+	pingService  network.PingService
+	interval     time.Duration
+}
+
+// NewLatencyProber returns a new LatencyProber which measures the round-trip times to all nodes other than
+// the given node with the given ping service.
+func NewLatencyProber(
+	log zerolog.Logger,
+	me flow.Identifier,
+	tracker *LatencyTracker,
+	idProvider id.IdentityProvider,
+	idTranslator PeerIDTranslator,
+	pingService network.PingService,
+	interval time.Duration,
+) *LatencyProber {
+	p := &LatencyProber{
+		log:          log.With().Str("component", "latency_prober").Logger(),
+		me:           me,
+		tracker:      tracker,
+		idProvider:   idProvider,
+		idTranslator: idTranslator,
+		pingService:  pingService,
+		interval:     interval,
+	}
+
+	p.Component = component.NewComponentManagerBuilder().
+		AddWorker(p.probeLoop).
+		Build()
+
+	return p
+}
+
+func (p *LatencyProber) probeLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.probe(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe pings all other nodes, and returns once all pings completed or timed out.
+func (p *LatencyProber) probe(ctx context.Context) {
+	peers := p.idProvider.Identities(filter.Not(filter.HasNodeID(p.me)))
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, latencyProbeConcurrency)
+
+	for _, identity := range peers {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case limit <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(nodeID flow.Identifier) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			p.probeNode(ctx, nodeID)
+		}(identity.NodeID)
+	}
+
+	wg.Wait()
+}
+
+// probeNode pings the given node and records the round-trip time.
+func (p *LatencyProber) probeNode(ctx context.Context, nodeID flow.Identifier) {
+	pid, err := p.idTranslator.GetPeerID(nodeID)
+	if err != nil {
+		p.log.Debug().Err(err).Hex("node_id", nodeID[:]).Msg("failed to get peer ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, latencyProbeTimeout)
+	defer cancel()
+
+	_, rtt, err := p.pingService.Ping(ctx, pid)
+	if err != nil {
+		p.log.Debug().Err(err).Hex("node_id", nodeID[:]).Msg("failed to ping")
+		p.tracker.Forget(nodeID)
+		return
+	}
+
+	p.tracker.Record(nodeID, rtt)
+}
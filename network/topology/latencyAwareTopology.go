@@ -0,0 +1,336 @@
+package topology
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+const LatencyAware = Name("latency-aware")
+
+const (
+	// DefaultLowLatencyPeers is the default number of peers with the lowest measured latency that a node
+	// connects to on each channel.
+	DefaultLowLatencyPeers = 4
+
+	// DefaultRandomPeers is the default number of weight-proportionally sampled peers that a node connects
+	// to on each channel, for redundancy.
+	DefaultRandomPeers = 3
+)
+
+// LatencyProvider provides the measured round-trip time to other nodes.
+type LatencyProvider interface {
+	// Latency returns the measured round-trip time to the given node, and false if the round-trip
+	// time to the node has not been measured.
+	Latency(nodeID flow.Identifier) (time.Duration, bool)
+}
+
+func LatencyAwareTopologyFactory(latency LatencyProvider) FactoryFunction {
+	return func(nodeId flow.Identifier, logger zerolog.Logger, state protocol.State, _ float64) (network.Topology, error) {
+		return NewLatencyAwareTopology(nodeId, logger, state, latency, DefaultLowLatencyPeers, DefaultRandomPeers)
+	}
+}
+
+// LatencyAwareTopology generates a topology per channel which prefers peers with a low measured latency,
+// while keeping randomized redundancy for resilience.
+// On each channel, a node connects to:
+//   - its two neighbors on a ring of all nodes subscribed to the channel, which guarantees that the graph is
+//     connected. The ring is ordered by a hash of the epoch counter and the node IDs, so it is the same on
+//     all nodes, and changes every epoch.
+//   - the peers with the lowest measured latency.
+//   - peers sampled with a probability proportional to their weight, using a source of randomness seeded
+//     with the epoch counter and the node ID.
+//
+// The latencies and weights of the nodes are frozen in a snapshot the first time the fanout is generated in an
+// epoch, so given the same identities and channels, the fanout is deterministic within an epoch, and it can be
+// cached with the topology Cache.
+//
+// As a convention with other topology implementations, LatencyAwareTopology is not concurrency-safe.
+type LatencyAwareTopology struct {
+	myNodeID        flow.Identifier // used to keep identifier of the node
+	state           protocol.State  // used to keep a read only protocol state
+	latency         LatencyProvider // used to look up the measured latency to peers, may be nil
+	lowLatencyPeers int             // number of peers with the lowest latency to connect to on each channel
+	randomPeers     int             // number of randomly sampled peers to connect to on each channel
+	snapshot        *epochSnapshot  // latencies and weights of the nodes, frozen for the current epoch
+	logger          zerolog.Logger
+}
+
+// epochSnapshot holds the latencies and weights of the nodes, as of the first fanout generation in an epoch.
+type epochSnapshot struct {
+	epoch     uint64
+	latencies map[flow.Identifier]time.Duration
+	weights   map[flow.Identifier]uint64
+}
+
+// weight returns the weight of the node in the snapshot, or its current weight if it joined after the snapshot.
+func (s *epochSnapshot) weight(id *flow.Identity) uint64 {
+	weight, ok := s.weights[id.NodeID]
+	if !ok {
+		return id.Weight
+	}
+	return weight
+}
+
+// NewLatencyAwareTopology returns an instance of the LatencyAwareTopology.
+// If the latency provider is nil, the topology is built from the ring and the randomly sampled peers only.
+func NewLatencyAwareTopology(nodeID flow.Identifier,
+	logger zerolog.Logger,
+	state protocol.State,
+	latency LatencyProvider,
+	lowLatencyPeers int,
+	randomPeers int) (*LatencyAwareTopology, error) {
+
+	if lowLatencyPeers < 0 || randomPeers < 0 {
+		return nil, fmt.Errorf("number of low latency peers (%d) and random peers (%d) must not be negative", lowLatencyPeers, randomPeers)
+	}
+
+	return &LatencyAwareTopology{
+		myNodeID:        nodeID,
+		state:           state,
+		latency:         latency,
+		lowLatencyPeers: lowLatencyPeers,
+		randomPeers:     randomPeers,
+		logger:          logger.With().Str("component:", "latency-aware-topology").Logger(),
+	}, nil
+}
+
+// GenerateFanout receives IdentityList of entire network and constructs the fanout IdentityList
+// of this instance. A node directly communicates with its fanout IdentityList on epidemic dissemination
+// of the messages (i.e., publish and multicast).
+// Independent invocations of GenerateFanout on different nodes collaboratively construct a connected graph
+// of the nodes subscribed to each channel, as long as they are invoked with the same IdentityList in the same epoch.
+func (l *LatencyAwareTopology) GenerateFanout(ids flow.IdentityList, channels network.ChannelList) (flow.IdentityList, error) {
+	myUniqueChannels := network.UniqueChannels(channels)
+	if len(myUniqueChannels) == 0 {
+		// no subscribed channel, hence skip topology creation
+		// we do not return an error at this state as invocation of MakeTopology may happen before
+		// node subscribing to all its channels.
+		l.logger.Warn().Msg("skips generating fanout with no subscribed channels")
+		return flow.IdentityList{}, nil
+	}
+
+	epoch, err := l.state.Final().Epochs().Current().Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch counter: %w", err)
+	}
+	snapshot := l.epochSnapshot(ids, epoch)
+
+	var myFanout flow.IdentityList
+
+	// generates a latency-aware subgraph per channel
+	for _, myChannel := range myUniqueChannels {
+		topicFanout, err := l.subsetChannel(ids, myChannel, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive list of peer nodes to connect for topic %s: %w", myChannel, err)
+		}
+		myFanout = myFanout.Union(topicFanout)
+	}
+
+	if len(myFanout) == 0 {
+		return nil, fmt.Errorf("topology size reached zero")
+	}
+	l.logger.Debug().
+		Uint64("epoch", epoch).
+		Int("fanout_size", len(myFanout)).
+		Msg("fanout successfully generated")
+	return myFanout, nil
+}
+
+// epochSnapshot returns the snapshot of the latencies and weights of the given epoch, and takes it from the given
+// identities and the latency provider if the epoch changed since the last snapshot.
+func (l *LatencyAwareTopology) epochSnapshot(ids flow.IdentityList, epoch uint64) *epochSnapshot {
+	if l.snapshot != nil && l.snapshot.epoch == epoch {
+		return l.snapshot
+	}
+
+	snapshot := &epochSnapshot{
+		epoch:     epoch,
+		latencies: make(map[flow.Identifier]time.Duration),
+		weights:   make(map[flow.Identifier]uint64, len(ids)),
+	}
+	for _, id := range ids {
+		snapshot.weights[id.NodeID] = id.Weight
+		if l.latency == nil {
+			continue
+		}
+		if latency, ok := l.latency.Latency(id.NodeID); ok {
+			snapshot.latencies[id.NodeID] = latency
+		}
+	}
+
+	l.logger.Debug().
+		Uint64("epoch", epoch).
+		Int("measured_peers", len(snapshot.latencies)).
+		Msg("latency snapshot taken for epoch")
+	l.snapshot = snapshot
+	return snapshot
+}
+
+// subsetChannel returns the fanout of this node among the nodes of the identity list which are subscribed
+// to the specified `channel`.
+// Note: this method does not include identity of its executor.
+func (l *LatencyAwareTopology) subsetChannel(ids flow.IdentityList, channel network.Channel, snapshot *epochSnapshot) (flow.IdentityList, error) {
+	if network.IsClusterChannel(channel) {
+		// extracts cluster peer ids to which the node belongs to.
+		clusterPeers, err := clusterPeers(l.myNodeID, l.state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find cluster peers for node %s: %w", l.myNodeID.String(), err)
+		}
+		return l.sampleFanout(ids.Filter(filter.In(clusterPeers)), channel, snapshot)
+	}
+
+	// extracts flow roles subscribed to topic.
+	roles, ok := network.RolesByChannel(channel)
+	if !ok {
+		return nil, fmt.Errorf("unknown topic with no subscribed roles: %s", channel)
+	}
+
+	return l.sampleFanout(ids.Filter(filter.HasRole(roles...)), channel, snapshot)
+}
+
+// ringPosition is the position of a node on the ring of an epoch.
+type ringPosition struct {
+	Epoch  uint64
+	NodeID flow.Identifier
+}
+
+// sampleFanout samples the fanout of this node among the given identities, which are all subscribed to the channel,
+// based on the latencies and weights of the epoch snapshot.
+func (l *LatencyAwareTopology) sampleFanout(ids flow.IdentityList, channel network.Channel, snapshot *epochSnapshot) (flow.IdentityList, error) {
+	epoch := snapshot.epoch
+
+	// orders the nodes on the ring of the epoch, all nodes agree on this order
+	type positioned struct {
+		id       *flow.Identity
+		position flow.Identifier
+	}
+	nodes := make([]positioned, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, positioned{
+			id:       id,
+			position: flow.MakeID(ringPosition{Epoch: epoch, NodeID: id.NodeID}),
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(nodes[i].position[:], nodes[j].position[:]) < 0
+	})
+	ring := make(flow.IdentityList, 0, len(nodes))
+	for _, node := range nodes {
+		ring = append(ring, node.id)
+	}
+
+	fanout := flow.IdentityList{}
+	selected := map[flow.Identifier]struct{}{
+		l.myNodeID: {},
+	}
+	add := func(id *flow.Identity) {
+		if _, ok := selected[id.NodeID]; ok {
+			return
+		}
+		selected[id.NodeID] = struct{}{}
+		fanout = append(fanout, id)
+	}
+
+	// connects to both neighbors on the ring
+	for i, id := range ring {
+		if id.NodeID == l.myNodeID {
+			add(ring[(i+1)%len(ring)])
+			add(ring[(i+len(ring)-1)%len(ring)])
+			break
+		}
+	}
+
+	// connects to the peers with the lowest measured latency
+	if l.lowLatencyPeers > 0 {
+		type measured struct {
+			id      *flow.Identity
+			latency time.Duration
+		}
+		// keeps the peers with the lowest latency in ascending order, the ring order breaks ties
+		lowest := make([]measured, 0, l.lowLatencyPeers+1)
+		for _, id := range ring {
+			if _, ok := selected[id.NodeID]; ok {
+				continue
+			}
+			latency, ok := snapshot.latencies[id.NodeID]
+			if !ok {
+				continue
+			}
+			if len(lowest) == l.lowLatencyPeers && latency >= lowest[len(lowest)-1].latency {
+				continue
+			}
+
+			i := sort.Search(len(lowest), func(i int) bool {
+				return lowest[i].latency > latency
+			})
+			lowest = append(lowest, measured{})
+			copy(lowest[i+1:], lowest[i:])
+			lowest[i] = measured{id: id, latency: latency}
+			if len(lowest) > l.lowLatencyPeers {
+				lowest = lowest[:l.lowLatencyPeers]
+			}
+		}
+
+		for _, m := range lowest {
+			add(m.id)
+		}
+	}
+
+	// connects to peers sampled proportionally to their weight
+	seed, err := intSeedFromID(flow.MakeID(struct {
+		Epoch   uint64
+		NodeID  flow.Identifier
+		Channel network.Channel
+	}{
+		Epoch:   epoch,
+		NodeID:  l.myNodeID,
+		Channel: channel,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("could not generate seed: %w", err)
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	remaining := ring.Filter(func(id *flow.Identity) bool {
+		_, ok := selected[id.NodeID]
+		return !ok
+	})
+	for i := 0; i < l.randomPeers && len(remaining) > 0; i++ {
+		sampled := weightedSample(remaining, snapshot.weight, rng)
+		add(remaining[sampled])
+		remaining = append(remaining[:sampled:sampled], remaining[sampled+1:]...)
+	}
+
+	return fanout, nil
+}
+
+// weightedSample returns the index of an identity sampled from the non-empty list with a probability
+// proportional to its weight. If all identities have zero weight, the identity is sampled uniformly.
+func weightedSample(ids flow.IdentityList, weight func(*flow.Identity) uint64, rng *rand.Rand) int {
+	var total uint64
+	for _, id := range ids {
+		total += weight(id)
+	}
+	if total == 0 {
+		return rng.Intn(len(ids))
+	}
+
+	draw := rng.Uint64() % total
+	for i, id := range ids {
+		if draw < weight(id) {
+			return i
+		}
+		draw -= weight(id)
+	}
+	return len(ids) - 1
+}
@@ -0,0 +1,287 @@
+package topology
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/factory"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// LatencyAwareTopologyTestSuite tests encapsulates tests around the latency-aware topology.
+type LatencyAwareTopologyTestSuite struct {
+	suite.Suite
+	state    protocol.State              // represents a mocked protocol state
+	epoch    uint64                      // represents the counter of the current epoch of the mocked state
+	all      flow.IdentityList           // represents the identity list of all nodes in the system
+	clusters flow.ClusterList            // represents list of cluster ids of collection nodes
+	coords   map[flow.Identifier]float64 // represents the position of each node, latency is the distance between nodes
+	logger   zerolog.Logger
+}
+
+// TestLatencyAwareTopologyTestSuite starts all the tests in this test suite.
+func TestLatencyAwareTopologyTestSuite(t *testing.T) {
+	suite.Run(t, new(LatencyAwareTopologyTestSuite))
+}
+
+// SetupTest initiates the test setups prior to each test.
+func (suite *LatencyAwareTopologyTestSuite) SetupTest() {
+	// generates 400 nodes including 60 collection nodes in 3 clusters.
+	nClusters := 3
+	nCollectors := 60
+	nTotal := 340
+
+	suite.logger = zerolog.New(os.Stderr).Level(zerolog.InfoLevel)
+	suite.epoch = 1
+
+	collectors := unittest.IdentityListFixture(nCollectors, unittest.WithRole(flow.RoleCollection))
+	others := unittest.IdentityListFixture(nTotal, unittest.WithAllRolesExcept(flow.RoleCollection))
+	suite.all = append(others, collectors...)
+
+	// places the nodes at random positions, in milliseconds
+	suite.coords = make(map[flow.Identifier]float64)
+	for _, id := range suite.all {
+		suite.coords[id.NodeID] = rand.Float64() * 300
+	}
+
+	// mocks state for collector nodes topology, and the counter of the current epoch
+	state := new(mockprotocol.State)
+	snapshot := new(mockprotocol.Snapshot)
+	epochQuery := new(mockprotocol.EpochQuery)
+	epoch := new(mockprotocol.Epoch)
+	assignments := unittest.ClusterAssignment(uint(nClusters), collectors)
+	clusters, err := factory.NewClusterList(assignments, collectors)
+	require.NoError(suite.T(), err)
+
+	epoch.On("Clustering").Return(clusters, nil)
+	epoch.On("Counter").Return(func() uint64 { return suite.epoch }, nil)
+	epochQuery.On("Current").Return(epoch)
+	snapshot.On("Epochs").Return(epochQuery)
+	state.On("Final").Return(snapshot, nil)
+
+	suite.state = state
+	suite.clusters = clusters
+}
+
+// coordinateLatency is a LatencyProvider which reports the distance between the positions of two nodes as
+// their latency.
+type coordinateLatency struct {
+	me     flow.Identifier
+	coords map[flow.Identifier]float64
+}
+
+func (c coordinateLatency) Latency(nodeID flow.Identifier) (time.Duration, bool) {
+	coord, ok := c.coords[nodeID]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(math.Abs(coord-c.coords[c.me]) * float64(time.Millisecond)), true
+}
+
+// topology creates a latency-aware topology for the given node, which measured the latency to all other nodes.
+func (suite *LatencyAwareTopologyTestSuite) topology(nodeID flow.Identifier) *LatencyAwareTopology {
+	top, err := NewLatencyAwareTopology(nodeID, suite.logger, suite.state,
+		coordinateLatency{me: nodeID, coords: suite.coords}, DefaultLowLatencyPeers, DefaultRandomPeers)
+	require.NoError(suite.T(), err)
+	return top
+}
+
+// channelGraph generates the fanout of all given nodes on the channel, and returns the adjacency map of the graph.
+func (suite *LatencyAwareTopologyTestSuite) channelGraph(ids flow.IdentityList, channel network.Channel) map[flow.Identifier]flow.IdentityList {
+	adjMap := make(map[flow.Identifier]flow.IdentityList)
+	for _, id := range ids {
+		top := suite.topology(id.NodeID)
+		subset, err := top.subsetChannel(ids, channel, top.epochSnapshot(ids, suite.epoch))
+		require.NoError(suite.T(), err)
+
+		// topology should not contain the node itself nor any duplicates
+		require.Empty(suite.T(), subset.Filter(filter.HasNodeID(id.NodeID)))
+		uniquenessCheck(suite.T(), subset)
+
+		adjMap[id.NodeID] = subset
+	}
+	return adjMap
+}
+
+// TestUnhappyInitialization concerns initializing latency-aware topology with unhappy inputs.
+func (suite *LatencyAwareTopologyTestSuite) TestUnhappyInitialization() {
+	_, err := NewLatencyAwareTopology(suite.all[0].NodeID, suite.logger, suite.state, nil, -1, DefaultRandomPeers)
+	require.Error(suite.T(), err)
+
+	_, err = NewLatencyAwareTopology(suite.all[0].NodeID, suite.logger, suite.state, nil, DefaultLowLatencyPeers, -1)
+	require.Error(suite.T(), err)
+}
+
+// TestConnectedness_NonClusterChannel checks whether graph components corresponding to a
+// non-cluster channel are individually connected.
+func (suite *LatencyAwareTopologyTestSuite) TestConnectedness_NonClusterChannel() {
+	channel := network.TestNetworkChannel
+	adjMap := suite.channelGraph(suite.all, channel)
+	connectednessByChannel(suite.T(), adjMap, suite.all, channel)
+}
+
+// TestConnectedness_ClusterChannel checks whether graph components corresponding to a
+// cluster channel are individually connected.
+func (suite *LatencyAwareTopologyTestSuite) TestConnectedness_ClusterChannel() {
+	channel := network.ChannelSyncCluster(flow.Emulator)
+	adjMap := suite.channelGraph(suite.all.Filter(filter.HasRole(flow.RoleCollection)), channel)
+
+	for _, cluster := range suite.clusters {
+		connectedByCluster(suite.T(), adjMap, suite.all, cluster)
+
+		// nodes only connect to nodes of their own cluster
+		for _, id := range cluster {
+			CheckMembership(suite.T(), adjMap[id.NodeID], cluster)
+		}
+	}
+}
+
+// TestLowLatencyPreferred checks that the fanout of a node includes the peers with the lowest latency, and
+// that the latency-preferred peers are ignored if the latency is unknown.
+func (suite *LatencyAwareTopologyTestSuite) TestLowLatencyPreferred() {
+	channel := network.TestNetworkChannel
+	me := suite.all[0]
+	latency := coordinateLatency{me: me.NodeID, coords: suite.coords}
+
+	peers := suite.all.Filter(filter.Not(filter.HasNodeID(me.NodeID)))
+	sort.Slice(peers, func(i, j int) bool {
+		li, _ := latency.Latency(peers[i].NodeID)
+		lj, _ := latency.Latency(peers[j].NodeID)
+		return li < lj
+	})
+
+	top := suite.topology(me.NodeID)
+	fanout, err := top.subsetChannel(suite.all, channel, top.epochSnapshot(suite.all, suite.epoch))
+	require.NoError(suite.T(), err)
+	CheckMembership(suite.T(), peers[:DefaultLowLatencyPeers], fanout)
+	require.LessOrEqual(suite.T(), len(fanout), 2+DefaultLowLatencyPeers+DefaultRandomPeers)
+
+	// without latency measurements, the fanout consists of the ring neighbors and the random peers
+	top, err = NewLatencyAwareTopology(me.NodeID, suite.logger, suite.state, NewLatencyTracker(), DefaultLowLatencyPeers, DefaultRandomPeers)
+	require.NoError(suite.T(), err)
+	fanout, err = top.subsetChannel(suite.all, channel, top.epochSnapshot(suite.all, suite.epoch))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), fanout, 2+DefaultRandomPeers)
+}
+
+// TestDeterminism checks that the fanout is deterministic within an epoch, and changes with the epoch.
+func (suite *LatencyAwareTopologyTestSuite) TestDeterminism() {
+	me := suite.all[0]
+	channels := network.ChannelsByRole(me.Role)
+	require.Greater(suite.T(), len(channels), 1)
+
+	fanout, err := suite.topology(me.NodeID).GenerateFanout(suite.all, channels)
+	require.NoError(suite.T(), err)
+
+	for i := 0; i < 10; i++ {
+		other, err := suite.topology(me.NodeID).GenerateFanout(suite.all, channels)
+		require.NoError(suite.T(), err)
+		require.ElementsMatch(suite.T(), fanout, other)
+	}
+
+	suite.epoch++
+	next, err := suite.topology(me.NodeID).GenerateFanout(suite.all, channels)
+	require.NoError(suite.T(), err)
+	require.NotEqual(suite.T(), sortedNodeIDs(fanout), sortedNodeIDs(next))
+}
+
+// TestEpochSnapshot checks that the latencies are frozen within an epoch, so that the fanout can be cached, and
+// that the latencies measured in the meantime are taken into account once the next epoch starts.
+func (suite *LatencyAwareTopologyTestSuite) TestEpochSnapshot() {
+	me := suite.all[0]
+	channels := network.ChannelList{network.TestNetworkChannel}
+	peers := suite.all.Filter(filter.Not(filter.HasNodeID(me.NodeID)))
+
+	tracker := NewLatencyTracker()
+	for _, id := range peers {
+		tracker.Record(id.NodeID, time.Second)
+	}
+	top, err := NewLatencyAwareTopology(me.NodeID, suite.logger, suite.state, tracker, DefaultLowLatencyPeers, DefaultRandomPeers)
+	require.NoError(suite.T(), err)
+
+	fanout, err := top.GenerateFanout(suite.all, channels)
+	require.NoError(suite.T(), err)
+
+	// the latency to some peers outside the fanout drops within the epoch
+	fast := peers.Filter(filter.Not(filter.In(fanout)))[:DefaultLowLatencyPeers]
+	for _, id := range fast {
+		tracker.Forget(id.NodeID)
+		tracker.Record(id.NodeID, time.Millisecond)
+	}
+
+	same, err := top.GenerateFanout(suite.all, channels)
+	require.NoError(suite.T(), err)
+	require.ElementsMatch(suite.T(), fanout, same)
+
+	suite.epoch++
+	next, err := top.GenerateFanout(suite.all, channels)
+	require.NoError(suite.T(), err)
+	CheckMembership(suite.T(), fast, next)
+}
+
+// sortedNodeIDs returns the sorted string representations of the node IDs of the identity list.
+func sortedNodeIDs(ids flow.IdentityList) []string {
+	nodeIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		nodeIDs = append(nodeIDs, id.NodeID.String())
+	}
+	sort.Strings(nodeIDs)
+	return nodeIDs
+}
+
+// TestConnectedness_Churn is a simulation of the network under churn. In each round, a fraction of the nodes
+// leave the network and new nodes join, and a new epoch starts every few rounds. It checks that
+//   - the graph of each channel is connected after all nodes regenerated their fanout.
+//   - the graph of each channel remains connected if a fraction of the nodes fails without the other nodes
+//     regenerating their fanout, thanks to the redundancy of the topology.
+func (suite *LatencyAwareTopologyTestSuite) TestConnectedness_Churn() {
+	const (
+		rounds         = 6
+		churnFraction  = 0.1
+		failFraction   = 0.1
+		roundsPerEpoch = 2
+	)
+
+	channels := network.ChannelList{network.TestNetworkChannel, network.PushBlocks, network.ConsensusCommittee}
+	ids := suite.all.Filter(filter.Not(filter.HasRole(flow.RoleCollection)))
+
+	for round := 0; round < rounds; round++ {
+		if round > 0 && round%roundsPerEpoch == 0 {
+			suite.epoch++
+		}
+
+		// a fraction of the nodes leave, and the same number of new nodes joins
+		churn := int(churnFraction * float64(len(ids)))
+		leaving := ids.Sample(uint(churn))
+		joining := unittest.IdentityListFixture(churn, unittest.WithAllRolesExcept(flow.RoleCollection))
+		for _, id := range joining {
+			suite.coords[id.NodeID] = rand.Float64() * 300
+		}
+		ids = append(ids.Filter(filter.Not(filter.In(leaving))), joining...)
+
+		for _, channel := range channels {
+			roles, ok := network.RolesByChannel(channel)
+			require.True(suite.T(), ok)
+
+			adjMap := suite.channelGraph(ids.Filter(filter.HasRole(roles...)), channel)
+			Connected(suite.T(), adjMap, ids, filter.HasRole(roles...))
+
+			// a fraction of the nodes fails before the other nodes notice
+			failed := ids.Filter(filter.HasRole(roles...)).SamplePct(failFraction)
+			Connected(suite.T(), adjMap, ids, filter.And(filter.HasRole(roles...), filter.Not(filter.In(failed))))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package topology
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestLatencyTracker evaluates that the tracker keeps a moving average of the recorded round-trip times,
+// and that it forgets the round-trip times of unreachable nodes.
+func TestLatencyTracker(t *testing.T) {
+	tracker := NewLatencyTracker()
+	nodeID := unittest.IdentifierFixture()
+
+	_, ok := tracker.Latency(nodeID)
+	assert.False(t, ok)
+
+	// the first measurement is taken as is
+	tracker.Record(nodeID, 100*time.Millisecond)
+	latency, ok := tracker.Latency(nodeID)
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, latency)
+
+	// further measurements are smoothed
+	tracker.Record(nodeID, 200*time.Millisecond)
+	latency, ok = tracker.Latency(nodeID)
+	assert.True(t, ok)
+	assert.Equal(t, 120*time.Millisecond, latency)
+
+	// other nodes are not affected
+	_, ok = tracker.Latency(unittest.IdentifierFixture())
+	assert.False(t, ok)
+
+	tracker.Forget(nodeID)
+	_, ok = tracker.Latency(nodeID)
+	assert.False(t, ok)
+}
@@ -64,7 +64,14 @@ type ValidatorData struct {
 	From              peer.ID
 }
 
-func TopicValidator(log zerolog.Logger, codec network.Codec, peerFilter func(peer.ID) bool, validators ...MessageValidator) pubsub.ValidatorEx {
+// RateLimiter decides whether a message of the given size, originated by the given peer, is processed.
+type RateLimiter func(origin peer.ID, size int) bool
+
+// TopicValidator returns a pubsub validator which decodes the messages of a topic and passes them through the
+// given validators. Messages are rejected if their sender is not allowed by the peer filter, and ignored if the
+// (optional) rate limiter rejects them, both before their payload is decoded. The rate limits are applied to the
+// origin of a message, so that peers relaying the messages of others are not limited for their traffic.
+func TopicValidator(log zerolog.Logger, codec network.Codec, peerFilter func(peer.ID) bool, rateLimiter RateLimiter, validators ...MessageValidator) pubsub.ValidatorEx {
 	log = log.With().
 		Str("component", "libp2p_node_topic_validator").
 		Logger()
@@ -92,6 +99,15 @@ func TopicValidator(log zerolog.Logger, codec network.Codec, peerFilter func(pee
 			return pubsub.ValidationReject
 		}
 
+		// from is the verified signer of the message, not the peer which forwarded it to us
+		if rateLimiter != nil && !rateLimiter(from, len(rawMsg.Data)) {
+			log.Debug().
+				Str("peer_id", from.String()).
+				Hex("sender", msg.OriginID).
+				Msg("ignoring message exceeding the rate limits")
+			return pubsub.ValidationIgnore
+		}
+
 		// Convert message payload to a known message type
 		decodedMsgPayload, err := codec.Decode(msg.Payload)
 		if err != nil {
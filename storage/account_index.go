@@ -0,0 +1,31 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// AccountIndex represents persistent storage for the secondary indexes of transactions and events
+// by account address. Blocks are indexed in consecutive height order.
+type AccountIndex interface {
+
+	// Store indexes the transactions and events of the block at the given height. The height must be
+	// the height following the latest indexed height, or the first height of the index if no block
+	// was indexed yet.
+	Store(height uint64, transactions []flow.AccountTransaction, events []flow.AccountEvent) error
+
+	// FirstHeight returns the height of the first indexed block.
+	FirstHeight() (uint64, error)
+
+	// LatestHeight returns the height of the latest indexed block.
+	LatestHeight() (uint64, error)
+
+	// TransactionsByAddress returns up to limit transactions signed by the given account within the
+	// height range [startHeight, endHeight], in ascending order. If cursor is not nil, the results start
+	// at the cursor's position. If there are more results, the cursor of the next page is returned.
+	TransactionsByAddress(address flow.Address, startHeight uint64, endHeight uint64, cursor *flow.AccountIndexCursor, limit uint) ([]flow.AccountTransaction, *flow.AccountIndexCursor, error)
+
+	// EventsByAddress returns up to limit events emitted by contracts deployed to the given account
+	// within the height range [startHeight, endHeight], in ascending order. If contract is not empty,
+	// only events emitted by the contract with this name are returned. If cursor is not nil, the
+	// results start at the cursor's position. If there are more results, the cursor of the next page
+	// is returned.
+	EventsByAddress(address flow.Address, contract string, startHeight uint64, endHeight uint64, cursor *flow.AccountIndexCursor, limit uint) ([]flow.AccountEvent, *flow.AccountIndexCursor, error)
+}
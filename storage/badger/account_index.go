@@ -0,0 +1,158 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// AccountIndex implements the account index of transactions and events on top of badger.
+type AccountIndex struct {
+	db *badger.DB
+}
+
+var _ storage.AccountIndex = (*AccountIndex)(nil)
+
+func NewAccountIndex(db *badger.DB) *AccountIndex {
+	return &AccountIndex{
+		db: db,
+	}
+}
+
+// Store indexes the transactions and events of the block at the given height, and updates the latest
+// indexed height within the same database transaction.
+// Expected errors:
+// - an error if the height does not follow the latest indexed height
+func (a *AccountIndex) Store(height uint64, transactions []flow.AccountTransaction, events []flow.AccountEvent) error {
+	return operation.RetryOnConflict(a.db.Update, func(tx *badger.Txn) error {
+		var latest uint64
+		err := operation.RetrieveAccountIndexHeight(&latest)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			// this is the first indexed block
+			err = operation.InsertAccountIndexFirstHeight(height)(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert first height: %w", err)
+			}
+			err = operation.InsertAccountIndexHeight(height)(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert latest height: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		} else {
+			if height != latest+1 {
+				return fmt.Errorf("must index block at height %d next, but got height %d", latest+1, height)
+			}
+			err = operation.UpdateAccountIndexHeight(height)(tx)
+			if err != nil {
+				return fmt.Errorf("could not update latest height: %w", err)
+			}
+		}
+
+		for i := range transactions {
+			err = operation.IndexAccountTransaction(&transactions[i])(tx)
+			if err != nil {
+				return fmt.Errorf("could not index account transaction: %w", err)
+			}
+		}
+
+		for i := range events {
+			err = operation.IndexAccountEvent(&events[i])(tx)
+			if err != nil {
+				return fmt.Errorf("could not index account event: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// FirstHeight returns the height of the first indexed block.
+// Expected errors:
+// - storage.ErrNotFound: if no block was indexed yet
+func (a *AccountIndex) FirstHeight() (uint64, error) {
+	var height uint64
+	err := a.db.View(operation.RetrieveAccountIndexFirstHeight(&height))
+	return height, err
+}
+
+// LatestHeight returns the height of the latest indexed block.
+// Expected errors:
+// - storage.ErrNotFound: if no block was indexed yet
+func (a *AccountIndex) LatestHeight() (uint64, error) {
+	var height uint64
+	err := a.db.View(operation.RetrieveAccountIndexHeight(&height))
+	return height, err
+}
+
+// TransactionsByAddress returns a page of the transactions signed by the given account within the
+// given height range, and the cursor of the next page if there are more results.
+func (a *AccountIndex) TransactionsByAddress(
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountIndexCursor,
+	limit uint,
+) ([]flow.AccountTransaction, *flow.AccountIndexCursor, error) {
+	start := flow.AccountIndexCursor{BlockHeight: startHeight}
+	if cursor != nil {
+		start = *cursor
+	}
+
+	// look up one more entry than requested to find the start of the next page
+	var entries []flow.AccountTransaction
+	err := a.db.View(operation.LookupAccountTransactions(address, start, endHeight, limit+1, &entries))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not look up account transactions: %w", err)
+	}
+
+	if uint(len(entries)) <= limit {
+		return entries, nil, nil
+	}
+
+	next := entries[limit]
+	return entries[:limit], &flow.AccountIndexCursor{
+		BlockHeight:      next.BlockHeight,
+		TransactionIndex: next.TransactionIndex,
+	}, nil
+}
+
+// EventsByAddress returns a page of the events emitted by contracts deployed to the given account
+// within the given height range, optionally filtered by contract name, and the cursor of the next
+// page if there are more results.
+func (a *AccountIndex) EventsByAddress(
+	address flow.Address,
+	contract string,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountIndexCursor,
+	limit uint,
+) ([]flow.AccountEvent, *flow.AccountIndexCursor, error) {
+	start := flow.AccountIndexCursor{BlockHeight: startHeight}
+	if cursor != nil {
+		start = *cursor
+	}
+
+	// look up one more entry than requested to find the start of the next page
+	var entries []flow.AccountEvent
+	err := a.db.View(operation.LookupAccountEvents(address, contract, start, endHeight, limit+1, &entries))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not look up account events: %w", err)
+	}
+
+	if uint(len(entries)) <= limit {
+		return entries, nil, nil
+	}
+
+	next := entries[limit]
+	return entries[:limit], &flow.AccountIndexCursor{
+		BlockHeight:      next.BlockHeight,
+		TransactionIndex: next.Event.TransactionIndex,
+		EventIndex:       next.Event.EventIndex,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+func TestAccountIndexStore(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewAccountIndex(db)
+
+		_, err := index.LatestHeight()
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		require.NoError(t, index.Store(10, nil, nil))
+		require.NoError(t, index.Store(11, nil, nil))
+
+		// heights must be indexed consecutively
+		require.Error(t, index.Store(11, nil, nil))
+		require.Error(t, index.Store(13, nil, nil))
+
+		first, err := index.FirstHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), first)
+
+		latest, err := index.LatestHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), latest)
+	})
+}
+
+func TestAccountIndexTransactionsByAddress(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewAccountIndex(db)
+		address := unittest.RandomAddressFixture()
+
+		var expected []flow.AccountTransaction
+		for height := uint64(1); height <= 3; height++ {
+			var entries []flow.AccountTransaction
+			for i := uint32(0); i < 2; i++ {
+				entries = append(entries, flow.AccountTransaction{
+					Address:          address,
+					BlockID:          unittest.IdentifierFixture(),
+					BlockHeight:      height,
+					TransactionID:    unittest.IdentifierFixture(),
+					TransactionIndex: i,
+					Roles:            flow.TransactionRoleAuthorizer | flow.TransactionRolePayer,
+				})
+			}
+			require.NoError(t, index.Store(height, entries, nil))
+			expected = append(expected, entries...)
+		}
+
+		// page through all transactions
+		var actual []flow.AccountTransaction
+		var cursor *flow.AccountIndexCursor
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3)
+
+			page, next, err := index.TransactionsByAddress(address, 1, 3, cursor, 4)
+			require.NoError(t, err)
+			actual = append(actual, page...)
+			if next == nil {
+				break
+			}
+			assert.Equal(t, flow.AccountIndexCursor{BlockHeight: 3, TransactionIndex: 0}, *next)
+			cursor = next
+		}
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestAccountIndexEventsByAddress(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewAccountIndex(db)
+		address := flow.HexToAddress("0000000000000001")
+		blockID := unittest.IdentifierFixture()
+
+		events := []flow.Event{
+			unittest.EventFixture("A.0000000000000001.Contract1.EventA", 0, 0, unittest.IdentifierFixture(), 0),
+			unittest.EventFixture("A.0000000000000001.Contract1.EventA", 0, 1, unittest.IdentifierFixture(), 0),
+			unittest.EventFixture("A.0000000000000001.Contract1.EventA", 2, 0, unittest.IdentifierFixture(), 0),
+		}
+		require.NoError(t, index.Store(5, nil, flow.AccountEvents(blockID, 5, events)))
+
+		page, cursor, err := index.EventsByAddress(address, "", 5, 5, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, events[0], page[0].Event)
+		assert.Equal(t, events[1], page[1].Event)
+		require.NotNil(t, cursor)
+		assert.Equal(t, flow.AccountIndexCursor{BlockHeight: 5, TransactionIndex: 2, EventIndex: 0}, *cursor)
+
+		page, cursor, err = index.EventsByAddress(address, "", 5, 5, cursor, 2)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, events[2], page[0].Event)
+		assert.Nil(t, cursor)
+	})
+}
@@ -61,6 +61,12 @@ func (c *Collections) ByID(colID flow.Identifier) (*flow.Collection, error) {
 
 	err := c.db.View(func(btx *badger.Txn) error {
 		err := operation.RetrieveCollection(colID, &light)(btx)
+		if errors.Is(err, storage.ErrNotFound) {
+			prunedErr := checkPrunedCollection(colID)(btx)
+			if prunedErr != nil {
+				return prunedErr
+			}
+		}
 		if err != nil {
 			return fmt.Errorf("could not retrieve collection: %w", err)
 		}
@@ -88,6 +94,12 @@ func (c *Collections) LightByID(colID flow.Identifier) (*flow.LightCollection, e
 
 	err := c.db.View(func(tx *badger.Txn) error {
 		err := operation.RetrieveCollection(colID, &collection)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			prunedErr := checkPrunedCollection(colID)(tx)
+			if prunedErr != nil {
+				return prunedErr
+			}
+		}
 		if err != nil {
 			return fmt.Errorf("could not retrieve collection: %w", err)
 		}
@@ -142,6 +154,12 @@ func (c *Collections) LightByTransactionID(txID flow.Identifier) (*flow.LightCol
 		}
 
 		err = operation.RetrieveCollection(*collID, &collection)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			prunedErr := checkPrunedCollection(*collID)(tx)
+			if prunedErr != nil {
+				return prunedErr
+			}
+		}
 		if err != nil {
 			return fmt.Errorf("could not retrieve collection: %w", err)
 		}
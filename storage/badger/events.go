@@ -126,7 +126,12 @@ func (e *Events) ByBlockIDEventType(blockID flow.Identifier, eventType flow.Even
 
 // RemoveByBlockID removes events by block ID
 func (e *Events) RemoveByBlockID(blockID flow.Identifier) error {
-	return e.db.Update(operation.RemoveEventsByBlockID(blockID))
+	err := e.db.Update(operation.RemoveEventsByBlockID(blockID))
+	if err != nil {
+		return err
+	}
+	e.cache.Remove(blockID)
+	return nil
 }
 
 type ServiceEvents struct {
@@ -181,5 +186,10 @@ func (e *ServiceEvents) ByBlockID(blockID flow.Identifier) ([]flow.Event, error)
 
 // RemoveByBlockID removes service events by block ID
 func (e *ServiceEvents) RemoveByBlockID(blockID flow.Identifier) error {
-	return e.db.Update(operation.RemoveServiceEventsByBlockID(blockID))
+	err := e.db.Update(operation.RemoveServiceEventsByBlockID(blockID))
+	if err != nil {
+		return err
+	}
+	e.cache.Remove(blockID)
+	return nil
 }
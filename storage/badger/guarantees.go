@@ -1,11 +1,14 @@
 package badger
 
 import (
+	"errors"
+
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/storage/badger/transaction"
 )
@@ -51,6 +54,12 @@ func (g *Guarantees) storeTx(guarantee *flow.CollectionGuarantee) func(*transact
 func (g *Guarantees) retrieveTx(collID flow.Identifier) func(*badger.Txn) (*flow.CollectionGuarantee, error) {
 	return func(tx *badger.Txn) (*flow.CollectionGuarantee, error) {
 		val, err := g.cache.Get(collID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			prunedErr := checkPrunedCollection(collID)(tx)
+			if prunedErr != nil {
+				return nil, prunedErr
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
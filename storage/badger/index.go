@@ -3,11 +3,14 @@
 package badger
 
 import (
+	"errors"
+
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/storage/badger/procedure"
 	"github.com/onflow/flow-go/storage/badger/transaction"
@@ -54,6 +57,12 @@ func (i *Index) storeTx(blockID flow.Identifier, index *flow.Index) func(*transa
 func (i *Index) retrieveTx(blockID flow.Identifier) func(*badger.Txn) (*flow.Index, error) {
 	return func(tx *badger.Txn) (*flow.Index, error) {
 		val, err := i.cache.Get(blockID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			prunedErr := checkPrunedBlock(blockID)(tx)
+			if prunedErr != nil {
+				return nil, prunedErr
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -43,3 +43,21 @@ func InitSecret(opts badger.Options) (*badger.DB, error) {
 
 	return db, nil
 }
+
+// InitSlashingEvidence initializes a slashing evidence database by checking and setting
+// the database type marker. If an existing, inconsistent type marker is set, this method
+// will return an error. Once a database type marker has been set using these methods,
+// the type cannot be changed.
+func InitSlashingEvidence(opts badger.Options) (*badger.DB, error) {
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not open db: %w", err)
+	}
+	err = db.Update(operation.InsertSlashingEvidenceDBMarker)
+	if err != nil {
+		return nil, fmt.Errorf("could not assert db type: %w", err)
+	}
+
+	return db, nil
+}
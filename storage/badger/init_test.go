@@ -29,6 +29,17 @@ func TestInitSecret(t *testing.T) {
 	})
 }
 
+func TestInitSlashingEvidence(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSlashingEvidence, func(db *badger.DB) {
+		err := operation.EnsureSlashingEvidenceDB(db)
+		require.NoError(t, err)
+		err = operation.EnsurePublicDB(db)
+		require.Error(t, err)
+		err = operation.EnsureSecretDB(db)
+		require.Error(t, err)
+	})
+}
+
 // opening a database which has previously been opened with encryption enabled,
 // using a different encryption key, should fail
 func TestEncryptionKeyMismatch(t *testing.T) {
@@ -145,6 +145,12 @@ func (m *MyExecutionReceipts) MyReceipt(blockID flow.Identifier) (*flow.Executio
 	return m.myReceipt(blockID)(tx)
 }
 
+// RemoveIndexByBlockID removes the index marking a receipt for the given block as mine.
 func (m *MyExecutionReceipts) RemoveIndexByBlockID(blockID flow.Identifier) error {
-	return m.db.Update(operation.SkipNonExist(operation.RemoveOwnExecutionReceipt(blockID)))
+	err := m.db.Update(operation.SkipNonExist(operation.RemoveOwnExecutionReceipt(blockID)))
+	if err != nil {
+		return err
+	}
+	m.cache.Remove(blockID)
+	return nil
 }
@@ -0,0 +1,165 @@
+package operation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func InsertAccountIndexFirstHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeAccountIndexFirstHeight), height)
+}
+
+func RetrieveAccountIndexFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeAccountIndexFirstHeight), height)
+}
+
+func InsertAccountIndexHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeAccountIndexHeight), height)
+}
+
+func UpdateAccountIndexHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeAccountIndexHeight), height)
+}
+
+func RetrieveAccountIndexHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeAccountIndexHeight), height)
+}
+
+// IndexAccountTransaction indexes the transaction by the address of the account which signed it.
+func IndexAccountTransaction(entry *flow.AccountTransaction) func(*badger.Txn) error {
+	return insert(makePrefix(codeAccountTransactions, entry.Address, entry.BlockHeight, entry.TransactionIndex), entry)
+}
+
+// IndexAccountEvent indexes the event by the address of the account its contract is deployed to, and
+// by the address and the name of the contract.
+func IndexAccountEvent(entry *flow.AccountEvent) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := insert(makePrefix(codeAccountEvents, entry.Address, entry.BlockHeight, entry.Event.TransactionIndex, entry.Event.EventIndex), entry)(tx)
+		if err != nil {
+			return err
+		}
+
+		_, contract, ok := flow.ParseAccountEventType(entry.Event.Type)
+		if !ok {
+			return nil
+		}
+		// the contract index only references the entry of the account event index, to avoid storing
+		// the event twice
+		cursor := flow.AccountIndexCursor{
+			BlockHeight:      entry.BlockHeight,
+			TransactionIndex: entry.Event.TransactionIndex,
+			EventIndex:       entry.Event.EventIndex,
+		}
+		return insert(makeAccountContractEventsKey(entry.Address, contract, cursor.BlockHeight, cursor.TransactionIndex, cursor.EventIndex), &cursor)(tx)
+	}
+}
+
+// makeAccountContractEventsKey returns the key of the contract index. The contract name is terminated
+// by a zero byte, which is not valid in contract names, so that the key of one contract is never a
+// prefix of the key of another contract.
+func makeAccountContractEventsKey(address flow.Address, contract string, height uint64, txIndex uint32, eventIndex uint32) []byte {
+	return makePrefix(codeAccountContractEvents, address, contract, uint8(0), height, txIndex, eventIndex)
+}
+
+// LookupAccountTransactions retrieves up to limit transactions of the given account, starting at the
+// position of the cursor and ending at the given height (inclusive).
+func LookupAccountTransactions(address flow.Address, cursor flow.AccountIndexCursor, endHeight uint64, limit uint, entries *[]flow.AccountTransaction) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if cursor.BlockHeight > endHeight || limit == 0 {
+			return nil
+		}
+
+		start := makePrefix(codeAccountTransactions, address, cursor.BlockHeight, cursor.TransactionIndex)
+		// the end key must not be shorter than the start key, otherwise iteration is reversed
+		end := makePrefix(codeAccountTransactions, address, endHeight, uint32(math.MaxUint32))
+		return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				return true
+			}
+			var entry flow.AccountTransaction
+			create := func() interface{} {
+				return &entry
+			}
+			handle := func() error {
+				*entries = append(*entries, entry)
+				if uint(len(*entries)) >= limit {
+					return errStopIteration
+				}
+				return nil
+			}
+			return check, create, handle
+		})(tx)
+	}
+}
+
+// LookupAccountEvents retrieves up to limit events of the given account, starting at the position of
+// the cursor and ending at the given height (inclusive). If contract is not empty, only events emitted
+// by the contract with this name are retrieved, using the contract index.
+func LookupAccountEvents(address flow.Address, contract string, cursor flow.AccountIndexCursor, endHeight uint64, limit uint, entries *[]flow.AccountEvent) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if cursor.BlockHeight > endHeight || limit == 0 {
+			return nil
+		}
+
+		if contract != "" {
+			return lookupAccountContractEvents(address, contract, cursor, endHeight, limit, entries)(tx)
+		}
+
+		start := makePrefix(codeAccountEvents, address, cursor.BlockHeight, cursor.TransactionIndex, cursor.EventIndex)
+		// the end key must not be shorter than the start key, otherwise iteration is reversed
+		end := makePrefix(codeAccountEvents, address, endHeight, uint32(math.MaxUint32), uint32(math.MaxUint32))
+		return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				return true
+			}
+			var entry flow.AccountEvent
+			create := func() interface{} {
+				return &entry
+			}
+			handle := func() error {
+				*entries = append(*entries, entry)
+				if uint(len(*entries)) >= limit {
+					return errStopIteration
+				}
+				return nil
+			}
+			return check, create, handle
+		})(tx)
+	}
+}
+
+// lookupAccountContractEvents retrieves up to limit events emitted by the given contract, by iterating
+// the contract index and retrieving the referenced entries of the account event index.
+func lookupAccountContractEvents(address flow.Address, contract string, cursor flow.AccountIndexCursor, endHeight uint64, limit uint, entries *[]flow.AccountEvent) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		start := makeAccountContractEventsKey(address, contract, cursor.BlockHeight, cursor.TransactionIndex, cursor.EventIndex)
+		// the end key must not be shorter than the start key, otherwise iteration is reversed
+		end := makeAccountContractEventsKey(address, contract, endHeight, uint32(math.MaxUint32), uint32(math.MaxUint32))
+		return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				return true
+			}
+			var ref flow.AccountIndexCursor
+			create := func() interface{} {
+				return &ref
+			}
+			handle := func() error {
+				var entry flow.AccountEvent
+				err := retrieve(makePrefix(codeAccountEvents, address, ref.BlockHeight, ref.TransactionIndex, ref.EventIndex), &entry)(tx)
+				if err != nil {
+					return fmt.Errorf("could not retrieve indexed account event: %w", err)
+				}
+				*entries = append(*entries, entry)
+				if uint(len(*entries)) >= limit {
+					return errStopIteration
+				}
+				return nil
+			}
+			return check, create, handle
+		})(tx)
+	}
+}
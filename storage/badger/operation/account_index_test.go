@@ -0,0 +1,156 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAccountIndexHeights(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		err := db.Update(InsertAccountIndexFirstHeight(10))
+		require.NoError(t, err)
+		err = db.Update(InsertAccountIndexHeight(10))
+		require.NoError(t, err)
+		err = db.Update(UpdateAccountIndexHeight(11))
+		require.NoError(t, err)
+
+		var first, latest uint64
+		err = db.View(RetrieveAccountIndexFirstHeight(&first))
+		require.NoError(t, err)
+		err = db.View(RetrieveAccountIndexHeight(&latest))
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(10), first)
+		assert.Equal(t, uint64(11), latest)
+	})
+}
+
+func TestLookupAccountTransactions(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		address := unittest.RandomAddressFixture()
+		other := unittest.RandomAddressFixture()
+
+		// two transactions per height for heights 1 to 5, and one transaction of another account
+		var expected []flow.AccountTransaction
+		for height := uint64(1); height <= 5; height++ {
+			for index := uint32(0); index < 2; index++ {
+				entry := flow.AccountTransaction{
+					Address:          address,
+					BlockHeight:      height,
+					TransactionID:    unittest.IdentifierFixture(),
+					TransactionIndex: index,
+					Roles:            flow.TransactionRolePayer,
+				}
+				require.NoError(t, db.Update(IndexAccountTransaction(&entry)))
+				expected = append(expected, entry)
+			}
+		}
+		otherEntry := flow.AccountTransaction{Address: other, BlockHeight: 3, TransactionIndex: 5}
+		require.NoError(t, db.Update(IndexAccountTransaction(&otherEntry)))
+
+		t.Run("full range", func(t *testing.T) {
+			var entries []flow.AccountTransaction
+			err := db.View(LookupAccountTransactions(address, flow.AccountIndexCursor{BlockHeight: 1}, 5, 100, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, expected, entries)
+		})
+
+		t.Run("height range", func(t *testing.T) {
+			var entries []flow.AccountTransaction
+			err := db.View(LookupAccountTransactions(address, flow.AccountIndexCursor{BlockHeight: 2}, 3, 100, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, expected[2:6], entries)
+		})
+
+		t.Run("cursor and limit", func(t *testing.T) {
+			var entries []flow.AccountTransaction
+			cursor := flow.AccountIndexCursor{BlockHeight: 2, TransactionIndex: 1}
+			err := db.View(LookupAccountTransactions(address, cursor, 5, 3, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, expected[3:6], entries)
+		})
+
+		t.Run("single height", func(t *testing.T) {
+			var entries []flow.AccountTransaction
+			cursor := flow.AccountIndexCursor{BlockHeight: 4, TransactionIndex: 0}
+			err := db.View(LookupAccountTransactions(address, cursor, 4, 100, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, expected[6:8], entries)
+		})
+
+		t.Run("cursor after end height", func(t *testing.T) {
+			var entries []flow.AccountTransaction
+			err := db.View(LookupAccountTransactions(address, flow.AccountIndexCursor{BlockHeight: 4}, 3, 100, &entries))
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	})
+}
+
+func TestLookupAccountEvents(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		address := unittest.RandomAddressFixture()
+
+		var expected []flow.AccountEvent
+		for height := uint64(1); height <= 3; height++ {
+			for index := uint32(0); index < 2; index++ {
+				entry := flow.AccountEvent{
+					Address:     address,
+					BlockHeight: height,
+					Event:       unittest.EventFixture("A.0000000000000001.Contract1.EventA", 1, index, unittest.IdentifierFixture(), 0),
+				}
+				require.NoError(t, db.Update(IndexAccountEvent(&entry)))
+				expected = append(expected, entry)
+			}
+		}
+
+		other := flow.AccountEvent{
+			Address:     address,
+			BlockHeight: 2,
+			Event:       unittest.EventFixture("A.0000000000000001.Contract2.EventB", 3, 0, unittest.IdentifierFixture(), 0),
+		}
+		require.NoError(t, db.Update(IndexAccountEvent(&other)))
+
+		t.Run("all contracts", func(t *testing.T) {
+			var entries []flow.AccountEvent
+			cursor := flow.AccountIndexCursor{BlockHeight: 1, TransactionIndex: 1, EventIndex: 1}
+			err := db.View(LookupAccountEvents(address, "", cursor, 2, 100, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]flow.AccountEvent{}, expected[1:4]...), other), entries)
+		})
+
+		// a contract whose name is a prefix of the name of another contract
+		prefixed := flow.AccountEvent{
+			Address:     address,
+			BlockHeight: 3,
+			Event:       unittest.EventFixture("A.0000000000000001.Contract.EventC", 4, 0, unittest.IdentifierFixture(), 0),
+		}
+		require.NoError(t, db.Update(IndexAccountEvent(&prefixed)))
+
+		t.Run("contract filter", func(t *testing.T) {
+			var entries []flow.AccountEvent
+			err := db.View(LookupAccountEvents(address, "Contract2", flow.AccountIndexCursor{}, 3, 100, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, []flow.AccountEvent{other}, entries)
+
+			entries = nil
+			err = db.View(LookupAccountEvents(address, "Contract", flow.AccountIndexCursor{}, 3, 100, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, []flow.AccountEvent{prefixed}, entries)
+		})
+
+		t.Run("contract filter with cursor and limit", func(t *testing.T) {
+			var entries []flow.AccountEvent
+			cursor := flow.AccountIndexCursor{BlockHeight: 1, TransactionIndex: 1, EventIndex: 1}
+			err := db.View(LookupAccountEvents(address, "Contract1", cursor, 3, 3, &entries))
+			require.NoError(t, err)
+			assert.Equal(t, expected[1:4], entries)
+		})
+	})
+}
@@ -198,6 +198,10 @@ type createFunc func() interface{}
 // and the entity was decoded.
 type handleFunc func() error
 
+// errStopIteration can be returned by a handleFunc to end an iteration early
+// without an error, e.g. once a page of results is complete.
+var errStopIteration = errors.New("stop iteration")
+
 // iterationFunc is a function provided to our low-level iteration function that
 // allows us to pass badger efficiencies across badger boundaries. By calling it
 // for each iteration step, we can inject a function to check the key, a
@@ -245,7 +249,8 @@ func withPrefetchValuesFalse(options *badger.IteratorOptions) {
 //   * have a prefix that is lexicographically between start and end
 //
 // On each iteration, it will call the iteration function to initialize
-// functions specific to processing the given key-value pair.
+// functions specific to processing the given key-value pair. The iteration
+// ends early if the handle function returns errStopIteration.
 //
 // TODO: this function is unbounded – pass context.Context to this or calling
 // functions to allow timing functions out.
@@ -342,6 +347,9 @@ func iterate(start []byte, end []byte, iteration iterationFunc, opts ...func(*ba
 
 				return nil
 			})
+			if errors.Is(err, errStopIteration) {
+				return nil
+			}
 			if err != nil {
 				return fmt.Errorf("could not process value: %w", err)
 			}
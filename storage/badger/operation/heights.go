@@ -49,3 +49,15 @@ func UpdateLastCompleteBlockHeight(height uint64) func(*badger.Txn) error {
 func RetrieveLastCompleteBlockHeight(height *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeLastCompleteBlockHeight), height)
 }
+
+func InsertPrunedHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codePrunedHeight), height)
+}
+
+func UpdatePrunedHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codePrunedHeight), height)
+}
+
+func RetrievePrunedHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codePrunedHeight), height)
+}
@@ -16,6 +16,7 @@ func (marker dbTypeMarker) String() string {
 	return [...]string{
 		"dbMarkerPublic",
 		"dbMarkerSecret",
+		"dbMarkerSlashingEvidence",
 	}[marker]
 }
 
@@ -24,6 +25,8 @@ const (
 	dbMarkerPublic dbTypeMarker = iota
 	// dbMarkerSecret denotes the secrets database
 	dbMarkerSecret
+	// dbMarkerSlashingEvidence denotes the slashing evidence database
+	dbMarkerSlashingEvidence
 )
 
 func InsertPublicDBMarker(txn *badger.Txn) error {
@@ -34,6 +37,10 @@ func InsertSecretDBMarker(txn *badger.Txn) error {
 	return insertDBTypeMarker(dbMarkerSecret)(txn)
 }
 
+func InsertSlashingEvidenceDBMarker(txn *badger.Txn) error {
+	return insertDBTypeMarker(dbMarkerSlashingEvidence)(txn)
+}
+
 func EnsurePublicDB(db *badger.DB) error {
 	return ensureDBWithType(db, dbMarkerPublic)
 }
@@ -42,6 +49,10 @@ func EnsureSecretDB(db *badger.DB) error {
 	return ensureDBWithType(db, dbMarkerSecret)
 }
 
+func EnsureSlashingEvidenceDB(db *badger.DB) error {
+	return ensureDBWithType(db, dbMarkerSlashingEvidence)
+}
+
 // insertDBTypeMarker inserts a database type marker if none exists. If a marker
 // already exists in the database, this function will return an error if the
 // marker does not match the argument, or return nil if it matches.
@@ -25,12 +25,17 @@ const (
 	codeProtocolVersion       = 14
 
 	// code for heights with special meaning
-	codeFinalizedHeight         = 20 // latest finalized block height
-	codeSealedHeight            = 21 // latest sealed block height
-	codeClusterHeight           = 22 // latest finalized height on cluster
-	codeExecutedBlock           = 23 // latest executed block with max height
-	codeRootHeight              = 24 // the height of the first loaded block
-	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codePrunedHeight             = 19 // the height up to which block payloads were pruned
+	codeFinalizedHeight          = 20 // latest finalized block height
+	codeSealedHeight             = 21 // latest sealed block height
+	codeClusterHeight            = 22 // latest finalized height on cluster
+	codeExecutedBlock            = 23 // latest executed block with max height
+	codeRootHeight               = 24 // the height of the first loaded block
+	codeLastCompleteBlockHeight  = 25 // the height of the last block for which all collections were received
+	codeAccountIndexFirstHeight  = 26 // the height of the first block in the account index
+	codeAccountIndexHeight       = 27 // the height of the latest block in the account index
+	codeRegisterIndexFirstHeight = 28 // the height of the first block in the register index
+	codeRegisterIndexHeight      = 29 // the height of the latest block in the register index
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -72,11 +77,25 @@ const (
 	codeDKGStarted       = 64 // flag that the DKG for an epoch has been started
 	codeDKGEnded         = 65 // flag that the DKG for an epoch has ended (stores end state)
 
+	// codes for the account index
+	codeAccountTransactions   = 66 // index mapping account address to the transactions signed by the account
+	codeAccountEvents         = 67 // index mapping account address to the events emitted by the account's contracts
+	codeAccountContractEvents = 74 // index mapping account address and contract name to the account event index
+
+	// codes for the register index
+	codeRegisters = 68 // index mapping register ID and block height to the value of the register
+
+	// codes for the slashing evidence database
+	codeSlashingEvidence = 69 // slashing evidence, keyed by view and evidence ID
+
 	// job queue consumers and producers
 	codeJobConsumerProcessed = 70
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes for node-local statistics of the sealing engine
+	codeVerifierStats = 73 // responsiveness of verification nodes to approval requests, keyed by node ID
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -123,6 +142,18 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
+	case flow.RegisterID:
+		// owner and key are length-prefixed, so that the encoding of a register ID is never a
+		// prefix of the encoding of another register ID
+		b := make([]byte, 2, 4+len(i.Owner)+len(i.Key))
+		binary.BigEndian.PutUint16(b, uint16(len(i.Owner)))
+		b = append(b, i.Owner...)
+		b = append(b, 0, 0)
+		binary.BigEndian.PutUint16(b[len(b)-2:], uint16(len(i.Key)))
+		b = append(b, i.Key...)
+		return b
 	case flow.ChainID:
 		return []byte(i)
 	default:
@@ -22,6 +22,11 @@ func RetrieveExecutionReceiptMeta(receiptID flow.Identifier, meta *flow.Executio
 	return retrieve(makePrefix(codeExecutionReceiptMeta, receiptID), meta)
 }
 
+// RemoveExecutionReceiptMeta removes an execution receipt meta by ID.
+func RemoveExecutionReceiptMeta(receiptID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionReceiptMeta, receiptID))
+}
+
 // IndexOwnExecutionReceipt inserts an execution receipt ID keyed by block ID
 func IndexOwnExecutionReceipt(blockID flow.Identifier, receiptID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeOwnBlockReceipt, blockID), receiptID)
@@ -54,6 +59,11 @@ func BatchIndexExecutionReceipts(blockID, receiptID flow.Identifier) func(batch
 	return batchWrite(makePrefix(codeAllBlockReceipts, blockID, receiptID), receiptID)
 }
 
+// RemoveExecutionReceiptsIndex removes the index of all execution receipts for the given block ID.
+func RemoveExecutionReceiptsIndex(blockID flow.Identifier) func(*badger.Txn) error {
+	return removeByPrefix(makePrefix(codeAllBlockReceipts, blockID))
+}
+
 // LookupExecutionReceipts finds all execution receipts by block ID
 func LookupExecutionReceipts(blockID flow.Identifier, receiptIDs *[]flow.Identifier) func(*badger.Txn) error {
 	iterationFunc := receiptIterationFunc(receiptIDs)
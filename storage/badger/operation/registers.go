@@ -0,0 +1,59 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func InsertRegisterIndexFirstHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisterIndexFirstHeight), height)
+}
+
+func RetrieveRegisterIndexFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeRegisterIndexFirstHeight), height)
+}
+
+func InsertRegisterIndexHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisterIndexHeight), height)
+}
+
+func UpdateRegisterIndexHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeRegisterIndexHeight), height)
+}
+
+func RetrieveRegisterIndexHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeRegisterIndexHeight), height)
+}
+
+// InsertRegister inserts the value a register was updated to at the given height.
+func InsertRegister(height uint64, entry flow.RegisterEntry) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisters, entry.Key, height), entry.Value)
+}
+
+// BatchInsertRegister inserts the value a register was updated to at the given height, as part of
+// a write batch. If the register was already updated at this height, its value is overwritten.
+func BatchInsertRegister(height uint64, entry flow.RegisterEntry) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeRegisters, entry.Key, height), entry.Value)
+}
+
+// LookupRegister retrieves the value of the register at the given height, which is the value of its
+// latest update at or below the height. If the register was never updated up to this height, the
+// value is left empty.
+func LookupRegister(id flow.RegisterID, height uint64, value *flow.RegisterValue) func(*badger.Txn) error {
+	// iterate backwards from the given height, and stop at the first update
+	start := makePrefix(codeRegisters, id, height)
+	end := makePrefix(codeRegisters, id, uint64(0))
+	return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		create := func() interface{} {
+			return value
+		}
+		handle := func() error {
+			return errStopIteration
+		}
+		return check, create, handle
+	})
+}
@@ -0,0 +1,70 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestLookupRegister(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		owner := string(unittest.RandomAddressFixture().Bytes())
+		id := flow.NewRegisterID(owner, "key")
+
+		// registers whose encoding is close to the register's encoding must not be returned
+		neighbours := []flow.RegisterID{
+			flow.NewRegisterID(owner, "ke"),
+			flow.NewRegisterID(owner, "key2"),
+			flow.NewRegisterID("", owner+"key"),
+		}
+		for _, neighbour := range neighbours {
+			for _, height := range []uint64{1, 5, 20} {
+				entry := flow.RegisterEntry{Key: neighbour, Value: []byte("neighbour")}
+				require.NoError(t, db.Update(InsertRegister(height, entry)))
+			}
+		}
+
+		require.NoError(t, db.Update(InsertRegister(5, flow.RegisterEntry{Key: id, Value: []byte("five")})))
+		require.NoError(t, db.Update(InsertRegister(10, flow.RegisterEntry{Key: id, Value: []byte("ten")})))
+
+		// a register can only be inserted once per height
+		err := db.Update(InsertRegister(10, flow.RegisterEntry{Key: id, Value: []byte("other")}))
+		require.Error(t, err)
+
+		cases := map[uint64]flow.RegisterValue{
+			4:  nil,
+			5:  []byte("five"),
+			9:  []byte("five"),
+			10: []byte("ten"),
+			30: []byte("ten"),
+		}
+		for height, expected := range cases {
+			var value flow.RegisterValue
+			err := db.View(LookupRegister(id, height, &value))
+			require.NoError(t, err)
+			assert.Equal(t, expected, value, "unexpected value at height %d", height)
+		}
+	})
+}
+
+func TestBatchInsertRegister(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		id := flow.NewRegisterID("", "key")
+
+		batch := db.NewWriteBatch()
+		require.NoError(t, BatchInsertRegister(1, flow.RegisterEntry{Key: id, Value: []byte("first")})(batch))
+		// the batch overwrites existing values
+		require.NoError(t, BatchInsertRegister(1, flow.RegisterEntry{Key: id, Value: []byte("second")})(batch))
+		require.NoError(t, batch.Flush())
+
+		var value flow.RegisterValue
+		err := db.View(LookupRegister(id, 1, &value))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("second"), value)
+	})
+}
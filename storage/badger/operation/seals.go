@@ -38,6 +38,18 @@ func LookupPayloadResults(blockID flow.Identifier, resultIDs *[]flow.Identifier)
 	return retrieve(makePrefix(codePayloadResults, blockID), resultIDs)
 }
 
+func RemovePayloadSeals(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadSeals, blockID))
+}
+
+func RemovePayloadReceipts(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadReceipts, blockID))
+}
+
+func RemovePayloadResults(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadResults, blockID))
+}
+
 // IndexLatestSealAtBlock persists the highest seal that was included in the fork up to (and including) blockID.
 // In most cases, it is the highest seal included in this block's payload. However, if there are no
 // seals in this block, sealID should reference the highest seal in blockID's ancestor.
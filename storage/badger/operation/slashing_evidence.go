@@ -0,0 +1,40 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSlashingEvidence stores the evidence, keyed by the view of the violation and the
+// evidence ID.
+func InsertSlashingEvidence(evidence *flow.SlashingEvidence) func(*badger.Txn) error {
+	return insert(makePrefix(codeSlashingEvidence, evidence.View, evidence.ID()), evidence)
+}
+
+// LookupSlashingEvidence retrieves the evidence of violations committed in views within
+// [fromView, toView], ordered by view.
+func LookupSlashingEvidence(fromView uint64, toView uint64, evidence *[]*flow.SlashingEvidence) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if fromView > toView {
+			return nil
+		}
+
+		start := makePrefix(codeSlashingEvidence, fromView)
+		end := makePrefix(codeSlashingEvidence, toView)
+		return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				return true
+			}
+			var entry flow.SlashingEvidence
+			create := func() interface{} {
+				return &entry
+			}
+			handle := func() error {
+				*evidence = append(*evidence, &entry)
+				return nil
+			}
+			return check, create, handle
+		})(tx)
+	}
+}
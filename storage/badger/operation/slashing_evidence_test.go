@@ -0,0 +1,56 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSlashingEvidenceInsertLookup(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		// two pieces of evidence per view for views 10 to 14
+		var expected []*flow.SlashingEvidence
+		for view := uint64(10); view < 15; view++ {
+			for i := 0; i < 2; i++ {
+				evidence := unittest.SlashingEvidenceFixture(func(evidence *flow.SlashingEvidence) {
+					evidence.View = view
+				})
+				require.NoError(t, db.Update(InsertSlashingEvidence(evidence)))
+				expected = append(expected, evidence)
+			}
+		}
+
+		t.Run("duplicate", func(t *testing.T) {
+			err := db.Update(InsertSlashingEvidence(expected[0]))
+			require.ErrorIs(t, err, storage.ErrAlreadyExists)
+		})
+
+		t.Run("full range", func(t *testing.T) {
+			var evidence []*flow.SlashingEvidence
+			require.NoError(t, db.View(LookupSlashingEvidence(0, 100, &evidence)))
+			require.Len(t, evidence, len(expected))
+			for i, entry := range evidence {
+				assert.Equal(t, uint64(10+i/2), entry.View)
+			}
+		})
+
+		t.Run("partial range", func(t *testing.T) {
+			var evidence []*flow.SlashingEvidence
+			require.NoError(t, db.View(LookupSlashingEvidence(11, 12, &evidence)))
+			require.Len(t, evidence, 4)
+			assert.ElementsMatch(t, flow.GetIDs(expected[2:6]), flow.GetIDs(evidence))
+		})
+
+		t.Run("empty range", func(t *testing.T) {
+			var evidence []*flow.SlashingEvidence
+			require.NoError(t, db.View(LookupSlashingEvidence(12, 11, &evidence)))
+			assert.Empty(t, evidence)
+		})
+	})
+}
@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"errors"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// UpsertVerifierStats stores the statistics of the verifier, replacing any previously stored
+// statistics of the same verifier.
+func UpsertVerifierStats(stats *flow.VerifierStats) func(*badger.Txn) error {
+	key := makePrefix(codeVerifierStats, stats.VerifierID)
+	return func(tx *badger.Txn) error {
+		err := update(key, stats)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return insert(key, stats)(tx)
+		}
+		return err
+	}
+}
+
+// RetrieveVerifierStats retrieves the statistics of all verifiers, ordered by verifier ID.
+func RetrieveVerifierStats(stats *[]*flow.VerifierStats) func(*badger.Txn) error {
+	return traverse(makePrefix(codeVerifierStats), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var entry flow.VerifierStats
+		create := func() interface{} {
+			return &entry
+		}
+		handle := func() error {
+			*stats = append(*stats, &entry)
+			return nil
+		}
+		return check, create, handle
+	})
+}
@@ -0,0 +1,202 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/badger/procedure"
+)
+
+// PayloadPruner implements pruning of block payloads, collections and transactions around a
+// badger DB.
+type PayloadPruner struct {
+	db           *badger.DB
+	index        *Index
+	guarantees   *Guarantees
+	transactions *Transactions
+}
+
+var _ storage.PayloadPruner = (*PayloadPruner)(nil)
+
+func NewPayloadPruner(db *badger.DB, index *Index, guarantees *Guarantees, transactions *Transactions) *PayloadPruner {
+	return &PayloadPruner{
+		db:           db,
+		index:        index,
+		guarantees:   guarantees,
+		transactions: transactions,
+	}
+}
+
+func (p *PayloadPruner) PrunedHeight() (uint64, error) {
+	var height uint64
+	err := p.db.View(operation.RetrievePrunedHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve pruned height: %w", err)
+	}
+	return height, nil
+}
+
+func (p *PayloadPruner) PruneHeight(height uint64) error {
+	var (
+		blockID flow.Identifier
+		index   flow.Index
+		txIDs   []flow.Identifier
+	)
+
+	err := operation.RetryOnConflict(p.db.Update, func(tx *badger.Txn) error {
+		// reset the removed transactions in case the badger transaction is retried
+		txIDs = nil
+
+		var prunedHeight uint64
+		err := operation.RetrievePrunedHeight(&prunedHeight)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			// nothing was pruned yet, so pruning starts after the root block
+			err = operation.RetrieveRootHeight(&prunedHeight)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve root height: %w", err)
+			}
+			err = operation.InsertPrunedHeight(prunedHeight)(tx)
+			if err != nil {
+				return fmt.Errorf("could not initialize pruned height: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("could not retrieve pruned height: %w", err)
+		}
+
+		if height != prunedHeight+1 {
+			return fmt.Errorf("can only prune next height %d, but got %d", prunedHeight+1, height)
+		}
+
+		err = operation.LookupBlockHeight(height, &blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up finalized block at height %d: %w", height, err)
+		}
+
+		err = procedure.RetrieveIndex(blockID, &index)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve payload index: %w", err)
+		}
+
+		for _, collID := range index.CollectionIDs {
+			// collections and transactions are only stored by nodes which ingest them
+			var light flow.LightCollection
+			err = operation.RetrieveCollection(collID, &light)(tx)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("could not retrieve collection %v: %w", collID, err)
+			}
+
+			for _, txID := range light.Transactions {
+				err = operation.SkipNonExist(operation.RemoveTransaction(txID))(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove transaction %v: %w", txID, err)
+				}
+			}
+			txIDs = append(txIDs, light.Transactions...)
+
+			err = operation.SkipNonExist(operation.RemoveCollection(collID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove collection %v: %w", collID, err)
+			}
+
+			err = operation.SkipNonExist(operation.RemoveGuarantee(collID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove guarantee %v: %w", collID, err)
+			}
+		}
+
+		err = procedure.RemoveIndex(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove payload index: %w", err)
+		}
+
+		err = operation.UpdatePrunedHeight(height)(tx)
+		if err != nil {
+			return fmt.Errorf("could not update pruned height: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not prune height %d: %w", height, err)
+	}
+
+	p.index.cache.Remove(blockID)
+	for _, collID := range index.CollectionIDs {
+		p.guarantees.cache.Remove(collID)
+	}
+	for _, txID := range txIDs {
+		p.transactions.cache.Remove(txID)
+	}
+
+	return nil
+}
+
+// checkPrunedBlock returns storage.ErrPruned if the payload of the given block was pruned. It
+// returns nil if the block is unknown, or its payload was not pruned.
+func checkPrunedBlock(blockID flow.Identifier) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var prunedHeight uint64
+		err := operation.RetrievePrunedHeight(&prunedHeight)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve pruned height: %w", err)
+		}
+
+		var header flow.Header
+		err = operation.RetrieveHeader(blockID, &header)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve header: %w", err)
+		}
+
+		if header.Height <= prunedHeight {
+			return fmt.Errorf("payload of block %v at height %d was pruned: %w", blockID, header.Height, storage.ErrPruned)
+		}
+		return nil
+	}
+}
+
+// checkPrunedCollection returns storage.ErrPruned if the given collection was pruned together with
+// the payload of the block it was included in. It returns nil if the block of the collection is
+// not indexed, or its payload was not pruned.
+func checkPrunedCollection(collID flow.Identifier) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var blockID flow.Identifier
+		err := operation.LookupCollectionBlock(collID, &blockID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up block of collection: %w", err)
+		}
+
+		return checkPrunedBlock(blockID)(tx)
+	}
+}
+
+// checkPrunedTransaction returns storage.ErrPruned if the given transaction was pruned together
+// with its collection. It returns nil if the collection of the transaction is not indexed, or was
+// not pruned.
+func checkPrunedTransaction(txID flow.Identifier) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var collID flow.Identifier
+		err := operation.RetrieveCollectionID(txID, &collID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up collection of transaction: %w", err)
+		}
+
+		return checkPrunedCollection(collID)(tx)
+	}
+}
@@ -0,0 +1,134 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPayloadPruner(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		all := bstorage.InitAll(metrics.NewNoopCollector(), db)
+
+		// store a chain of finalized blocks with one collection each, starting at the root height
+		root := unittest.BlockHeaderFixture()
+		root.Height = 10
+		require.NoError(t, db.Update(operation.InsertRootHeight(root.Height)))
+
+		parent := root
+		blocks := make([]*flow.Block, 3)
+		collections := make([]*flow.Collection, len(blocks))
+		for i := range blocks {
+			collection := unittest.CollectionFixture(2)
+			guarantee := unittest.CollectionGuaranteeFixture(unittest.WithCollection(&collection))
+			payload := unittest.PayloadFixture(unittest.WithGuarantees(guarantee))
+
+			block := unittest.BlockWithParentFixture(parent)
+			block.SetPayload(payload)
+
+			require.NoError(t, all.Blocks.Store(block))
+			require.NoError(t, db.Update(operation.IndexBlockHeight(block.Header.Height, block.ID())))
+			light := collection.Light()
+			require.NoError(t, all.Collections.StoreLightAndIndexByTransaction(&light))
+			for _, tx := range collection.Transactions {
+				require.NoError(t, all.Transactions.Store(tx))
+			}
+			require.NoError(t, all.Blocks.IndexBlockForCollections(block.ID(), []flow.Identifier{collection.ID()}))
+
+			blocks[i] = block
+			collections[i] = &collection
+			parent = block.Header
+		}
+
+		// nothing was pruned yet
+		_, err := all.PayloadPruner.PrunedHeight()
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		// only the height after the root height can be pruned
+		err = all.PayloadPruner.PruneHeight(root.Height + 2)
+		require.Error(t, err)
+
+		require.NoError(t, all.PayloadPruner.PruneHeight(root.Height+1))
+
+		height, err := all.PayloadPruner.PrunedHeight()
+		require.NoError(t, err)
+		assert.Equal(t, root.Height+1, height)
+
+		// heights can't be pruned twice
+		err = all.PayloadPruner.PruneHeight(root.Height + 1)
+		require.Error(t, err)
+
+		pruned := blocks[0]
+		prunedCollection := collections[0]
+
+		t.Run("pruned data returns ErrPruned", func(t *testing.T) {
+			_, err := all.Payloads.ByBlockID(pruned.ID())
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			_, err = all.Blocks.ByID(pruned.ID())
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			_, err = all.Blocks.ByHeight(pruned.Header.Height)
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			_, err = all.Guarantees.ByCollectionID(prunedCollection.ID())
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			_, err = all.Collections.ByID(prunedCollection.ID())
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			_, err = all.Collections.LightByID(prunedCollection.ID())
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			txID := prunedCollection.Transactions[0].ID()
+			_, err = all.Collections.LightByTransactionID(txID)
+			assert.ErrorIs(t, err, storage.ErrPruned)
+
+			_, err = all.Transactions.ByID(txID)
+			assert.ErrorIs(t, err, storage.ErrPruned)
+		})
+
+		t.Run("headers of pruned blocks are kept", func(t *testing.T) {
+			header, err := all.Headers.ByHeight(pruned.Header.Height)
+			require.NoError(t, err)
+			assert.Equal(t, pruned.ID(), header.ID())
+		})
+
+		t.Run("unknown data returns ErrNotFound", func(t *testing.T) {
+			_, err := all.Payloads.ByBlockID(unittest.IdentifierFixture())
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+
+			_, err = all.Collections.ByID(unittest.IdentifierFixture())
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+
+			_, err = all.Transactions.ByID(unittest.IdentifierFixture())
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		})
+
+		t.Run("data above the pruned height is kept", func(t *testing.T) {
+			for i, block := range blocks[1:] {
+				payload, err := all.Payloads.ByBlockID(block.ID())
+				require.NoError(t, err)
+				assert.Equal(t, block.Payload.Guarantees, payload.Guarantees)
+
+				collection, err := all.Collections.ByID(collections[i+1].ID())
+				require.NoError(t, err)
+				assert.Equal(t, collections[i+1].ID(), collection.ID())
+			}
+		})
+
+		// pruning continues at the next height
+		require.NoError(t, all.PayloadPruner.PruneHeight(root.Height+2))
+		_, err = all.Payloads.ByBlockID(blocks[1].ID())
+		assert.ErrorIs(t, err, storage.ErrPruned)
+	})
+}
@@ -152,3 +152,33 @@ func (r *ExecutionReceipts) ByBlockID(blockID flow.Identifier) (flow.ExecutionRe
 	defer tx.Discard()
 	return r.byBlockID(blockID)(tx)
 }
+
+// RemoveByBlockID removes all known execution receipts for the given block. The execution results
+// contained in the receipts are not removed.
+func (r *ExecutionReceipts) RemoveByBlockID(blockID flow.Identifier) error {
+	var receiptIDs []flow.Identifier
+	err := operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		receiptIDs = nil
+		err := operation.LookupExecutionReceipts(blockID, &receiptIDs)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not find receipt index for block: %w", err)
+		}
+
+		for _, receiptID := range receiptIDs {
+			err = operation.SkipNonExist(operation.RemoveExecutionReceiptMeta(receiptID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove receipt %v: %w", receiptID, err)
+			}
+		}
+
+		return operation.RemoveExecutionReceiptsIndex(blockID)(tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, receiptID := range receiptIDs {
+		r.cache.Remove(receiptID)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -143,4 +144,38 @@ func TestExecutionReceiptsStorage(t *testing.T) {
 			require.ElementsMatch(t, []*flow.ExecutionReceipt{receipt1, receipt2}, receipts)
 		})
 	})
+	t.Run("remove by block ID", func(t *testing.T) {
+		withStore(t, func(store *bstorage.ExecutionReceipts) {
+			block1 := unittest.BlockFixture()
+			block2 := unittest.BlockFixture()
+
+			receipt1 := unittest.ReceiptForBlockFixture(&block1)
+			receipt2 := unittest.ReceiptForBlockFixture(&block1)
+			receipt3 := unittest.ReceiptForBlockFixture(&block2)
+
+			for _, receipt := range []*flow.ExecutionReceipt{receipt1, receipt2, receipt3} {
+				err := store.Store(receipt)
+				require.NoError(t, err)
+			}
+
+			err := store.RemoveByBlockID(block1.ID())
+			require.NoError(t, err)
+
+			receipts, err := store.ByBlockID(block1.ID())
+			require.NoError(t, err)
+			require.Empty(t, receipts)
+
+			_, err = store.ByID(receipt1.ID())
+			require.ErrorIs(t, err, storage.ErrNotFound)
+
+			// receipts of other blocks are kept
+			receipts, err = store.ByBlockID(block2.ID())
+			require.NoError(t, err)
+			require.Equal(t, flow.ExecutionReceiptList{receipt3}, receipts)
+
+			// removing again is a no-op
+			err = store.RemoveByBlockID(block1.ID())
+			require.NoError(t, err)
+		})
+	})
 }
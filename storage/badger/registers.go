@@ -0,0 +1,160 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// RegisterIndex implements the height-indexed storage of execution state registers on top of badger.
+type RegisterIndex struct {
+	db *badger.DB
+}
+
+var _ storage.RegisterIndex = (*RegisterIndex)(nil)
+
+func NewRegisterIndex(db *badger.DB) *RegisterIndex {
+	return &RegisterIndex{
+		db: db,
+	}
+}
+
+// Bootstrap stores the values of all registers at the given height. The registers are written in a
+// write batch, since the full execution state doesn't fit in a single database transaction. The
+// first and latest heights are only set once all registers were written, so an interrupted bootstrap
+// can be retried.
+// Expected errors:
+// - storage.ErrAlreadyExists: if the index was already bootstrapped
+func (r *RegisterIndex) Bootstrap(height uint64, entries flow.RegisterEntries) error {
+	_, err := r.LatestHeight()
+	if err == nil {
+		return storage.ErrAlreadyExists
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not retrieve latest height: %w", err)
+	}
+
+	batch := NewBatch(r.db)
+	for _, entry := range entries {
+		err = operation.BatchInsertRegister(height, entry)(batch.GetWriter())
+		if err != nil {
+			return fmt.Errorf("could not batch insert register: %w", err)
+		}
+	}
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush registers: %w", err)
+	}
+
+	return operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		err := operation.InsertRegisterIndexFirstHeight(height)(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert first height: %w", err)
+		}
+		err = operation.InsertRegisterIndexHeight(height)(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert latest height: %w", err)
+		}
+		return nil
+	})
+}
+
+// Store stores the register updates of the block at the given height, and then updates the latest
+// indexed height. The registers are written in a write batch, since the updates of a large block may
+// not fit in a single database transaction. Registers above the latest indexed height are never read,
+// so an interrupted store can be retried.
+// Expected errors:
+// - storage.ErrNotFound: if the index was not bootstrapped yet
+// - an error if the height does not follow the latest indexed height
+func (r *RegisterIndex) Store(height uint64, entries flow.RegisterEntries) error {
+	checkHeight := func(tx *badger.Txn) error {
+		var latest uint64
+		err := operation.RetrieveRegisterIndexHeight(&latest)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		}
+		if height != latest+1 {
+			return fmt.Errorf("must index block at height %d next, but got height %d", latest+1, height)
+		}
+		return nil
+	}
+
+	err := r.db.View(checkHeight)
+	if err != nil {
+		return err
+	}
+
+	batch := NewBatch(r.db)
+	for _, entry := range entries {
+		err = operation.BatchInsertRegister(height, entry)(batch.GetWriter())
+		if err != nil {
+			return fmt.Errorf("could not batch insert register: %w", err)
+		}
+	}
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush registers: %w", err)
+	}
+
+	return operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		err := checkHeight(tx)
+		if err != nil {
+			return err
+		}
+		err = operation.UpdateRegisterIndexHeight(height)(tx)
+		if err != nil {
+			return fmt.Errorf("could not update latest height: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get returns the value of the register at the given height.
+// Expected errors:
+// - storage.ErrNotFound: if the height is outside the indexed height range
+func (r *RegisterIndex) Get(id flow.RegisterID, height uint64) (flow.RegisterValue, error) {
+	var value flow.RegisterValue
+	err := r.db.View(func(tx *badger.Txn) error {
+		var first, latest uint64
+		err := operation.RetrieveRegisterIndexFirstHeight(&first)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve first height: %w", err)
+		}
+		err = operation.RetrieveRegisterIndexHeight(&latest)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve latest height: %w", err)
+		}
+		if height < first || height > latest {
+			return fmt.Errorf("height %d is outside of indexed range [%d, %d]: %w", height, first, latest, storage.ErrNotFound)
+		}
+
+		return operation.LookupRegister(id, height, &value)(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// FirstHeight returns the height the index was bootstrapped at.
+// Expected errors:
+// - storage.ErrNotFound: if the index was not bootstrapped yet
+func (r *RegisterIndex) FirstHeight() (uint64, error) {
+	var height uint64
+	err := r.db.View(operation.RetrieveRegisterIndexFirstHeight(&height))
+	return height, err
+}
+
+// LatestHeight returns the height of the latest indexed block.
+// Expected errors:
+// - storage.ErrNotFound: if the index was not bootstrapped yet
+func (r *RegisterIndex) LatestHeight() (uint64, error) {
+	var height uint64
+	err := r.db.View(operation.RetrieveRegisterIndexHeight(&height))
+	return height, err
+}
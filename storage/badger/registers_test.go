@@ -0,0 +1,80 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+func TestRegisterIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewRegisterIndex(db)
+
+		idA := flow.NewRegisterID("", "a")
+		idB := flow.NewRegisterID("", "b")
+
+		// registers can't be stored before the index was bootstrapped
+		err := index.Store(11, flow.RegisterEntries{{Key: idA, Value: []byte("a11")}})
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		err = index.Bootstrap(10, flow.RegisterEntries{{Key: idA, Value: []byte("a10")}})
+		require.NoError(t, err)
+
+		err = index.Bootstrap(10, nil)
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		require.NoError(t, index.Store(11, flow.RegisterEntries{{Key: idB, Value: []byte("b11")}}))
+		require.NoError(t, index.Store(12, flow.RegisterEntries{{Key: idA, Value: []byte("a12")}}))
+
+		// heights must be indexed consecutively
+		require.Error(t, index.Store(12, nil))
+		require.Error(t, index.Store(14, nil))
+
+		first, err := index.FirstHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), first)
+
+		latest, err := index.LatestHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12), latest)
+
+		expected := map[uint64][2]flow.RegisterValue{
+			10: {[]byte("a10"), nil},
+			11: {[]byte("a10"), []byte("b11")},
+			12: {[]byte("a12"), []byte("b11")},
+		}
+		for height, values := range expected {
+			value, err := index.Get(idA, height)
+			require.NoError(t, err)
+			assert.Equal(t, values[0], value, "unexpected value of register a at height %d", height)
+
+			value, err = index.Get(idB, height)
+			require.NoError(t, err)
+			assert.Equal(t, values[1], value, "unexpected value of register b at height %d", height)
+		}
+
+		// heights outside of the indexed range
+		_, err = index.Get(idA, 9)
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+		_, err = index.Get(idA, 13)
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		// a store which was interrupted after writing the registers can be retried
+		err = db.Update(operation.InsertRegister(13, flow.RegisterEntry{Key: idA, Value: []byte("interrupted")}))
+		require.NoError(t, err)
+		require.NoError(t, index.Store(13, flow.RegisterEntries{{Key: idA, Value: []byte("a13")}}))
+		value, err := index.Get(idA, 13)
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("a13"), value)
+	})
+}
@@ -0,0 +1,56 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// SlashingEvidence implements the storage of slashing evidence on top of badger. It must
+// be instantiated using the slashing evidence database.
+type SlashingEvidence struct {
+	db *badger.DB
+}
+
+var _ storage.SlashingEvidence = (*SlashingEvidence)(nil)
+
+// NewSlashingEvidence returns the slashing evidence storage backed by the given database,
+// which must be initialized with InitSlashingEvidence.
+func NewSlashingEvidence(db *badger.DB) (*SlashingEvidence, error) {
+	err := operation.EnsureSlashingEvidenceDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("cannot instantiate slashing evidence storage in non-slashing-evidence db: %w", err)
+	}
+
+	return &SlashingEvidence{
+		db: db,
+	}, nil
+}
+
+// Store stores the evidence. Storing the same evidence again is a no-op.
+func (s *SlashingEvidence) Store(evidence *flow.SlashingEvidence) error {
+	err := operation.RetryOnConflict(s.db.Update, operation.InsertSlashingEvidence(evidence))
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not store slashing evidence: %w", err)
+	}
+	return nil
+}
+
+// ByView returns the evidence of violations committed in views within [fromView, toView],
+// ordered by view.
+func (s *SlashingEvidence) ByView(fromView uint64, toView uint64) ([]*flow.SlashingEvidence, error) {
+	var evidence []*flow.SlashingEvidence
+	err := s.db.View(operation.LookupSlashingEvidence(fromView, toView, &evidence))
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup slashing evidence: %w", err)
+	}
+	return evidence, nil
+}
@@ -0,0 +1,52 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSlashingEvidenceStoreRetrieve(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSlashingEvidence, func(db *badger.DB) {
+		store, err := bstorage.NewSlashingEvidence(db)
+		require.NoError(t, err)
+
+		first := unittest.SlashingEvidenceFixture(func(evidence *flow.SlashingEvidence) {
+			evidence.View = 10
+		})
+		second := unittest.SlashingEvidenceFixture(func(evidence *flow.SlashingEvidence) {
+			evidence.View = 20
+		})
+		require.NoError(t, store.Store(second))
+		require.NoError(t, store.Store(first))
+
+		// storing the same evidence again is a no-op
+		require.NoError(t, store.Store(first))
+
+		evidence, err := store.ByView(0, 100)
+		require.NoError(t, err)
+		require.Len(t, evidence, 2)
+		assert.Equal(t, first.ID(), evidence[0].ID())
+		assert.Equal(t, first.Checksum(), evidence[0].Checksum())
+		assert.Equal(t, second.ID(), evidence[1].ID())
+
+		evidence, err = store.ByView(11, 20)
+		require.NoError(t, err)
+		require.Len(t, evidence, 1)
+		assert.Equal(t, second.ID(), evidence[0].ID())
+	})
+}
+
+// the slashing evidence storage must not be instantiated on other databases
+func TestSlashingEvidenceWrongDB(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitPublic, func(db *badger.DB) {
+		_, err := bstorage.NewSlashingEvidence(db)
+		require.Error(t, err)
+	})
+}
@@ -235,5 +235,23 @@ func (tr *TransactionResults) ByBlockID(blockID flow.Identifier) ([]flow.Transac
 
 // RemoveByBlockID removes transaction results by block ID
 func (tr *TransactionResults) RemoveByBlockID(blockID flow.Identifier) error {
-	return tr.db.Update(operation.RemoveTransactionResultsByBlockID(blockID))
+	var txResults []flow.TransactionResult
+	err := tr.db.Update(func(tx *badger.Txn) error {
+		err := operation.LookupTransactionResultsByBlockIDUsingIndex(blockID, &txResults)(tx)
+		if err != nil {
+			return fmt.Errorf("could not lookup transaction results: %w", err)
+		}
+		return operation.RemoveTransactionResultsByBlockID(blockID)(tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, txResult := range txResults {
+		tr.cache.Remove(KeyFromBlockIDTransactionID(blockID, txResult.TransactionID))
+		tr.indexCache.Remove(KeyFromBlockIDIndex(blockID, uint32(i)))
+	}
+	tr.blockCache.Remove(KeyFromBlockID(blockID))
+
+	return nil
 }
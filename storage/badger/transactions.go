@@ -1,11 +1,14 @@
 package badger
 
 import (
+	"errors"
+
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/storage/badger/transaction"
 )
@@ -63,6 +66,12 @@ func (t *Transactions) storeTx(flowTx *flow.TransactionBody) func(*transaction.T
 func (t *Transactions) retrieveTx(txID flow.Identifier) func(*badger.Txn) (*flow.TransactionBody, error) {
 	return func(tx *badger.Txn) (*flow.TransactionBody, error) {
 		val, err := t.cache.Get(txID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			prunedErr := checkPrunedTransaction(txID)(tx)
+			if prunedErr != nil {
+				return nil, prunedErr
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
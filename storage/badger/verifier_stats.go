@@ -0,0 +1,51 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// VerifierStats implements the storage of verifier statistics on top of badger.
+type VerifierStats struct {
+	db *badger.DB
+}
+
+var _ storage.VerifierStats = (*VerifierStats)(nil)
+
+func NewVerifierStats(db *badger.DB) *VerifierStats {
+	return &VerifierStats{
+		db: db,
+	}
+}
+
+// Store stores the statistics, replacing any previously stored statistics of the same verifiers.
+func (v *VerifierStats) Store(stats []*flow.VerifierStats) error {
+	err := operation.RetryOnConflict(v.db.Update, func(tx *badger.Txn) error {
+		for _, entry := range stats {
+			err := operation.UpsertVerifierStats(entry)(tx)
+			if err != nil {
+				return fmt.Errorf("could not store statistics of verifier %v: %w", entry.VerifierID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not store verifier statistics: %w", err)
+	}
+	return nil
+}
+
+// All returns the statistics of all verifiers, ordered by verifier ID.
+func (v *VerifierStats) All() ([]*flow.VerifierStats, error) {
+	var stats []*flow.VerifierStats
+	err := v.db.View(operation.RetrieveVerifierStats(&stats))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve verifier statistics: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,58 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestVerifierStatsStoreRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewVerifierStats(db)
+
+		stats, err := store.All()
+		require.NoError(t, err)
+		assert.Empty(t, stats)
+
+		now := time.Now().UTC()
+		first := &flow.VerifierStats{
+			VerifierID:        flow.Identifier{1},
+			Requests:          3,
+			Responses:         2,
+			TotalResponseTime: 5 * time.Second,
+			LastRequest:       now,
+			LastResponse:      now.Add(-time.Minute),
+		}
+		second := &flow.VerifierStats{
+			VerifierID:        flow.Identifier{2},
+			Requests:          4,
+			Misses:            4,
+			ConsecutiveMisses: 4,
+			LastRequest:       now,
+		}
+		require.NoError(t, store.Store([]*flow.VerifierStats{second, first}))
+
+		stats, err = store.All()
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, first.VerifierID, stats[0].VerifierID)
+		assert.Equal(t, first.TotalResponseTime, stats[0].TotalResponseTime)
+		assert.True(t, first.LastResponse.Equal(stats[0].LastResponse))
+		assert.Equal(t, second.ConsecutiveMisses, stats[1].ConsecutiveMisses)
+
+		// storing the statistics of a verifier again replaces them
+		first.Requests++
+		require.NoError(t, store.Store([]*flow.VerifierStats{first}))
+		stats, err = store.All()
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, uint64(4), stats[0].Requests)
+	})
+}
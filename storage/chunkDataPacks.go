@@ -15,4 +15,8 @@ type ChunkDataPacks interface {
 
 	// ByChunkID returns the chunk data for the given a chunk ID.
 	ByChunkID(chunkID flow.Identifier) (*flow.ChunkDataPack, error)
+
+	// Remove removes the chunk data pack with the given chunk ID.
+	// It returns storage.ErrNotFound if the chunk data pack does not exist.
+	Remove(chunkID flow.Identifier) error
 }
@@ -12,6 +12,10 @@ var (
 	// return storage.ErrNotFound for not found error
 	ErrNotFound = errors.New("key not found")
 
+	// ErrPruned is returned instead of ErrNotFound when the requested data existed, but was
+	// removed because it is older than the retention of the node.
+	ErrPruned = errors.New("data was pruned")
+
 	ErrAlreadyExists = errors.New("key already exists")
 	ErrDataMismatch  = errors.New("data for key is different")
 )
@@ -19,6 +19,9 @@ type Events interface {
 
 	// ByBlockIDEventType returns the events for the given block ID and event type
 	ByBlockIDEventType(blockID flow.Identifier, eventType flow.EventType) ([]flow.Event, error)
+
+	// RemoveByBlockID removes the events for the given block ID
+	RemoveByBlockID(blockID flow.Identifier) error
 }
 
 type ServiceEvents interface {
@@ -27,4 +30,7 @@ type ServiceEvents interface {
 
 	// ByBlockID returns the events for the given block ID
 	ByBlockID(blockID flow.Identifier) ([]flow.Event, error)
+
+	// RemoveByBlockID removes the service events for the given block ID
+	RemoveByBlockID(blockID flow.Identifier) error
 }
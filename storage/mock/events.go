@@ -120,6 +120,20 @@ func (_m *Events) ByBlockIDTransactionIndex(blockID flow.Identifier, txIndex uin
 	return r0, r1
 }
 
+// RemoveByBlockID provides a mock function with given fields: blockID
+func (_m *Events) RemoveByBlockID(blockID flow.Identifier) error {
+	ret := _m.Called(blockID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier) error); ok {
+		r0 = rf(blockID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewEventsT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -51,6 +51,20 @@ func (_m *MyExecutionReceipts) MyReceipt(blockID flow.Identifier) (*flow.Executi
 	return r0, r1
 }
 
+// RemoveIndexByBlockID provides a mock function with given fields: blockID
+func (_m *MyExecutionReceipts) RemoveIndexByBlockID(blockID flow.Identifier) error {
+	ret := _m.Called(blockID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier) error); ok {
+		r0 = rf(blockID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreMyReceipt provides a mock function with given fields: receipt
 func (_m *MyExecutionReceipts) StoreMyReceipt(receipt *flow.ExecutionReceipt) error {
 	ret := _m.Called(receipt)
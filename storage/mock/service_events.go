@@ -51,6 +51,20 @@ func (_m *ServiceEvents) ByBlockID(blockID flow.Identifier) ([]flow.Event, error
 	return r0, r1
 }
 
+// RemoveByBlockID provides a mock function with given fields: blockID
+func (_m *ServiceEvents) RemoveByBlockID(blockID flow.Identifier) error {
+	ret := _m.Called(blockID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier) error); ok {
+		r0 = rf(blockID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewServiceEventsT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -97,6 +97,20 @@ func (_m *TransactionResults) ByBlockIDTransactionIndex(blockID flow.Identifier,
 	return r0, r1
 }
 
+// RemoveByBlockID provides a mock function with given fields: id
+func (_m *TransactionResults) RemoveByBlockID(id flow.Identifier) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewTransactionResultsT interface {
 	mock.TestingT
 	Cleanup(func())
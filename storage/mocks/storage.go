@@ -615,6 +615,20 @@ func (mr *MockEventsMockRecorder) ByBlockIDTransactionIndex(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByBlockIDTransactionIndex", reflect.TypeOf((*MockEvents)(nil).ByBlockIDTransactionIndex), arg0, arg1)
 }
 
+// RemoveByBlockID mocks base method
+func (m *MockEvents) RemoveByBlockID(arg0 flow.Identifier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByBlockID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByBlockID indicates an expected call of RemoveByBlockID
+func (mr *MockEventsMockRecorder) RemoveByBlockID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByBlockID", reflect.TypeOf((*MockEvents)(nil).RemoveByBlockID), arg0)
+}
+
 // MockServiceEvents is a mock of ServiceEvents interface
 type MockServiceEvents struct {
 	ctrl     *gomock.Controller
@@ -667,6 +681,20 @@ func (mr *MockServiceEventsMockRecorder) ByBlockID(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByBlockID", reflect.TypeOf((*MockServiceEvents)(nil).ByBlockID), arg0)
 }
 
+// RemoveByBlockID mocks base method
+func (m *MockServiceEvents) RemoveByBlockID(arg0 flow.Identifier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByBlockID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByBlockID indicates an expected call of RemoveByBlockID
+func (mr *MockServiceEventsMockRecorder) RemoveByBlockID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByBlockID", reflect.TypeOf((*MockServiceEvents)(nil).RemoveByBlockID), arg0)
+}
+
 // MockTransactionResults is a mock of TransactionResults interface
 type MockTransactionResults struct {
 	ctrl     *gomock.Controller
@@ -748,3 +776,17 @@ func (mr *MockTransactionResultsMockRecorder) ByBlockIDTransactionIndex(arg0, ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByBlockIDTransactionIndex", reflect.TypeOf((*MockTransactionResults)(nil).ByBlockIDTransactionIndex), arg0, arg1)
 }
+
+// RemoveByBlockID mocks base method
+func (m *MockTransactionResults) RemoveByBlockID(arg0 flow.Identifier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByBlockID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByBlockID indicates an expected call of RemoveByBlockID
+func (mr *MockTransactionResultsMockRecorder) RemoveByBlockID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByBlockID", reflect.TypeOf((*MockTransactionResults)(nil).RemoveByBlockID), arg0)
+}
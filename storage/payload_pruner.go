@@ -0,0 +1,21 @@
+package storage
+
+// PayloadPruner removes the payloads of finalized blocks, together with the collections and
+// transactions they contain. Block headers, the height index, and the seals, execution receipts
+// and execution results referenced by the protocol state are kept.
+//
+// Payloads are pruned height by height, starting at the height after the root block. Retrieving
+// data of a pruned block returns ErrPruned instead of ErrNotFound.
+type PayloadPruner interface {
+
+	// PrunedHeight returns the highest height up to which the payloads of all finalized blocks were
+	// pruned.
+	// Expected errors:
+	// - ErrNotFound if no payload was pruned yet
+	PrunedHeight() (uint64, error)
+
+	// PruneHeight removes the payload of the finalized block at the given height, as well as the
+	// collections and transactions it contains. The height must be the next height after the pruned
+	// height, or the height after the root block if no payload was pruned yet.
+	PruneHeight(height uint64) error
+}
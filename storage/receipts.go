@@ -24,6 +24,10 @@ type ExecutionReceipts interface {
 	// ByBlockID retrieves all known execution receipts for the given block
 	// (from any Execution Node).
 	ByBlockID(blockID flow.Identifier) (flow.ExecutionReceiptList, error)
+
+	// RemoveByBlockID removes all known execution receipts for the given block.
+	// The execution results contained in the receipts are not removed.
+	RemoveByBlockID(blockID flow.Identifier) error
 }
 
 // MyExecutionReceipts reuses the storage.ExecutionReceipts API, but doesn't expose
@@ -41,4 +45,7 @@ type MyExecutionReceipts interface {
 
 	// MyReceipt retrieves my receipt for the given block.
 	MyReceipt(blockID flow.Identifier) (*flow.ExecutionReceipt, error)
+
+	// RemoveIndexByBlockID removes the index marking a receipt for the given block as mine.
+	RemoveIndexByBlockID(blockID flow.Identifier) error
 }
@@ -0,0 +1,27 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// RegisterIndex represents persistent storage for the values of the execution state registers at
+// each block height. The index is bootstrapped with the full execution state at its first height,
+// after which the register updates of each block are stored in consecutive height order.
+type RegisterIndex interface {
+
+	// Bootstrap stores the values of all registers at the given height, which becomes the first and
+	// latest height of the index. The index must be empty.
+	Bootstrap(height uint64, entries flow.RegisterEntries) error
+
+	// Store stores the register updates of the block at the given height. The height must be the
+	// height following the latest indexed height.
+	Store(height uint64, entries flow.RegisterEntries) error
+
+	// Get returns the value of the register at the given height. Registers which were never set have
+	// an empty value. Returns storage.ErrNotFound if the height is not indexed.
+	Get(id flow.RegisterID, height uint64) (flow.RegisterValue, error)
+
+	// FirstHeight returns the height the index was bootstrapped at.
+	FirstHeight() (uint64, error)
+
+	// LatestHeight returns the height of the latest indexed block.
+	LatestHeight() (uint64, error)
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SlashingEvidence stores evidence of slashable violations detected by the consensus
+// participant. It must be backed by the dedicated slashing evidence database, so that the
+// evidence is retained independently of the protocol state.
+type SlashingEvidence interface {
+
+	// Store stores the evidence. Storing the same evidence again is a no-op.
+	Store(evidence *flow.SlashingEvidence) error
+
+	// ByView returns the evidence of violations committed in views within [fromView, toView],
+	// ordered by view.
+	ByView(fromView uint64, toView uint64) ([]*flow.SlashingEvidence, error)
+}
@@ -16,4 +16,7 @@ type TransactionResults interface {
 
 	// ByBlockID gets all transaction results for a block, ordered by transaction index
 	ByBlockID(id flow.Identifier) ([]flow.TransactionResult, error)
+
+	// RemoveByBlockID removes all transaction results for a block
+	RemoveByBlockID(id flow.Identifier) error
 }
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// VerifierStats stores the responsiveness statistics of verification nodes, as tracked by the
+// sealing engine of a consensus node.
+type VerifierStats interface {
+
+	// Store stores the statistics, replacing any previously stored statistics of the same verifiers.
+	Store(stats []*flow.VerifierStats) error
+
+	// All returns the statistics of all verifiers, ordered by verifier ID.
+	All() ([]*flow.VerifierStats, error)
+}
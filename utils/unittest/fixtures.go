@@ -23,6 +23,7 @@ import (
 	"github.com/onflow/flow-go/model/chainsync"
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/cluster"
+	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -1860,6 +1861,42 @@ func EpochCommitFixture(opts ...func(*flow.EpochCommit)) *flow.EpochCommit {
 	return commit
 }
 
+// SlashingEvidenceFixture returns evidence of a double vote, with a committee of four consensus
+// participants which includes the offender.
+func SlashingEvidenceFixture(opts ...func(*flow.SlashingEvidence)) *flow.SlashingEvidence {
+	participants := IdentityListFixture(4, WithRole(flow.RoleConsensus))
+	offenderID := participants[0].NodeID
+	view := uint64(rand.Uint32())
+
+	dkgKeys := make([]encodable.RandomBeaconPubKey, 0, len(participants))
+	for _, key := range PublicKeysFixture(len(participants), crypto.BLSBLS12381) {
+		dkgKeys = append(dkgKeys, encodable.RandomBeaconPubKey{PublicKey: key})
+	}
+
+	evidence := &flow.SlashingEvidence{
+		Violation:  flow.SlashingViolationDoubleVote,
+		View:       view,
+		OffenderID: offenderID,
+		Votes: []flow.SlashingVote{
+			{View: view, BlockID: IdentifierFixture(), SignerID: offenderID, SigData: SignatureFixture()},
+			{View: view, BlockID: IdentifierFixture(), SignerID: offenderID, SigData: SignatureFixture()},
+		},
+		Committee: flow.SlashingCommittee{
+			EpochCounter:       uint64(rand.Uint32()),
+			Participants:       participants,
+			DKGGroupKey:        encodable.RandomBeaconPubKey{PublicKey: KeyFixture(crypto.BLSBLS12381).PublicKey()},
+			DKGParticipantKeys: dkgKeys,
+		},
+		ReporterID:  participants[1].NodeID,
+		DetectedAt:  time.Now().UTC(),
+		ReporterSig: SignatureFixture(),
+	}
+	for _, apply := range opts {
+		apply(evidence)
+	}
+	return evidence
+}
+
 // BootstrapFixture generates all the artifacts necessary to bootstrap the
 // protocol state.
 func BootstrapFixture(participants flow.IdentityList, opts ...func(*flow.Block)) (*flow.Block, *flow.ExecutionResult, *flow.Seal) {